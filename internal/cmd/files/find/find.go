@@ -4,9 +4,13 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"os"
 	"path/filepath"
 
 	"github.com/debricked/cli/internal/file"
+	"github.com/debricked/cli/internal/git"
+	"github.com/debricked/cli/internal/image"
+	"github.com/debricked/cli/internal/tui"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -15,12 +19,29 @@ var exclusions = file.Exclusions()
 var jsonPrint bool
 var lockfileOnly bool
 var strictness int
+var changedSince string
+var format string
+var stats bool
+var imagePath string
+var lockOutputDir string
 
 const (
-	ExclusionFlag    = "exclusion"
-	JsonFlag         = "json"
-	LockfileOnlyFlag = "lockfile"
-	StrictFlag       = "strict"
+	ExclusionFlag     = "exclusion"
+	JsonFlag          = "json"
+	LockfileOnlyFlag  = "lockfile"
+	StrictFlag        = "strict"
+	ChangedSinceFlag  = "changed-since"
+	FormatFlag        = "format"
+	StatsFlag         = "stats"
+	ImageFlag         = "image"
+	LockOutputDirFlag = "lock-output-dir"
+)
+
+const (
+	TreeFormat  = "tree"
+	CsvFormat   = "csv"
+	JsonFormat  = "json"
+	TableFormat = "table"
 )
 
 func NewFindCmd(finder file.IFinder) *cobra.Command {
@@ -51,7 +72,7 @@ Exclude flags could alternatively be set using DEBRICKED_EXCLUSIONS="path1,path2
 Example: 
 $ debricked files find . `+exampleFlags)
 
-	cmd.Flags().BoolVarP(&jsonPrint, JsonFlag, "j", false, `Print files in JSON format
+	cmd.Flags().BoolVarP(&jsonPrint, JsonFlag, "j", false, `Print files in JSON format. Same as --format=json
 Format:
 [
   {
@@ -62,6 +83,22 @@ Format:
   },
 ]
 `)
+	cmd.Flags().StringVarP(&format, FormatFlag, "f", "", `Output format of found files, by default manifest files with their lock files listed below:
+Format  | Meaning
+------- | -------
+tree    | Same as the default, marking lock files generated by Debricked and linking documentation for unpaired manifest files
+csv     | One row per lock file, or per manifest file lacking lock files
+json    | Same as --json
+table   | Table with ecosystem, manifest file, lock files and documentation for unpaired manifest files
+`)
+	cmd.Flags().BoolVar(&stats, StatsFlag, false, `Print a summary of found files, counting groups per ecosystem, pairs of manifest and lock files, unpaired files and lock files generated by Debricked.
+If used together with --format=json the groups and the summary are printed as {"groups": [...], "stats": {...}}`)
+	cmd.Flags().StringVar(&imagePath, ImageFlag, "", `Find dependency files inside a container image tarball, created by "docker save" or as an OCI image layout, instead of in the inputted path.
+The image layers are flattened without a Docker daemon and files are printed with their paths inside the image.
+
+Example:
+$ docker save -o image.tar my-image:latest
+$ debricked files find --image image.tar`)
 	cmd.Flags().BoolVarP(&lockfileOnly, LockfileOnlyFlag, "l", false, "If set, only lock files are found")
 	cmd.Flags().IntVarP(&strictness, StrictFlag, "s", file.StrictAll, `Allows to control which files will be matched:
 Strictness Level | Meaning
@@ -71,10 +108,25 @@ Strictness Level | Meaning
 2                | Returns only pairs of manifest and lock file
 `)
 
+	cmd.Flags().StringVar(&changedSince, ChangedSinceFlag, "", `Only find dependency files that changed between the git reference and HEAD, including uncommitted changes.
+Changes to package manager configuration files, such as settings.gradle or .npmrc, find all dependency files.
+
+Example:
+$ debricked files find . --changed-since=origin/main`)
+	cmd.Flags().StringVar(&lockOutputDir, LockOutputDirFlag, "", `Also find the lock files written to the directory by "debricked resolve --lock-output-dir", grouped with the manifest files in the directories they mirror.
+
+Example:
+$ debricked files find . --lock-output-dir=/tmp/debricked-locks`)
+
 	viper.MustBindEnv(ExclusionFlag)
 	viper.MustBindEnv(JsonFlag)
 	viper.MustBindEnv(LockfileOnlyFlag)
 	viper.MustBindEnv(StrictFlag)
+	viper.MustBindEnv(ChangedSinceFlag)
+	viper.MustBindEnv(FormatFlag)
+	viper.MustBindEnv(StatsFlag)
+	viper.MustBindEnv(ImageFlag)
+	viper.MustBindEnv(LockOutputDirFlag)
 
 	return cmd
 }
@@ -91,28 +143,115 @@ func RunE(f file.IFinder) func(_ *cobra.Command, args []string) error {
 			return err
 		}
 
+		if imageFile := viper.GetString(ImageFlag); len(imageFile) > 0 {
+			root, err := image.FlattenToTempDir(imageFile)
+			if err != nil {
+				return err
+			}
+			defer os.RemoveAll(root)
+			path = root
+		}
+
 		fileGroups, err := f.GetGroups(
 			path,
 			viper.GetStringSlice(ExclusionFlag),
 			viper.GetBool(LockfileOnlyFlag),
 			viper.GetInt(StrictFlag),
+			viper.GetString(LockOutputDirFlag),
 		)
 		if err != nil {
 			return err
 		}
-		if viper.GetBool(JsonFlag) {
-			jsonFileGroups, _ := json.Marshal(fileGroups.ToSlice())
-			fmt.Println(string(jsonFileGroups))
-		} else {
-			for _, group := range fileGroups.ToSlice() {
-				group.Print()
+		if ref := viper.GetString(ChangedSinceFlag); len(ref) > 0 {
+			changedFiles, err := git.FindChangedFilesSince(path, ref)
+			if err != nil {
+				return err
 			}
+			fileGroups.FilterGroupsByChangedFiles(changedFiles)
+		}
+		groups := fileGroups.ToSlice()
+		if len(viper.GetString(ImageFlag)) > 0 {
+			groups = toImagePaths(groups, path)
 		}
 
-		return nil
+		return printGroups(groups, getFormat(), viper.GetBool(StatsFlag))
 	}
 }
 
+// toImagePaths makes the paths of groups found in the flattened image root relative to the root
+func toImagePaths(groups []file.Group, root string) []file.Group {
+	toImagePath := func(filePath string) string {
+		relPath, err := filepath.Rel(root, filePath)
+		if err != nil {
+			return filePath
+		}
+
+		return relPath
+	}
+	for i, group := range groups {
+		if group.HasFile() {
+			groups[i].ManifestFile = toImagePath(group.ManifestFile)
+		}
+		lockFiles := make([]string, 0, len(group.LockFiles))
+		for _, lockFile := range group.LockFiles {
+			lockFiles = append(lockFiles, toImagePath(lockFile))
+		}
+		groups[i].LockFiles = lockFiles
+	}
+
+	return groups
+}
+
+func getFormat() string {
+	if viper.GetBool(JsonFlag) {
+		return JsonFormat
+	}
+
+	return viper.GetString(FormatFlag)
+}
+
+func printGroups(groups []file.Group, format string, printStats bool) error {
+	var err error
+	switch format {
+	case JsonFormat:
+		return printJson(groups, printStats)
+	case CsvFormat:
+		err = tui.NewFileGroupList(os.Stdout, groups).RenderCsv()
+	case TableFormat:
+		tui.NewFileGroupList(os.Stdout, groups).RenderTable()
+	case TreeFormat:
+		for _, group := range groups {
+			group.PrintTree()
+		}
+	default:
+		for _, group := range groups {
+			group.Print()
+		}
+	}
+	if err == nil && printStats {
+		tui.NewFileGroupStats(os.Stdout, file.NewStats(groups)).Render()
+	}
+
+	return err
+}
+
+func printJson(groups []file.Group, printStats bool) error {
+	var output any = groups
+	if printStats {
+		output = struct {
+			Groups []file.Group `json:"groups"`
+			Stats  file.Stats   `json:"stats"`
+		}{groups, file.NewStats(groups)}
+	}
+	jsonOutput, err := json.Marshal(output)
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(jsonOutput))
+
+	return nil
+}
+
 func AssertFlagsAreValid() error {
 	if viper.GetBool(LockfileOnlyFlag) && viper.GetInt(StrictFlag) != file.StrictAll {
 		return errors.New("'lockfile' and 'strict' flags are mutually exclusive")
@@ -122,5 +261,20 @@ func AssertFlagsAreValid() error {
 		return errors.New("'strict' supports values within range 0-2")
 	}
 
+	outputFormat := viper.GetString(FormatFlag)
+	switch outputFormat {
+	case "", TreeFormat, CsvFormat, JsonFormat, TableFormat:
+	default:
+		return fmt.Errorf("'format' supports the values %s, %s, %s and %s", TreeFormat, CsvFormat, JsonFormat, TableFormat)
+	}
+
+	if len(viper.GetString(ImageFlag)) > 0 && len(viper.GetString(ChangedSinceFlag)) > 0 {
+		return errors.New("'image' and 'changed-since' flags are mutually exclusive")
+	}
+
+	if viper.GetBool(JsonFlag) && outputFormat != "" && outputFormat != JsonFormat {
+		return errors.New("'json' and 'format' flags are mutually exclusive")
+	}
+
 	return nil
 }
@@ -5,10 +5,14 @@ import (
 	"errors"
 	"io"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/debricked/cli/internal/file"
 	"github.com/debricked/cli/internal/file/testdata"
+	imageTestdata "github.com/debricked/cli/internal/image/testdata"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 )
@@ -34,6 +38,11 @@ func TestNewFindCmd(t *testing.T) {
 		JsonFlag,
 		LockfileOnlyFlag,
 		StrictFlag,
+		ChangedSinceFlag,
+		FormatFlag,
+		StatsFlag,
+		ImageFlag,
+		LockOutputDirFlag,
 	}
 	viperKeys := viper.AllKeys()
 	for _, flagKey := range flagKeys {
@@ -154,3 +163,161 @@ func TestPreRun(t *testing.T) {
 	cmd := NewFindCmd(nil)
 	cmd.PreRun(cmd, nil)
 }
+
+func TestRunEWithChangedSinceBadRef(t *testing.T) {
+	viper.Set(ChangedSinceFlag, "non-existing-ref")
+	defer viper.Set(ChangedSinceFlag, "")
+	dir := t.TempDir()
+	r, err := git.PlainInit(dir, false)
+	assert.NoError(t, err)
+	w, err := r.Worktree()
+	assert.NoError(t, err)
+	_, err = w.Commit("commit", &git.CommitOptions{Author: &object.Signature{Name: "author"}, AllowEmptyCommits: true})
+	assert.NoError(t, err)
+
+	f := testdata.NewFinderMock()
+	runE := RunE(f)
+	err = runE(nil, []string{dir})
+
+	assert.ErrorContains(t, err, "failed to resolve git reference non-existing-ref")
+}
+
+func TestRunEWithInvalidFormatFlag(t *testing.T) {
+	viper.Set(FormatFlag, "xml")
+	defer viper.Set(FormatFlag, "")
+
+	f := testdata.NewFinderMock()
+	runE := RunE(f)
+	err := runE(nil, []string{"."})
+
+	assert.EqualError(t, err, "'format' supports the values tree, csv, json and table")
+}
+
+func TestRunEWithBothJsonAndFormatFlagsSet(t *testing.T) {
+	viper.Set(FormatFlag, CsvFormat)
+	viper.Set(JsonFlag, true)
+	defer viper.Set(FormatFlag, "")
+	defer viper.Set(JsonFlag, false)
+
+	f := testdata.NewFinderMock()
+	runE := RunE(f)
+	err := runE(nil, []string{"."})
+
+	assert.EqualError(t, err, "'json' and 'format' flags are mutually exclusive")
+}
+
+func TestRunEFormats(t *testing.T) {
+	url := "https://debricked.com/docs/language-support/javascript.html"
+	compiledFormat, _ := file.NewCompiledFormat(&file.Format{ManifestFileRegex: "package\\.json", DocumentationUrl: url})
+	groups := file.Groups{}
+	groups.Add(*file.NewGroup("package.json", compiledFormat, []string{}))
+	groups.Add(*file.NewGroup("go.mod", nil, []string{"gomod.debricked.lock"}))
+	f := testdata.NewFinderMock()
+	f.SetGetGroupsReturnMock(groups, nil)
+
+	cases := map[string][]string{
+		TreeFormat:  {"package.json", "no lock file found, see " + url, "gomod.debricked.lock " + file.GeneratedLockFileMarker},
+		CsvFormat:   {"javascript,package.json,,false," + url, "unknown,go.mod,gomod.debricked.lock,true,"},
+		TableFormat: {"javascript", url, file.GeneratedLockFileMarker},
+		JsonFormat:  {`"stats":{"groups":2,"ecosystems":{"javascript":1,"unknown":1},"pairs":1,"unpairedManifestFiles":1`},
+	}
+	for outputFormat, expectedOutputs := range cases {
+		t.Run(outputFormat, func(t *testing.T) {
+			viper.Set(FormatFlag, outputFormat)
+			viper.Set(StatsFlag, true)
+			defer viper.Set(FormatFlag, "")
+			defer viper.Set(StatsFlag, false)
+
+			rescueStdout := os.Stdout
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			err := RunE(f)(nil, []string{"."})
+			assert.NoError(t, err)
+
+			_ = w.Close()
+			output, _ := io.ReadAll(r)
+			os.Stdout = rescueStdout
+
+			for _, expectedOutput := range expectedOutputs {
+				assert.Contains(t, string(output), expectedOutput)
+			}
+		})
+	}
+}
+
+func TestRunEDefaultFormat(t *testing.T) {
+	url := "https://debricked.com/docs/language-support/javascript.html"
+	compiledFormat, _ := file.NewCompiledFormat(&file.Format{ManifestFileRegex: "package\\.json", DocumentationUrl: url})
+	groups := file.Groups{}
+	groups.Add(*file.NewGroup("package.json", compiledFormat, []string{}))
+	groups.Add(*file.NewGroup("go.mod", nil, []string{"gomod.debricked.lock"}))
+	f := testdata.NewFinderMock()
+	f.SetGetGroupsReturnMock(groups, nil)
+
+	rescueStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := RunE(f)(nil, []string{"."})
+	assert.NoError(t, err)
+
+	_ = w.Close()
+	output, _ := io.ReadAll(r)
+	os.Stdout = rescueStdout
+
+	assert.Equal(t, "package.json\ngo.mod\n * gomod.debricked.lock\n", string(output))
+}
+
+func TestRunEWithImage(t *testing.T) {
+	imagePath := filepath.Join(t.TempDir(), "image.tar")
+	err := imageTestdata.WriteDockerImage(imagePath, map[string]string{"app/package.json": "{}"})
+	assert.NoError(t, err)
+	viper.Set(ImageFlag, imagePath)
+	defer viper.Set(ImageFlag, "")
+
+	var root string
+	f := &rootRecordingFinder{FinderMock: testdata.NewFinderMock(), root: &root}
+	err = RunE(f)(nil, []string{"."})
+
+	assert.NoError(t, err)
+	assert.Contains(t, root, "debricked-image-")
+	assert.NoDirExists(t, root)
+}
+
+func TestRunEWithImageAndChangedSince(t *testing.T) {
+	viper.Set(ImageFlag, "image.tar")
+	viper.Set(ChangedSinceFlag, "main")
+	defer viper.Set(ImageFlag, "")
+	defer viper.Set(ChangedSinceFlag, "")
+
+	err := RunE(testdata.NewFinderMock())(nil, []string{"."})
+
+	assert.EqualError(t, err, "'image' and 'changed-since' flags are mutually exclusive")
+}
+
+func TestToImagePaths(t *testing.T) {
+	root := filepath.Join("tmp", "image")
+	groups := []file.Group{
+		*file.NewGroup(filepath.Join(root, "app", "package.json"), nil, []string{filepath.Join(root, "app", "yarn.lock")}),
+		*file.NewGroup("", nil, []string{filepath.Join(root, "Cargo.lock")}),
+	}
+
+	groups = toImagePaths(groups, root)
+
+	assert.Equal(t, filepath.Join("app", "package.json"), groups[0].ManifestFile)
+	assert.Equal(t, []string{filepath.Join("app", "yarn.lock")}, groups[0].LockFiles)
+	assert.Empty(t, groups[1].ManifestFile)
+	assert.Equal(t, []string{"Cargo.lock"}, groups[1].LockFiles)
+}
+
+type rootRecordingFinder struct {
+	*testdata.FinderMock
+	root *string
+}
+
+func (f *rootRecordingFinder) GetGroups(rootPath string, exclusions []string, lockfileOnly bool, strictness int, lockOutputDir string) (file.Groups, error) {
+	*f.root = rootPath
+
+	return f.FinderMock.GetGroups(rootPath, exclusions, lockfileOnly, strictness, lockOutputDir)
+}
@@ -0,0 +1,74 @@
+package resolve
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/debricked/cli/internal/resolution"
+	"github.com/jedib0t/go-pretty/v6/table"
+)
+
+const (
+	TablePlanFormat = "table"
+	JsonPlanFormat  = "json"
+)
+
+func validatePlanFormat(format string) error {
+	if format != TablePlanFormat && format != JsonPlanFormat {
+		return fmt.Errorf("invalid plan format: %s, use %s or %s", format, TablePlanFormat, JsonPlanFormat)
+	}
+
+	return nil
+}
+
+func printPlan(mirror io.Writer, plan resolution.Plan, format string) error {
+	switch format {
+	case JsonPlanFormat:
+		jsonOutput, err := json.MarshalIndent(plan, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(mirror, string(jsonOutput))
+
+		return err
+	case TablePlanFormat:
+		renderPlanTables(mirror, plan)
+
+		return nil
+	default:
+		return validatePlanFormat(format)
+	}
+}
+
+func renderPlanTables(mirror io.Writer, plan resolution.Plan) {
+	t := table.NewWriter()
+	t.SetOutputMirror(mirror)
+	t.SetStyle(table.StyleRounded)
+	t.SetTitle("Manifest files to resolve")
+	t.AppendHeader(table.Row{"Manifest file", "Package manager", "Toolchains", "Commands", "Lock files"})
+	for _, plannedJob := range plan.Jobs {
+		t.AppendRow(table.Row{
+			plannedJob.Manifest,
+			plannedJob.PackageManager,
+			strings.Join(plannedJob.Toolchains, "\n"),
+			strings.Join(plannedJob.Commands, "\n"),
+			strings.Join(plannedJob.LockFiles, "\n"),
+		})
+	}
+	t.Render()
+
+	if len(plan.Skipped) == 0 {
+		return
+	}
+	t = table.NewWriter()
+	t.SetOutputMirror(mirror)
+	t.SetStyle(table.StyleRounded)
+	t.SetTitle("Skipped manifest files")
+	t.AppendHeader(table.Row{"Manifest file", "Reason"})
+	for _, skipped := range plan.Skipped {
+		t.AppendRow(table.Row{skipped.Manifest, skipped.Reason})
+	}
+	t.Render()
+}
@@ -0,0 +1,54 @@
+package resolve
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/debricked/cli/internal/resolution"
+	"github.com/stretchr/testify/assert"
+)
+
+var testPlan = resolution.Plan{
+	Jobs: []resolution.PlannedJob{{
+		Manifest:       "go.mod",
+		PackageManager: "go",
+		Commands:       []string{"go mod graph", "go list -mod=readonly -e -m all"},
+		LockFiles:      []string{"gomod.debricked.lock"},
+	}},
+	Skipped: []resolution.SkippedManifest{{Manifest: "package.json", Reason: "lock file yarn.lock exists"}},
+}
+
+func TestValidatePlanFormat(t *testing.T) {
+	assert.NoError(t, validatePlanFormat(TablePlanFormat))
+	assert.NoError(t, validatePlanFormat(JsonPlanFormat))
+	assert.EqualError(t, validatePlanFormat("xml"), "invalid plan format: xml, use table or json")
+}
+
+func TestPrintPlanJson(t *testing.T) {
+	var output bytes.Buffer
+	err := printPlan(&output, testPlan, JsonPlanFormat)
+	assert.NoError(t, err)
+
+	var plan resolution.Plan
+	assert.NoError(t, json.Unmarshal(output.Bytes(), &plan))
+	assert.Equal(t, testPlan, plan)
+}
+
+func TestPrintPlanTable(t *testing.T) {
+	var output bytes.Buffer
+	err := printPlan(&output, testPlan, TablePlanFormat)
+	assert.NoError(t, err)
+
+	assert.Contains(t, output.String(), "Manifest files to resolve")
+	assert.Contains(t, output.String(), "go mod graph")
+	assert.Contains(t, output.String(), "gomod.debricked.lock")
+	assert.Contains(t, output.String(), "Skipped manifest files")
+	assert.Contains(t, output.String(), "lock file yarn.lock exists")
+}
+
+func TestPrintPlanInvalidFormat(t *testing.T) {
+	err := printPlan(&bytes.Buffer{}, testPlan, "xml")
+
+	assert.ErrorContains(t, err, "invalid plan format")
+}
@@ -2,11 +2,15 @@ package resolve
 
 import (
 	"fmt"
+	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/debricked/cli/internal/file"
 	"github.com/debricked/cli/internal/resolution"
+	"github.com/debricked/cli/internal/resolution/cache"
+	resolutionFile "github.com/debricked/cli/internal/resolution/file"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -15,16 +19,52 @@ var (
 	exclusions           = file.Exclusions()
 	verbose              bool
 	npmPreferred         bool
-	regenerate           int
+	jsPackageManager     string
+	lockfileOnly         bool
+	excludeScopes        []string
+	productionOnly       bool
+	useCache             bool
+	cacheDir             string
+	resolutionTimeout    time.Duration
+	pmResolutionTimeouts map[string]string
+	reportJson           string
+	reportJunit          string
+	registriesConfig     string
+	toolchains           map[string]string
+	isolated             bool
+	lockOutputDir        string
+	plan                 bool
+	planFormat           string
+	regenerate           string
+	checkDrift           bool
+	changedSince         string
 	resolutionStrictness int
 )
 
 const (
-	ExclusionFlag        = "exclusion"
-	VerboseFlag          = "verbose"
-	NpmPreferredFlag     = "prefer-npm"
-	RegenerateFlag       = "regenerate"
-	ResolutionStrictFlag = "resolution-strictness"
+	ExclusionFlag           = "exclusion"
+	VerboseFlag             = "verbose"
+	NpmPreferredFlag        = "prefer-npm"
+	JsPackageManagerFlag    = "js-package-manager"
+	LockfileOnlyFlag        = "lockfile-only"
+	ExcludeScopesFlag       = "exclude-scopes"
+	ProductionOnlyFlag      = "production-only"
+	CacheFlag               = "cache"
+	CacheDirFlag            = "cache-dir"
+	ResolutionTimeoutFlag   = "resolution-timeout"
+	PmResolutionTimeoutFlag = "pm-resolution-timeout"
+	ReportJsonFlag          = "report-json"
+	ReportJunitFlag         = "report-junit"
+	RegistriesConfigFlag    = "registries-config"
+	ToolchainFlag           = "toolchain"
+	IsolatedFlag            = "isolated"
+	LockOutputDirFlag       = "lock-output-dir"
+	PlanFlag                = "plan"
+	PlanFormatFlag          = "plan-format"
+	RegenerateFlag          = "regenerate"
+	CheckDriftFlag          = "check-drift"
+	ChangedSinceFlag        = "changed-since"
+	ResolutionStrictFlag    = "resolution-strictness"
 )
 
 func NewResolveCmd(resolver resolution.IResolver) *cobra.Command {
@@ -58,15 +98,33 @@ Example:
 $ debricked resolve . `+exampleFlags)
 	regenerateDoc := strings.Join(
 		[]string{
-			"Toggles regeneration of already existing lock files between 3 modes:\n",
+			"Toggles regeneration of already existing lock files between 4 modes:\n",
 			"Force Regeneration Level | Meaning",
 			"------------------------ | -------",
 			"0 (default)              | No regeneration",
 			"1                        | Regenerates existing non package manager native Debricked lock files",
 			"2                        | Regenerates all existing lock files",
+			"drifted                  | Regenerates existing lock files that are stale relative to their manifest files, see --check-drift",
 			"\nExample:\n$ debricked resolve . --regenerate=1",
 		}, "\n")
-	cmd.Flags().IntVar(&regenerate, RegenerateFlag, 0, regenerateDoc)
+	cmd.Flags().StringVar(&regenerate, RegenerateFlag, "0", regenerateDoc)
+	checkDriftDoc := strings.Join(
+		[]string{
+			"Checks whether existing lock files that aren't regenerated are stale relative to their manifest files, and fails if any is.",
+			"Checks that dependencies of package.json are in package-lock.json, npm-shrinkwrap.json or yarn.lock,",
+			"that requirements of go.mod have checksums in go.sum and that the content-hash of composer.lock matches composer.json.",
+			"go.sum is checked even if the lock file of go.mod is generated, since generating it doesn't update go.sum.",
+			"Lock files of other package managers are never stale.",
+			"\nExample:\n$ debricked resolve . --check-drift",
+		}, "\n")
+	cmd.Flags().BoolVar(&checkDrift, CheckDriftFlag, false, checkDriftDoc)
+	changedSinceDoc := strings.Join(
+		[]string{
+			"Only resolve manifest files that changed between the git reference and HEAD, including uncommitted changes.",
+			"Changes to package manager configuration files, such as settings.gradle or .npmrc, resolve all manifest files.",
+			"\nExample:\n$ debricked resolve . --changed-since=origin/main",
+		}, "\n")
+	cmd.Flags().StringVar(&changedSince, ChangedSinceFlag, "", changedSinceDoc)
 	verboseDoc := strings.Join(
 		[]string{
 			"This flag allows you to reduce error output for resolution.",
@@ -75,11 +133,127 @@ $ debricked resolve . `+exampleFlags)
 	cmd.Flags().BoolVar(&verbose, VerboseFlag, true, verboseDoc)
 	npmPreferredDoc := strings.Join(
 		[]string{
-			"This flag allows you to select which package manager will be used as a resolver: Yarn (default) or NPM.",
+			"This flag allows you to select NPM as resolver of package.json files. Alias of --js-package-manager=npm, used when --js-package-manager is auto.",
 			"Example: debricked resolve --prefer-npm",
 		}, "\n")
 
 	cmd.Flags().BoolP(NpmPreferredFlag, "", npmPreferred, npmPreferredDoc)
+	jsPackageManagerDoc := strings.Join(
+		[]string{
+			"Selects the package manager resolving package.json files: auto (default), yarn, npm or pnpm.",
+			"auto selects the package manager of each package.json, in the following order, searching from its directory up to the repository root:",
+			"the packageManager or devEngines.packageManager field of package.json, existing lock files or package manager configuration files.",
+			"Yarn is used if no package manager is found.",
+			"\nExample:\n$ debricked resolve . --js-package-manager=pnpm",
+		}, "\n")
+	cmd.Flags().StringVar(&jsPackageManager, JsPackageManagerFlag, resolutionFile.JsPackageManagerAuto, jsPackageManagerDoc)
+	lockfileOnlyDoc := strings.Join(
+		[]string{
+			"Resolves lock files without installing packages or running install scripts.",
+			"npm runs with --package-lock-only, Yarn 2 or later with --mode=update-lockfile and pip with --dry-run --report.",
+			"Bower lists the already installed components offline. Yarn Classic and Bower without installed components fail to resolve.",
+			"\nExample:\n$ debricked resolve . --lockfile-only",
+		}, "\n")
+	cmd.Flags().BoolVar(&lockfileOnly, LockfileOnlyFlag, false, lockfileOnlyDoc)
+	excludeScopesDoc := strings.Join(
+		[]string{
+			"Leaves the dependencies of the given scopes out of resolution: dev, test or both, separated by commas.",
+			"npm and Yarn Classic omit devDependencies, Composer runs with --no-dev and NuGet leaves out developmentDependency packages of packages.config.",
+			"Maven leaves out the test scope, and the provided scope too if dev is also excluded. Gradle leaves out test configurations for test,",
+			"and compileOnly, annotationProcessor and developmentOnly configurations for dev. Poetry leaves out dev and test groups",
+			"and pip skips requirements files of the scope, such as requirements-dev.txt.",
+			"\nExample:\n$ debricked resolve . --exclude-scopes dev,test",
+		}, "\n")
+	cmd.Flags().StringSliceVar(&excludeScopes, ExcludeScopesFlag, nil, excludeScopesDoc)
+	productionOnlyDoc := strings.Join(
+		[]string{
+			"Leaves the dependencies of all scopes that aren't shipped with the project out of resolution. Shorthand for --exclude-scopes dev,test.",
+			"\nExample:\n$ debricked resolve . --production-only",
+		}, "\n")
+	cmd.Flags().BoolVar(&productionOnly, ProductionOnlyFlag, false, productionOnlyDoc)
+	cacheDoc := strings.Join(
+		[]string{
+			"Enables the cache of resolution results. The lock files a resolution job writes for a manifest file, both the",
+			"*.debricked.lock files and lock files of package managers such as package-lock.json, are cached",
+			"in the cache directory, keyed by the contents of the manifest file, the package manager configuration files around it,",
+			"the package manager version and the resolution options. Resolution is skipped for manifest files with cached lock files,",
+			"which are restored instead. The cache is disabled by default, since computing the key reads the files in the directory",
+			"of each manifest file and its subdirectories.",
+			"\nExample:\n$ debricked resolve . --cache",
+		}, "\n")
+	cmd.Flags().BoolVar(&useCache, CacheFlag, false, cacheDoc)
+	cacheDirDoc := strings.Join(
+		[]string{
+			"The directory of the cache of resolution results. Defaults to debricked/resolution in the user cache directory.",
+			"\nExample:\n$ debricked resolve . --cache-dir=.debricked-cache",
+		}, "\n")
+	cmd.Flags().StringVar(&cacheDir, CacheDirFlag, cache.DefaultDir(), cacheDirDoc)
+	resolutionTimeoutDoc := strings.Join(
+		[]string{
+			"The time each manifest file may take to resolve, for example 10m. Package managers still running when it has passed",
+			"are killed together with the processes they started, and the manifest file fails to resolve. Defaults to no timeout.",
+			"\nExample:\n$ debricked resolve . --resolution-timeout=10m",
+		}, "\n")
+	cmd.Flags().DurationVar(&resolutionTimeout, ResolutionTimeoutFlag, 0, resolutionTimeoutDoc)
+	pmResolutionTimeoutDoc := strings.Join(
+		[]string{
+			"Overrides --resolution-timeout for manifest files of a package manager, keyed by package manager name.",
+			"\nExample:\n$ debricked resolve . --pm-resolution-timeout gradle=30m,pip=5m",
+		}, "\n")
+	cmd.Flags().StringToStringVar(&pmResolutionTimeouts, PmResolutionTimeoutFlag, nil, pmResolutionTimeoutDoc)
+	reportJsonDoc := strings.Join(
+		[]string{
+			"Writes a JSON report of the resolution of each manifest file to the file: the package manager, executed commands,",
+			"duration, produced lock files, status and errors, including their documentation.",
+			"\nExample:\n$ debricked resolve . --report-json=resolution-report.json",
+		}, "\n")
+	cmd.Flags().StringVar(&reportJson, ReportJsonFlag, "", reportJsonDoc)
+	reportJunitDoc := strings.Join(
+		[]string{
+			"Writes a JUnit XML report of the resolution to the file, with a test suite per package manager and a test case per manifest file.",
+			"\nExample:\n$ debricked resolve . --report-junit=resolution-report.xml",
+		}, "\n")
+	cmd.Flags().StringVar(&reportJunit, ReportJunitFlag, "", reportJunitDoc)
+	registriesConfigDoc := strings.Join(
+		[]string{
+			"Reads private registries of package managers from the registries section of the YAML file, with mirror URLs and credentials",
+			"read from environment variables or files. They are configured for the resolution in a temporary directory, outside of the repository.",
+			"Supported registries are npm (npm, Yarn and pnpm), pip (pip, Pipenv and pyproject.toml), maven, go, nuget and composer.",
+			"\nExample:\n$ debricked resolve . --registries-config=registries.yaml",
+		}, "\n")
+	cmd.Flags().StringVar(&registriesConfig, RegistriesConfigFlag, "", registriesConfigDoc)
+	toolchainDoc := strings.Join(
+		[]string{
+			"Executables of toolchains, keyed by toolchain: python, node, dotnet, go or mvn. Without one, the toolchain is selected by",
+			".python-version, .nvmrc or .node-version, global.json, the toolchain directive of go.mod and the Maven wrapper mvnw,",
+			"and otherwise found in PATH. The selected toolchain is shown in the status of each manifest file.",
+			"\nExample:\n$ debricked resolve . --toolchain python=/opt/py311/bin/python,node=/opt/node18/bin/node",
+		}, "\n")
+	cmd.Flags().StringToStringVar(&toolchains, ToolchainFlag, nil, toolchainDoc)
+	isolatedDoc := strings.Join(
+		[]string{
+			"Resolves manifest files in a copy of their directories in a temporary directory, without excluded files, and copies only",
+			"the produced lock files back, so that installed packages, virtual environments and temporary files don't end up in the repository.",
+			"\nExample:\n$ debricked resolve . --isolated",
+		}, "\n")
+	cmd.Flags().BoolVar(&isolated, IsolatedFlag, false, isolatedDoc)
+	lockOutputDirDoc := strings.Join(
+		[]string{
+			"Writes the lock files generated by Debricked to the directory, mirroring the tree of the working directory, instead of next to",
+			"the manifest files, so that protected source trees aren't written to. Together with --isolated, lock files of package managers",
+			"are written there too, and read-only source trees can be resolved. The lock files are found and uploaded as if they were next to the manifest files.",
+			"\nExample:\n$ debricked resolve . --lock-output-dir=/tmp/debricked-locks",
+		}, "\n")
+	cmd.Flags().StringVar(&lockOutputDir, LockOutputDirFlag, "", lockOutputDirDoc)
+	planDoc := strings.Join(
+		[]string{
+			"Prints the plan of the resolution without resolving anything: the manifest files that would be resolved,",
+			"by which package manager, with which commands and into which lock files, and why other manifest files are skipped.",
+			"Strategies of some package managers, such as Gradle and sbt, still run commands to find the projects of builds.",
+			"\nExample:\n$ debricked resolve . --plan --plan-format=json",
+		}, "\n")
+	cmd.Flags().BoolVar(&plan, PlanFlag, false, planDoc)
+	cmd.Flags().StringVar(&planFormat, PlanFormatFlag, TablePlanFormat, "Output format of --plan: table (default) or json")
 
 	cmd.Flags().IntVar(&resolutionStrictness, ResolutionStrictFlag, file.StrictAll, `Allows you to configure exit code 1 or 0 depending on if the resolution was successful or not.
 Strictness Level | Meaning
@@ -92,6 +266,22 @@ Strictness Level | Meaning
 
 	viper.MustBindEnv(ExclusionFlag)
 	viper.MustBindEnv(NpmPreferredFlag)
+	viper.MustBindEnv(JsPackageManagerFlag)
+	viper.MustBindEnv(LockfileOnlyFlag)
+	viper.MustBindEnv(ExcludeScopesFlag)
+	viper.MustBindEnv(ProductionOnlyFlag)
+	viper.MustBindEnv(CacheFlag)
+	viper.MustBindEnv(CacheDirFlag)
+	viper.MustBindEnv(ResolutionTimeoutFlag)
+	viper.MustBindEnv(ReportJsonFlag)
+	viper.MustBindEnv(ReportJunitFlag)
+	viper.MustBindEnv(RegistriesConfigFlag)
+	viper.MustBindEnv(IsolatedFlag)
+	viper.MustBindEnv(LockOutputDirFlag)
+	viper.MustBindEnv(PlanFlag)
+	viper.MustBindEnv(PlanFormatFlag)
+	viper.MustBindEnv(ChangedSinceFlag)
+	viper.MustBindEnv(CheckDriftFlag)
 
 	return cmd
 }
@@ -105,13 +295,49 @@ func RunE(resolver resolution.IResolver) func(_ *cobra.Command, args []string) e
 		if err != nil {
 			return err
 		}
+		regenerateLevel, err := resolution.GetRegenerateLevel(viper.GetString(RegenerateFlag))
+		if err != nil {
+			return err
+		}
+		pmTimeouts, err := resolution.GetPmResolutionTimeouts(viper.GetStringMapString(PmResolutionTimeoutFlag))
+		if err != nil {
+			return err
+		}
 		options := resolution.DebrickedOptions{
 			Exclusions:           viper.GetStringSlice(ExclusionFlag),
 			Verbose:              viper.GetBool(VerboseFlag),
-			Regenerate:           viper.GetInt(RegenerateFlag),
+			Regenerate:           regenerateLevel,
+			CheckDrift:           viper.GetBool(CheckDriftFlag),
+			ChangedSince:         viper.GetString(ChangedSinceFlag),
 			NpmPreferred:         viper.GetBool(NpmPreferredFlag),
+			JsPackageManager:     viper.GetString(JsPackageManagerFlag),
+			LockfileOnly:         viper.GetBool(LockfileOnlyFlag),
+			ExcludeScopes:        viper.GetStringSlice(ExcludeScopesFlag),
+			ProductionOnly:       viper.GetBool(ProductionOnlyFlag),
+			Cache:                viper.GetBool(CacheFlag),
+			CacheDir:             viper.GetString(CacheDirFlag),
+			ResolutionTimeout:    viper.GetDuration(ResolutionTimeoutFlag),
+			PmResolutionTimeouts: pmTimeouts,
+			ReportJson:           viper.GetString(ReportJsonFlag),
+			ReportJunit:          viper.GetString(ReportJunitFlag),
+			RegistriesConfig:     viper.GetString(RegistriesConfigFlag),
+			Toolchains:           viper.GetStringMapString(ToolchainFlag),
+			Isolated:             viper.GetBool(IsolatedFlag),
+			LockOutputDir:        viper.GetString(LockOutputDirFlag),
 			ResolutionStrictness: strictness,
 		}
+		if viper.GetBool(PlanFlag) {
+			err = validatePlanFormat(viper.GetString(PlanFormatFlag))
+			if err != nil {
+				return err
+			}
+			resolutionPlan, err := resolver.Plan(args, options)
+			if err != nil {
+				return err
+			}
+
+			return printPlan(os.Stdout, resolutionPlan, viper.GetString(PlanFormatFlag))
+		}
 		_, err = resolver.Resolve(args, options)
 
 		return err
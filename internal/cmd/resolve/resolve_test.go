@@ -30,6 +30,21 @@ func TestNewResolveCmd(t *testing.T) {
 
 	var flagKeys = []string{
 		ExclusionFlag,
+		JsPackageManagerFlag,
+		LockfileOnlyFlag,
+		ExcludeScopesFlag,
+		ProductionOnlyFlag,
+		CacheFlag,
+		CacheDirFlag,
+		ResolutionTimeoutFlag,
+		ReportJsonFlag,
+		ReportJunitFlag,
+		RegistriesConfigFlag,
+		IsolatedFlag,
+		LockOutputDirFlag,
+		PlanFlag,
+		PlanFormatFlag,
+		CheckDriftFlag,
 	}
 	viperKeys := viper.AllKeys()
 	for _, flagKey := range flagKeys {
@@ -102,3 +117,67 @@ func TestRunEErrorInvalidStrictness(t *testing.T) {
 
 	assert.EqualError(t, err, "invalid strictness level: 123", "error doesn't match expected")
 }
+
+func TestRunEErrorInvalidRegenerate(t *testing.T) {
+	r := &resolveTestdata.ResolverMock{}
+	resolutionStrictness = 0
+	cmd := NewResolveCmd(r)
+	assert.NoError(t, cmd.Flags().Set(RegenerateFlag, "always"))
+	cmd.PreRun(cmd, nil)
+	defer viper.Reset()
+
+	err := cmd.RunE(cmd, []string{"."})
+
+	assert.EqualError(t, err, "invalid regenerate level: always")
+}
+
+func TestRunEPmResolutionTimeouts(t *testing.T) {
+	r := &resolveTestdata.ResolverMock{}
+	resolutionStrictness = 0
+	cmd := NewResolveCmd(r)
+	assert.NoError(t, cmd.Flags().Set(PmResolutionTimeoutFlag, "gradle=30m,pip=5m"))
+	cmd.PreRun(cmd, nil)
+	defer viper.Reset()
+
+	assert.Equal(t, map[string]string{"gradle": "30m", "pip": "5m"}, viper.GetStringMapString(PmResolutionTimeoutFlag))
+	err := cmd.RunE(cmd, []string{"."})
+
+	assert.NoError(t, err)
+}
+
+func TestRunEErrorInvalidPmResolutionTimeout(t *testing.T) {
+	r := &resolveTestdata.ResolverMock{}
+	resolutionStrictness = 0
+	viper.Set(PmResolutionTimeoutFlag, map[string]string{"gradle": "forever"})
+	defer viper.Set(PmResolutionTimeoutFlag, nil)
+	runE := RunE(r)
+	err := runE(nil, []string{"."})
+
+	assert.ErrorContains(t, err, "invalid resolution timeout of gradle")
+}
+
+func TestRunEPlan(t *testing.T) {
+	r := &resolveTestdata.ResolverMock{}
+	resolutionStrictness = 0
+	viper.Set(PlanFlag, true)
+	viper.Set(PlanFormatFlag, JsonPlanFormat)
+	defer viper.Set(PlanFlag, false)
+	defer viper.Set(PlanFormatFlag, TablePlanFormat)
+	runE := RunE(r)
+	err := runE(nil, []string{"."})
+
+	assert.NoError(t, err)
+}
+
+func TestRunEPlanErrorInvalidFormat(t *testing.T) {
+	r := &resolveTestdata.ResolverMock{}
+	resolutionStrictness = 0
+	viper.Set(PlanFlag, true)
+	viper.Set(PlanFormatFlag, "xml")
+	defer viper.Set(PlanFlag, false)
+	defer viper.Set(PlanFormatFlag, TablePlanFormat)
+	runE := RunE(r)
+	err := runE(nil, []string{"."})
+
+	assert.EqualError(t, err, "invalid plan format: xml, use table or json")
+}
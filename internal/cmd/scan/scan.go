@@ -5,8 +5,12 @@ import (
 	"fmt"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/debricked/cli/internal/file"
+	"github.com/debricked/cli/internal/resolution"
+	"github.com/debricked/cli/internal/resolution/cache"
+	resolutionFile "github.com/debricked/cli/internal/resolution/file"
 	"github.com/debricked/cli/internal/scan"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
@@ -21,12 +25,30 @@ var repositoryUrl string
 var integrationName string
 var exclusions = file.Exclusions()
 var verbose bool
-var regenerate int
+var regenerate string
+var changedSince string
+var imagePath string
+var osPackages bool
+var goBinaries bool
 var noResolve bool
 var noFingerprint bool
 var passOnDowntime bool
 var callgraph bool
 var npmPreferred bool
+var jsPackageManager string
+var lockfileOnly bool
+var excludeScopes []string
+var productionOnly bool
+var useCache bool
+var cacheDir string
+var resolutionTimeout time.Duration
+var pmResolutionTimeouts map[string]string
+var reportJson string
+var reportJunit string
+var registriesConfig string
+var toolchains map[string]string
+var isolated bool
+var lockOutputDir string
 var writeToJson bool
 var callgraphUploadTimeout int
 var callgraphGenerateTimeout int
@@ -41,6 +63,10 @@ const (
 	ExclusionFlag                = "exclusion"
 	VerboseFlag                  = "verbose"
 	RegenerateFlag               = "regenerate"
+	ChangedSinceFlag             = "changed-since"
+	ImageFlag                    = "image"
+	OsPackagesFlag               = "os-packages"
+	GoBinariesFlag               = "go-binaries"
 	NoResolveFlag                = "no-resolve"
 	FingerprintFlag              = "fingerprint"
 	PassOnTimeOut                = "pass-on-timeout"
@@ -48,6 +74,20 @@ const (
 	CallGraphUploadTimeoutFlag   = "callgraph-upload-timeout"
 	CallGraphGenerateTimeoutFlag = "callgraph-generate-timeout"
 	NpmPreferredFlag             = "prefer-npm"
+	JsPackageManagerFlag         = "js-package-manager"
+	LockfileOnlyFlag             = "lockfile-only"
+	ExcludeScopesFlag            = "exclude-scopes"
+	ProductionOnlyFlag           = "production-only"
+	CacheFlag                    = "cache"
+	CacheDirFlag                 = "cache-dir"
+	ResolutionTimeoutFlag        = "resolution-timeout"
+	PmResolutionTimeoutFlag      = "pm-resolution-timeout"
+	ReportJsonFlag               = "report-json"
+	ReportJunitFlag              = "report-junit"
+	RegistriesConfigFlag         = "registries-config"
+	ToolchainFlag                = "toolchain"
+	IsolatedFlag                 = "isolated"
+	LockOutputDirFlag            = "lock-output-dir"
 	WriteToJsonFlag              = "write-json"
 )
 
@@ -100,15 +140,40 @@ Examples:
 $ debricked scan . `+exampleFlags)
 	regenerateDoc := strings.Join(
 		[]string{
-			"Toggles regeneration of already existing lock files between 3 modes:\n",
+			"Toggles regeneration of already existing lock files between 4 modes:\n",
 			"Force Regeneration Level | Meaning",
 			"------------------------ | -------",
 			"0 (default)              | No regeneration",
 			"1                        | Regenerates existing non package manager native Debricked lock files",
 			"2                        | Regenerates all existing lock files",
+			"drifted                  | Regenerates existing lock files that are stale relative to their manifest files",
 			"\nExample:\n$ debricked resolve . --regenerate=1",
 		}, "\n")
-	cmd.Flags().IntVar(&regenerate, RegenerateFlag, 0, regenerateDoc)
+	cmd.Flags().StringVar(&regenerate, RegenerateFlag, "0", regenerateDoc)
+	changedSinceDoc := strings.Join(
+		[]string{
+			"Only resolve and upload dependency files that changed between the git reference and HEAD, including uncommitted changes.",
+			"Changes to package manager configuration files, such as settings.gradle or .npmrc, scan all dependency files.",
+			"\nExample:\n$ debricked scan . --changed-since=origin/main",
+		}, "\n")
+	cmd.Flags().StringVar(&changedSince, ChangedSinceFlag, "", changedSinceDoc)
+	cmd.Flags().StringVar(&imagePath, ImageFlag, "", `Scan dependency files inside a container image tarball, created by "docker save" or as an OCI image layout.
+The image layers are flattened without a Docker daemon, and dependency files are uploaded with their paths inside the image.
+Resolution and call graph generation are disabled when scanning an image. Git metadata is still read from the inputted path. Can't be used together with --changed-since.
+
+Example:
+$ docker save -o image.tar my-image:latest
+$ debricked scan . --image image.tar`)
+	cmd.Flags().BoolVar(&osPackages, OsPackagesFlag, false, `Collect installed operating system packages from the dpkg and apk databases of the inputted path, or of the image if "image" is set.
+The packages are written to "dpkg.debricked.lock" and "apk.debricked.lock" next to the databases, and uploaded with the other dependency files.
+
+Example:
+$ debricked scan . --image image.tar --os-packages`)
+	cmd.Flags().BoolVar(&goBinaries, GoBinariesFlag, false, `Collect the Go modules compiled into Go executables found in the inputted path, or in the image if "image" is set.
+The modules are read from the build info embedded in each executable and written to "<executable>.gomod.debricked.lock" next to it.
+
+Example:
+$ debricked scan . --image image.tar --go-binaries`)
 	verboseDoc := strings.Join(
 		[]string{
 			"This flag allows you to reduce error output for resolution.",
@@ -125,11 +190,118 @@ For example, if there is a "go.mod" in the target path, its dependencies are goi
 	cmd.Flags().IntVar(&callgraphGenerateTimeout, CallGraphGenerateTimeoutFlag, 60*60, "Set a timeout (in seconds) on call graph generation.")
 	npmPreferredDoc := strings.Join(
 		[]string{
-			"This flag allows you to select which package manager will be used as a resolver: Yarn (default) or NPM.",
-			"Example: debricked resolve --prefer-npm",
+			"This flag allows you to select NPM as resolver of package.json files. Alias of --js-package-manager=npm, used when --js-package-manager is auto.",
+			"Example: debricked scan --prefer-npm",
 		}, "\n")
 
 	cmd.Flags().BoolP(NpmPreferredFlag, "", npmPreferred, npmPreferredDoc)
+	jsPackageManagerDoc := strings.Join(
+		[]string{
+			"Selects the package manager resolving package.json files: auto (default), yarn, npm or pnpm.",
+			"auto selects the package manager of each package.json, in the following order, searching from its directory up to the repository root:",
+			"the packageManager or devEngines.packageManager field of package.json, existing lock files or package manager configuration files.",
+			"Yarn is used if no package manager is found.",
+			"\nExample:\n$ debricked scan . --js-package-manager=pnpm",
+		}, "\n")
+	cmd.Flags().StringVar(&jsPackageManager, JsPackageManagerFlag, resolutionFile.JsPackageManagerAuto, jsPackageManagerDoc)
+	lockfileOnlyDoc := strings.Join(
+		[]string{
+			"Resolves lock files without installing packages or running install scripts.",
+			"npm runs with --package-lock-only, Yarn 2 or later with --mode=update-lockfile and pip with --dry-run --report.",
+			"Bower lists the already installed components offline. Yarn Classic and Bower without installed components fail to resolve.",
+			"\nExample:\n$ debricked scan . --lockfile-only",
+		}, "\n")
+	cmd.Flags().BoolVar(&lockfileOnly, LockfileOnlyFlag, false, lockfileOnlyDoc)
+	excludeScopesDoc := strings.Join(
+		[]string{
+			"Leaves the dependencies of the given scopes out of resolution: dev, test or both, separated by commas.",
+			"npm and Yarn Classic omit devDependencies, Composer runs with --no-dev and NuGet leaves out developmentDependency packages of packages.config.",
+			"Maven leaves out the test scope, and the provided scope too if dev is also excluded. Gradle leaves out test configurations for test,",
+			"and compileOnly, annotationProcessor and developmentOnly configurations for dev. Poetry leaves out dev and test groups",
+			"and pip skips requirements files of the scope, such as requirements-dev.txt.",
+			"\nExample:\n$ debricked scan . --exclude-scopes dev,test",
+		}, "\n")
+	cmd.Flags().StringSliceVar(&excludeScopes, ExcludeScopesFlag, nil, excludeScopesDoc)
+	productionOnlyDoc := strings.Join(
+		[]string{
+			"Leaves the dependencies of all scopes that aren't shipped with the project out of resolution. Shorthand for --exclude-scopes dev,test.",
+			"\nExample:\n$ debricked scan . --production-only",
+		}, "\n")
+	cmd.Flags().BoolVar(&productionOnly, ProductionOnlyFlag, false, productionOnlyDoc)
+	cacheDoc := strings.Join(
+		[]string{
+			"Enables the cache of resolution results. The lock files a resolution job writes for a manifest file, both the",
+			"*.debricked.lock files and lock files of package managers such as package-lock.json, are cached",
+			"in the cache directory, keyed by the contents of the manifest file, the package manager configuration files around it,",
+			"the package manager version and the resolution options. Resolution is skipped for manifest files with cached lock files,",
+			"which are restored instead. The cache is disabled by default, since computing the key reads the files in the directory",
+			"of each manifest file and its subdirectories.",
+			"\nExample:\n$ debricked scan . --cache",
+		}, "\n")
+	cmd.Flags().BoolVar(&useCache, CacheFlag, false, cacheDoc)
+	cacheDirDoc := strings.Join(
+		[]string{
+			"The directory of the cache of resolution results. Defaults to debricked/resolution in the user cache directory.",
+			"\nExample:\n$ debricked scan . --cache-dir=.debricked-cache",
+		}, "\n")
+	cmd.Flags().StringVar(&cacheDir, CacheDirFlag, cache.DefaultDir(), cacheDirDoc)
+	resolutionTimeoutDoc := strings.Join(
+		[]string{
+			"The time each manifest file may take to resolve, for example 10m. Package managers still running when it has passed",
+			"are killed together with the processes they started, and the manifest file fails to resolve. Defaults to no timeout.",
+			"\nExample:\n$ debricked scan . --resolution-timeout=10m",
+		}, "\n")
+	cmd.Flags().DurationVar(&resolutionTimeout, ResolutionTimeoutFlag, 0, resolutionTimeoutDoc)
+	pmResolutionTimeoutDoc := strings.Join(
+		[]string{
+			"Overrides --resolution-timeout for manifest files of a package manager, keyed by package manager name.",
+			"\nExample:\n$ debricked scan . --pm-resolution-timeout gradle=30m,pip=5m",
+		}, "\n")
+	cmd.Flags().StringToStringVar(&pmResolutionTimeouts, PmResolutionTimeoutFlag, nil, pmResolutionTimeoutDoc)
+	reportJsonDoc := strings.Join(
+		[]string{
+			"Writes a JSON report of the resolution of each manifest file to the file: the package manager, executed commands,",
+			"duration, produced lock files, status and errors, including their documentation.",
+			"\nExample:\n$ debricked scan . --report-json=resolution-report.json",
+		}, "\n")
+	cmd.Flags().StringVar(&reportJson, ReportJsonFlag, "", reportJsonDoc)
+	reportJunitDoc := strings.Join(
+		[]string{
+			"Writes a JUnit XML report of the resolution to the file, with a test suite per package manager and a test case per manifest file.",
+			"\nExample:\n$ debricked scan . --report-junit=resolution-report.xml",
+		}, "\n")
+	cmd.Flags().StringVar(&reportJunit, ReportJunitFlag, "", reportJunitDoc)
+	registriesConfigDoc := strings.Join(
+		[]string{
+			"Reads private registries of package managers from the registries section of the YAML file, with mirror URLs and credentials",
+			"read from environment variables or files. They are configured for the resolution in a temporary directory, outside of the repository.",
+			"Supported registries are npm (npm, Yarn and pnpm), pip (pip, Pipenv and pyproject.toml), maven, go, nuget and composer.",
+			"\nExample:\n$ debricked scan . --registries-config=registries.yaml",
+		}, "\n")
+	cmd.Flags().StringVar(&registriesConfig, RegistriesConfigFlag, "", registriesConfigDoc)
+	toolchainDoc := strings.Join(
+		[]string{
+			"Executables of toolchains, keyed by toolchain: python, node, dotnet, go or mvn. Without one, the toolchain is selected by",
+			".python-version, .nvmrc or .node-version, global.json, the toolchain directive of go.mod and the Maven wrapper mvnw,",
+			"and otherwise found in PATH. The selected toolchain is shown in the status of each manifest file.",
+			"\nExample:\n$ debricked scan . --toolchain python=/opt/py311/bin/python,node=/opt/node18/bin/node",
+		}, "\n")
+	cmd.Flags().StringToStringVar(&toolchains, ToolchainFlag, nil, toolchainDoc)
+	isolatedDoc := strings.Join(
+		[]string{
+			"Resolves manifest files in a copy of their directories in a temporary directory, without excluded files, and copies only",
+			"the produced lock files back, so that installed packages, virtual environments and temporary files don't end up in the repository.",
+			"\nExample:\n$ debricked scan . --isolated",
+		}, "\n")
+	cmd.Flags().BoolVar(&isolated, IsolatedFlag, false, isolatedDoc)
+	lockOutputDirDoc := strings.Join(
+		[]string{
+			"Writes the lock files generated by Debricked to the directory, mirroring the tree of the working directory, instead of next to",
+			"the manifest files, so that protected source trees aren't written to. Together with --isolated, lock files of package managers",
+			"are written there too, and read-only source trees can be resolved. The lock files are found and uploaded as if they were next to the manifest files.",
+			"\nExample:\n$ debricked scan . --lock-output-dir=/tmp/debricked-locks",
+		}, "\n")
+	cmd.Flags().StringVar(&lockOutputDir, LockOutputDirFlag, "", lockOutputDirDoc)
 
 	viper.MustBindEnv(RepositoryFlag)
 	viper.MustBindEnv(CommitFlag)
@@ -139,6 +311,19 @@ For example, if there is a "go.mod" in the target path, its dependencies are goi
 	viper.MustBindEnv(IntegrationFlag)
 	viper.MustBindEnv(PassOnTimeOut)
 	viper.MustBindEnv(NpmPreferredFlag)
+	viper.MustBindEnv(JsPackageManagerFlag)
+	viper.MustBindEnv(LockfileOnlyFlag)
+	viper.MustBindEnv(ExcludeScopesFlag)
+	viper.MustBindEnv(ProductionOnlyFlag)
+	viper.MustBindEnv(CacheFlag)
+	viper.MustBindEnv(CacheDirFlag)
+	viper.MustBindEnv(ResolutionTimeoutFlag)
+	viper.MustBindEnv(ReportJsonFlag)
+	viper.MustBindEnv(ReportJunitFlag)
+	viper.MustBindEnv(RegistriesConfigFlag)
+	viper.MustBindEnv(IsolatedFlag)
+	viper.MustBindEnv(LockOutputDirFlag)
+	viper.MustBindEnv(ChangedSinceFlag)
 
 	return cmd
 }
@@ -149,13 +334,25 @@ func RunE(s *scan.IScanner) func(_ *cobra.Command, args []string) error {
 		if len(args) > 0 {
 			path = args[0]
 		}
+		regenerateLevel, err := resolution.GetRegenerateLevel(viper.GetString(RegenerateFlag))
+		if err != nil {
+			return err
+		}
+		pmTimeouts, err := resolution.GetPmResolutionTimeouts(viper.GetStringMapString(PmResolutionTimeoutFlag))
+		if err != nil {
+			return err
+		}
 		options := scan.DebrickedOptions{
 			Path:                     path,
 			Resolve:                  !viper.GetBool(NoResolveFlag),
 			Fingerprint:              viper.GetBool(FingerprintFlag),
 			Exclusions:               viper.GetStringSlice(ExclusionFlag),
 			Verbose:                  viper.GetBool(VerboseFlag),
-			Regenerate:               viper.GetInt(RegenerateFlag),
+			Regenerate:               regenerateLevel,
+			ChangedSince:             viper.GetString(ChangedSinceFlag),
+			Image:                    viper.GetString(ImageFlag),
+			OsPackages:               viper.GetBool(OsPackagesFlag),
+			GoBinaries:               viper.GetBool(GoBinariesFlag),
 			RepositoryName:           viper.GetString(RepositoryFlag),
 			CommitName:               viper.GetString(CommitFlag),
 			BranchName:               viper.GetString(BranchFlag),
@@ -163,6 +360,20 @@ func RunE(s *scan.IScanner) func(_ *cobra.Command, args []string) error {
 			RepositoryUrl:            viper.GetString(RepositoryUrlFlag),
 			IntegrationName:          viper.GetString(IntegrationFlag),
 			NpmPreferred:             viper.GetBool(NpmPreferredFlag),
+			JsPackageManager:         viper.GetString(JsPackageManagerFlag),
+			LockfileOnly:             viper.GetBool(LockfileOnlyFlag),
+			ExcludeScopes:            viper.GetStringSlice(ExcludeScopesFlag),
+			ProductionOnly:           viper.GetBool(ProductionOnlyFlag),
+			Cache:                    viper.GetBool(CacheFlag),
+			CacheDir:                 viper.GetString(CacheDirFlag),
+			ResolutionTimeout:        viper.GetDuration(ResolutionTimeoutFlag),
+			PmResolutionTimeouts:     pmTimeouts,
+			ReportJson:               viper.GetString(ReportJsonFlag),
+			ReportJunit:              viper.GetString(ReportJunitFlag),
+			RegistriesConfig:         viper.GetString(RegistriesConfigFlag),
+			Toolchains:               viper.GetStringMapString(ToolchainFlag),
+			Isolated:                 viper.GetBool(IsolatedFlag),
+			LockOutputDir:            viper.GetString(LockOutputDirFlag),
 			PassOnTimeOut:            viper.GetBool(PassOnTimeOut),
 			CallGraph:                viper.GetBool(CallGraphFlag),
 			WriteToJson:              viper.GetBool(WriteToJsonFlag),
@@ -25,6 +25,22 @@ func TestNewScanCmd(t *testing.T) {
 		CallGraphFlag:                "",
 		CallGraphUploadTimeoutFlag:   "",
 		CallGraphGenerateTimeoutFlag: "",
+		OsPackagesFlag:               "",
+		GoBinariesFlag:               "",
+		JsPackageManagerFlag:         "",
+		LockfileOnlyFlag:             "",
+		ExcludeScopesFlag:            "",
+		ProductionOnlyFlag:           "",
+		CacheFlag:                    "",
+		CacheDirFlag:                 "",
+		ResolutionTimeoutFlag:        "",
+		PmResolutionTimeoutFlag:      "",
+		ReportJsonFlag:               "",
+		ReportJunitFlag:              "",
+		RegistriesConfigFlag:         "",
+		ToolchainFlag:                "",
+		IsolatedFlag:                 "",
+		LockOutputDirFlag:            "",
 	}
 	flags := cmd.Flags()
 	for name, shorthand := range flagAssertions {
@@ -22,9 +22,23 @@ var supportedFormats embed.FS
 const SupportedFormatsFallbackFilePath = "embedded/supported_formats.json"
 const SupportedFormatsUri = "/api/1.0/open/files/supported-formats"
 
+// generatedFormats are the formats of lock files generated by the CLI, which aren't part of the supported formats
+// of the server. They are matched after the supported formats
+var generatedFormats = []struct {
+	pm     string
+	format *Format
+}{
+	{"dpkg", &Format{LockFileRegexes: []string{`^dpkg\.debricked\.lock$`}}},
+	{"apk", &Format{LockFileRegexes: []string{`^apk\.debricked\.lock$`}}},
+	{"sbt", &Format{LockFileRegexes: []string{`^sbt\.debricked\.lock$`}}},
+	{"cargo", &Format{LockFileRegexes: []string{`^cargo\.debricked\.lock$`}}},
+	{"pyproject", &Format{LockFileRegexes: []string{`^pyproject\.debricked\.lock$`}}},
+}
+
 type IFinder interface {
-	GetGroups(rootPath string, exclusions []string, lockfileOnly bool, strictness int) (Groups, error)
+	GetGroups(rootPath string, exclusions []string, lockfileOnly bool, strictness int, lockOutputDir string) (Groups, error)
 	GetSupportedFormats() ([]*CompiledFormat, error)
+	GetManifestGroups(rootPath string, formats []*CompiledFormat, exclusions []string, lockOutputDir string) (Groups, error)
 }
 
 type Finder struct {
@@ -40,8 +54,9 @@ func NewFinder(c client.IDebClient, fs ioFs.IFileSystem) (*Finder, error) {
 	return &Finder{c, fs}, nil
 }
 
-// GetGroups return all file groups in specified path recursively.
-func (finder *Finder) GetGroups(rootPath string, exclusions []string, lockfileOnly bool, strictness int) (Groups, error) {
+// GetGroups return all file groups in specified path recursively. Lock files in lockOutputDir, if set, are grouped
+// with the manifest files in the directories they mirror
+func (finder *Finder) GetGroups(rootPath string, exclusions []string, lockfileOnly bool, strictness int, lockOutputDir string) (Groups, error) {
 	var groups Groups
 
 	formats, err := finder.GetSupportedFormats()
@@ -59,6 +74,9 @@ func (finder *Finder) GetGroups(rootPath string, exclusions []string, lockfileOn
 			if err != nil {
 				return err
 			}
+			if fileInfo.IsDir() && path != rootPath && InLockOutputDir(lockOutputDir, path) {
+				return filepath.SkipDir
+			}
 			if !fileInfo.IsDir() && !Excluded(exclusions, path) {
 				for _, format := range formats {
 					if groups.Match(format, path, lockfileOnly) {
@@ -71,12 +89,47 @@ func (finder *Finder) GetGroups(rootPath string, exclusions []string, lockfileOn
 			return nil
 		},
 	)
+	if err == nil && len(lockOutputDir) > 0 {
+		err = matchLockOutputDir(&groups, formats, lockOutputDir, exclusions)
+	}
 
+	groups.PairJsWorkspaceMembers()
 	groups.FilterGroupsByStrictness(strictness)
 
 	return groups, err
 }
 
+// GetManifestGroups returns a group without lock files of each manifest file in rootPath matching formats,
+// which are formats of manifest files that may be missing from the supported formats
+func (finder *Finder) GetManifestGroups(rootPath string, formats []*CompiledFormat, exclusions []string, lockOutputDir string) (Groups, error) {
+	var groups Groups
+	err := filepath.Walk(
+		rootPath,
+		func(path string, fileInfo os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if fileInfo.IsDir() && path != rootPath && InLockOutputDir(lockOutputDir, path) {
+				return filepath.SkipDir
+			}
+			if fileInfo.IsDir() || Excluded(exclusions, path) {
+				return nil
+			}
+			for _, format := range formats {
+				if format.MatchFile(filepath.Base(path)) {
+					groups.Add(*NewGroup(path, format, []string{}))
+
+					break
+				}
+			}
+
+			return nil
+		},
+	)
+
+	return groups, err
+}
+
 // GetSupportedFormats returns all supported dependency file formats
 func (finder *Finder) GetSupportedFormats() ([]*CompiledFormat, error) {
 	body, err := finder.GetSupportedFormatsJson()
@@ -99,6 +152,14 @@ func (finder *Finder) GetSupportedFormats() ([]*CompiledFormat, error) {
 			log.Println(err.Error())
 		}
 	}
+	for _, generatedFormat := range generatedFormats {
+		compiledDependencyFileFormat, err := NewPmCompiledFormat(generatedFormat.format, generatedFormat.pm)
+		if err == nil {
+			compiledDependencyFileFormats = append(compiledDependencyFileFormats, compiledDependencyFileFormat)
+		} else {
+			log.Println(err.Error())
+		}
+	}
 
 	return compiledDependencyFileFormats, nil
 }
@@ -131,3 +192,34 @@ func (finder *Finder) GetSupportedFormatsFallbackJson() ([]byte, error) {
 
 	return jsonData, nil
 }
+
+// matchLockOutputDir groups the lock files in lockOutputDir with the manifest files in the directories they mirror
+func matchLockOutputDir(groups *Groups, formats []*CompiledFormat, lockOutputDir string, exclusions []string) error {
+	if _, err := os.Stat(lockOutputDir); os.IsNotExist(err) {
+		return nil
+	}
+
+	return filepath.Walk(
+		lockOutputDir,
+		func(path string, fileInfo os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if fileInfo.IsDir() || Excluded(exclusions, path) {
+				return nil
+			}
+			sourcePath, ok := SourcePath(lockOutputDir, path)
+			if !ok {
+				return nil
+			}
+			for _, format := range formats {
+				if groups.MatchMirroredLockFile(format, path, sourcePath) {
+
+					break
+				}
+			}
+
+			return nil
+		},
+	)
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"io"
 	"net/http"
+	"os"
 	"path"
 	"path/filepath"
 	"sort"
@@ -99,6 +100,71 @@ func TestGetSupportedFormatsEndpointUnaccessible(t *testing.T) {
 	}
 }
 
+func TestGetGroupsOsPackageLockFiles(t *testing.T) {
+	setUp(true)
+	dir := t.TempDir()
+	dpkgLockFile := filepath.Join(dir, "var", "lib", "dpkg", "dpkg.debricked.lock")
+	apkLockFile := filepath.Join(dir, "lib", "apk", "db", "apk.debricked.lock")
+	for _, lockFile := range []string{dpkgLockFile, apkLockFile} {
+		assert.NoError(t, os.MkdirAll(filepath.Dir(lockFile), 0750))
+		assert.NoError(t, os.WriteFile(lockFile, []byte(`{"packageManager": "dpkg", "packages": []}`), 0600))
+	}
+
+	fileGroups, err := finder.GetGroups(dir, nil, false, StrictAll, "")
+
+	assert.NoError(t, err)
+	var lockFiles []string
+	for _, group := range fileGroups.ToSlice() {
+		lockFiles = append(lockFiles, group.LockFiles...)
+	}
+	assert.ElementsMatch(t, []string{dpkgLockFile, apkLockFile}, lockFiles)
+}
+
+func TestGetGroupsGeneratedLockFiles(t *testing.T) {
+	cases := map[string]string{
+		"sbt":       filepath.Join("core", "sbt.debricked.lock"),
+		"cargo":     filepath.Join("crate", "cargo.debricked.lock"),
+		"pyproject": filepath.Join("app", "pyproject.debricked.lock"),
+	}
+	for name, lockFile := range cases {
+		t.Run(name, func(t *testing.T) {
+			setUp(true)
+			dir := t.TempDir()
+			lockFile = filepath.Join(dir, lockFile)
+			assert.NoError(t, os.MkdirAll(filepath.Dir(lockFile), 0750))
+			assert.NoError(t, os.WriteFile(lockFile, []byte{}, 0600))
+
+			fileGroups, err := finder.GetGroups(dir, nil, false, StrictAll, "")
+
+			assert.NoError(t, err)
+			assert.Equal(t, 1, fileGroups.Size())
+			assert.Equal(t, []string{lockFile}, fileGroups.ToSlice()[0].LockFiles)
+		})
+	}
+}
+
+func TestGetManifestGroups(t *testing.T) {
+	setUp(true)
+	dir := t.TempDir()
+	lockOutputDir := filepath.Join(dir, "locks")
+	manifest := filepath.Join(dir, "app", "pyproject.toml")
+	for _, f := range []string{manifest, filepath.Join(dir, "README.md"), filepath.Join(dir, "excluded", "pyproject.toml"), filepath.Join(lockOutputDir, "pyproject.toml")} {
+		assert.NoError(t, os.MkdirAll(filepath.Dir(f), 0750))
+		assert.NoError(t, os.WriteFile(f, []byte{}, 0600))
+	}
+	format, err := NewCompiledFormat(&Format{ManifestFileRegex: `pyproject\.toml$`})
+	assert.NoError(t, err)
+
+	groups, err := finder.GetManifestGroups(dir, []*CompiledFormat{format}, []string{"**/excluded/**"}, lockOutputDir)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, groups.Size())
+	group := groups.ToSlice()[0]
+	assert.Equal(t, manifest, group.ManifestFile)
+	assert.Empty(t, group.LockFiles)
+	assert.Same(t, format, group.CompiledFormat)
+}
+
 func TestGetGroups(t *testing.T) {
 	setUp(true)
 	path := ""
@@ -107,7 +173,7 @@ func TestGetGroups(t *testing.T) {
 	excludedFiles := []string{"testdata/go/go.mod", "testdata/misc/requirements.txt", "testdata/misc/Cargo.lock"}
 	const nbrOfGroups = 5
 
-	fileGroups, err := finder.GetGroups(path, exclusions, false, StrictAll)
+	fileGroups, err := finder.GetGroups(path, exclusions, false, StrictAll, "")
 
 	assert.NoError(t, err)
 	assert.Equalf(t, nbrOfGroups, fileGroups.Size(), "failed to assert that %d groups were created. %d was found", nbrOfGroups, fileGroups.Size())
@@ -132,7 +198,7 @@ func TestGetGroupsPIP(t *testing.T) {
 	const nbrOfGroups = 3
 
 	lockfileOnly := false
-	fileGroups, err := finder.GetGroups(path, []string{}, lockfileOnly, StrictAll)
+	fileGroups, err := finder.GetGroups(path, []string{}, lockfileOnly, StrictAll, "")
 
 	assert.NoError(t, err)
 	assert.Equalf(t, nbrOfGroups, fileGroups.Size(), "failed to assert that %d groups were created. %d was found", nbrOfGroups, fileGroups.Size())
@@ -168,11 +234,49 @@ func TestGetGroupsPIP(t *testing.T) {
 
 }
 
+func TestGetGroupsLockOutputDir(t *testing.T) {
+	setUp(true)
+	path := "testdata/pip"
+	lockOutputDir := t.TempDir()
+	lockFile := filepath.Join(lockOutputDir, "testdata", "pip", ".requirements.test.txt.pip.debricked.lock")
+	assert.NoError(t, os.MkdirAll(filepath.Dir(lockFile), 0750))
+	assert.NoError(t, os.WriteFile(lockFile, nil, 0600))
+
+	fileGroups, err := finder.GetGroups(path, []string{}, false, StrictAll, lockOutputDir)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, fileGroups.Size())
+	for _, fg := range fileGroups.groups {
+		if strings.Contains(fg.ManifestFile, "requirements.test.txt") {
+			assert.Equal(t, []string{lockFile}, fg.LockFiles)
+		}
+	}
+}
+
+func TestGetGroupsLockOutputDirInRootPath(t *testing.T) {
+	setUp(true)
+	path := "testdata/pip"
+	lockOutputDir := filepath.Join(path, ".debricked")
+	lockFile := filepath.Join(lockOutputDir, "testdata", "pip", ".requirements.test.txt.pip.debricked.lock")
+	assert.NoError(t, os.MkdirAll(filepath.Dir(lockFile), 0750))
+	assert.NoError(t, os.WriteFile(lockFile, nil, 0600))
+	defer os.RemoveAll(lockOutputDir)
+
+	fileGroups, err := finder.GetGroups(path, []string{}, false, StrictAll, lockOutputDir)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, fileGroups.Size())
+	var lockFiles []string
+	for _, fg := range fileGroups.groups {
+		lockFiles = append(lockFiles, fg.LockFiles...)
+	}
+	assert.Len(t, lockFiles, 3)
+	assert.Contains(t, lockFiles, lockFile)
+}
+
 func TestGetGroupsWithOnlyLockFiles(t *testing.T) {
 	setUp(true)
 	path := "testdata/misc"
 	const nbrOfGroups = 2
-	fileGroups, err := finder.GetGroups(path, []string{"**/requirements*.txt", "**/composer.json", "**/composer.lock", "**/go.mod"}, false, StrictAll)
+	fileGroups, err := finder.GetGroups(path, []string{"**/requirements*.txt", "**/composer.json", "**/composer.lock", "**/go.mod"}, false, StrictAll, "")
 	assert.NoError(t, err)
 	assert.Equalf(t, nbrOfGroups, fileGroups.Size(), "failed to assert that %d groups were created. %d was found", nbrOfGroups, fileGroups.Size())
 
@@ -189,7 +293,7 @@ func TestGetGroupsWithTwoFileMatchesInSameDir(t *testing.T) {
 	setUp(true)
 	path := "testdata/pip"
 	const nbrOfGroups = 3
-	fileGroups, err := finder.GetGroups(path, []string{}, false, StrictAll)
+	fileGroups, err := finder.GetGroups(path, []string{}, false, StrictAll, "")
 	assert.NoError(t, err)
 	assert.Equalf(t, nbrOfGroups, fileGroups.Size(), "failed to assert that %d groups were created. %d was found", nbrOfGroups, fileGroups.Size())
 
@@ -257,7 +361,7 @@ func TestGetGroupsWithStrictFlag(t *testing.T) {
 	for _, c := range cases {
 		t.Run(c.name, func(t *testing.T) {
 			filePath := "testdata"
-			fileGroups, err := finder.GetGroups(filePath, []string{}, false, c.strictness)
+			fileGroups, err := finder.GetGroups(filePath, []string{}, false, c.strictness, "")
 			fileGroup := fileGroups.groups[c.testedGroupIndex]
 
 			assert.Nilf(t, err, "failed to assert that no error occurred. Error: %s", err)
@@ -49,6 +49,7 @@ func NewCompiledFormat(format *Format) (*CompiledFormat, error) {
 		compiledLockFileRegexes,
 		format,
 		isPcre,
+		"",
 	}
 
 	if err == nil && lockErr != nil {
@@ -58,12 +59,75 @@ func NewCompiledFormat(format *Format) (*CompiledFormat, error) {
 	return &compiledFormat, err
 }
 
+// NewPmCompiledFormat compiles the format of the files of the package manager named pmName
+func NewPmCompiledFormat(format *Format, pmName string) (*CompiledFormat, error) {
+	compiledFormat, err := NewCompiledFormat(format)
+	compiledFormat.packageManager = pmName
+
+	return compiledFormat, err
+}
+
+const UnknownEcosystem = "unknown"
+
+// pmEcosystems are the ecosystems of package managers by their names
+var pmEcosystems = map[string]string{
+	"npm":       "javascript",
+	"yarn":      "javascript",
+	"pnpm":      "javascript",
+	"bower":     "javascript",
+	"composer":  "php",
+	"go":        "go",
+	"cargo":     "rust",
+	"pip":       "python",
+	"pipenv":    "python",
+	"pyproject": "python",
+	"mvn":       "java",
+	"gradle":    "java",
+	"sbt":       "scala",
+	"nuget":     "dotnet",
+	"bundler":   "ruby",
+	"swift":     "swift",
+	"cocoapods": "swift",
+	"dpkg":      "debian",
+	"apk":       "alpine",
+}
+
+// pmFiles lists manifest and lock files of package managers, which identify the package manager of formats
+// without one, such as the supported formats of the server
+var pmFiles = []struct {
+	pm    string
+	files []string
+}{
+	{"npm", []string{"package.json", "package-lock.json", "yarn.lock", "pnpm-lock.yaml"}},
+	{"bower", []string{"bower.json", "bower.debricked.lock"}},
+	{"composer", []string{"composer.json", "composer.lock"}},
+	{"go", []string{"go.mod", "go.sum", "gomod.debricked.lock"}},
+	{"cargo", []string{"Cargo.toml", "Cargo.lock"}},
+	{"pip", []string{"requirements.txt", ".requirements.txt.pip.debricked.lock"}},
+	{"mvn", []string{"pom.xml", "maven.debricked.lock"}},
+	{"gradle", []string{"build.gradle", "build.gradle.kts", "gradle.debricked.lock"}},
+	{"nuget", []string{"project.csproj", "packages.lock.json", "packages.config", "packages.config.nuget.debricked.lock"}},
+	{"bundler", []string{"Gemfile", "Gemfile.lock"}},
+	{"dpkg", []string{"dpkg.debricked.lock"}},
+	{"apk", []string{"apk.debricked.lock"}},
+}
+
+// PmEcosystem returns the ecosystem of the package manager, or UnknownEcosystem if the package manager is unknown
+func PmEcosystem(pmName string) string {
+	if ecosystem, ok := pmEcosystems[pmName]; ok {
+		return ecosystem
+	}
+
+	return UnknownEcosystem
+}
+
 type CompiledFormat struct {
 	ManifestFileRegex *regexp.Regexp
 	DocumentationUrl  *string
 	LockFileRegexes   []*regexp.Regexp
 	format            *Format
 	pcre              bool
+	packageManager    string
 }
 
 func (format *CompiledFormat) MatchFile(filename string) bool {
@@ -103,3 +167,26 @@ func (format *CompiledFormat) MatchLockFile(filename string) bool {
 
 	return false
 }
+
+// PackageManager returns the name of the package manager of the format, or an empty string if it is unknown.
+// Formats without package manager, such as the supported formats of the server, are identified by the files they match.
+// For example a format matching "package.json" results in "npm"
+func (format *CompiledFormat) PackageManager() string {
+	if len(format.packageManager) > 0 {
+		return format.packageManager
+	}
+	for _, p := range pmFiles {
+		for _, filename := range p.files {
+			if format.MatchFile(filename) || format.MatchLockFile(filename) {
+				return p.pm
+			}
+		}
+	}
+
+	return ""
+}
+
+// Ecosystem returns the ecosystem of the package manager of the format
+func (format *CompiledFormat) Ecosystem() string {
+	return PmEcosystem(format.PackageManager())
+}
@@ -202,3 +202,57 @@ func TestMatchPcreLockFile(t *testing.T) {
 	assert.NoError(t, err)
 	assert.True(t, compiledF.MatchLockFile("deps.bzl"))
 }
+
+func TestEcosystem(t *testing.T) {
+	cases := map[string]*Format{
+		"php":            {ManifestFileRegex: `composer\.json`, LockFileRegexes: []string{`composer\.lock`}},
+		"javascript":     {ManifestFileRegex: `package\.json`, DocumentationUrl: "https://portal.debricked.com/language-support-14/javascript-53"},
+		"java":           {ManifestFileRegex: `build\.gradle(\.kts)?`, DocumentationUrl: "https://portal.debricked.com/language-support-14/java-kotlin-57"},
+		"python":         {ManifestFileRegex: `requirements.*\.txt$`},
+		"dotnet":         {ManifestFileRegex: `.*\.csproj`},
+		"rust":           {LockFileRegexes: []string{`Cargo\.lock`}},
+		"debian":         {LockFileRegexes: []string{`^dpkg\.debricked\.lock$`}},
+		UnknownEcosystem: {ManifestFileRegex: `unknown\.json`, DocumentationUrl: "https://debricked.com/docs/language-support/php.html"},
+	}
+	for expected, format := range cases {
+		t.Run(expected, func(t *testing.T) {
+			compiledF, _ := NewCompiledFormat(format)
+			assert.Equal(t, expected, compiledF.Ecosystem())
+		})
+	}
+}
+
+func TestPmEcosystem(t *testing.T) {
+	compiledF, err := NewPmCompiledFormat(&Format{ManifestFileRegex: `^build\.sbt$`}, "sbt")
+	assert.NoError(t, err)
+	assert.Equal(t, "sbt", compiledF.PackageManager())
+	assert.Equal(t, "scala", compiledF.Ecosystem())
+
+	compiledF, _ = NewPmCompiledFormat(&Format{ManifestFileRegex: `^Podfile$`}, "cocoapods")
+	assert.Equal(t, "swift", compiledF.Ecosystem())
+
+	assert.Equal(t, UnknownEcosystem, PmEcosystem("unknown"))
+}
+
+func TestPackageManager(t *testing.T) {
+	cases := map[string]*Format{
+		"npm":      {ManifestFileRegex: `package\.json`, LockFileRegexes: []string{`yarn\.lock`, `pnpm-lock\.yaml`}},
+		"composer": {ManifestFileRegex: `composer\.json`},
+		"cargo":    {LockFileRegexes: []string{`Cargo\.lock`}},
+		"":         {ManifestFileRegex: `unknown\.json`},
+	}
+	for expected, format := range cases {
+		t.Run(expected, func(t *testing.T) {
+			compiledF, _ := NewCompiledFormat(format)
+			assert.Equal(t, expected, compiledF.PackageManager())
+		})
+	}
+}
+
+func TestGeneratedFormatsEcosystem(t *testing.T) {
+	for _, generatedFormat := range generatedFormats {
+		compiledF, err := NewPmCompiledFormat(generatedFormat.format, generatedFormat.pm)
+		assert.NoError(t, err)
+		assert.NotEqual(t, UnknownEcosystem, compiledF.Ecosystem(), generatedFormat.pm)
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"strings"
 )
 
+const GeneratedLockFileMarker = "(generated by Debricked)"
+
 type Group struct {
 	ManifestFile   string          `json:"manifestFile"`
 	CompiledFormat *CompiledFormat `json:"-"`
@@ -30,6 +32,28 @@ func (fileGroup *Group) Print() {
 	}
 }
 
+// PrintTree prints the group like Print, marking lock files generated by Debricked and linking the documentation
+// of manifest files lacking lock files
+func (fileGroup *Group) PrintTree() {
+	hasFile := fileGroup.HasFile()
+	if hasFile {
+		fmt.Println(fileGroup.ManifestFile)
+	}
+	for _, filePath := range fileGroup.LockFiles {
+		if IsGeneratedLockFile(filePath) {
+			filePath = filePath + " " + GeneratedLockFileMarker
+		}
+		if hasFile {
+			fmt.Println(" * " + filePath)
+		} else {
+			fmt.Println(filePath)
+		}
+	}
+	if hasFile && !fileGroup.HasLockFiles() && len(fileGroup.GetDocumentationUrl()) > 0 {
+		fmt.Println(" * no lock file found, see " + fileGroup.GetDocumentationUrl())
+	}
+}
+
 func (fileGroup *Group) HasFile() bool {
 	return fileGroup.ManifestFile != ""
 }
@@ -38,6 +62,38 @@ func (fileGroup *Group) HasLockFiles() bool {
 	return len(fileGroup.LockFiles) > 0
 }
 
+func (fileGroup *Group) IsPaired() bool {
+	return fileGroup.HasFile() && fileGroup.HasLockFiles()
+}
+
+// GetEcosystem returns the ecosystem of the format matched by the group
+func (fileGroup *Group) GetEcosystem() string {
+	if fileGroup.CompiledFormat == nil {
+		return UnknownEcosystem
+	}
+
+	return fileGroup.CompiledFormat.Ecosystem()
+}
+
+func (fileGroup *Group) GetDocumentationUrl() string {
+	if fileGroup.CompiledFormat == nil || fileGroup.CompiledFormat.DocumentationUrl == nil {
+		return ""
+	}
+
+	return *fileGroup.CompiledFormat.DocumentationUrl
+}
+
+// IsGeneratedLockFile returns true if lockFile was generated by Debricked resolution
+func IsGeneratedLockFile(lockFile string) bool {
+	return strings.HasSuffix(lockFile, ".debricked.lock")
+}
+
+// IsLockFileOf returns true if lockFile, in the directory of manifestFile, may belong to it.
+// Only pip lock files are named after their manifest file, for example ".requirements.txt.pip.debricked.lock"
+func IsLockFileOf(manifestFile, lockFile string) bool {
+	return matchFile(filepath.Base(manifestFile), filepath.Base(lockFile))
+}
+
 func (fileGroup *Group) GetAllFiles() []string {
 	var files []string
 	if fileGroup.HasFile() {
@@ -20,6 +20,25 @@ func ExamplePrint() {
 	//  * yarn.lock
 }
 
+func ExampleGroup_PrintTree() {
+	format := Format{
+		ManifestFileRegex: "ManifestFileRegex",
+		DocumentationUrl:  "https://debricked.com/docs",
+		LockFileRegexes:   []string{},
+	}
+	compiledFormat, _ := NewCompiledFormat(&format)
+	NewGroup("package.json", compiledFormat, []string{"yarn.lock"}).PrintTree()
+	NewGroup("go.mod", compiledFormat, []string{"gomod.debricked.lock"}).PrintTree()
+	NewGroup("composer.json", compiledFormat, []string{}).PrintTree()
+	// output:
+	// package.json
+	//  * yarn.lock
+	// go.mod
+	//  * gomod.debricked.lock (generated by Debricked)
+	// composer.json
+	//  * no lock file found, see https://debricked.com/docs
+}
+
 func TestHasFile(t *testing.T) {
 	g := Group{
 		ManifestFile:   "",
@@ -104,3 +123,25 @@ func TestGroupMatchFile(t *testing.T) {
 	match = matchFile("requirements-test.txt", "requirements.txt.pip.debricked.lock")
 	assert.Equal(t, match, false)
 }
+
+func TestIsGeneratedLockFile(t *testing.T) {
+	assert.True(t, IsGeneratedLockFile("gomod.debricked.lock"))
+	assert.True(t, IsGeneratedLockFile(".requirements.txt.pip.debricked.lock"))
+	assert.False(t, IsGeneratedLockFile("yarn.lock"))
+}
+
+func TestIsLockFileOf(t *testing.T) {
+	assert.True(t, IsLockFileOf("dir/package.json", "dir/yarn.lock"))
+	assert.True(t, IsLockFileOf("requirements.txt", ".requirements.txt.pip.debricked.lock"))
+	assert.False(t, IsLockFileOf("requirements-dev.txt", ".requirements.txt.pip.debricked.lock"))
+}
+
+func TestGetDocumentationUrl(t *testing.T) {
+	g := NewGroup("package.json", nil, []string{})
+	assert.Empty(t, g.GetDocumentationUrl())
+	assert.Equal(t, UnknownEcosystem, g.GetEcosystem())
+
+	compiledFormat, _ := NewCompiledFormat(&Format{DocumentationUrl: "https://debricked.com/docs"})
+	g = NewGroup("package.json", compiledFormat, []string{})
+	assert.Equal(t, "https://debricked.com/docs", g.GetDocumentationUrl())
+}
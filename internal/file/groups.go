@@ -2,6 +2,7 @@ package file
 
 import (
 	"path/filepath"
+	"regexp"
 )
 
 const (
@@ -42,6 +43,34 @@ func (gs *Groups) Match(format *CompiledFormat, path string, lockfileOnly bool)
 	return true
 }
 
+// MatchMirroredLockFile matches the lock file at path in the lock output dir with `format`, and appends it to the group
+// of the manifest file in the directory of sourcePath, the path it mirrors, or to a new group
+func (gs *Groups) MatchMirroredLockFile(format *CompiledFormat, path string, sourcePath string) bool {
+	sourceDir, lockFile := filepath.Split(sourcePath)
+	if !format.MatchLockFile(lockFile) {
+		return false
+	}
+	absSourceDir, err := filepath.Abs(sourceDir)
+	if err != nil {
+		return false
+	}
+	for _, g := range gs.groups {
+		if format != g.CompiledFormat || !g.HasFile() {
+			continue
+		}
+		manifestDir, manifestFile := filepath.Split(g.ManifestFile)
+		absManifestDir, err := filepath.Abs(manifestDir)
+		if err == nil && absManifestDir == absSourceDir && matchFile(manifestFile, lockFile) {
+			g.LockFiles = append(g.LockFiles, path)
+
+			return true
+		}
+	}
+	gs.Add(*NewGroup("", format, []string{path}))
+
+	return true
+}
+
 func (gs *Groups) groupExists(format *CompiledFormat, matchOnManifestFile bool, matchOnLockFile bool, dir string, file string) bool {
 	for _, g := range gs.groups {
 		if format != g.CompiledFormat {
@@ -82,6 +111,85 @@ func (gs *Groups) FilterGroupsByStrictness(strictness int) {
 	gs.groups = groups
 }
 
+// FullScanFileRegexes matches configuration files that may affect the resolution of every manifest in the repository.
+// A change to any of them makes FilterGroupsByChangedFiles keep all groups
+var FullScanFileRegexes = []string{
+	`^\.npmrc$`,
+	`^\.yarnrc(\.yml)?$`,
+	`^settings\.gradle(\.kts)?$`,
+	`^gradle\.properties$`,
+	`^gradle-wrapper\.properties$`,
+	`^go\.work$`,
+	`(?i)^nuget\.config$`,
+	`^Directory\.(Build|Packages)\.props$`,
+	`^pip\.conf$`,
+	`^settings\.xml$`,
+}
+
+var fullScanFileRegexps = mustCompileAll(FullScanFileRegexes)
+
+// FilterGroupsByChangedFiles keeps only the groups where the manifest file or a lock file is among changedFiles.
+// If a changed file matches FullScanFileRegexes all groups are kept
+func (gs *Groups) FilterGroupsByChangedFiles(changedFiles []string) {
+	changedFileSet := map[string]bool{}
+	for _, changedFile := range changedFiles {
+		if requiresFullScan(changedFile) {
+			return
+		}
+		changedFileSet[evaluatedPath(changedFile)] = true
+	}
+
+	var groups []*Group
+	for _, group := range gs.groups {
+		for _, groupFile := range group.GetAllFiles() {
+			if changedFileSet[evaluatedPath(groupFile)] {
+				groups = append(groups, group)
+
+				break
+			}
+		}
+	}
+
+	gs.groups = groups
+}
+
+// evaluatedPath returns the absolute path with symbolic links evaluated, so that paths through different links
+// to the same file are equal. Only the directory is evaluated if the file doesn't exist, such as a deleted file
+func evaluatedPath(path string) string {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return filepath.Clean(path)
+	}
+	if evaluated, err := filepath.EvalSymlinks(absPath); err == nil {
+		return evaluated
+	}
+	if evaluatedDir, err := filepath.EvalSymlinks(filepath.Dir(absPath)); err == nil {
+		return filepath.Join(evaluatedDir, filepath.Base(absPath))
+	}
+
+	return absPath
+}
+
+func requiresFullScan(changedFile string) bool {
+	fileName := filepath.Base(changedFile)
+	for _, fullScanFileRegexp := range fullScanFileRegexps {
+		if fullScanFileRegexp.MatchString(fileName) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func mustCompileAll(expressions []string) []*regexp.Regexp {
+	regexps := make([]*regexp.Regexp, 0, len(expressions))
+	for _, expression := range expressions {
+		regexps = append(regexps, regexp.MustCompile(expression))
+	}
+
+	return regexps
+}
+
 func (gs *Groups) ToSlice() []Group {
 	var groups []Group
 	for _, g := range gs.groups {
@@ -99,10 +207,20 @@ func (gs *Groups) Add(g Group) {
 	gs.groups = append(gs.groups, &g)
 }
 
+// GetFiles returns the files of all groups. Lock files shared by several groups, like the lock file of a workspace, are only returned once
 func (gs *Groups) GetFiles() []string {
 	var files []string
+	fileSet := map[string]bool{}
 	for _, g := range gs.groups {
-		files = append(files, g.GetAllFiles()...)
+		groupFiles := g.GetAllFiles()
+		for _, file := range groupFiles {
+			if !fileSet[file] {
+				files = append(files, file)
+			}
+		}
+		for _, file := range groupFiles {
+			fileSet[file] = true
+		}
 	}
 
 	return files
@@ -1,6 +1,9 @@
 package file
 
 import (
+	"os"
+	"path/filepath"
+	"runtime"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -58,6 +61,28 @@ func TestMatch(t *testing.T) {
 	}
 }
 
+func TestMatchMirroredLockFile(t *testing.T) {
+	f := Format{
+		ManifestFileRegex: "composer\\.json",
+		LockFileRegexes:   []string{"composer\\.lock"},
+	}
+	compiledF, _ := NewCompiledFormat(&f)
+	var gs Groups
+	gs.Match(compiledF, "directory/composer.json", false)
+
+	mirrored := filepath.Join("locks", "directory", "composer.lock")
+	assert.False(t, gs.MatchMirroredLockFile(compiledF, mirrored, "directory/composer.json"))
+	assert.True(t, gs.MatchMirroredLockFile(compiledF, mirrored, "directory/composer.lock"))
+	assert.Equal(t, 1, gs.Size())
+	assert.Equal(t, []string{mirrored}, gs.groups[0].LockFiles)
+
+	other := filepath.Join("locks", "other", "composer.lock")
+	assert.True(t, gs.MatchMirroredLockFile(compiledF, other, "other/composer.lock"))
+	assert.Equal(t, 2, gs.Size())
+	assert.False(t, gs.groups[1].HasFile())
+	assert.Equal(t, []string{other}, gs.groups[1].LockFiles)
+}
+
 func TestGetFiles(t *testing.T) {
 	g1 := NewGroup("file1", nil, []string{"lockfile1"})
 	g2 := NewGroup("", nil, []string{"lockfile2"})
@@ -72,6 +97,14 @@ func TestGetFiles(t *testing.T) {
 	}
 }
 
+func TestGetFilesSharedLockFile(t *testing.T) {
+	gs := Groups{}
+	gs.Add(*NewGroup("package.json", nil, []string{"yarn.lock"}))
+	gs.Add(*NewGroup("packages/button/package.json", nil, []string{"yarn.lock"}))
+
+	assert.Equal(t, []string{"package.json", "yarn.lock", "packages/button/package.json"}, gs.GetFiles())
+}
+
 func TestFilterGroupsByStrictness(t *testing.T) {
 	g1 := NewGroup("file1", nil, []string{})
 	g2 := NewGroup("", nil, []string{"lockfile2"})
@@ -184,3 +217,58 @@ func TestMatchGroupsExpected(t *testing.T) {
 
 	assert.Equal(t, len(testData), len(groups.groups))
 }
+
+func TestFilterGroupsByChangedFiles(t *testing.T) {
+	var gs Groups
+	gs.Add(*NewGroup("composer.json", nil, []string{"composer.lock"}))
+	gs.Add(*NewGroup("package.json", nil, []string{}))
+	gs.Add(*NewGroup("", nil, []string{"Cargo.lock"}))
+
+	changedLockFile, _ := filepath.Abs("composer.lock")
+	changedOtherFile, _ := filepath.Abs("README.md")
+	gs.FilterGroupsByChangedFiles([]string{changedLockFile, changedOtherFile})
+
+	assert.Equal(t, 1, gs.Size())
+	assert.Equal(t, "composer.json", gs.ToSlice()[0].ManifestFile)
+}
+
+func TestFilterGroupsByChangedFilesSymlink(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("symbolic links require privileges on Windows")
+	}
+	dir := t.TempDir()
+	realDir := filepath.Join(dir, "real")
+	assert.NoError(t, os.MkdirAll(realDir, 0750))
+	assert.NoError(t, os.WriteFile(filepath.Join(realDir, "composer.json"), []byte("{}"), 0600))
+	link := filepath.Join(dir, "link")
+	assert.NoError(t, os.Symlink(realDir, link))
+	var gs Groups
+	gs.Add(*NewGroup(filepath.Join(link, "composer.json"), nil, []string{filepath.Join(link, "composer.lock")}))
+	gs.Add(*NewGroup(filepath.Join(link, "package.json"), nil, []string{}))
+
+	// composer.lock was deleted, so only its directory can be evaluated
+	gs.FilterGroupsByChangedFiles([]string{filepath.Join(realDir, "composer.lock")})
+
+	assert.Equal(t, 1, gs.Size())
+	assert.Equal(t, filepath.Join(link, "composer.json"), gs.ToSlice()[0].ManifestFile)
+}
+
+func TestFilterGroupsByChangedFilesNoChanges(t *testing.T) {
+	var gs Groups
+	gs.Add(*NewGroup("composer.json", nil, []string{"composer.lock"}))
+
+	gs.FilterGroupsByChangedFiles([]string{})
+
+	assert.Equal(t, 0, gs.Size())
+}
+
+func TestFilterGroupsByChangedFilesFullScan(t *testing.T) {
+	var gs Groups
+	gs.Add(*NewGroup("composer.json", nil, []string{"composer.lock"}))
+	gs.Add(*NewGroup("build.gradle", nil, []string{}))
+
+	changedConfigFile, _ := filepath.Abs(filepath.Join("sub", "settings.gradle"))
+	gs.FilterGroupsByChangedFiles([]string{changedConfigFile})
+
+	assert.Equal(t, 2, gs.Size())
+}
@@ -0,0 +1,157 @@
+package file
+
+import (
+	"encoding/json"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+)
+
+const jsManifestFile = "package.json"
+
+type jsWorkspaceManifest struct {
+	Workspaces json.RawMessage `json:"workspaces"`
+}
+
+// readJsWorkspaces returns the workspace patterns of package.json. Like npm and Yarn, the workspaces field is
+// either a list of patterns or an object with the patterns in packages
+func readJsWorkspaces(file string) []string {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil
+	}
+	var manifest jsWorkspaceManifest
+	if json.Unmarshal(content, &manifest) != nil || len(manifest.Workspaces) == 0 {
+		return nil
+	}
+
+	var patterns []string
+	if json.Unmarshal(manifest.Workspaces, &patterns) == nil {
+		return patterns
+	}
+	var workspaces struct {
+		Packages []string `json:"packages"`
+	}
+	if json.Unmarshal(manifest.Workspaces, &workspaces) == nil {
+		return workspaces.Packages
+	}
+
+	return nil
+}
+
+// FindJsWorkspaceRoot returns the package.json of the npm or Yarn workspace root that has the package as a member,
+// or the package.json itself if it is not a workspace member.
+// The closest parent package.json with a workspaces field including the package, up to the repository root, is the workspace root
+func FindJsWorkspaceRoot(manifest string) string {
+	absDir, err := filepath.Abs(filepath.Dir(manifest))
+	if err != nil {
+		return manifest
+	}
+
+	for parent := absDir; !isGitRoot(parent) && parent != filepath.Dir(parent); {
+		parent = filepath.Dir(parent)
+		patterns := readJsWorkspaces(filepath.Join(parent, jsManifestFile))
+		if len(patterns) == 0 {
+			continue
+		}
+		member, err := filepath.Rel(parent, absDir)
+		if err != nil {
+			return manifest
+		}
+		if isJsWorkspaceMember(filepath.ToSlash(member), patterns) {
+			rel, err := filepath.Rel(absDir, parent)
+			if err != nil {
+				return manifest
+			}
+
+			return filepath.Join(filepath.Dir(manifest), rel, jsManifestFile)
+		}
+	}
+
+	return manifest
+}
+
+// isJsWorkspaceMember returns true if member matches a pattern and no negated pattern, prefixed by !
+func isJsWorkspaceMember(member string, patterns []string) bool {
+	included := false
+	for _, pattern := range patterns {
+		negated := strings.HasPrefix(pattern, "!")
+		pattern = path.Clean(strings.TrimPrefix(pattern, "!"))
+		if matched, _ := doublestar.Match(pattern, member); !matched {
+			continue
+		}
+		if negated {
+			return false
+		}
+		included = true
+	}
+
+	return included
+}
+
+func isGitRoot(dir string) bool {
+	_, err := os.Stat(filepath.Join(dir, ".git"))
+
+	return err == nil
+}
+
+// PairJsWorkspaceMembers pairs the package.json of npm and Yarn workspace members, which don't have lock files of their own,
+// with the lock files of the workspace root
+func (gs *Groups) PairJsWorkspaceMembers() {
+	roots := map[string]*Group{}
+	for _, g := range gs.groups {
+		if g.HasFile() && filepath.Base(g.ManifestFile) == jsManifestFile {
+			roots[filepath.Clean(g.ManifestFile)] = g
+		}
+	}
+
+	for _, g := range gs.groups {
+		if !g.HasFile() || g.HasLockFiles() || filepath.Base(g.ManifestFile) != jsManifestFile {
+			continue
+		}
+		root, ok := roots[filepath.Clean(FindJsWorkspaceRoot(g.ManifestFile))]
+		if !ok || root == g {
+			continue
+		}
+		g.LockFiles = append(g.LockFiles, root.LockFiles...)
+	}
+}
+
+// FindJsWorkspaceRoots returns the package.json files to resolve, replacing workspace members by their workspace root,
+// since members share the lock file of the workspace root. Members of a workspace whose root already has one of
+// lockFiles are left out, unless the root itself is to be resolved
+func FindJsWorkspaceRoots(manifests []string, lockFiles []string) []string {
+	manifestSet := map[string]bool{}
+	for _, manifest := range manifests {
+		manifestSet[filepath.Clean(manifest)] = true
+	}
+
+	var roots []string
+	rootSet := map[string]bool{}
+	for _, manifest := range manifests {
+		root := filepath.Clean(FindJsWorkspaceRoot(manifest))
+		if rootSet[root] {
+			continue
+		}
+		if !manifestSet[root] && hasJsLockFile(root, lockFiles) {
+			continue
+		}
+		rootSet[root] = true
+		roots = append(roots, root)
+	}
+
+	return roots
+}
+
+func hasJsLockFile(manifest string, lockFiles []string) bool {
+	for _, lockFile := range lockFiles {
+		if _, err := os.Stat(filepath.Join(filepath.Dir(manifest), lockFile)); err == nil {
+			return true
+		}
+	}
+
+	return false
+}
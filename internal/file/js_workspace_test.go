@@ -0,0 +1,111 @@
+package file
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeJsWorkspaceFixture(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	files := map[string]string{
+		".git/HEAD":                         "",
+		"package.json":                      `{"workspaces": ["packages/*", "!packages/excluded"]}`,
+		"yarn.lock":                         "",
+		"packages/button/package.json":      `{"name": "button"}`,
+		"packages/excluded/package.json":    `{"name": "excluded"}`,
+		"tools/package.json":                `{"name": "tools"}`,
+		"apps/package.json":                 `{"workspaces": {"packages": ["web"]}}`,
+		"apps/web/package.json":             `{"name": "web"}`,
+		"apps/web/nested/demo/package.json": `{"name": "demo"}`,
+	}
+	for file, content := range files {
+		file = filepath.Join(root, filepath.FromSlash(file))
+		assert.NoError(t, os.MkdirAll(filepath.Dir(file), 0755))
+		assert.NoError(t, os.WriteFile(file, []byte(content), 0600))
+	}
+
+	return root
+}
+
+func TestFindJsWorkspaceRoot(t *testing.T) {
+	root := writeJsWorkspaceFixture(t)
+	cases := map[string]string{
+		"package.json":                      "package.json",
+		"packages/button/package.json":      "package.json",
+		"packages/excluded/package.json":    "packages/excluded/package.json",
+		"tools/package.json":                "tools/package.json",
+		"apps/web/package.json":             "apps/package.json",
+		"apps/web/nested/demo/package.json": "apps/web/nested/demo/package.json",
+	}
+
+	for manifest, workspaceRoot := range cases {
+		t.Run(manifest, func(t *testing.T) {
+			file := filepath.Join(root, filepath.FromSlash(manifest))
+			assert.Equal(t, filepath.Join(root, filepath.FromSlash(workspaceRoot)), FindJsWorkspaceRoot(file))
+		})
+	}
+}
+
+func TestIsJsWorkspaceMember(t *testing.T) {
+	assert.True(t, isJsWorkspaceMember("packages/button", []string{"packages/*"}))
+	assert.True(t, isJsWorkspaceMember("packages/button", []string{"./packages/button"}))
+	assert.True(t, isJsWorkspaceMember("packages/ui/button", []string{"packages/**"}))
+	assert.False(t, isJsWorkspaceMember("packages/button", []string{"packages/*", "!packages/button"}))
+	assert.False(t, isJsWorkspaceMember("tools", []string{"packages/*"}))
+}
+
+func TestReadJsWorkspaces(t *testing.T) {
+	root := writeJsWorkspaceFixture(t)
+
+	assert.Equal(t, []string{"packages/*", "!packages/excluded"}, readJsWorkspaces(filepath.Join(root, "package.json")))
+	assert.Equal(t, []string{"web"}, readJsWorkspaces(filepath.Join(root, "apps", "package.json")))
+	assert.Empty(t, readJsWorkspaces(filepath.Join(root, "tools", "package.json")))
+	assert.Empty(t, readJsWorkspaces(filepath.Join(root, "missing", "package.json")))
+}
+
+func TestPairJsWorkspaceMembers(t *testing.T) {
+	root := writeJsWorkspaceFixture(t)
+	format, _ := NewCompiledFormat(&Format{ManifestFileRegex: "package\\.json", LockFileRegexes: []string{"yarn\\.lock"}})
+	var gs Groups
+	for _, file := range []string{"package.json", "yarn.lock", "packages/button/package.json", "tools/package.json"} {
+		gs.Match(format, filepath.Join(root, filepath.FromSlash(file)), false)
+	}
+
+	gs.PairJsWorkspaceMembers()
+
+	groups := gs.ToSlice()
+	assert.Len(t, groups, 3)
+	rootLockFile := filepath.Join(root, "yarn.lock")
+	assert.Equal(t, []string{rootLockFile}, groups[0].LockFiles)
+	assert.Equal(t, []string{rootLockFile}, groups[1].LockFiles)
+	assert.Empty(t, groups[2].LockFiles)
+	assert.Len(t, gs.GetFiles(), 4)
+}
+
+func TestFindJsWorkspaceRoots(t *testing.T) {
+	root := writeJsWorkspaceFixture(t)
+	manifest := func(file string) string {
+		return filepath.Join(root, filepath.FromSlash(file))
+	}
+
+	roots := FindJsWorkspaceRoots([]string{
+		manifest("packages/button/package.json"),
+		manifest("apps/web/package.json"),
+		manifest("tools/package.json"),
+		manifest("apps/package.json"),
+	}, []string{"yarn.lock"})
+	assert.Equal(t, []string{manifest("apps/package.json"), manifest("tools/package.json")}, roots)
+
+	roots = FindJsWorkspaceRoots([]string{
+		manifest("packages/button/package.json"),
+		manifest("package.json"),
+	}, []string{"yarn.lock"})
+	assert.Equal(t, []string{manifest("package.json")}, roots)
+
+	roots = FindJsWorkspaceRoots([]string{manifest("packages/button/package.json")}, []string{"package-lock.json"})
+	assert.Equal(t, []string{manifest("package.json")}, roots)
+}
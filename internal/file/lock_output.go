@@ -0,0 +1,75 @@
+package file
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// MirrorPath returns the path of path in the lock output dir, which mirrors the tree of the working directory.
+// It returns false if there is no lock output dir or path is outside of the working directory
+func MirrorPath(lockOutputDir string, path string) (string, bool) {
+	if len(lockOutputDir) == 0 {
+		return path, false
+	}
+	rel, ok := relativeToWorkingDir(path)
+	if !ok {
+		return path, false
+	}
+	absLockOutputDir, err := filepath.Abs(lockOutputDir)
+	if err != nil {
+		return path, false
+	}
+
+	return filepath.Join(absLockOutputDir, rel), true
+}
+
+// SourcePath returns the path, relative to the working directory, that path in the lock output dir mirrors.
+// It returns false if path isn't in the lock output dir
+func SourcePath(lockOutputDir string, path string) (string, bool) {
+	if len(lockOutputDir) == 0 {
+		return path, false
+	}
+	absLockOutputDir, err := filepath.Abs(lockOutputDir)
+	if err != nil {
+		return path, false
+	}
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return path, false
+	}
+	rel, err := filepath.Rel(absLockOutputDir, absPath)
+	if err != nil || escapes(rel) {
+		return path, false
+	}
+
+	return rel, true
+}
+
+// InLockOutputDir returns true if path is the lock output dir or in it
+func InLockOutputDir(lockOutputDir string, path string) bool {
+	_, ok := SourcePath(lockOutputDir, path)
+
+	return ok
+}
+
+func relativeToWorkingDir(path string) (string, bool) {
+	workingDir, err := os.Getwd()
+	if err != nil {
+		return "", false
+	}
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return "", false
+	}
+	rel, err := filepath.Rel(workingDir, absPath)
+	if err != nil || escapes(rel) {
+		return "", false
+	}
+
+	return rel, true
+}
+
+func escapes(rel string) bool {
+	return rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
@@ -0,0 +1,55 @@
+package file
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMirrorPath(t *testing.T) {
+	workingDir, err := os.Getwd()
+	assert.NoError(t, err)
+	lockOutputDir := t.TempDir()
+
+	mirrorPath, ok := MirrorPath(lockOutputDir, filepath.Join("testdata", "go", "gomod.debricked.lock"))
+	assert.True(t, ok)
+	assert.Equal(t, filepath.Join(lockOutputDir, "testdata", "go", "gomod.debricked.lock"), mirrorPath)
+
+	mirrorPath, ok = MirrorPath(lockOutputDir, filepath.Join(workingDir, "go.mod"))
+	assert.True(t, ok)
+	assert.Equal(t, filepath.Join(lockOutputDir, "go.mod"), mirrorPath)
+}
+
+func TestMirrorPathNotMirrored(t *testing.T) {
+	mirrorPath, ok := MirrorPath("", "go.mod")
+	assert.False(t, ok)
+	assert.Equal(t, "go.mod", mirrorPath)
+
+	outside := filepath.Join(t.TempDir(), "go.mod")
+	mirrorPath, ok = MirrorPath("locks", outside)
+	assert.False(t, ok)
+	assert.Equal(t, outside, mirrorPath)
+}
+
+func TestSourcePath(t *testing.T) {
+	lockOutputDir := t.TempDir()
+
+	sourcePath, ok := SourcePath(lockOutputDir, filepath.Join(lockOutputDir, "app", "gomod.debricked.lock"))
+	assert.True(t, ok)
+	assert.Equal(t, filepath.Join("app", "gomod.debricked.lock"), sourcePath)
+
+	_, ok = SourcePath(lockOutputDir, filepath.Join("app", "gomod.debricked.lock"))
+	assert.False(t, ok)
+	_, ok = SourcePath("", filepath.Join(lockOutputDir, "app", "gomod.debricked.lock"))
+	assert.False(t, ok)
+}
+
+func TestInLockOutputDir(t *testing.T) {
+	lockOutputDir := t.TempDir()
+	assert.True(t, InLockOutputDir(lockOutputDir, lockOutputDir))
+	assert.True(t, InLockOutputDir(lockOutputDir, filepath.Join(lockOutputDir, "app")))
+	assert.False(t, InLockOutputDir(lockOutputDir, lockOutputDir+"-other"))
+	assert.False(t, InLockOutputDir("", lockOutputDir))
+}
@@ -0,0 +1,34 @@
+package file
+
+type Stats struct {
+	Groups             int            `json:"groups"`
+	Ecosystems         map[string]int `json:"ecosystems"`
+	Pairs              int            `json:"pairs"`
+	UnpairedManifests  int            `json:"unpairedManifestFiles"`
+	UnpairedLockFiles  int            `json:"unpairedLockFiles"`
+	GeneratedLockFiles int            `json:"generatedLockFiles"`
+}
+
+// NewStats summarises groups per ecosystem and by how manifest and lock files are paired
+func NewStats(groups []Group) Stats {
+	stats := Stats{Ecosystems: map[string]int{}}
+	for _, group := range groups {
+		stats.Groups++
+		stats.Ecosystems[group.GetEcosystem()]++
+		switch {
+		case group.IsPaired():
+			stats.Pairs++
+		case group.HasFile():
+			stats.UnpairedManifests++
+		default:
+			stats.UnpairedLockFiles++
+		}
+		for _, lockFile := range group.LockFiles {
+			if IsGeneratedLockFile(lockFile) {
+				stats.GeneratedLockFiles++
+			}
+		}
+	}
+
+	return stats
+}
@@ -0,0 +1,25 @@
+package file
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewStats(t *testing.T) {
+	compiledFormat, _ := NewCompiledFormat(&Format{ManifestFileRegex: `go\.mod`, LockFileRegexes: []string{`gomod\.debricked\.lock`}})
+	groups := []Group{
+		*NewGroup("go.mod", compiledFormat, []string{"gomod.debricked.lock"}),
+		*NewGroup("sub/go.mod", compiledFormat, []string{}),
+		*NewGroup("", nil, []string{"Cargo.lock"}),
+	}
+
+	stats := NewStats(groups)
+
+	assert.Equal(t, 3, stats.Groups)
+	assert.Equal(t, map[string]int{"go": 2, UnknownEcosystem: 1}, stats.Ecosystems)
+	assert.Equal(t, 1, stats.Pairs)
+	assert.Equal(t, 1, stats.UnpairedManifests)
+	assert.Equal(t, 1, stats.UnpairedLockFiles)
+	assert.Equal(t, 1, stats.GeneratedLockFiles)
+}
@@ -6,6 +6,7 @@ import (
 
 type FinderMock struct {
 	groups          file.Groups
+	manifestGroups  file.Groups
 	compiledFormats []*file.CompiledFormat
 	error           error
 }
@@ -19,7 +20,7 @@ func NewFinderMock() *FinderMock {
 }
 
 // GetGroups return all file groups in specified path recursively.
-func (f *FinderMock) GetGroups(_ string, _ []string, _ bool, _ int) (file.Groups, error) {
+func (f *FinderMock) GetGroups(_ string, _ []string, _ bool, _ int, _ string) (file.Groups, error) {
 	return f.groups, f.error
 }
 
@@ -27,6 +28,14 @@ func (f *FinderMock) GetSupportedFormats() ([]*file.CompiledFormat, error) {
 	return f.compiledFormats, f.error
 }
 
+func (f *FinderMock) GetManifestGroups(_ string, _ []*file.CompiledFormat, _ []string, _ string) (file.Groups, error) {
+	return f.manifestGroups, nil
+}
+
+func (f *FinderMock) SetGetManifestGroupsReturnMock(gs file.Groups) {
+	f.manifestGroups = gs
+}
+
 func (f *FinderMock) SetGetGroupsReturnMock(gs file.Groups, err error) {
 	f.groups = gs
 	f.error = err
@@ -0,0 +1,114 @@
+package git
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/debricked/cli/internal/file"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// FindChangedFilesSince returns the absolute paths of all files changed since ref in the repository enclosing path
+func FindChangedFilesSince(path string, ref string) ([]string, error) {
+	if len(path) == 0 {
+		path = "."
+	}
+	repository, err := git.PlainOpenWithOptions(path, &git.PlainOpenOptions{DetectDotGit: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find git repository in %s. Error: %s", path, err)
+	}
+
+	return FindChangedFiles(repository, ref)
+}
+
+// FindChangedFiles returns the absolute paths of all files changed between the merge base of ref and HEAD,
+// including uncommitted and untracked changes in the worktree. Untracked lock files generated by Debricked resolution
+// aren't changes, since they are written by previous runs. Symbolic links in the path of the repository are evaluated
+func FindChangedFiles(repository *git.Repository, ref string) ([]string, error) {
+	worktree, err := repository.Worktree()
+	if err != nil {
+		return nil, err
+	}
+	root := worktree.Filesystem.Root()
+	if evaluatedRoot, err := filepath.EvalSymlinks(root); err == nil {
+		root = evaluatedRoot
+	}
+
+	changedFiles := map[string]bool{}
+	committedFiles, err := findCommittedChanges(repository, ref)
+	if err != nil {
+		return nil, err
+	}
+	for _, committedFile := range committedFiles {
+		changedFiles[committedFile] = true
+	}
+
+	status, err := worktree.Status()
+	if err != nil {
+		return nil, err
+	}
+	for uncommittedFile, fileStatus := range status {
+		if fileStatus.Worktree == git.Untracked && file.IsGeneratedLockFile(uncommittedFile) {
+			continue
+		}
+		if fileStatus.Staging != git.Unmodified || fileStatus.Worktree != git.Unmodified {
+			changedFiles[uncommittedFile] = true
+		}
+	}
+
+	var files []string
+	for changedFile := range changedFiles {
+		files = append(files, filepath.Join(root, filepath.FromSlash(changedFile)))
+	}
+
+	return files, nil
+}
+
+func findCommittedChanges(repository *git.Repository, ref string) ([]string, error) {
+	hash, err := repository.ResolveRevision(plumbing.Revision(ref))
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve git reference %s. Error: %s", ref, err)
+	}
+	refCommit, err := repository.CommitObject(*hash)
+	if err != nil {
+		return nil, err
+	}
+	headCommit, err := FindCommit(repository)
+	if err != nil {
+		return nil, err
+	}
+
+	// Compare against the merge base, like `git diff ref...HEAD`, so that changes made on ref are ignored
+	baseCommit := refCommit
+	mergeBases, err := refCommit.MergeBase(headCommit)
+	if err == nil && len(mergeBases) > 0 {
+		baseCommit = mergeBases[0]
+	}
+
+	baseTree, err := baseCommit.Tree()
+	if err != nil {
+		return nil, err
+	}
+	headTree, err := headCommit.Tree()
+	if err != nil {
+		return nil, err
+	}
+	changes, err := object.DiffTree(baseTree, headTree)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, change := range changes {
+		if len(change.From.Name) > 0 {
+			files = append(files, change.From.Name)
+		}
+		if len(change.To.Name) > 0 && change.To.Name != change.From.Name {
+			files = append(files, change.To.Name)
+		}
+	}
+
+	return files, nil
+}
@@ -0,0 +1,98 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"testing"
+
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindChangedFiles(t *testing.T) {
+	fs := memfs.New()
+	r, err := git.Init(memory.NewStorage(), fs)
+	assert.NoError(t, err)
+	w, err := r.Worktree()
+	assert.NoError(t, err)
+	commit := func(files ...string) {
+		for _, f := range files {
+			createdFile, createErr := fs.Create(f)
+			assert.NoError(t, createErr)
+			_, _ = createdFile.Write([]byte(f))
+			_ = createdFile.Close()
+			_, addErr := w.Add(f)
+			assert.NoError(t, addErr)
+		}
+		_, commitErr := w.Commit("commit", &git.CommitOptions{Author: &object.Signature{Name: "author"}})
+		assert.NoError(t, commitErr)
+	}
+
+	commit("go.mod", "package.json")
+	base, err := r.Head()
+	assert.NoError(t, err)
+	commit("sub/go.mod")
+	uncommittedFile, err := fs.Create("composer.json")
+	assert.NoError(t, err)
+	_ = uncommittedFile.Close()
+	generatedLockFile, err := fs.Create(filepath.Join("sub", "gomod.debricked.lock"))
+	assert.NoError(t, err)
+	_ = generatedLockFile.Close()
+
+	files, err := FindChangedFiles(r, base.Hash().String())
+	assert.NoError(t, err)
+
+	root := fs.Root()
+	expected := []string{
+		filepath.Join(root, "composer.json"),
+		filepath.Join(root, "sub", "go.mod"),
+	}
+	sort.Strings(files)
+	assert.Equal(t, expected, files)
+}
+
+func TestFindChangedFilesSymlinkedRepository(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("symbolic links require privileges on Windows")
+	}
+	dir := t.TempDir()
+	r, err := git.PlainInit(dir, false)
+	assert.NoError(t, err)
+	w, err := r.Worktree()
+	assert.NoError(t, err)
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/app\n"), 0600))
+	_, err = w.Add("go.mod")
+	assert.NoError(t, err)
+	_, err = w.Commit("commit", &git.CommitOptions{Author: &object.Signature{Name: "author"}})
+	assert.NoError(t, err)
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "package.json"), []byte("{}"), 0600))
+	link := filepath.Join(t.TempDir(), "link")
+	assert.NoError(t, os.Symlink(dir, link))
+
+	files, err := FindChangedFilesSince(link, "HEAD")
+
+	assert.NoError(t, err)
+	evaluatedDir, _ := filepath.EvalSymlinks(dir)
+	assert.Equal(t, []string{filepath.Join(evaluatedDir, "package.json")}, files)
+}
+
+func TestFindChangedFilesBadRef(t *testing.T) {
+	r := mockRepository(true, t)
+
+	files, err := FindChangedFiles(r, "non-existing-ref")
+
+	assert.Nil(t, files)
+	assert.ErrorContains(t, err, "failed to resolve git reference non-existing-ref")
+}
+
+func TestFindChangedFilesSinceNoRepository(t *testing.T) {
+	files, err := FindChangedFilesSince(t.TempDir(), "main")
+
+	assert.Nil(t, files)
+	assert.ErrorContains(t, err, "failed to find git repository")
+}
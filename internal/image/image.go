@@ -0,0 +1,225 @@
+package image
+
+import (
+	"archive/tar"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+)
+
+const (
+	dockerManifestFile = "manifest.json"
+	ociIndexFile       = "index.json"
+	maxIndexDepth      = 8
+)
+
+var ErrNoManifest = errors.New("failed to find manifest.json or index.json in image. Make sure the image was exported by `docker save` or is an OCI image layout tarball")
+
+// FlattenToTempDir flattens the image tarball into a new temporary directory.
+// The caller is responsible for removing the directory
+func FlattenToTempDir(imagePath string) (string, error) {
+	root, err := os.MkdirTemp("", "debricked-image-")
+	if err != nil {
+		return "", err
+	}
+	err = Flatten(imagePath, root)
+	if err != nil {
+		_ = os.RemoveAll(root)
+
+		return "", err
+	}
+
+	return root, nil
+}
+
+// Flatten extracts all layers of a `docker save` or OCI image layout tarball into destination, in order, applying whiteouts.
+// Only directories, regular files and hard links are extracted
+func Flatten(imagePath string, destination string) error {
+	a, err := openArchive(imagePath)
+	if err != nil {
+		return err
+	}
+	defer a.close()
+
+	layers, err := a.layers()
+	if err != nil {
+		return err
+	}
+
+	for _, layer := range layers {
+		layerReader, err := a.open(layer)
+		if err != nil {
+			return err
+		}
+		err = applyLayer(layerReader, destination)
+		if err != nil {
+			return fmt.Errorf("failed to apply layer %s. Error: %w", layer, err)
+		}
+	}
+
+	return nil
+}
+
+type entry struct {
+	offset int64
+	size   int64
+}
+
+// archive indexes the regular files of the image tarball, so that layers can be read in any order without extracting them
+type archive struct {
+	file    *os.File
+	entries map[string]entry
+}
+
+func openArchive(imagePath string) (*archive, error) {
+	file, err := os.Open(imagePath)
+	if err != nil {
+		return nil, err
+	}
+
+	a := &archive{file: file, entries: map[string]entry{}}
+	reader := tar.NewReader(file)
+	for {
+		header, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			a.close()
+
+			return nil, fmt.Errorf("failed to read image %s. Error: %s", imagePath, err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		// The tar reader does not buffer, so the file offset is at the start of the entry content
+		offset, err := file.Seek(0, io.SeekCurrent)
+		if err != nil {
+			a.close()
+
+			return nil, err
+		}
+		a.entries[path.Clean(header.Name)] = entry{offset: offset, size: header.Size}
+	}
+
+	return a, nil
+}
+
+func (a *archive) close() {
+	_ = a.file.Close()
+}
+
+func (a *archive) has(name string) bool {
+	_, ok := a.entries[name]
+
+	return ok
+}
+
+func (a *archive) open(name string) (io.Reader, error) {
+	e, ok := a.entries[path.Clean(name)]
+	if !ok {
+		return nil, fmt.Errorf("failed to find %s in image", name)
+	}
+
+	return io.NewSectionReader(a.file, e.offset, e.size), nil
+}
+
+func (a *archive) readJson(name string, v any) error {
+	reader, err := a.open(name)
+	if err != nil {
+		return err
+	}
+	err = json.NewDecoder(reader).Decode(v)
+	if err != nil {
+		return fmt.Errorf("failed to parse %s. Error: %s", name, err)
+	}
+
+	return nil
+}
+
+// layers returns the names of the layer entries, from the base layer to the top layer
+func (a *archive) layers() ([]string, error) {
+	if a.has(dockerManifestFile) {
+		return a.dockerLayers()
+	}
+	if a.has(ociIndexFile) {
+		return a.ociLayers()
+	}
+
+	return nil, ErrNoManifest
+}
+
+type dockerManifest struct {
+	Layers []string `json:"Layers"`
+}
+
+func (a *archive) dockerLayers() ([]string, error) {
+	var manifests []dockerManifest
+	err := a.readJson(dockerManifestFile, &manifests)
+	if err != nil {
+		return nil, err
+	}
+	if len(manifests) == 0 {
+		return nil, fmt.Errorf("failed to find any image in %s", dockerManifestFile)
+	}
+
+	return manifests[0].Layers, nil
+}
+
+type ociDescriptor struct {
+	MediaType string `json:"mediaType"`
+	Digest    string `json:"digest"`
+}
+
+type ociManifest struct {
+	Manifests []ociDescriptor `json:"manifests"`
+	Layers    []ociDescriptor `json:"layers"`
+}
+
+func (a *archive) ociLayers() ([]string, error) {
+	var index ociManifest
+	err := a.readJson(ociIndexFile, &index)
+	if err != nil {
+		return nil, err
+	}
+
+	// An index may point to nested indexes, for example multi-platform images. The first image manifest is used
+	for depth := 0; len(index.Manifests) > 0; depth++ {
+		if depth == maxIndexDepth {
+			return nil, fmt.Errorf("failed to find an image manifest within %d nested indexes", maxIndexDepth)
+		}
+		blob, err := blobPath(index.Manifests[0].Digest)
+		if err != nil {
+			return nil, err
+		}
+		index = ociManifest{}
+		err = a.readJson(blob, &index)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var layers []string
+	for _, layer := range index.Layers {
+		blob, err := blobPath(layer.Digest)
+		if err != nil {
+			return nil, err
+		}
+		layers = append(layers, blob)
+	}
+
+	return layers, nil
+}
+
+func blobPath(digest string) (string, error) {
+	algorithm, hash, found := strings.Cut(digest, ":")
+	if !found || len(algorithm) == 0 || len(hash) == 0 || strings.ContainsAny(digest, "/\\") {
+		return "", fmt.Errorf("invalid digest %s", digest)
+	}
+
+	return path.Join("blobs", algorithm, hash), nil
+}
@@ -0,0 +1,180 @@
+package image
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type tarEntry struct {
+	name     string
+	content  string
+	typeflag byte
+	linkname string
+}
+
+func makeTar(t *testing.T, entries []tarEntry) []byte {
+	var buffer bytes.Buffer
+	writer := tar.NewWriter(&buffer)
+	for _, e := range entries {
+		typeflag := e.typeflag
+		if typeflag == 0 {
+			typeflag = tar.TypeReg
+		}
+		header := &tar.Header{Name: e.name, Mode: 0644, Size: int64(len(e.content)), Typeflag: typeflag, Linkname: e.linkname}
+		if typeflag != tar.TypeReg {
+			header.Size = 0
+		}
+		assert.NoError(t, writer.WriteHeader(header))
+		if typeflag == tar.TypeReg {
+			_, err := writer.Write([]byte(e.content))
+			assert.NoError(t, err)
+		}
+	}
+	assert.NoError(t, writer.Close())
+
+	return buffer.Bytes()
+}
+
+func gzipBytes(t *testing.T, content []byte) []byte {
+	var buffer bytes.Buffer
+	writer := gzip.NewWriter(&buffer)
+	_, err := writer.Write(content)
+	assert.NoError(t, err)
+	assert.NoError(t, writer.Close())
+
+	return buffer.Bytes()
+}
+
+func writeImage(t *testing.T, entries []tarEntry) string {
+	imagePath := filepath.Join(t.TempDir(), "image.tar")
+	assert.NoError(t, os.WriteFile(imagePath, makeTar(t, entries), 0600))
+
+	return imagePath
+}
+
+func toJson(t *testing.T, v any) string {
+	content, err := json.Marshal(v)
+	assert.NoError(t, err)
+
+	return string(content)
+}
+
+var baseLayer = []tarEntry{
+	{name: "app/", typeflag: tar.TypeDir},
+	{name: "app/package.json", content: "{}"},
+	{name: "app/package-lock.json", content: "{}"},
+	{name: "app/node_modules/left-pad/package.json", content: "{}"},
+	{name: "etc/passwd", content: "root"},
+	{name: "lib", typeflag: tar.TypeSymlink, linkname: "/usr/lib"},
+}
+
+var topLayer = []tarEntry{
+	{name: "app/.wh.package-lock.json"},
+	{name: "app/node_modules/.wh..wh..opq"},
+	{name: "app/node_modules/right-pad/package.json", content: "{}"},
+	{name: "app/go.mod", content: "module app"},
+	{name: "app/go.sum", typeflag: tar.TypeLink, linkname: "app/go.mod"},
+	{name: "../../escape.txt", content: "escape"},
+}
+
+func assertFlattened(t *testing.T, root string) {
+	assert.FileExists(t, filepath.Join(root, "app", "package.json"))
+	assert.NoFileExists(t, filepath.Join(root, "app", "package-lock.json"))
+	assert.NoDirExists(t, filepath.Join(root, "app", "node_modules", "left-pad"))
+	assert.FileExists(t, filepath.Join(root, "app", "node_modules", "right-pad", "package.json"))
+	assert.FileExists(t, filepath.Join(root, "app", "go.sum"))
+	assert.FileExists(t, filepath.Join(root, "etc", "passwd"))
+	assert.FileExists(t, filepath.Join(root, "escape.txt"))
+	assert.NoFileExists(t, filepath.Join(root, "lib"))
+	content, err := os.ReadFile(filepath.Join(root, "app", "go.sum"))
+	assert.NoError(t, err)
+	assert.Equal(t, "module app", string(content))
+}
+
+func TestFlattenDockerSave(t *testing.T) {
+	imagePath := writeImage(t, []tarEntry{
+		{name: "manifest.json", content: toJson(t, []dockerManifest{{Layers: []string{"base/layer.tar", "top/layer.tar"}}})},
+		{name: "base/layer.tar", content: string(makeTar(t, baseLayer))},
+		{name: "top/layer.tar", content: string(makeTar(t, topLayer))},
+	})
+
+	root := t.TempDir()
+	err := Flatten(imagePath, root)
+
+	assert.NoError(t, err)
+	assertFlattened(t, root)
+}
+
+func TestFlattenOciLayout(t *testing.T) {
+	index := ociManifest{Manifests: []ociDescriptor{{Digest: "sha256:index"}}}
+	nestedIndex := ociManifest{Manifests: []ociDescriptor{{Digest: "sha256:manifest"}}}
+	manifest := ociManifest{Layers: []ociDescriptor{{Digest: "sha256:base"}, {Digest: "sha256:top"}}}
+	imagePath := writeImage(t, []tarEntry{
+		{name: "oci-layout", content: `{"imageLayoutVersion": "1.0.0"}`},
+		{name: "index.json", content: toJson(t, index)},
+		{name: "blobs/sha256/index", content: toJson(t, nestedIndex)},
+		{name: "blobs/sha256/manifest", content: toJson(t, manifest)},
+		{name: "blobs/sha256/top", content: string(gzipBytes(t, makeTar(t, topLayer)))},
+		{name: "blobs/sha256/base", content: string(makeTar(t, baseLayer))},
+	})
+
+	root, err := FlattenToTempDir(imagePath)
+	defer os.RemoveAll(root)
+
+	assert.NoError(t, err)
+	assertFlattened(t, root)
+}
+
+func TestFlattenNoManifest(t *testing.T) {
+	imagePath := writeImage(t, []tarEntry{{name: "layer.tar", content: ""}})
+
+	root, err := FlattenToTempDir(imagePath)
+
+	assert.ErrorIs(t, err, ErrNoManifest)
+	assert.Empty(t, root)
+}
+
+func TestFlattenMissingLayer(t *testing.T) {
+	imagePath := writeImage(t, []tarEntry{
+		{name: "manifest.json", content: toJson(t, []dockerManifest{{Layers: []string{"missing/layer.tar"}}})},
+	})
+
+	err := Flatten(imagePath, t.TempDir())
+
+	assert.ErrorContains(t, err, "failed to find missing/layer.tar in image")
+}
+
+func TestFlattenZstdLayer(t *testing.T) {
+	imagePath := writeImage(t, []tarEntry{
+		{name: "manifest.json", content: toJson(t, []dockerManifest{{Layers: []string{"layer.tar"}}})},
+		{name: "layer.tar", content: string(zstdMagic) + "content"},
+	})
+
+	err := Flatten(imagePath, t.TempDir())
+
+	assert.ErrorIs(t, err, ErrZstdLayer)
+}
+
+func TestFlattenImageNotFound(t *testing.T) {
+	err := Flatten(filepath.Join(t.TempDir(), "image.tar"), t.TempDir())
+
+	assert.Error(t, err)
+}
+
+func TestBlobPath(t *testing.T) {
+	blob, err := blobPath("sha256:abc")
+	assert.NoError(t, err)
+	assert.Equal(t, "blobs/sha256/abc", blob)
+
+	for _, digest := range []string{"abc", "sha256:", "sha256:../../abc"} {
+		_, err = blobPath(digest)
+		assert.ErrorContains(t, err, "invalid digest")
+	}
+}
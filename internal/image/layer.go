@@ -0,0 +1,190 @@
+package image
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	whiteoutPrefix = ".wh."
+	opaqueWhiteout = ".wh..wh..opq"
+)
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+var ErrZstdLayer = errors.New("zstd compressed layers are not supported")
+
+// applyLayer extracts the layer on top of destination.
+// Whiteout files remove files of lower layers and opaque whiteouts remove all lower layer content of their directory
+func applyLayer(layer io.Reader, destination string) error {
+	reader, err := decompress(layer)
+	if err != nil {
+		return err
+	}
+
+	// All paths, including parent directories, added by this layer. Used to keep them when applying opaque whiteouts
+	added := map[string]bool{}
+	var opaqueDirs []string
+
+	tarReader := tar.NewReader(reader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		// Cleaning a rooted path makes sure the entry can't escape destination
+		name := strings.TrimPrefix(path.Clean("/"+header.Name), "/")
+		if len(name) == 0 {
+			continue
+		}
+		dir, base := path.Split(name)
+		switch {
+		case base == opaqueWhiteout:
+			opaqueDirs = append(opaqueDirs, strings.TrimSuffix(dir, "/"))
+
+			continue
+		case strings.HasPrefix(base, whiteoutPrefix):
+			err = os.RemoveAll(toLocalPath(destination, path.Join(dir, strings.TrimPrefix(base, whiteoutPrefix))))
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		extracted, err := extractEntry(header, tarReader, destination, name)
+		if err != nil {
+			return err
+		}
+		if extracted {
+			markAdded(added, name)
+		}
+	}
+
+	for _, opaqueDir := range opaqueDirs {
+		err = removeLowerContent(destination, opaqueDir, added)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func decompress(layer io.Reader) (io.Reader, error) {
+	reader := bufio.NewReader(layer)
+	magic, _ := reader.Peek(4)
+	switch {
+	case bytes.HasPrefix(magic, gzipMagic):
+		return gzip.NewReader(reader)
+	case bytes.HasPrefix(magic, zstdMagic):
+		return nil, ErrZstdLayer
+	}
+
+	return reader, nil
+}
+
+func extractEntry(header *tar.Header, content io.Reader, destination string, name string) (bool, error) {
+	target := toLocalPath(destination, name)
+	switch header.Typeflag {
+	case tar.TypeDir:
+		info, err := os.Lstat(target)
+		if err == nil && !info.IsDir() {
+			err = os.Remove(target)
+			if err != nil {
+				return false, err
+			}
+		}
+
+		return true, os.MkdirAll(target, header.FileInfo().Mode().Perm()|0700)
+	case tar.TypeReg:
+		return true, writeFile(target, content, header.FileInfo().Mode().Perm())
+	case tar.TypeLink:
+		linkName := strings.TrimPrefix(path.Clean("/"+header.Linkname), "/")
+		source, err := os.Open(toLocalPath(destination, linkName))
+		if err != nil {
+			return false, err
+		}
+		defer source.Close()
+
+		return true, writeFile(target, source, header.FileInfo().Mode().Perm())
+	}
+
+	// Symbolic links, devices and other special files are skipped, which also prevents writing outside destination
+	return false, nil
+}
+
+func writeFile(target string, content io.Reader, perm os.FileMode) error {
+	err := os.RemoveAll(target)
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(filepath.Dir(target), 0755)
+	if err != nil {
+		return err
+	}
+	file, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm|0600)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(file, content)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+
+	return err
+}
+
+func markAdded(added map[string]bool, name string) {
+	for ; name != "." && name != "/" && len(name) > 0; name = path.Dir(name) {
+		added[name] = true
+	}
+}
+
+func removeLowerContent(destination string, dir string, added map[string]bool) error {
+	root := toLocalPath(destination, dir)
+	if _, err := os.Lstat(root); os.IsNotExist(err) {
+		return nil
+	}
+
+	return filepath.Walk(root, func(localPath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if localPath == root {
+			return nil
+		}
+		relPath, err := filepath.Rel(destination, localPath)
+		if err != nil {
+			return err
+		}
+		if added[filepath.ToSlash(relPath)] {
+			return nil
+		}
+		err = os.RemoveAll(localPath)
+		if err == nil && info.IsDir() {
+			return filepath.SkipDir
+		}
+
+		return err
+	})
+}
+
+func toLocalPath(destination string, name string) string {
+	return filepath.Join(destination, filepath.FromSlash(name))
+}
@@ -0,0 +1,60 @@
+package testdata
+
+import (
+	"archive/tar"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+)
+
+// WriteDockerImage writes a `docker save` tarball to imagePath with one layer per map of file names to contents
+func WriteDockerImage(imagePath string, layers ...map[string]string) error {
+	files := map[string]string{}
+	var layerNames []string
+	for i, layer := range layers {
+		layerTar, err := makeTar(layer)
+		if err != nil {
+			return err
+		}
+		layerName := fmt.Sprintf("layer-%d/layer.tar", i)
+		layerNames = append(layerNames, layerName)
+		files[layerName] = string(layerTar)
+	}
+	manifest, err := json.Marshal([]map[string][]string{{"Layers": layerNames}})
+	if err != nil {
+		return err
+	}
+	files["manifest.json"] = string(manifest)
+
+	imageTar, err := makeTar(files)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(imagePath, imageTar, 0600)
+}
+
+func makeTar(files map[string]string) ([]byte, error) {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var buffer bytes.Buffer
+	writer := tar.NewWriter(&buffer)
+	for _, name := range names {
+		header := &tar.Header{Name: name, Mode: 0644, Size: int64(len(files[name])), Typeflag: tar.TypeReg}
+		if err := writer.WriteHeader(header); err != nil {
+			return nil, err
+		}
+		if _, err := writer.Write([]byte(files[name])); err != nil {
+			return nil, err
+		}
+	}
+	err := writer.Close()
+
+	return buffer.Bytes(), err
+}
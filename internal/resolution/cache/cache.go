@@ -0,0 +1,310 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/debricked/cli/internal/file"
+	"github.com/debricked/cli/internal/resolution/job"
+	"github.com/debricked/cli/internal/resolution/pm"
+	"github.com/debricked/cli/internal/resolution/toolchain"
+)
+
+const (
+	keyVersion        = "debricked-resolution-cache-v2"
+	gitDir            = ".git"
+	tmpEntryDirPrefix = ".tmp-"
+)
+
+// configFileRegexes matches build configuration files, in addition to file.FullScanFileRegexes, that change the resolved
+// dependencies without being manifest files themselves
+var configFileRegexes = []string{
+	`\.versions\.toml$`,
+	`^maven\.config$`,
+	`^extensions\.xml$`,
+	`^global\.json$`,
+	`^build\.properties$`,
+	`^\.python-version$`,
+	`^rust-toolchain(\.toml)?$`,
+}
+
+type ICache interface {
+	Wrap(j job.IJob, pmName string, options string, lockFiles []string) job.IJob
+}
+
+// Cache stores the lock files written by resolution jobs in dir, both those generated by Debricked and those of
+// package managers, keyed by a hash of the manifest, the build configuration files around it, the package manager
+// version and options
+type Cache struct {
+	dir          string
+	exclusions   []string
+	isolated     bool
+	versions     map[string]string
+	versionsLock *sync.Mutex
+	versionCmd   func(pmName string, toolchains []toolchain.Toolchain, timeout time.Duration) string
+}
+
+// NewCache makes a cache in dir. isolated is true if jobs run in an isolated workspace, which writes lock files of
+// package managers to the lock output dir too
+func NewCache(dir string, exclusions []string, isolated bool) Cache {
+	return Cache{
+		dir:          dir,
+		exclusions:   exclusions,
+		isolated:     isolated,
+		versions:     map[string]string{},
+		versionsLock: &sync.Mutex{},
+		versionCmd:   toolVersion,
+	}
+}
+
+// DefaultDir returns the directory of the cache in the user cache directory, or an empty string if there is none
+func DefaultDir() string {
+	userCacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(userCacheDir, "debricked", "resolution")
+}
+
+// Wrap makes the job restore the lock files of a previous run with the same key instead of running, and store
+// the lock files it writes otherwise. options are the CLI options changing the output of the job, and lockFiles
+// the lock files the job writes, relative to the directory of its manifest file. Only those are stored, since jobs
+// run in parallel and may write lock files of other jobs in the same directories meanwhile. Jobs without lock files
+// are returned as they are
+func (c Cache) Wrap(j job.IJob, pmName string, options string, lockFiles []string) job.IJob {
+	if len(lockFiles) == 0 {
+		return j
+	}
+
+	return NewJob(j, c, pmName, options, lockFiles)
+}
+
+// key returns the hash of the files the resolution of manifest depends on: the manifest, the files matching the manifest
+// and configuration file patterns of the package manager in the directory of manifest and its subdirectories,
+// as well as the configuration files in parent directories up to the repository root. The toolchains selected for
+// the job, such as a configured executable or a Maven wrapper, are part of the key along with the version they report.
+// timeout is the timeout of the job, which the version command is run with
+func (c Cache) key(manifest string, pmName string, options string, toolchains []toolchain.Toolchain, timeout time.Duration) (string, error) {
+	dir, err := filepath.Abs(filepath.Dir(manifest))
+	if err != nil {
+		return "", err
+	}
+	regexes := keyFileRegexes(pmName)
+
+	files := map[string]bool{filepath.Base(manifest): true}
+	err = c.walk(dir, func(rel string) {
+		if matchesAny(regexes, filepath.Base(rel)) {
+			files[rel] = true
+		}
+	})
+	if err != nil {
+		return "", err
+	}
+	for parent, rel := dir, ""; !isGitRoot(parent) && parent != filepath.Dir(parent); {
+		parent = filepath.Dir(parent)
+		rel = filepath.Join(rel, "..")
+		entries, err := os.ReadDir(parent)
+		if err != nil {
+			break
+		}
+		for _, entry := range entries {
+			if !entry.IsDir() && matchesAny(regexes, entry.Name()) {
+				files[filepath.Join(rel, entry.Name())] = true
+			}
+		}
+	}
+
+	var sortedFiles []string
+	for f := range files {
+		sortedFiles = append(sortedFiles, f)
+	}
+	sort.Strings(sortedFiles)
+
+	hash := sha256.New()
+	toolchainNames := toolchainsString(toolchains)
+	_, _ = io.WriteString(hash, strings.Join([]string{keyVersion, pmName, options, toolchainNames, c.version(pmName, toolchains, timeout)}, "\n"))
+	for _, f := range sortedFiles {
+		content, err := os.ReadFile(filepath.Join(dir, f))
+		if err != nil {
+			return "", err
+		}
+		fileHash := sha256.Sum256(content)
+		_, _ = io.WriteString(hash, "\n"+filepath.ToSlash(f)+"\x00"+hex.EncodeToString(fileHash[:]))
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// version returns the version of the package manager used by the toolchains, running the version command
+// once per package manager and toolchains
+func (c Cache) version(pmName string, toolchains []toolchain.Toolchain, timeout time.Duration) string {
+	c.versionsLock.Lock()
+	defer c.versionsLock.Unlock()
+	id := pmName + "\x00" + toolchainsString(toolchains)
+	if version, ok := c.versions[id]; ok {
+		return version
+	}
+	version := c.versionCmd(pmName, toolchains, timeout)
+	c.versions[id] = version
+
+	return version
+}
+
+func toolchainsString(toolchains []toolchain.Toolchain) string {
+	var names []string
+	for _, t := range toolchains {
+		names = append(names, t.String())
+	}
+
+	return strings.Join(names, ", ")
+}
+
+// walk calls fn with the path, relative to dir, of the files in dir and its subdirectories, except excluded ones
+func (c Cache) walk(dir string, fn func(rel string)) error {
+	return filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if path != dir && (entry.Name() == gitDir || file.Excluded(c.exclusions, path)) {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+		if !entry.Type().IsRegular() || file.Excluded(c.exclusions, path) {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		fn(rel)
+
+		return nil
+	})
+}
+
+func (c Cache) entryDir(key string) string {
+	return filepath.Join(c.dir, key[:2], key)
+}
+
+// restore copies the lock files of the cache entry to their paths, by their paths relative to the entry. It returns
+// false if there is no entry
+func (c Cache) restore(key string, paths map[string]string) (bool, error) {
+	entryDir := c.entryDir(key)
+	if _, err := os.Stat(entryDir); err != nil {
+		return false, nil
+	}
+
+	restored := false
+	for rel, path := range paths {
+		entryFile := filepath.Join(entryDir, rel)
+		if _, err := os.Stat(entryFile); err != nil {
+			continue
+		}
+		err := copyFile(entryFile, path)
+		if err != nil {
+			return false, err
+		}
+		restored = true
+	}
+
+	return restored, nil
+}
+
+// store copies the lock files at paths to a new cache entry, by their paths relative to the entry. The entry is written
+// to a temporary directory first, so that concurrent runs never see partial entries
+func (c Cache) store(key string, paths map[string]string) error {
+	err := os.MkdirAll(c.dir, 0750)
+	if err != nil {
+		return err
+	}
+	tmpDir, err := os.MkdirTemp(c.dir, tmpEntryDirPrefix)
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	for rel, path := range paths {
+		err = copyFile(path, filepath.Join(tmpDir, rel))
+		if err != nil {
+			return err
+		}
+	}
+	entryDir := c.entryDir(key)
+	err = os.MkdirAll(filepath.Dir(entryDir), 0750)
+	if err != nil {
+		return err
+	}
+	err = os.Rename(tmpDir, entryDir)
+	if err != nil && isDir(entryDir) {
+		// Stored by a concurrent run
+		return nil
+	}
+
+	return err
+}
+
+func copyFile(source string, target string) error {
+	content, err := os.ReadFile(source)
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(filepath.Dir(target), 0750)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(target, content, 0600)
+}
+
+func keyFileRegexes(pmName string) []*regexp.Regexp {
+	var expressions []string
+	for _, p := range pm.Pms() {
+		if p.Name() == pmName {
+			expressions = append(expressions, p.Manifests()...)
+		}
+	}
+	expressions = append(expressions, file.FullScanFileRegexes...)
+	expressions = append(expressions, configFileRegexes...)
+
+	var regexes []*regexp.Regexp
+	for _, expression := range expressions {
+		regexes = append(regexes, regexp.MustCompile(expression))
+	}
+
+	return regexes
+}
+
+func matchesAny(regexes []*regexp.Regexp, name string) bool {
+	for _, regex := range regexes {
+		if regex.MatchString(name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isGitRoot(dir string) bool {
+	_, err := os.Stat(filepath.Join(dir, gitDir))
+
+	return err == nil
+}
+
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+
+	return err == nil && info.IsDir()
+}
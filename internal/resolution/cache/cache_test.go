@@ -0,0 +1,245 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/debricked/cli/internal/file"
+	"github.com/debricked/cli/internal/resolution/pm/maven"
+	"github.com/debricked/cli/internal/resolution/toolchain"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeFile(t *testing.T, path string, content string) {
+	t.Helper()
+	assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0750))
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0600))
+}
+
+// newTestCache makes a cache in a temporary directory with a fixed tool version
+func newTestCache(t *testing.T) Cache {
+	t.Helper()
+	c := NewCache(t.TempDir(), file.DefaultExclusions(), false)
+	c.versionCmd = func(_ string, _ []toolchain.Toolchain, _ time.Duration) string {
+		return "1.0.0"
+	}
+
+	return c
+}
+
+// newTestProject makes a Gradle project in a temporary git repository and returns the path of its build.gradle
+func newTestProject(t *testing.T) string {
+	t.Helper()
+	repository := t.TempDir()
+	assert.NoError(t, os.Mkdir(filepath.Join(repository, ".git"), 0750))
+	writeFile(t, filepath.Join(repository, "gradle.properties"), "org.gradle.jvmargs=-Xmx2g")
+	writeFile(t, filepath.Join(repository, "app", "build.gradle"), "plugins { id 'java' }")
+	writeFile(t, filepath.Join(repository, "app", "settings.gradle"), "include 'core'")
+	writeFile(t, filepath.Join(repository, "app", "core", "build.gradle"), "dependencies {}")
+	writeFile(t, filepath.Join(repository, "app", "README.md"), "readme")
+
+	return filepath.Join(repository, "app", "build.gradle")
+}
+
+func TestNewCache(t *testing.T) {
+	c := NewCache("dir", nil, true)
+	assert.Equal(t, "dir", c.dir)
+	assert.True(t, c.isolated)
+	assert.NotNil(t, c.versions)
+}
+
+func TestDefaultDir(t *testing.T) {
+	userCacheDir, err := os.UserCacheDir()
+	if err != nil {
+		assert.Empty(t, DefaultDir())
+
+		return
+	}
+	assert.Equal(t, filepath.Join(userCacheDir, "debricked", "resolution"), DefaultDir())
+}
+
+func TestWrap(t *testing.T) {
+	j := newLockJob("build.gradle")
+	wrapped := newTestCache(t).Wrap(j, "gradle", "options", []string{"gradle.debricked.lock"})
+	assert.Implements(t, (*ICacheJob)(nil), wrapped)
+	assert.Equal(t, "build.gradle", wrapped.GetFile())
+}
+
+func TestWrapNoLockFiles(t *testing.T) {
+	j := newLockJob("build.gradle")
+	wrapped := newTestCache(t).Wrap(j, "gradle", "options", nil)
+	assert.Same(t, j, wrapped)
+}
+
+func TestKey(t *testing.T) {
+	c := newTestCache(t)
+	manifest := newTestProject(t)
+	dir := filepath.Dir(manifest)
+
+	key, err := c.key(manifest, "gradle", "options", nil, 0)
+	assert.NoError(t, err)
+	assert.Len(t, key, 64)
+
+	sameKey, err := c.key(manifest, "gradle", "options", nil, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, key, sameKey)
+
+	otherOptionsKey, _ := c.key(manifest, "gradle", "other-options", nil, 0)
+	assert.NotEqual(t, key, otherOptionsKey)
+	otherPmKey, _ := c.key(manifest, "mvn", "options", nil, 0)
+	assert.NotEqual(t, key, otherPmKey)
+
+	writeFile(t, filepath.Join(dir, "README.md"), "changed readme")
+	writeFile(t, filepath.Join(dir, "gradle.debricked.lock"), "lock")
+	writeFile(t, filepath.Join(dir, "core", "node_modules", "build.gradle"), "excluded")
+	unchangedKey, _ := c.key(manifest, "gradle", "options", nil, 0)
+	assert.Equal(t, key, unchangedKey, "files that aren't manifest or configuration files are not part of the key")
+
+	cases := map[string]string{
+		"manifest":              manifest,
+		"subproject manifest":   filepath.Join(dir, "core", "build.gradle"),
+		"configuration":         filepath.Join(dir, "settings.gradle"),
+		"new configuration":     filepath.Join(dir, "gradle", "wrapper", "gradle-wrapper.properties"),
+		"parent configuration":  filepath.Join(filepath.Dir(dir), "gradle.properties"),
+		"version catalog":       filepath.Join(dir, "gradle", "libs.versions.toml"),
+		"nested configuration":  filepath.Join(dir, "core", "gradle.properties"),
+		"configuration at root": filepath.Join(filepath.Dir(dir), "settings.xml"),
+	}
+	for name, changedFile := range cases {
+		t.Run(name, func(t *testing.T) {
+			previousKey, _ := c.key(manifest, "gradle", "options", nil, 0)
+			writeFile(t, changedFile, "changed "+name)
+			changedKey, err := c.key(manifest, "gradle", "options", nil, 0)
+			assert.NoError(t, err)
+			assert.NotEqual(t, previousKey, changedKey)
+		})
+	}
+}
+
+func TestKeyVersion(t *testing.T) {
+	c := newTestCache(t)
+	manifest := newTestProject(t)
+	key, _ := c.key(manifest, "gradle", "options", nil, 0)
+
+	c = newTestCache(t)
+	c.versionCmd = func(_ string, _ []toolchain.Toolchain, _ time.Duration) string {
+		return "2.0.0"
+	}
+	otherVersionKey, _ := c.key(manifest, "gradle", "options", nil, 0)
+	assert.NotEqual(t, key, otherVersionKey)
+}
+
+func TestKeyManifestNotFound(t *testing.T) {
+	_, err := newTestCache(t).key(filepath.Join(t.TempDir(), "build.gradle"), "gradle", "options", nil, 0)
+	assert.Error(t, err)
+}
+
+func TestVersionOncePerPm(t *testing.T) {
+	c := newTestCache(t)
+	calls := 0
+	c.versionCmd = func(pmName string, toolchains []toolchain.Toolchain, _ time.Duration) string {
+		calls++
+
+		return pmName + toolchainsString(toolchains)
+	}
+	wrapper := []toolchain.Toolchain{{Name: toolchain.Maven, Executable: "mvnw"}}
+	assert.Equal(t, "gradle", c.version("gradle", nil, 0))
+	assert.Equal(t, "gradle", c.version("gradle", nil, 0))
+	assert.Equal(t, "maven", c.version("maven", nil, 0))
+	assert.Equal(t, "gradlemvn (mvnw)", c.version("gradle", wrapper, 0))
+	assert.Equal(t, 3, calls)
+}
+
+func TestKeyToolchains(t *testing.T) {
+	c := newTestCache(t)
+	manifest := newTestProject(t)
+	key, _ := c.key(manifest, "maven", "options", nil, 0)
+
+	wrapperKey, err := c.key(manifest, "maven", "options", []toolchain.Toolchain{{Name: toolchain.Maven, Executable: "/project/mvnw", Source: "mvnw"}}, 0)
+	assert.NoError(t, err)
+	assert.NotEqual(t, key, wrapperKey)
+
+	configuredKey, _ := c.key(manifest, "maven", "options", []toolchain.Toolchain{{Name: toolchain.Maven, Executable: "/opt/maven/bin/mvn", Source: toolchain.ConfiguredSource}}, 0)
+	assert.NotEqual(t, wrapperKey, configuredKey)
+}
+
+func TestToolVersionUnknownPm(t *testing.T) {
+	assert.Empty(t, toolVersion("unknown", nil, 0))
+}
+
+func TestToolVersionToolchain(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("sh is not available on Windows")
+	}
+	dir := t.TempDir()
+	wrapper := filepath.Join(dir, "mvnw")
+	assert.NoError(t, os.WriteFile(wrapper, []byte("#!/bin/sh\necho Apache Maven 3.9.6\n"), 0700)) // #nosec G306
+
+	version := toolVersion(maven.Name, []toolchain.Toolchain{{Name: toolchain.Maven, Executable: wrapper}}, time.Minute)
+
+	assert.Equal(t, "Apache Maven 3.9.6\n", version)
+}
+
+func TestToolVersionTimeout(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("sh is not available on Windows")
+	}
+	wrapper := filepath.Join(t.TempDir(), "mvnw")
+	assert.NoError(t, os.WriteFile(wrapper, []byte("#!/bin/sh\nsleep 30\necho Apache Maven 3.9.6\n"), 0700)) // #nosec G306
+
+	start := time.Now()
+	version := toolVersion(maven.Name, []toolchain.Toolchain{{Name: toolchain.Maven, Executable: wrapper}}, 100*time.Millisecond)
+
+	assert.Empty(t, version)
+	assert.Less(t, time.Since(start), 20*time.Second)
+}
+
+func TestStoreRestore(t *testing.T) {
+	c := newTestCache(t)
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "gradle.debricked.lock"), "root")
+	writeFile(t, filepath.Join(dir, "core", "gradle.debricked.lock"), "core")
+	coreLockFile := filepath.Join("core", "gradle.debricked.lock")
+	paths := map[string]string{
+		"gradle.debricked.lock": filepath.Join(dir, "gradle.debricked.lock"),
+		coreLockFile:            filepath.Join(dir, coreLockFile),
+	}
+	key := "0123456789abcdef"
+
+	restored, err := c.restore(key, paths)
+	assert.NoError(t, err)
+	assert.False(t, restored)
+
+	err = c.store(key, paths)
+	assert.NoError(t, err)
+	assert.DirExists(t, filepath.Join(c.dir, "01", key))
+	err = c.store(key, map[string]string{"gradle.debricked.lock": paths["gradle.debricked.lock"]})
+	assert.NoError(t, err, "storing an existing entry is not an error")
+
+	restoreDir := t.TempDir()
+	restored, err = c.restore(key, map[string]string{
+		"gradle.debricked.lock": filepath.Join(restoreDir, "gradle.debricked.lock"),
+		coreLockFile:            filepath.Join(restoreDir, coreLockFile),
+		"other.debricked.lock":  filepath.Join(restoreDir, "other.debricked.lock"),
+	})
+	assert.NoError(t, err)
+	assert.True(t, restored)
+	content, _ := os.ReadFile(filepath.Join(restoreDir, "gradle.debricked.lock"))
+	assert.Equal(t, "root", string(content))
+	content, _ = os.ReadFile(filepath.Join(restoreDir, coreLockFile))
+	assert.Equal(t, "core", string(content))
+	assert.NoFileExists(t, filepath.Join(restoreDir, "other.debricked.lock"))
+
+	entries, _ := os.ReadDir(c.dir)
+	assert.Len(t, entries, 1, "temporary entry directories are removed")
+}
+
+func TestStoreLockFileNotFound(t *testing.T) {
+	c := newTestCache(t)
+	err := c.store("0123456789abcdef", map[string]string{"gradle.debricked.lock": filepath.Join(t.TempDir(), "gradle.debricked.lock")})
+	assert.Error(t, err)
+	assert.NoDirExists(t, filepath.Join(c.dir, "01", "0123456789abcdef"))
+}
@@ -0,0 +1,127 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/debricked/cli/internal/file"
+	"github.com/debricked/cli/internal/resolution/job"
+)
+
+const (
+	StatusHit  = "cache hit"
+	StatusMiss = "cache miss"
+)
+
+// ICacheJob is a job wrapped by the cache, reporting whether its lock files were restored from the cache
+type ICacheJob interface {
+	job.IJob
+	CacheStatus() string
+}
+
+type Job struct {
+	job.IJob
+	cache     Cache
+	pmName    string
+	options   string
+	lockFiles []string
+	status    string
+}
+
+func NewJob(j job.IJob, cache Cache, pmName string, options string, lockFiles []string) *Job {
+	return &Job{
+		IJob:      j,
+		cache:     cache,
+		pmName:    pmName,
+		options:   options,
+		lockFiles: lockFiles,
+	}
+}
+
+// Run restores the lock files of the cache entry of the job, if any, and runs the wrapped job otherwise.
+// The lock files of the job that the wrapped job wrote are stored if it succeeds. Cache errors never fail the job,
+// which then runs as if there was no cache
+func (j *Job) Run() {
+	key, err := j.cache.key(j.GetFile(), j.pmName, j.options, j.Toolchains(), j.GetTimeout())
+	if err != nil {
+		j.IJob.Run()
+
+		return
+	}
+	paths, err := j.lockFilePaths()
+	if err != nil || len(paths) == 0 {
+		j.IJob.Run()
+
+		return
+	}
+
+	restored, err := j.cache.restore(key, paths)
+	if err == nil && restored {
+		j.status = StatusHit
+
+		return
+	}
+	j.status = StatusMiss
+
+	before := modTimes(paths)
+	j.IJob.Run()
+	if j.Errors().HasError() {
+		return
+	}
+	after := modTimes(paths)
+
+	written := map[string]string{}
+	for rel, modTime := range after {
+		if previous, ok := before[rel]; !ok || !previous.Equal(modTime) {
+			written[rel] = paths[rel]
+		}
+	}
+	if len(written) > 0 {
+		_ = j.cache.store(key, written)
+	}
+}
+
+// lockFilePaths returns the paths the lock files of the job are written to, by their paths relative to the directory
+// of the manifest file. Lock files generated by Debricked, and in isolated workspaces all lock files, are written to
+// the lock output dir, if it is set. Lock files outside the directory of the manifest file aren't cached
+func (j *Job) lockFilePaths() (map[string]string, error) {
+	dir, err := filepath.Abs(filepath.Dir(j.GetFile()))
+	if err != nil {
+		return nil, err
+	}
+	outputDir, _ := file.MirrorPath(j.LockOutputDir(), dir)
+	paths := map[string]string{}
+	for _, lockFile := range j.lockFiles {
+		rel := filepath.Clean(lockFile)
+		if filepath.IsAbs(rel) || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+		if j.cache.isolated || file.IsGeneratedLockFile(rel) {
+			paths[rel] = filepath.Join(outputDir, rel)
+		} else {
+			paths[rel] = filepath.Join(dir, rel)
+		}
+	}
+
+	return paths, nil
+}
+
+// modTimes returns the modification time of the existing files at paths, by their keys
+func modTimes(paths map[string]string) map[string]time.Time {
+	times := map[string]time.Time{}
+	for rel, path := range paths {
+		if info, err := os.Stat(path); err == nil {
+			times[rel] = info.ModTime()
+		}
+	}
+
+	return times
+}
+
+// CacheStatus returns StatusHit if the lock files were restored from the cache, StatusMiss if the wrapped job ran,
+// or an empty string if the job couldn't use the cache
+func (j *Job) CacheStatus() string {
+	return j.status
+}
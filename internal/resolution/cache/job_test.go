@@ -0,0 +1,196 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/debricked/cli/internal/resolution/job"
+	"github.com/stretchr/testify/assert"
+)
+
+// lockJob writes gradle.debricked.lock next to the manifest, like a resolution job
+type lockJob struct {
+	job.BaseJob
+	runs int
+	err  job.IError
+}
+
+func newLockJob(file string) *lockJob {
+	return &lockJob{BaseJob: job.NewBaseJob(file)}
+}
+
+func (j *lockJob) Run() {
+	j.runs++
+	if j.err != nil {
+		j.Errors().Critical(j.err)
+
+		return
+	}
+	_ = os.WriteFile(filepath.Join(filepath.Dir(j.GetFile()), "gradle.debricked.lock"), []byte("lock"), 0600)
+}
+
+func TestRunMissThenHit(t *testing.T) {
+	c := newTestCache(t)
+	manifest := newTestProject(t)
+	lockFile := filepath.Join(filepath.Dir(manifest), "gradle.debricked.lock")
+
+	inner := newLockJob(manifest)
+	j := NewJob(inner, c, "gradle", "options", []string{"gradle.debricked.lock"})
+	assert.Empty(t, j.CacheStatus())
+	j.Run()
+	assert.Equal(t, 1, inner.runs)
+	assert.Equal(t, StatusMiss, j.CacheStatus())
+	assert.False(t, j.Errors().HasError())
+
+	assert.NoError(t, os.Remove(lockFile))
+	inner = newLockJob(manifest)
+	j = NewJob(inner, c, "gradle", "options", []string{"gradle.debricked.lock"})
+	j.Run()
+	assert.Equal(t, 0, inner.runs)
+	assert.Equal(t, StatusHit, j.CacheStatus())
+	assert.FileExists(t, lockFile)
+
+	writeFile(t, manifest, "plugins { id 'application' }")
+	inner = newLockJob(manifest)
+	j = NewJob(inner, c, "gradle", "options", []string{"gradle.debricked.lock"})
+	j.Run()
+	assert.Equal(t, 1, inner.runs)
+	assert.Equal(t, StatusMiss, j.CacheStatus())
+}
+
+func TestRunErrNotStored(t *testing.T) {
+	c := newTestCache(t)
+	manifest := newTestProject(t)
+
+	inner := newLockJob(manifest)
+	inner.err = job.NewBaseJobError("job-error")
+	j := NewJob(inner, c, "gradle", "options", []string{"gradle.debricked.lock"})
+	j.Run()
+	assert.True(t, j.Errors().HasError())
+	assert.Equal(t, StatusMiss, j.CacheStatus())
+
+	entries, _ := os.ReadDir(c.dir)
+	assert.Empty(t, entries)
+}
+
+func TestRunNoLockFilesNotStored(t *testing.T) {
+	c := newTestCache(t)
+	manifest := newTestProject(t)
+	writeFile(t, filepath.Join(filepath.Dir(manifest), "gradle.debricked.lock"), "lock")
+
+	inner := &noLockJob{BaseJob: job.NewBaseJob(manifest)}
+	j := NewJob(inner, c, "gradle", "options", []string{"gradle.debricked.lock"})
+	j.Run()
+	assert.Equal(t, StatusMiss, j.CacheStatus())
+
+	entries, _ := os.ReadDir(c.dir)
+	assert.Empty(t, entries, "lock files that weren't generated by the job are not stored")
+}
+
+func TestRunKeyErr(t *testing.T) {
+	c := newTestCache(t)
+	inner := newLockJob(filepath.Join(t.TempDir(), "missing", "build.gradle"))
+	j := NewJob(inner, c, "gradle", "options", []string{"gradle.debricked.lock"})
+	j.Run()
+	assert.Equal(t, 1, inner.runs)
+	assert.Empty(t, j.CacheStatus())
+}
+
+// noLockJob doesn't write any lock file, like package managers with native lock files
+type noLockJob struct {
+	job.BaseJob
+}
+
+func (j *noLockJob) Run() {}
+
+// otherLockJob writes the lock file of another job in the directory of its manifest file while it runs, like
+// jobs running in parallel, besides gradle.debricked.lock
+type otherLockJob struct {
+	lockJob
+}
+
+func (j *otherLockJob) Run() {
+	j.lockJob.Run()
+	_ = os.WriteFile(filepath.Join(filepath.Dir(j.GetFile()), ".requirements.txt.pip.debricked.lock"), []byte("pip"), 0600)
+}
+
+func TestRunStoresOnlyLockFilesOfJob(t *testing.T) {
+	c := newTestCache(t)
+	manifest := newTestProject(t)
+	dir := filepath.Dir(manifest)
+
+	inner := &otherLockJob{lockJob: *newLockJob(manifest)}
+	j := NewJob(inner, c, "gradle", "options", []string{"gradle.debricked.lock"})
+	j.Run()
+	assert.Equal(t, StatusMiss, j.CacheStatus())
+
+	assert.NoError(t, os.Remove(filepath.Join(dir, "gradle.debricked.lock")))
+	writeFile(t, filepath.Join(dir, ".requirements.txt.pip.debricked.lock"), "fresh pip")
+	j = NewJob(newLockJob(manifest), c, "gradle", "options", []string{"gradle.debricked.lock"})
+	j.Run()
+	assert.Equal(t, StatusHit, j.CacheStatus())
+	assert.FileExists(t, filepath.Join(dir, "gradle.debricked.lock"))
+	content, _ := os.ReadFile(filepath.Join(dir, ".requirements.txt.pip.debricked.lock"))
+	assert.Equal(t, "fresh pip", string(content), "lock files of other jobs are never restored")
+}
+
+// nativeLockJob writes package-lock.json, like package managers with native lock files
+type nativeLockJob struct {
+	job.BaseJob
+	runs int
+}
+
+func (j *nativeLockJob) Run() {
+	j.runs++
+	_ = os.WriteFile(filepath.Join(filepath.Dir(j.GetFile()), "package-lock.json"), []byte("{}"), 0600)
+}
+
+func TestRunNativeLockFile(t *testing.T) {
+	c := newTestCache(t)
+	dir := t.TempDir()
+	manifest := filepath.Join(dir, "package.json")
+	writeFile(t, manifest, "{}")
+	lockOutputDir := t.TempDir()
+
+	inner := &nativeLockJob{BaseJob: job.NewBaseJob(manifest)}
+	j := NewJob(inner, c, "npm", "options", []string{"package-lock.json"})
+	j.SetLockOutputDir(lockOutputDir)
+	j.Run()
+	assert.Equal(t, StatusMiss, j.CacheStatus())
+
+	assert.NoError(t, os.Remove(filepath.Join(dir, "package-lock.json")))
+	inner = &nativeLockJob{BaseJob: job.NewBaseJob(manifest)}
+	j = NewJob(inner, c, "npm", "options", []string{"package-lock.json"})
+	j.SetLockOutputDir(lockOutputDir)
+	j.Run()
+	assert.Equal(t, 0, inner.runs)
+	assert.Equal(t, StatusHit, j.CacheStatus())
+	assert.FileExists(t, filepath.Join(dir, "package-lock.json"), "lock files of package managers stay next to the manifest file")
+}
+
+func TestLockFilePaths(t *testing.T) {
+	c := newTestCache(t)
+	j := NewJob(newLockJob("build.gradle"), c, "gradle", "options", []string{
+		"gradle.debricked.lock",
+		filepath.Join("core", "gradle.debricked.lock"),
+		filepath.Join("..", "outside.debricked.lock"),
+		"package-lock.json",
+	})
+	dir, _ := filepath.Abs(".")
+
+	paths, err := j.lockFilePaths()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"gradle.debricked.lock":                        filepath.Join(dir, "gradle.debricked.lock"),
+		filepath.Join("core", "gradle.debricked.lock"): filepath.Join(dir, "core", "gradle.debricked.lock"),
+		"package-lock.json":                            filepath.Join(dir, "package-lock.json"),
+	}, paths)
+
+	c.isolated = true
+	j = NewJob(newLockJob("build.gradle"), c, "gradle", "options", []string{"package-lock.json"})
+	j.SetLockOutputDir("out")
+	paths, err = j.lockFilePaths()
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "out", "package-lock.json"), paths["package-lock.json"])
+}
@@ -0,0 +1,89 @@
+package cache
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"time"
+
+	"github.com/debricked/cli/internal/resolution/pm/bower"
+	"github.com/debricked/cli/internal/resolution/pm/bundler"
+	"github.com/debricked/cli/internal/resolution/pm/cargo"
+	"github.com/debricked/cli/internal/resolution/pm/cocoapods"
+	"github.com/debricked/cli/internal/resolution/pm/composer"
+	"github.com/debricked/cli/internal/resolution/pm/gomod"
+	"github.com/debricked/cli/internal/resolution/pm/gradle"
+	"github.com/debricked/cli/internal/resolution/pm/maven"
+	"github.com/debricked/cli/internal/resolution/pm/npm"
+	"github.com/debricked/cli/internal/resolution/pm/nuget"
+	"github.com/debricked/cli/internal/resolution/pm/pip"
+	"github.com/debricked/cli/internal/resolution/pm/pipenv"
+	"github.com/debricked/cli/internal/resolution/pm/pnpm"
+	"github.com/debricked/cli/internal/resolution/pm/pyproject"
+	"github.com/debricked/cli/internal/resolution/pm/sbt"
+	"github.com/debricked/cli/internal/resolution/pm/swift"
+	"github.com/debricked/cli/internal/resolution/pm/yarn"
+	"github.com/debricked/cli/internal/resolution/toolchain"
+)
+
+// versionWaitDelay is how long toolVersion waits for the output of processes started by a killed version command
+const versionWaitDelay = 5 * time.Second
+
+// versionCmds are the commands printing the version of the tool resolving the manifest files of each package manager.
+// Wrapper scripts, like gradlew, are pinned by their configuration files, which are part of the key
+var versionCmds = map[string][]string{
+	maven.Name:     {"mvn", "--version"},
+	gradle.Name:    {"gradle", "--version"},
+	gomod.Name:     {"go", "version"},
+	pip.Name:       {"python3", "-m", "pip", "--version"},
+	yarn.Name:      {"yarn", "--version"},
+	npm.Name:       {"npm", "--version"},
+	pnpm.Name:      {"pnpm", "--version"},
+	bower.Name:     {"bower", "--version"},
+	nuget.Name:     {"dotnet", "--version"},
+	composer.Name:  {"composer", "--version"},
+	pyproject.Name: {"poetry", "--version"},
+	pipenv.Name:    {"pipenv", "--version"},
+	cargo.Name:     {"cargo", "--version"},
+	bundler.Name:   {"bundle", "--version"},
+	sbt.Name:       {"sbt", "--script-version"},
+	swift.Name:     {"swift", "--version"},
+	cocoapods.Name: {"pod", "--version"},
+}
+
+// toolVersion returns the output of the version command of the package manager, or an empty string if it fails.
+// The command is run by the toolchains of the job, like the commands of the job itself, and killed if it doesn't
+// finish within timeout, or may run until it finishes if timeout is 0. Versions selected per project are pinned by
+// configuration files, like rust-toolchain or .python-version, which are part of the key.
+// A missing tool makes the job fail, which is never cached
+func toolVersion(pmName string, toolchains []toolchain.Toolchain, timeout time.Duration) string {
+	args, ok := versionCmds[pmName]
+	if !ok {
+		return ""
+	}
+	ctx := context.Background()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...) // #nosec G204
+	cmd.WaitDelay = versionWaitDelay
+	for _, t := range toolchains {
+		if executable, ok := t.Replaces(args[0]); ok {
+			cmd.Path, cmd.Err = executable, nil
+		}
+		if env := t.Env(); len(env) > 0 {
+			if cmd.Env == nil {
+				cmd.Env = os.Environ()
+			}
+			cmd.Env = append(cmd.Env, env...)
+		}
+	}
+	output, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+
+	return string(output)
+}
@@ -0,0 +1,208 @@
+package drift
+
+import (
+	"bytes"
+	"crypto/md5" // #nosec G501 -- Composer hashes the content of composer.json with MD5
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"unicode/utf16"
+)
+
+// composerRelevantKeys are the keys of composer.json hashed into the content-hash of composer.lock
+var composerRelevantKeys = []string{
+	"name",
+	"version",
+	"require",
+	"require-dev",
+	"conflict",
+	"replace",
+	"provide",
+	"minimum-stability",
+	"prefer-stable",
+	"repositories",
+	"extra",
+}
+
+type composerLock struct {
+	ContentHash string `json:"content-hash"`
+}
+
+// checkComposerLock compares the content-hash of composer.lock with the hash of composer.json, which Composer
+// computes from the keys of composer.json affecting resolution
+func checkComposerLock(manifestFile string, lockFile string) (string, error) {
+	manifestContent, err := os.ReadFile(manifestFile)
+	if err != nil {
+		return "", err
+	}
+	lockContent, err := os.ReadFile(lockFile)
+	if err != nil {
+		return "", err
+	}
+	var lock composerLock
+	err = json.Unmarshal(lockContent, &lock)
+	if err != nil {
+		return "", err
+	}
+	if len(lock.ContentHash) == 0 {
+		return "", nil
+	}
+	contentHash, err := composerContentHash(manifestContent)
+	if err != nil {
+		return "", err
+	}
+	if contentHash != lock.ContentHash {
+		return fmt.Sprintf("content-hash %s doesn't match composer.json, which hashes to %s", lock.ContentHash, contentHash), nil
+	}
+
+	return "", nil
+}
+
+// composerContentHash returns the content-hash of composer.json like Composer, which MD5 hashes the relevant keys
+// encoded by PHP's json_encode
+func composerContentHash(manifestContent []byte) (string, error) {
+	var manifest map[string]json.RawMessage
+	err := json.Unmarshal(manifestContent, &manifest)
+	if err != nil {
+		return "", err
+	}
+	relevant := map[string]string{}
+	for _, key := range composerRelevantKeys {
+		if raw, ok := manifest[key]; ok {
+			if relevant[key], err = phpJsonEncode(raw); err != nil {
+				return "", err
+			}
+		}
+	}
+	var config map[string]json.RawMessage
+	if raw, ok := manifest["config"]; ok && json.Unmarshal(raw, &config) == nil {
+		if platform, ok := config["platform"]; ok {
+			encodedPlatform, err := phpJsonEncode(platform)
+			if err != nil {
+				return "", err
+			}
+			relevant["config"] = `{"platform":` + encodedPlatform + "}"
+		}
+	}
+
+	keys := make([]string, 0, len(relevant))
+	for key := range relevant {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var encoded strings.Builder
+	encoded.WriteString("{")
+	for i, key := range keys {
+		if i > 0 {
+			encoded.WriteString(",")
+		}
+		encoded.WriteString(phpJsonString(key) + ":" + relevant[key])
+	}
+	encoded.WriteString("}")
+	sum := md5.Sum([]byte(encoded.String())) // #nosec G401
+
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// phpJsonEncode re-encodes raw JSON like PHP's json_encode of the decoded associative array, which keeps the
+// order of keys, escapes slashes and non-ASCII characters, and encodes empty objects as empty arrays
+func phpJsonEncode(raw json.RawMessage) (string, error) {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var encoded strings.Builder
+	err := encodeValue(decoder, &encoded)
+
+	return encoded.String(), err
+}
+
+func encodeValue(decoder *json.Decoder, encoded *strings.Builder) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	switch value := token.(type) {
+	case json.Delim:
+		return encodeContainer(decoder, encoded, value)
+	case string:
+		encoded.WriteString(phpJsonString(value))
+	case json.Number:
+		encoded.WriteString(value.String())
+	case bool:
+		encoded.WriteString(fmt.Sprint(value))
+	case nil:
+		encoded.WriteString("null")
+	}
+
+	return nil
+}
+
+func encodeContainer(decoder *json.Decoder, encoded *strings.Builder, delim json.Delim) error {
+	var elements []string
+	for decoder.More() {
+		var element strings.Builder
+		if delim == '{' {
+			key, err := decoder.Token()
+			if err != nil {
+				return err
+			}
+			element.WriteString(phpJsonString(fmt.Sprint(key)) + ":")
+		}
+		err := encodeValue(decoder, &element)
+		if err != nil {
+			return err
+		}
+		elements = append(elements, element.String())
+	}
+	if _, err := decoder.Token(); err != nil {
+		return err
+	}
+	if delim == '{' && len(elements) > 0 {
+		encoded.WriteString("{" + strings.Join(elements, ",") + "}")
+	} else {
+		encoded.WriteString("[" + strings.Join(elements, ",") + "]")
+	}
+
+	return nil
+}
+
+// phpJsonString encodes s like PHP's json_encode without flags
+func phpJsonString(s string) string {
+	var encoded strings.Builder
+	encoded.WriteString(`"`)
+	for _, r := range s {
+		switch r {
+		case '"':
+			encoded.WriteString(`\"`)
+		case '\\':
+			encoded.WriteString(`\\`)
+		case '/':
+			encoded.WriteString(`\/`)
+		case '\b':
+			encoded.WriteString(`\b`)
+		case '\f':
+			encoded.WriteString(`\f`)
+		case '\n':
+			encoded.WriteString(`\n`)
+		case '\r':
+			encoded.WriteString(`\r`)
+		case '\t':
+			encoded.WriteString(`\t`)
+		default:
+			switch {
+			case r < 0x20 || (r > 0x7f && r < 0x10000):
+				encoded.WriteString(fmt.Sprintf(`\u%04x`, r))
+			case r >= 0x10000:
+				high, low := utf16.EncodeRune(r)
+				encoded.WriteString(fmt.Sprintf(`\u%04x\u%04x`, high, low))
+			default:
+				encoded.WriteRune(r)
+			}
+		}
+	}
+	encoded.WriteString(`"`)
+
+	return encoded.String()
+}
@@ -0,0 +1,97 @@
+package drift
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/debricked/cli/internal/file"
+)
+
+// Drift is a lock file that is stale relative to its manifest file, such as a lock file missing a dependency
+// added to the manifest file
+type Drift struct {
+	ManifestFile string `json:"manifestFile"`
+	LockFile     string `json:"lockFile"`
+	Reason       string `json:"reason"`
+}
+
+func (d Drift) String() string {
+	return fmt.Sprintf("%s is stale relative to %s: %s", d.LockFile, d.ManifestFile, d.Reason)
+}
+
+// checker returns why lockFile is stale relative to manifestFile, or an empty string if it isn't
+type checker func(manifestFile string, lockFile string) (string, error)
+
+// checkers are the checkers of lock files by the names of their manifest files and lock files
+var checkers = map[string]map[string]checker{
+	"package.json": {
+		"package-lock.json":   checkPackageLock,
+		"npm-shrinkwrap.json": checkPackageLock,
+		"yarn.lock":           checkYarnLock,
+	},
+	"composer.json": {
+		"composer.lock": checkComposerLock,
+	},
+	"go.mod": {
+		"go.sum": checkGoSum,
+	},
+}
+
+// siblingLockFiles are lock files next to manifest files which aren't part of their groups, since Debricked
+// resolves the manifest files into lock files of its own, but which are stale whenever the generated lock files are
+var siblingLockFiles = map[string][]string{
+	"go.mod": {"go.sum"},
+}
+
+// Check returns the drift of the lock files of the group, and of the sibling lock files of its manifest file, from
+// its manifest file. Lock files without a checker, such as lock files generated by Debricked, are never stale.
+// Lock files which can't be read are stale
+func Check(group file.Group) []Drift {
+	return append(checkLockFiles(group.ManifestFile, group.LockFiles), CheckSiblings(group)...)
+}
+
+// CheckSiblings returns the drift of the sibling lock files of the manifest file of the group, which aren't part of
+// the group, from the manifest file. Generating the lock files of the group doesn't update them
+func CheckSiblings(group file.Group) []Drift {
+	var lockFiles []string
+	for _, name := range siblingLockFiles[filepath.Base(group.ManifestFile)] {
+		lockFile := filepath.Join(filepath.Dir(group.ManifestFile), name)
+		if _, err := os.Stat(lockFile); err == nil && !contains(group.LockFiles, lockFile) {
+			lockFiles = append(lockFiles, lockFile)
+		}
+	}
+
+	return checkLockFiles(group.ManifestFile, lockFiles)
+}
+
+// checkLockFiles returns the drift of lockFiles from manifestFile
+func checkLockFiles(manifestFile string, lockFiles []string) []Drift {
+	manifestName := filepath.Base(manifestFile)
+	var drifts []Drift
+	for _, lockFile := range lockFiles {
+		check, ok := checkers[manifestName][filepath.Base(lockFile)]
+		if !ok {
+			continue
+		}
+		reason, err := check(manifestFile, lockFile)
+		if err != nil {
+			reason = fmt.Sprintf("failed to check lock file: %s", err)
+		}
+		if len(reason) > 0 {
+			drifts = append(drifts, Drift{ManifestFile: manifestFile, LockFile: lockFile, Reason: reason})
+		}
+	}
+
+	return drifts
+}
+
+func contains(files []string, f string) bool {
+	for _, candidate := range files {
+		if filepath.Clean(candidate) == filepath.Clean(f) {
+			return true
+		}
+	}
+
+	return false
+}
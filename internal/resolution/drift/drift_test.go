@@ -0,0 +1,224 @@
+package drift
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/debricked/cli/internal/file"
+	"github.com/stretchr/testify/assert"
+)
+
+// driftedCopy copies the files of the testdata dir to a temporary dir, replacing the content of manifestName
+func driftedCopy(t *testing.T, dir string, manifestName string, manifestContent string) string {
+	t.Helper()
+	copied := t.TempDir()
+	entries, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	for _, entry := range entries {
+		content, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		assert.NoError(t, err)
+		assert.NoError(t, os.WriteFile(filepath.Join(copied, entry.Name()), content, 0600))
+	}
+	assert.NoError(t, os.WriteFile(filepath.Join(copied, manifestName), []byte(manifestContent), 0600))
+
+	return copied
+}
+
+func TestCheckNoDrift(t *testing.T) {
+	cases := []file.Group{
+		{ManifestFile: "testdata/npm/package.json", LockFiles: []string{"testdata/npm/package-lock.json"}},
+		{ManifestFile: "testdata/npm-v1/package.json", LockFiles: []string{"testdata/npm-v1/package-lock.json"}},
+		{
+			ManifestFile: "testdata/npm-workspace/packages/app/package.json",
+			LockFiles:    []string{"testdata/npm-workspace/package-lock.json"},
+		},
+		{ManifestFile: "testdata/yarn/package.json", LockFiles: []string{"testdata/yarn/yarn.lock"}},
+		{ManifestFile: "testdata/go/go.mod", LockFiles: []string{"testdata/go/gomod.debricked.lock"}},
+		{ManifestFile: "testdata/composer/composer.json", LockFiles: []string{"testdata/composer/composer.lock"}},
+	}
+	for _, c := range cases {
+		t.Run(c.ManifestFile, func(t *testing.T) {
+			assert.Empty(t, Check(c))
+		})
+	}
+}
+
+func TestCheckUncheckedLockFile(t *testing.T) {
+	group := file.Group{ManifestFile: "testdata/npm/package.json", LockFiles: []string{"testdata/npm/package.json.debricked.lock"}}
+
+	assert.Empty(t, Check(group))
+}
+
+func TestCheckPackageLock(t *testing.T) {
+	cases := map[string]struct {
+		manifest string
+		reason   string
+	}{
+		"missing dependency": {
+			manifest: `{"dependencies": {"lodash": "^4.17.21", "express": "^4.18.2", "axios": "^1.6.0"}, "devDependencies": {"jest": "^29.7.0"}}`,
+			reason:   "dependencies axios isn't locked",
+		},
+		"changed spec": {
+			manifest: `{"dependencies": {"lodash": "^4.17.0", "express": "^4.18.2"}, "devDependencies": {"jest": "^29.7.0"}}`,
+			reason:   "dependencies lodash is locked as ^4.17.21 but declared as ^4.17.0",
+		},
+		"removed dependency": {
+			manifest: `{"dependencies": {"lodash": "^4.17.21", "express": "^4.18.2"}}`,
+			reason:   "devDependencies jest is locked but no longer declared",
+		},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			dir := driftedCopy(t, "testdata/npm", "package.json", c.manifest)
+			group := file.Group{
+				ManifestFile: filepath.Join(dir, "package.json"),
+				LockFiles:    []string{filepath.Join(dir, "package-lock.json")},
+			}
+
+			drifts := Check(group)
+
+			assert.Len(t, drifts, 1)
+			assert.Equal(t, group.ManifestFile, drifts[0].ManifestFile)
+			assert.Equal(t, group.LockFiles[0], drifts[0].LockFile)
+			assert.Equal(t, c.reason, drifts[0].Reason)
+		})
+	}
+}
+
+func TestCheckPackageLockV1(t *testing.T) {
+	dir := driftedCopy(t, "testdata/npm-v1", "package.json", `{"devDependencies": {"jest": "^29.7.0"}}`)
+	group := file.Group{
+		ManifestFile: filepath.Join(dir, "package.json"),
+		LockFiles:    []string{filepath.Join(dir, "package-lock.json")},
+	}
+
+	drifts := Check(group)
+
+	assert.Len(t, drifts, 1)
+	assert.Equal(t, "devDependencies jest isn't locked", drifts[0].Reason)
+}
+
+func TestCheckPackageLockMissingPackage(t *testing.T) {
+	group := file.Group{
+		ManifestFile: "testdata/npm-workspace/packages/app/package.json",
+		LockFiles:    []string{"testdata/npm/package-lock.json"},
+	}
+
+	drifts := Check(group)
+
+	assert.Len(t, drifts, 1)
+	assert.Contains(t, drifts[0].Reason, "isn't locked")
+}
+
+func TestCheckYarnLock(t *testing.T) {
+	dir := driftedCopy(t, "testdata/yarn", "package.json", `{"dependencies": {"@babel/core": "^7.24.0", "lodash": "^4.17.21"}}`)
+	group := file.Group{
+		ManifestFile: filepath.Join(dir, "package.json"),
+		LockFiles:    []string{filepath.Join(dir, "yarn.lock")},
+	}
+
+	drifts := Check(group)
+
+	assert.Len(t, drifts, 1)
+	assert.Equal(t, "dependencies @babel/core@^7.24.0 isn't locked", drifts[0].Reason)
+}
+
+func TestCheckYarnBerryLock(t *testing.T) {
+	dir := t.TempDir()
+	manifestFile := filepath.Join(dir, "package.json")
+	lockFile := filepath.Join(dir, "yarn.lock")
+	assert.NoError(t, os.WriteFile(manifestFile, []byte(`{"dependencies": {"lodash": "^4.17.21"}}`), 0600))
+	assert.NoError(t, os.WriteFile(lockFile, []byte("__metadata:\n  version: 6\n\n\"lodash@npm:^4.17.21\":\n  version: 4.17.21\n"), 0600))
+
+	assert.Empty(t, Check(file.Group{ManifestFile: manifestFile, LockFiles: []string{lockFile}}))
+}
+
+func TestCheckGoSum(t *testing.T) {
+	manifest, err := os.ReadFile("testdata/go/go.mod")
+	assert.NoError(t, err)
+	dir := driftedCopy(t, "testdata/go", "go.mod", string(manifest)+"\nrequire github.com/spf13/viper v1.16.0\n")
+	group := file.Group{ManifestFile: filepath.Join(dir, "go.mod")}
+
+	drifts := Check(group)
+
+	assert.Len(t, drifts, 1)
+	assert.Equal(t, filepath.Join(dir, "go.sum"), drifts[0].LockFile)
+	assert.Equal(t, "requirement github.com/spf13/viper v1.16.0 has no checksum", drifts[0].Reason)
+}
+
+func TestCheckGoSumReplacement(t *testing.T) {
+	manifest, err := os.ReadFile("testdata/go/go.mod")
+	assert.NoError(t, err)
+	dir := driftedCopy(t, "testdata/go", "go.mod", string(manifest)+"\nreplace github.com/pkg/errors => github.com/fork/errors v0.9.2\n")
+	group := file.Group{ManifestFile: filepath.Join(dir, "go.mod")}
+
+	drifts := Check(group)
+
+	assert.Len(t, drifts, 1)
+	assert.Equal(t, "requirement github.com/fork/errors v0.9.2 has no checksum", drifts[0].Reason)
+}
+
+func TestCheckSiblings(t *testing.T) {
+	manifest, err := os.ReadFile("testdata/go/go.mod")
+	assert.NoError(t, err)
+	dir := driftedCopy(t, "testdata/go", "go.mod", string(manifest)+"\nrequire github.com/spf13/viper v1.16.0\n")
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "package.json"), []byte(`{"dependencies": {"lodash": "^4.17.21"}}`), 0600))
+
+	drifts := CheckSiblings(file.Group{ManifestFile: filepath.Join(dir, "go.mod")})
+	assert.Len(t, drifts, 1)
+	assert.Equal(t, filepath.Join(dir, "go.sum"), drifts[0].LockFile)
+
+	lockFile := filepath.Join(dir, "package-lock.json")
+	assert.NoError(t, os.WriteFile(lockFile, []byte(`{"packages": {"": {"dependencies": {}}}}`), 0600))
+	assert.Empty(t, CheckSiblings(file.Group{ManifestFile: filepath.Join(dir, "package.json"), LockFiles: []string{lockFile}}))
+}
+
+func TestCheckComposerLock(t *testing.T) {
+	dir := driftedCopy(t, "testdata/composer", "composer.json", `{"require": {"monolog/monolog": "^3.0"}}`)
+	group := file.Group{
+		ManifestFile: filepath.Join(dir, "composer.json"),
+		LockFiles:    []string{filepath.Join(dir, "composer.lock")},
+	}
+
+	drifts := Check(group)
+
+	assert.Len(t, drifts, 1)
+	assert.Contains(t, drifts[0].Reason, "content-hash 216459f34e26baa9bd4b49d54c9ccc0e doesn't match composer.json")
+}
+
+func TestCheckUnreadableLockFile(t *testing.T) {
+	dir := driftedCopy(t, "testdata/npm", "package-lock.json", "{")
+	group := file.Group{
+		ManifestFile: filepath.Join(dir, "package.json"),
+		LockFiles:    []string{filepath.Join(dir, "package-lock.json")},
+	}
+
+	drifts := Check(group)
+
+	assert.Len(t, drifts, 1)
+	assert.Contains(t, drifts[0].Reason, "failed to check lock file")
+}
+
+func TestComposerContentHash(t *testing.T) {
+	hash, err := composerContentHash([]byte(`{"name": "a/b", "config": {"platform": {"php": "8.1"}, "sort-packages": true}, "extra": {}}`))
+	assert.NoError(t, err)
+	assert.Len(t, hash, 32)
+
+	reordered, err := composerContentHash([]byte(`{"extra": [], "config": {"platform": {"php": "8.1"}}, "name": "a/b"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, hash, reordered)
+
+	_, err = composerContentHash([]byte(`[`))
+	assert.Error(t, err)
+}
+
+func TestPhpJsonString(t *testing.T) {
+	assert.Equal(t, `"a\/b \"c\" \u00e9 \ud83d\ude00\n"`, phpJsonString("a/b \"c\" é 😀\n"))
+}
+
+func TestDriftString(t *testing.T) {
+	d := Drift{ManifestFile: "package.json", LockFile: "yarn.lock", Reason: "dependencies lodash@^4.17.21 isn't locked"}
+
+	assert.Equal(t, "yarn.lock is stale relative to package.json: dependencies lodash@^4.17.21 isn't locked", d.String())
+}
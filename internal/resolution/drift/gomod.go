@@ -0,0 +1,161 @@
+package drift
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+type goModule struct {
+	path    string
+	version string
+}
+
+type goReplacement struct {
+	old goModule
+	new goModule
+}
+
+// checkGoSum checks that every requirement of go.mod has a checksum in go.sum, taking replacements into account.
+// Requirements replaced by local directories have no checksums and aren't checked
+func checkGoSum(manifestFile string, lockFile string) (string, error) {
+	requirements, replacements, err := readGoMod(manifestFile)
+	if err != nil {
+		return "", err
+	}
+	sums, err := readGoSum(lockFile)
+	if err != nil {
+		return "", err
+	}
+	for _, requirement := range requirements {
+		module := replace(requirement, replacements)
+		if len(module.version) == 0 {
+			continue
+		}
+		if !sums[module] {
+			return fmt.Sprintf("requirement %s %s has no checksum", module.path, module.version), nil
+		}
+	}
+
+	return "", nil
+}
+
+// replace returns the replacement of the module, preferring replacements of its version over replacements of
+// all versions
+func replace(module goModule, replacements []goReplacement) goModule {
+	replaced := module
+	for _, replacement := range replacements {
+		if replacement.old.path != module.path {
+			continue
+		}
+		if replacement.old.version == module.version {
+			return replacement.new
+		}
+		if len(replacement.old.version) == 0 {
+			replaced = replacement.new
+		}
+	}
+
+	return replaced
+}
+
+// readGoMod returns the require and replace directives of go.mod, both single-line and in blocks
+func readGoMod(manifestFile string) ([]goModule, []goReplacement, error) {
+	f, err := os.Open(manifestFile)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+
+	var requirements []goModule
+	var replacements []goReplacement
+	block := ""
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "//")
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		directive := block
+		if len(block) == 0 {
+			directive, fields = fields[0], fields[1:]
+			if len(fields) == 1 && fields[0] == "(" {
+				block = directive
+
+				continue
+			}
+		} else if fields[0] == ")" {
+			block = ""
+
+			continue
+		}
+		switch directive {
+		case "require":
+			if len(fields) >= 2 {
+				requirements = append(requirements, goModule{path: unquote(fields[0]), version: unquote(fields[1])})
+			}
+		case "replace":
+			if replacement, ok := parseReplacement(fields); ok {
+				replacements = append(replacements, replacement)
+			}
+		}
+	}
+
+	return requirements, replacements, scanner.Err()
+}
+
+// parseReplacement parses the fields of a replace directive, such as old v1 => new v2 or old => ../new
+func parseReplacement(fields []string) (goReplacement, bool) {
+	for i, field := range fields {
+		if field != "=>" {
+			continue
+		}
+		old, replacement := fields[:i], fields[i+1:]
+		if len(old) == 0 || len(replacement) == 0 {
+			return goReplacement{}, false
+		}
+		r := goReplacement{old: goModule{path: unquote(old[0])}, new: goModule{path: unquote(replacement[0])}}
+		if len(old) > 1 {
+			r.old.version = unquote(old[1])
+		}
+		if len(replacement) > 1 {
+			r.new.version = unquote(replacement[1])
+		}
+
+		return r, true
+	}
+
+	return goReplacement{}, false
+}
+
+// readGoSum returns the modules with checksums in go.sum, of either their content or their go.mod
+func readGoSum(lockFile string) (map[goModule]bool, error) {
+	f, err := os.Open(lockFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	sums := map[goModule]bool{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 3 {
+			continue
+		}
+		sums[goModule{path: fields[0], version: strings.TrimSuffix(fields[1], "/go.mod")}] = true
+	}
+
+	return sums, scanner.Err()
+}
+
+func unquote(field string) string {
+	if unquoted, err := strconv.Unquote(field); err == nil {
+		return unquoted
+	}
+
+	return field
+}
@@ -0,0 +1,182 @@
+package drift
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// dependencyFields are the fields of package.json declaring dependencies, which npm copies to the package
+// entries of lock files
+var dependencyFields = []string{"dependencies", "devDependencies", "optionalDependencies", "peerDependencies"}
+
+// installedDependencyFields are the fields of package.json declaring dependencies installed into node_modules,
+// which are locked by lock files without package entries
+var installedDependencyFields = []string{"dependencies", "devDependencies", "optionalDependencies"}
+
+type packageJson map[string]json.RawMessage
+
+type packageLock struct {
+	Packages     map[string]packageJson     `json:"packages"`
+	Dependencies map[string]json.RawMessage `json:"dependencies"`
+}
+
+func readPackageJson(manifestFile string) (packageJson, error) {
+	content, err := os.ReadFile(manifestFile)
+	if err != nil {
+		return nil, err
+	}
+	var manifest packageJson
+	err = json.Unmarshal(content, &manifest)
+
+	return manifest, err
+}
+
+// dependencies returns the dependencies of the field by name, ignoring fields which aren't objects of strings
+func (p packageJson) dependencies(field string) map[string]string {
+	dependencies := map[string]string{}
+	if raw, ok := p[field]; ok {
+		_ = json.Unmarshal(raw, &dependencies)
+	}
+
+	return dependencies
+}
+
+// checkPackageLock compares the dependencies of package.json with the package entry of its directory in
+// package-lock.json, which npm copies from package.json, or with the locked dependencies of lock files older
+// than lockfileVersion 2
+func checkPackageLock(manifestFile string, lockFile string) (string, error) {
+	manifest, err := readPackageJson(manifestFile)
+	if err != nil {
+		return "", err
+	}
+	content, err := os.ReadFile(lockFile)
+	if err != nil {
+		return "", err
+	}
+	var lock packageLock
+	err = json.Unmarshal(content, &lock)
+	if err != nil {
+		return "", err
+	}
+
+	if lock.Packages == nil {
+		for _, field := range installedDependencyFields {
+			for _, name := range sortedNames(manifest.dependencies(field)) {
+				if _, ok := lock.Dependencies[name]; !ok {
+					return fmt.Sprintf("%s %s isn't locked", field, name), nil
+				}
+			}
+		}
+
+		return "", nil
+	}
+
+	entry, err := packageEntry(manifestFile, lockFile)
+	if err != nil {
+		return "", err
+	}
+	locked, ok := lock.Packages[entry]
+	if !ok {
+		return fmt.Sprintf("package %s isn't locked", manifestFile), nil
+	}
+	for _, field := range dependencyFields {
+		declared := manifest.dependencies(field)
+		lockedDependencies := locked.dependencies(field)
+		for _, name := range sortedNames(declared) {
+			lockedSpec, ok := lockedDependencies[name]
+			if !ok {
+				return fmt.Sprintf("%s %s isn't locked", field, name), nil
+			}
+			if lockedSpec != declared[name] {
+				return fmt.Sprintf("%s %s is locked as %s but declared as %s", field, name, lockedSpec, declared[name]), nil
+			}
+		}
+		for _, name := range sortedNames(lockedDependencies) {
+			if _, ok := declared[name]; !ok {
+				return fmt.Sprintf("%s %s is locked but no longer declared", field, name), nil
+			}
+		}
+	}
+
+	return "", nil
+}
+
+// packageEntry returns the key of the package entry of the directory of manifestFile, which is relative to the
+// directory of lockFile
+func packageEntry(manifestFile string, lockFile string) (string, error) {
+	entry, err := filepath.Rel(filepath.Dir(lockFile), filepath.Dir(manifestFile))
+	if err != nil {
+		return "", err
+	}
+	if entry == "." {
+		return "", nil
+	}
+
+	return filepath.ToSlash(entry), nil
+}
+
+// checkYarnLock checks that every dependency of package.json has an entry in yarn.lock, which is keyed by the
+// descriptors of the dependencies, such as lodash@^4.17.21 or lodash@npm:^4.17.21. Dependencies using other
+// protocols, such as workspace: or file:, are keyed differently and aren't checked
+func checkYarnLock(manifestFile string, lockFile string) (string, error) {
+	manifest, err := readPackageJson(manifestFile)
+	if err != nil {
+		return "", err
+	}
+	descriptors, err := readYarnDescriptors(lockFile)
+	if err != nil {
+		return "", err
+	}
+	for _, field := range installedDependencyFields {
+		declared := manifest.dependencies(field)
+		for _, name := range sortedNames(declared) {
+			spec := declared[name]
+			if strings.Contains(spec, ":") && !strings.HasPrefix(spec, "npm:") {
+				continue
+			}
+			if !descriptors[name+"@"+spec] && !descriptors[name+"@npm:"+spec] {
+				return fmt.Sprintf("%s %s@%s isn't locked", field, name, spec), nil
+			}
+		}
+	}
+
+	return "", nil
+}
+
+// readYarnDescriptors returns the descriptors keying the entries of yarn.lock, of both Yarn Classic and Yarn Berry
+func readYarnDescriptors(lockFile string) (map[string]bool, error) {
+	f, err := os.Open(lockFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	descriptors := map[string]bool{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if len(line) == 0 || strings.HasPrefix(line, " ") || strings.HasPrefix(line, "#") || !strings.HasSuffix(line, ":") {
+			continue
+		}
+		for _, descriptor := range strings.Split(strings.TrimSuffix(line, ":"), ",") {
+			descriptors[strings.Trim(strings.TrimSpace(descriptor), `"`)] = true
+		}
+	}
+
+	return descriptors, scanner.Err()
+}
+
+func sortedNames(dependencies map[string]string) []string {
+	names := make([]string, 0, len(dependencies))
+	for name := range dependencies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
@@ -0,0 +1,17 @@
+module example.com/app
+
+go 1.20
+
+require github.com/pkg/errors v0.9.1 // indirect
+
+require (
+	github.com/spf13/cobra v1.7.0
+	example.com/local v1.0.0
+	example.com/forked v1.2.0
+)
+
+replace example.com/local => ../local
+
+replace (
+	example.com/forked v1.2.0 => github.com/fork/forked v1.2.1
+)
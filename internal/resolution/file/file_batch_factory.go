@@ -2,51 +2,65 @@ package file
 
 import (
 	"path"
+	"path/filepath"
 	"regexp"
 
 	"github.com/debricked/cli/internal/resolution/pm"
-	"github.com/debricked/cli/internal/resolution/pm/npm"
-	"github.com/debricked/cli/internal/resolution/pm/yarn"
 )
 
 type IBatchFactory interface {
 	Make(files []string) []IBatch
-	SetNpmPreferred(npmPreferred bool)
+	SetJsPackageManager(jsPackageManager string)
+	SetRoots(roots []string)
 }
 
 type BatchFactory struct {
-	pms          []pm.IPm
-	npmPreferred bool
+	pms              []pm.IPm
+	jsPackageManager string
+	roots            []string
 }
 
 func NewBatchFactory() *BatchFactory {
 	return &BatchFactory{
-		pms: pm.Pms(),
+		pms:              pm.Pms(),
+		jsPackageManager: JsPackageManagerAuto,
 	}
 }
 
-func (bf *BatchFactory) SetNpmPreferred(npmPreferred bool) {
-	bf.npmPreferred = npmPreferred
+// SetJsPackageManager sets the package manager resolving package.json files, or auto to detect it for each file
+func (bf *BatchFactory) SetJsPackageManager(jsPackageManager string) {
+	bf.jsPackageManager = jsPackageManager
+}
+
+// SetRoots sets the directories being resolved. Detection of the package manager of a package.json file doesn't look
+// above the outermost of them containing the file
+func (bf *BatchFactory) SetRoots(roots []string) {
+	bf.roots = roots
 }
 
 func (bf *BatchFactory) Make(files []string) []IBatch {
 	batchMap := make(map[string]IBatch)
 	for _, file := range files {
+		// The JavaScript package manager is only selected once the file is known to be a package.json
+		jsPackageManager := ""
 		for _, p := range bf.pms {
-			if bf.skipPackageManager(p) {
-				continue
-			}
-
 			for _, manifest := range p.Manifests() {
 				compiledRegex, _ := regexp.Compile(manifest)
-				if compiledRegex.MatchString(path.Base(file)) {
-					batch, ok := batchMap[p.Name()]
-					if !ok {
-						batch = NewBatch(p)
-						batchMap[p.Name()] = batch
-					}
-					batch.Add(file)
+				if !compiledRegex.MatchString(path.Base(file)) {
+					continue
 				}
+				if isJsPackageManager(p.Name()) && len(jsPackageManager) == 0 {
+					jsPackageManager = bf.selectJsPackageManager(file)
+				}
+				if bf.skipPackageManager(p, jsPackageManager) {
+					continue
+				}
+				batch, ok := batchMap[p.Name()]
+				if !ok {
+					batch = NewBatch(p)
+					batchMap[p.Name()] = batch
+				}
+				batch.Add(file)
 			}
 		}
 	}
@@ -60,15 +74,30 @@ func (bf *BatchFactory) Make(files []string) []IBatch {
 	return batches
 }
 
-func (bf *BatchFactory) skipPackageManager(p pm.IPm) bool {
-	name := p.Name()
+func (bf *BatchFactory) selectJsPackageManager(file string) string {
+	if len(bf.jsPackageManager) == 0 || bf.jsPackageManager == JsPackageManagerAuto {
+		return DetectJsPackageManager(file, bf.rootOf(file))
+	}
+
+	return bf.jsPackageManager
+}
 
-	switch true {
-	case name == npm.Name && !bf.npmPreferred:
-		return true
-	case name == yarn.Name && bf.npmPreferred:
-		return true
+// rootOf returns the outermost root containing file, or the directory of file if no root contains it
+func (bf *BatchFactory) rootOf(file string) string {
+	absFile := absPath(file)
+	root := filepath.Dir(absFile)
+	for _, r := range bf.roots {
+		absRoot := absPath(r)
+		if isWithin(absFile, absRoot) && len(absRoot) < len(root) {
+			root = absRoot
+		}
 	}
 
-	return false
+	return root
+}
+
+func (bf *BatchFactory) skipPackageManager(p pm.IPm, jsPackageManager string) bool {
+	name := p.Name()
+
+	return isJsPackageManager(name) && name != jsPackageManager
 }
@@ -1,10 +1,16 @@
 package file
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/debricked/cli/internal/resolution/pm"
+	"github.com/debricked/cli/internal/resolution/pm/gomod"
+	"github.com/debricked/cli/internal/resolution/pm/npm"
+	"github.com/debricked/cli/internal/resolution/pm/pnpm"
 	"github.com/debricked/cli/internal/resolution/pm/testdata"
+	"github.com/debricked/cli/internal/resolution/pm/yarn"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -104,3 +110,81 @@ func TestMakeMultipleBatchesMultipleFiles(t *testing.T) {
 		}
 	}
 }
+
+func TestMakeJsPackageManager(t *testing.T) {
+	cases := map[string]string{
+		yarn.Name: yarn.Name,
+		npm.Name:  npm.Name,
+		pnpm.Name: pnpm.Name,
+	}
+	for jsPackageManager, expected := range cases {
+		t.Run(jsPackageManager, func(t *testing.T) {
+			bf := NewBatchFactory()
+			bf.SetJsPackageManager(jsPackageManager)
+			batches := bf.Make([]string{"package.json", "test/package.json", "go.mod"})
+			assert.Len(t, batches, 2)
+			for _, batch := range batches {
+				if batch.Pm().Name() != gomod.Name {
+					assert.Equal(t, expected, batch.Pm().Name())
+					assert.Len(t, batch.Files(), 2)
+				}
+			}
+		})
+	}
+}
+
+func TestMakeJsPackageManagerAuto(t *testing.T) {
+	dir := t.TempDir()
+	pnpmManifest := filepath.Join(dir, "pnpm", "package.json")
+	npmManifest := filepath.Join(dir, "npm", "package.json")
+	assert.NoError(t, os.MkdirAll(filepath.Dir(pnpmManifest), 0755))
+	assert.NoError(t, os.WriteFile(pnpmManifest, []byte(`{"packageManager": "pnpm@8.6.0"}`), 0600))
+	assert.NoError(t, os.MkdirAll(filepath.Dir(npmManifest), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "npm", "package-lock.json"), []byte("{}"), 0600))
+
+	bf := NewBatchFactory()
+	batches := bf.Make([]string{pnpmManifest, npmManifest})
+
+	assert.Len(t, batches, 2)
+	for _, batch := range batches {
+		assert.Len(t, batch.Files(), 1)
+		switch batch.Pm().Name() {
+		case pnpm.Name:
+			assert.Equal(t, pnpmManifest, batch.Files()[0])
+		case npm.Name:
+			assert.Equal(t, npmManifest, batch.Files()[0])
+		default:
+			t.Errorf("unexpected batch %s", batch.Pm().Name())
+		}
+	}
+}
+
+func TestMakeJsPackageManagerAutoRoots(t *testing.T) {
+	dir := t.TempDir()
+	appDir := filepath.Join(dir, "app")
+	manifest := filepath.Join(appDir, "package.json")
+	assert.NoError(t, os.MkdirAll(appDir, 0755))
+	assert.NoError(t, os.WriteFile(manifest, []byte("{}"), 0600))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "package-lock.json"), []byte("{}"), 0600))
+
+	cases := map[string]struct {
+		roots    []string
+		expected string
+	}{
+		"parent root":         {[]string{dir}, npm.Name},
+		"manifest root":       {[]string{appDir}, yarn.Name},
+		"outermost root":      {[]string{appDir, dir}, npm.Name},
+		"root not containing": {[]string{filepath.Join(dir, "other")}, yarn.Name},
+		"no roots":            {nil, yarn.Name},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			bf := NewBatchFactory()
+			bf.SetRoots(c.roots)
+			batches := bf.Make([]string{manifest})
+
+			assert.Len(t, batches, 1)
+			assert.Equal(t, c.expected, batches[0].Pm().Name())
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package file
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/debricked/cli/internal/resolution/pm/npm"
+	"github.com/debricked/cli/internal/resolution/pm/pnpm"
+	"github.com/debricked/cli/internal/resolution/pm/yarn"
+)
+
+const JsPackageManagerAuto = "auto"
+
+// JsPackageManagers are the supported choices of JavaScript package manager, auto being the default
+var JsPackageManagers = []string{JsPackageManagerAuto, yarn.Name, npm.Name, pnpm.Name}
+
+// jsPackageManagerFiles maps files, found in the directory of package.json or in a parent directory, to the package manager they belong to
+var jsPackageManagerFiles = []struct {
+	name           string
+	packageManager string
+}{
+	{"pnpm-lock.yaml", pnpm.Name},
+	{"pnpm-workspace.yaml", pnpm.Name},
+	{"yarn.lock", yarn.Name},
+	{".yarnrc.yml", yarn.Name},
+	{"package-lock.json", npm.Name},
+	{"npm-shrinkwrap.json", npm.Name},
+}
+
+func ValidateJsPackageManager(name string) error {
+	for _, packageManager := range JsPackageManagers {
+		if name == packageManager {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("invalid JavaScript package manager: %s. Supported package managers are %s", name, strings.Join(JsPackageManagers, ", "))
+}
+
+func isJsPackageManager(name string) bool {
+	return name == yarn.Name || name == npm.Name || name == pnpm.Name
+}
+
+// DetectJsPackageManager selects the package manager of package.json. Starting in the directory of package.json and moving up to
+// root, or the repository root if it's closer, the corepack configuration of package.json takes precedence over existing lock files
+// and package manager configuration. Yarn is used if no package manager is found
+func DetectJsPackageManager(manifest string, root string) string {
+	dir := absPath(filepath.Dir(manifest))
+	root = absPath(root)
+	for {
+		if packageManager := readCorepackPackageManager(filepath.Join(dir, "package.json")); len(packageManager) > 0 {
+			return packageManager
+		}
+		for _, file := range jsPackageManagerFiles {
+			if _, err := os.Stat(filepath.Join(dir, file.name)); err == nil {
+				return file.packageManager
+			}
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir || dir == root || isRepositoryRoot(dir) {
+			break
+		}
+		dir = parent
+	}
+
+	return yarn.Name
+}
+
+type packageJson struct {
+	PackageManager string `json:"packageManager"`
+	DevEngines     struct {
+		PackageManager json.RawMessage `json:"packageManager"`
+	} `json:"devEngines"`
+}
+
+type devEnginesPackageManager struct {
+	Name string `json:"name"`
+}
+
+// readCorepackPackageManager reads the package manager from the packageManager field, for example "pnpm@8.6.0",
+// or the devEngines.packageManager field, which is either an object or a list of objects with a name
+func readCorepackPackageManager(file string) string {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return ""
+	}
+	var manifest packageJson
+	if json.Unmarshal(content, &manifest) != nil {
+		return ""
+	}
+
+	names := []string{strings.SplitN(manifest.PackageManager, "@", 2)[0]}
+	var devEngine devEnginesPackageManager
+	var devEngines []devEnginesPackageManager
+	if json.Unmarshal(manifest.DevEngines.PackageManager, &devEngine) == nil {
+		names = append(names, devEngine.Name)
+	} else if json.Unmarshal(manifest.DevEngines.PackageManager, &devEngines) == nil {
+		for _, engine := range devEngines {
+			names = append(names, engine.Name)
+		}
+	}
+
+	for _, name := range names {
+		if isJsPackageManager(name) {
+			return name
+		}
+	}
+
+	return ""
+}
+
+func isRepositoryRoot(dir string) bool {
+	_, err := os.Stat(filepath.Join(dir, ".git"))
+
+	return err == nil
+}
+
+func absPath(path string) string {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return filepath.Clean(path)
+	}
+
+	return abs
+}
+
+// isWithin returns true if path is dir or a path in it
+func isWithin(path string, dir string) bool {
+	rel, err := filepath.Rel(dir, path)
+
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
@@ -0,0 +1,111 @@
+package file
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/debricked/cli/internal/resolution/pm/npm"
+	"github.com/debricked/cli/internal/resolution/pm/pnpm"
+	"github.com/debricked/cli/internal/resolution/pm/yarn"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateJsPackageManager(t *testing.T) {
+	for _, jsPackageManager := range JsPackageManagers {
+		assert.NoError(t, ValidateJsPackageManager(jsPackageManager))
+	}
+
+	err := ValidateJsPackageManager("bun")
+	assert.ErrorContains(t, err, "invalid JavaScript package manager: bun. Supported package managers are auto, yarn, npm, pnpm")
+}
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		file := filepath.Join(dir, filepath.FromSlash(name))
+		assert.NoError(t, os.MkdirAll(filepath.Dir(file), 0755))
+		assert.NoError(t, os.WriteFile(file, []byte(content), 0600))
+	}
+}
+
+func TestDetectJsPackageManager(t *testing.T) {
+	cases := []struct {
+		name     string
+		files    map[string]string
+		root     string
+		expected string
+	}{
+		{
+			name:     "no signals",
+			files:    map[string]string{"app/package.json": "{}"},
+			expected: yarn.Name,
+		},
+		{
+			name:     "packageManager field",
+			files:    map[string]string{"app/package.json": `{"packageManager": "pnpm@8.6.0+sha256.abc"}`, "app/yarn.lock": ""},
+			expected: pnpm.Name,
+		},
+		{
+			name:     "devEngines object",
+			files:    map[string]string{"app/package.json": `{"devEngines": {"packageManager": {"name": "npm", "version": "^10"}}}`},
+			expected: npm.Name,
+		},
+		{
+			name:     "devEngines list",
+			files:    map[string]string{"app/package.json": `{"devEngines": {"packageManager": [{"name": "bun"}, {"name": "pnpm"}]}}`},
+			expected: pnpm.Name,
+		},
+		{
+			name:     "unknown packageManager",
+			files:    map[string]string{"app/package.json": `{"packageManager": "bun@1.0.0"}`, "app/package-lock.json": "{}"},
+			expected: npm.Name,
+		},
+		{
+			name:     "lock file",
+			files:    map[string]string{"app/package.json": "{}", "app/pnpm-lock.yaml": ""},
+			expected: pnpm.Name,
+		},
+		{
+			name:     "workspace root",
+			files:    map[string]string{"packages/app/package.json": "{}", "pnpm-workspace.yaml": "packages:\n  - packages/*\n"},
+			expected: pnpm.Name,
+		},
+		{
+			name:     "workspace root packageManager",
+			files:    map[string]string{"packages/app/package.json": "{}", "package.json": `{"packageManager": "npm@10.2.0"}`},
+			expected: npm.Name,
+		},
+		{
+			name:     "outside of repository",
+			files:    map[string]string{"repo/.git/HEAD": "", "repo/app/package.json": "{}", "package-lock.json": "{}"},
+			expected: yarn.Name,
+		},
+		{
+			name:     "outside of root",
+			files:    map[string]string{"app/package.json": "{}", "package-lock.json": "{}"},
+			root:     "app",
+			expected: yarn.Name,
+		},
+		{
+			name:     "invalid package.json",
+			files:    map[string]string{"app/package.json": "{", "app/.yarnrc.yml": ""},
+			expected: yarn.Name,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeFiles(t, dir, c.files)
+			manifest := ""
+			for name := range c.files {
+				if filepath.Base(name) == "package.json" && (len(manifest) == 0 || len(name) > len(manifest)) {
+					manifest = name
+				}
+			}
+
+			root := filepath.Join(dir, filepath.FromSlash(c.root))
+			assert.Equal(t, c.expected, DetectJsPackageManager(filepath.Join(dir, filepath.FromSlash(manifest)), root))
+		})
+	}
+}
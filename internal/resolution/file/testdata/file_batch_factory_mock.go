@@ -15,7 +15,10 @@ func NewBatchFactoryMock() BatchFactoryMock {
 	}
 }
 
-func (bf BatchFactoryMock) SetNpmPreferred(_ bool) {
+func (bf BatchFactoryMock) SetJsPackageManager(_ string) {
+}
+
+func (bf BatchFactoryMock) SetRoots(_ []string) {
 }
 
 func (bf BatchFactoryMock) Make(_ []string) []file.IBatch {
@@ -0,0 +1,59 @@
+package isolation
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/debricked/cli/internal/file"
+	"github.com/debricked/cli/internal/resolution/job"
+)
+
+// Job is a job resolving the copy of its manifest file in a workspace
+type Job struct {
+	job.IJob
+	workspace *Workspace
+}
+
+func NewJob(j job.IJob, workspace *Workspace) *Job {
+	return &Job{
+		IJob:      j,
+		workspace: workspace,
+	}
+}
+
+// GetFile returns the manifest file in the repository, rather than its copy
+func (j *Job) GetFile() string {
+	return j.workspace.original(j.IJob.GetFile())
+}
+
+// Run runs the wrapped job and copies the lock files it created or modified, in the directory of the copy
+// of the manifest file and its subdirectories, to the same place in the repository or the lock output dir
+func (j *Job) Run() {
+	scratchDir := filepath.Dir(j.IJob.GetFile())
+	before := j.workspace.lockFiles(scratchDir)
+
+	j.IJob.Run()
+
+	dir, err := filepath.Abs(filepath.Dir(j.GetFile()))
+	if err != nil {
+		j.Errors().Critical(job.NewBaseJobError(err.Error()))
+
+		return
+	}
+	// With a lock output dir, even lock files of package managers go there, so that the repository isn't written to
+	dir, _ = file.MirrorPath(j.LockOutputDir(), dir)
+	for lockFile, modTime := range j.workspace.lockFiles(scratchDir) {
+		if previous, ok := before[lockFile]; ok && previous.Equal(modTime) {
+			continue
+		}
+		source := filepath.Join(scratchDir, lockFile)
+		info, err := os.Stat(source)
+		if err == nil {
+			err = copyFile(source, filepath.Join(dir, lockFile), info.Mode().Perm())
+		}
+		if err != nil {
+			j.Errors().Critical(job.NewBaseJobError(fmt.Sprintf("failed to copy lock file %s from isolated workspace: %s", lockFile, err)))
+		}
+	}
+}
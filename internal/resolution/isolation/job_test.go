@@ -0,0 +1,75 @@
+package isolation
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/debricked/cli/internal/file"
+	"github.com/debricked/cli/internal/resolution/job"
+	"github.com/stretchr/testify/assert"
+)
+
+// installJob writes lock files and installs packages next to the manifest, like a resolution job
+type installJob struct {
+	job.BaseJob
+	files []string
+}
+
+func newInstallJob(file string, files ...string) *installJob {
+	return &installJob{BaseJob: job.NewBaseJob(file), files: files}
+}
+
+func (j *installJob) Run() {
+	time.Sleep(time.Millisecond)
+	for _, f := range j.files {
+		path := filepath.Join(filepath.Dir(j.GetFile()), f)
+		_ = os.MkdirAll(filepath.Dir(path), 0750)
+		_ = os.WriteFile(path, []byte("lock"), 0600)
+	}
+}
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, "package.json", "yarn.lock", "sub/package.json")
+	format, err := file.NewCompiledFormat(&file.Format{
+		ManifestFileRegex: `^package\.json$`,
+		LockFileRegexes:   []string{`^yarn\.lock$`},
+	})
+	assert.NoError(t, err)
+	workspace, err := NewWorkspace([]string{dir}, nil, []*file.CompiledFormat{format})
+	assert.NoError(t, err)
+	defer workspace.Close()
+
+	manifest := filepath.Join(dir, "package.json")
+	scratchManifest := workspace.Paths([]string{manifest})[0]
+	j := NewJob(newInstallJob(scratchManifest, "yarn.lock", "sub/.package.json.debricked.lock", "node_modules/a/index.js"), workspace)
+	j.Run()
+
+	assert.False(t, j.Errors().HasError())
+	content, err := os.ReadFile(filepath.Join(dir, "yarn.lock"))
+	assert.NoError(t, err)
+	assert.Equal(t, "lock", string(content))
+	assert.FileExists(t, filepath.Join(dir, "sub", ".package.json.debricked.lock"))
+	assert.NoDirExists(t, filepath.Join(dir, "node_modules"))
+	assert.FileExists(t, filepath.Join(filepath.Dir(scratchManifest), "node_modules", "a", "index.js"))
+}
+
+func TestRunUnchangedLockFile(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, "go.mod", "go.mod.debricked.lock")
+	workspace, err := NewWorkspace([]string{dir}, nil, nil)
+	assert.NoError(t, err)
+	defer workspace.Close()
+	lockFile := filepath.Join(dir, "go.mod.debricked.lock")
+	assert.NoError(t, os.WriteFile(lockFile, []byte("changed in repository"), 0600))
+
+	j := NewJob(newInstallJob(workspace.Paths([]string{filepath.Join(dir, "go.mod")})[0]), workspace)
+	j.Run()
+
+	assert.False(t, j.Errors().HasError())
+	content, err := os.ReadFile(lockFile)
+	assert.NoError(t, err)
+	assert.Equal(t, "changed in repository", string(content))
+}
@@ -0,0 +1,305 @@
+package isolation
+
+import (
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/debricked/cli/internal/file"
+	"github.com/debricked/cli/internal/resolution/job"
+)
+
+const (
+	tempDirName = "debricked-isolated-"
+	gitDir      = ".git"
+)
+
+// root is a directory of the repository and its copy in the workspace
+type root struct {
+	original string
+	scratch  string
+}
+
+// Workspace is a scratch copy of the directories of the resolved paths. Jobs resolve the copies, so that installed
+// packages, virtual environments and temporary files never end up in the repository, and only the lock files
+// they produce are copied back
+type Workspace struct {
+	dir        string
+	roots      []root
+	exclusions []string
+	formats    []*file.CompiledFormat
+	originals  map[string]string
+}
+
+// NewWorkspace copies the directories of paths, or the directory of each path that is a file, to a temporary directory.
+// Excluded files and .git directories aren't copied. Lock files are the files generated by Debricked or matching
+// the lock file regexes of formats
+func NewWorkspace(paths []string, exclusions []string, formats []*file.CompiledFormat) (*Workspace, error) {
+	dir, err := os.MkdirTemp("", tempDirName)
+	if err != nil {
+		return nil, err
+	}
+	workspace := &Workspace{
+		dir:        dir,
+		exclusions: exclusions,
+		formats:    formats,
+		originals:  map[string]string{},
+	}
+	originals, err := refineRoots(paths)
+	if err != nil {
+		_ = workspace.Close()
+
+		return nil, err
+	}
+	for i, original := range originals {
+		// The copy keeps the name of the directory, which some build tools name projects by
+		scratch := filepath.Join(dir, strconv.Itoa(i), filepath.Base(original))
+		err = workspace.copyTree(original, scratch)
+		if err != nil {
+			_ = workspace.Close()
+
+			return nil, err
+		}
+		workspace.roots = append(workspace.roots, root{original: original, scratch: scratch})
+	}
+
+	return workspace, nil
+}
+
+// Dir returns the temporary directory of the workspace, or an empty string if there is no workspace
+func (w *Workspace) Dir() string {
+	if w == nil {
+		return ""
+	}
+
+	return w.dir
+}
+
+// Paths returns the copies of paths in the workspace. Paths outside of the workspace are returned as they are
+func (w *Workspace) Paths(paths []string) []string {
+	if w == nil {
+		return paths
+	}
+	scratchPaths := make([]string, 0, len(paths))
+	for _, path := range paths {
+		scratchPaths = append(scratchPaths, w.path(path))
+	}
+
+	return scratchPaths
+}
+
+// Wrap makes the job resolve the copy of its manifest file and copy the lock files it produces back to the repository.
+// Jobs are returned as they are if there is no workspace
+func (w *Workspace) Wrap(jobs []job.IJob) []job.IJob {
+	if w == nil {
+		return jobs
+	}
+	wrappedJobs := make([]job.IJob, 0, len(jobs))
+	for _, j := range jobs {
+		wrappedJobs = append(wrappedJobs, NewJob(j, w))
+	}
+
+	return wrappedJobs
+}
+
+// Close removes the workspace
+func (w *Workspace) Close() error {
+	if w == nil {
+		return nil
+	}
+
+	return os.RemoveAll(w.dir)
+}
+
+func (w *Workspace) path(path string) string {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return path
+	}
+	for _, r := range w.roots {
+		if rel, ok := relativePath(r.original, absPath); ok {
+			scratchPath := filepath.Join(r.scratch, rel)
+			w.originals[scratchPath] = path
+
+			return scratchPath
+		}
+	}
+
+	return path
+}
+
+// original returns the path in the repository of the copy, as it was passed to Paths if it was
+func (w *Workspace) original(scratchPath string) string {
+	if path, ok := w.originals[scratchPath]; ok {
+		return path
+	}
+	for _, r := range w.roots {
+		if rel, ok := relativePath(r.scratch, scratchPath); ok {
+			return filepath.Join(r.original, rel)
+		}
+	}
+
+	return scratchPath
+}
+
+// lockFiles returns the modification time of the lock files in dir and its subdirectories, by relative path
+func (w *Workspace) lockFiles(dir string) map[string]time.Time {
+	lockFiles := map[string]time.Time{}
+	_ = w.walk(dir, func(path string, entry fs.DirEntry) error {
+		if !entry.Type().IsRegular() || !w.isLockFile(entry.Name()) {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err == nil {
+			lockFiles[rel] = info.ModTime()
+		}
+
+		return nil
+	})
+
+	return lockFiles
+}
+
+func (w *Workspace) isLockFile(name string) bool {
+	if file.IsGeneratedLockFile(name) {
+		return true
+	}
+	for _, format := range w.formats {
+		if format.MatchLockFile(name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// copyTree copies the regular files and symbolic links of dir to target, keeping their permissions
+func (w *Workspace) copyTree(dir string, target string) error {
+	return w.walk(dir, func(path string, entry fs.DirEntry) error {
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		targetPath := filepath.Join(target, rel)
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		switch {
+		case entry.IsDir():
+			return os.MkdirAll(targetPath, info.Mode().Perm()|0700)
+		case entry.Type()&fs.ModeSymlink != 0:
+			link, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+
+			return os.Symlink(link, targetPath)
+		case entry.Type().IsRegular():
+			return copyFile(path, targetPath, info.Mode().Perm())
+		default:
+			return nil
+		}
+	})
+}
+
+// walk calls fn with dir and the files and directories in it, except excluded ones and .git directories
+func (w *Workspace) walk(dir string, fn func(path string, entry fs.DirEntry) error) error {
+	return filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path != dir && (entry.Name() == gitDir || file.Excluded(w.exclusions, path)) {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		return fn(path, entry)
+	})
+}
+
+func copyFile(source string, target string, perm fs.FileMode) error {
+	sourceFile, err := os.Open(source)
+	if err != nil {
+		return err
+	}
+	defer sourceFile.Close()
+	err = os.MkdirAll(filepath.Dir(target), 0750)
+	if err != nil {
+		return err
+	}
+	targetFile, err := os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(targetFile, sourceFile)
+	if err != nil {
+		_ = targetFile.Close()
+
+		return err
+	}
+
+	return targetFile.Close()
+}
+
+// refineRoots returns the absolute directories of paths, or the directory of each path that is a file,
+// without directories in other ones
+func refineRoots(paths []string) ([]string, error) {
+	var dirs []string
+	for _, path := range paths {
+		absPath, err := filepath.Abs(path)
+		if err != nil {
+			return nil, err
+		}
+		info, err := os.Stat(absPath)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			absPath = filepath.Dir(absPath)
+		}
+		dirs = append(dirs, absPath)
+	}
+	sort.Strings(dirs)
+
+	var roots []string
+	for _, dir := range dirs {
+		if !inAny(roots, dir) {
+			roots = append(roots, dir)
+		}
+	}
+
+	return roots, nil
+}
+
+func inAny(dirs []string, path string) bool {
+	for _, dir := range dirs {
+		if _, ok := relativePath(dir, path); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// relativePath returns path relative to dir, if path is dir or in it
+func relativePath(dir string, path string) (string, bool) {
+	rel, err := filepath.Rel(dir, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+
+	return rel, true
+}
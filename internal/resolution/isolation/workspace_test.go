@@ -0,0 +1,108 @@
+package isolation
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/debricked/cli/internal/file"
+	"github.com/debricked/cli/internal/resolution/job"
+	jobTestdata "github.com/debricked/cli/internal/resolution/job/testdata"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeFiles(t *testing.T, dir string, files ...string) {
+	for _, f := range files {
+		path := filepath.Join(dir, f)
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0750))
+		assert.NoError(t, os.WriteFile(path, []byte(f), 0600))
+	}
+}
+
+func TestNewWorkspace(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "project")
+	writeFiles(t, dir, "package.json", "sub/go.mod", "node_modules/a/package.json", ".git/HEAD")
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "gradlew"), nil, 0700))
+
+	workspace, err := NewWorkspace([]string{dir, filepath.Join(dir, "sub", "go.mod")}, file.DefaultExclusions(), nil)
+	assert.NoError(t, err)
+	defer workspace.Close()
+
+	assert.Len(t, workspace.roots, 1)
+	scratch := workspace.roots[0].scratch
+	assert.Equal(t, "project", filepath.Base(scratch))
+	assert.FileExists(t, filepath.Join(scratch, "package.json"))
+	assert.FileExists(t, filepath.Join(scratch, "sub", "go.mod"))
+	assert.NoDirExists(t, filepath.Join(scratch, "node_modules"))
+	assert.NoDirExists(t, filepath.Join(scratch, ".git"))
+	info, err := os.Stat(filepath.Join(scratch, "gradlew"))
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0700), info.Mode().Perm())
+}
+
+func TestNewWorkspaceNotExist(t *testing.T) {
+	workspace, err := NewWorkspace([]string{filepath.Join(t.TempDir(), "missing")}, nil, nil)
+	assert.Nil(t, workspace)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestClose(t *testing.T) {
+	workspace, err := NewWorkspace([]string{t.TempDir()}, nil, nil)
+	assert.NoError(t, err)
+	assert.DirExists(t, workspace.Dir())
+
+	assert.NoError(t, workspace.Close())
+	assert.NoDirExists(t, workspace.Dir())
+}
+
+func TestNilWorkspace(t *testing.T) {
+	var workspace *Workspace
+	paths := []string{"go.mod"}
+	jobs := workspace.Wrap(nil)
+	assert.Empty(t, workspace.Dir())
+	assert.Equal(t, paths, workspace.Paths(paths))
+	assert.Nil(t, jobs)
+	assert.NoError(t, workspace.Close())
+}
+
+func TestPaths(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, "sub/go.mod")
+	workspace, err := NewWorkspace([]string{dir}, nil, nil)
+	assert.NoError(t, err)
+	defer workspace.Close()
+
+	outside := filepath.Join(filepath.Dir(dir), "go.mod")
+	manifest := filepath.Join(dir, "sub", "go.mod")
+	scratchPaths := workspace.Paths([]string{manifest, outside})
+	assert.Equal(t, filepath.Join(workspace.roots[0].scratch, "sub", "go.mod"), scratchPaths[0])
+	assert.Equal(t, outside, scratchPaths[1])
+	assert.Equal(t, manifest, workspace.original(scratchPaths[0]))
+	assert.Equal(t, filepath.Join(dir, "sub", "go.sum"), workspace.original(filepath.Join(workspace.roots[0].scratch, "sub", "go.sum")))
+}
+
+func TestWrap(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, "go.mod")
+	workspace, err := NewWorkspace([]string{dir}, nil, nil)
+	assert.NoError(t, err)
+	defer workspace.Close()
+
+	manifest := filepath.Join(dir, "go.mod")
+	jobs := workspace.Wrap([]job.IJob{jobTestdata.NewJobMock(workspace.Paths([]string{manifest})[0])})
+	assert.Len(t, jobs, 1)
+	assert.IsType(t, &Job{}, jobs[0])
+	assert.Equal(t, manifest, jobs[0].GetFile())
+}
+
+func TestRefineRoots(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, "a/go.mod", "a/b/package.json", "a-b/pom.xml")
+	roots, err := refineRoots([]string{
+		filepath.Join(dir, "a", "b"),
+		filepath.Join(dir, "a-b", "pom.xml"),
+		filepath.Join(dir, "a", "go.mod"),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "a"), filepath.Join(dir, "a-b")}, roots)
+}
@@ -1,15 +1,34 @@
 package job
 
 import (
+	"bytes"
+	"context"
 	"errors"
+	"fmt"
+	"os"
 	"os/exec"
 	"strings"
+	"time"
+
+	"github.com/debricked/cli/internal/file"
+	"github.com/debricked/cli/internal/resolution/toolchain"
 )
 
+// waitDelay is how long Output waits for the output of processes that escaped the killed process group of a command,
+// or outlived a command that exited, before closing its pipes
+var waitDelay = 5 * time.Second
+
 type BaseJob struct {
-	file   string
-	errs   IErrors
-	status chan string
+	file          string
+	errs          IErrors
+	status        chan string
+	ctx           context.Context
+	timeout       time.Duration
+	interrupted   error
+	commands      []string
+	env           []string
+	toolchains    []toolchain.Toolchain
+	lockOutputDir string
 }
 
 func NewBaseJob(file string) BaseJob {
@@ -17,6 +36,7 @@ func NewBaseJob(file string) BaseJob {
 		file:   file,
 		errs:   NewErrors(file),
 		status: make(chan string),
+		ctx:    context.Background(),
 	}
 }
 
@@ -24,15 +44,29 @@ func (j *BaseJob) GetFile() string {
 	return j.file
 }
 
+// Errors returns the errors of the job. Errors added after a command of the job was killed are documented
+// as a timeout or cancellation, since jobs handle the error of the killed command like any other command error
 func (j *BaseJob) Errors() IErrors {
-	return j.errs
+	if j.errs == nil {
+		return nil
+	}
+
+	return interruptedErrors{IErrors: j.errs, job: j}
 }
 
 func (j *BaseJob) ReceiveStatus() chan string {
 	return j.status
 }
 
+// SendStatus sends the status of the job, together with the toolchains it runs its commands with
 func (j *BaseJob) SendStatus(status string) {
+	if len(j.toolchains) > 0 {
+		var toolchains []string
+		for _, t := range j.toolchains {
+			toolchains = append(toolchains, t.String())
+		}
+		status = fmt.Sprintf("%s with %s", status, strings.Join(toolchains, ", "))
+	}
 	j.status <- status
 }
 
@@ -58,3 +92,194 @@ func (j *BaseJob) GetExecutableNotFoundErrorDocumentation(pm string) string {
 			"Please check if it is installed and accessible by the CLI.",
 		}, " ")
 }
+
+// SetContext sets the context of the job. Commands run by Output are killed when it is done
+func (j *BaseJob) SetContext(ctx context.Context) {
+	j.ctx = ctx
+}
+
+func (j *BaseJob) Context() context.Context {
+	if j.ctx == nil {
+		return context.Background()
+	}
+
+	return j.ctx
+}
+
+// SetTimeout sets the time the job may run, or 0 if it may run until it finishes
+func (j *BaseJob) SetTimeout(timeout time.Duration) {
+	j.timeout = timeout
+}
+
+func (j *BaseJob) GetTimeout() time.Duration {
+	return j.timeout
+}
+
+// SetEnv sets environment variables added to the environment of the commands run by Output,
+// such as the settings of the registries of the package manager
+func (j *BaseJob) SetEnv(env []string) {
+	j.env = env
+}
+
+func (j *BaseJob) GetEnv() []string {
+	return j.env
+}
+
+// SetToolchains sets the toolchains whose executables replace the executables of the commands run by Output
+func (j *BaseJob) SetToolchains(toolchains []toolchain.Toolchain) {
+	j.toolchains = toolchains
+}
+
+func (j *BaseJob) Toolchains() []toolchain.Toolchain {
+	return j.toolchains
+}
+
+// SetLockOutputDir sets the directory the job writes the lock files it generates to, mirroring the tree
+// of the working directory, instead of next to the manifest files
+func (j *BaseJob) SetLockOutputDir(dir string) {
+	j.lockOutputDir = dir
+}
+
+func (j *BaseJob) LockOutputDir() string {
+	return j.lockOutputDir
+}
+
+// LockFilePath returns the path the job writes the lock file it generates at lockFile to,
+// which mirrors lockFile in the lock output dir if it is set
+func (j *BaseJob) LockFilePath(lockFile string) string {
+	mirrorPath, _ := file.MirrorPath(j.lockOutputDir, lockFile)
+
+	return mirrorPath
+}
+
+// Commands returns the commands run by Output
+func (j *BaseJob) Commands() []string {
+	return j.commands
+}
+
+// Output runs the command and returns its standard output, like exec.Cmd.Output. When the context of the job is done,
+// the command is killed together with the processes it started, and an error telling that it timed out or was
+// cancelled is returned
+func (j *BaseJob) Output(cmd *exec.Cmd) ([]byte, error) {
+	ctx := j.Context()
+	if ctx.Err() != nil {
+		return nil, j.interrupt(cmd, ctx.Err())
+	}
+	if cmd.Stdout != nil {
+		return nil, errors.New("exec: Stdout already set")
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	captureStderr := cmd.Stderr == nil
+	if captureStderr {
+		cmd.Stderr = &stderr
+	}
+	j.useToolchains(cmd)
+	if len(j.env) > 0 {
+		if cmd.Env == nil {
+			cmd.Env = os.Environ()
+		}
+		cmd.Env = append(cmd.Env, j.env...)
+	}
+	SetProcessGroup(cmd)
+	cmd.WaitDelay = waitDelay
+
+	j.commands = append(j.commands, cmd.String())
+	err := cmd.Start()
+	if err != nil {
+		return nil, err
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- cmd.Wait()
+	}()
+
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		_ = KillProcessGroup(cmd.Process)
+		<-done
+
+		return stdout.Bytes(), j.interrupt(cmd, ctx.Err())
+	}
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && captureStderr {
+		exitErr.Stderr = stderr.Bytes()
+	}
+
+	return stdout.Bytes(), err
+}
+
+// useToolchains replaces the executable of the command by the executable of a toolchain of the job,
+// and runs it with the environment of the toolchains
+func (j *BaseJob) useToolchains(cmd *exec.Cmd) {
+	if len(j.toolchains) == 0 {
+		return
+	}
+	if cmd.Env == nil {
+		cmd.Env = os.Environ()
+	}
+	for _, t := range j.toolchains {
+		if len(cmd.Args) > 0 {
+			if executable, ok := t.Replaces(cmd.Args[0]); ok {
+				// The command is run by the executable, even if the executable it was made with wasn't found
+				cmd.Path, cmd.Err = executable, nil
+			}
+		}
+		cmd.Env = append(cmd.Env, t.Env()...)
+	}
+}
+
+func (j *BaseJob) interrupt(cmd *exec.Cmd, ctxErr error) error {
+	j.interrupted = ctxErr
+	if errors.Is(ctxErr, context.DeadlineExceeded) {
+		return fmt.Errorf("%s timed out after %s and was killed", cmd.String(), j.timeout)
+	}
+
+	return fmt.Errorf("%s was cancelled and killed", cmd.String())
+}
+
+func (j *BaseJob) getInterruptedDocumentation() string {
+	if errors.Is(j.interrupted, context.DeadlineExceeded) {
+		return strings.Join(
+			[]string{
+				fmt.Sprintf("Resolution didn't finish within the timeout of %s,", j.timeout),
+				"so the package manager was killed together with the processes it started.",
+				"Please increase the timeout with --resolution-timeout, or only for this package manager",
+				"with --pm-resolution-timeout, for example --pm-resolution-timeout gradle=30m.",
+			}, " ")
+	}
+
+	return strings.Join(
+		[]string{
+			"Resolution was cancelled before it finished,",
+			"so the package manager was killed together with the processes it started.",
+		}, " ")
+}
+
+type interruptedErrors struct {
+	IErrors
+	job *BaseJob
+}
+
+func (e interruptedErrors) Warning(err IError) {
+	e.IErrors.Warning(e.document(err))
+}
+
+func (e interruptedErrors) Critical(err IError) {
+	e.IErrors.Critical(e.document(err))
+}
+
+func (e interruptedErrors) Append(err IError) {
+	e.IErrors.Append(e.document(err))
+}
+
+func (e interruptedErrors) document(err IError) IError {
+	if e.job.interrupted != nil {
+		err.SetDocumentation(e.job.getInterruptedDocumentation())
+		err.SetIsCritical(true)
+	}
+
+	return err
+}
@@ -1,10 +1,15 @@
 package job
 
 import (
+	"context"
 	"errors"
 	"os/exec"
+	"path/filepath"
+	"runtime"
 	"testing"
+	"time"
 
+	"github.com/debricked/cli/internal/resolution/toolchain"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -23,6 +28,16 @@ func TestGetFile(t *testing.T) {
 	assert.Equal(t, testFile, j.GetFile())
 }
 
+func TestLockFilePath(t *testing.T) {
+	j := NewBaseJob(testFile)
+	assert.Equal(t, "gomod.debricked.lock", j.LockFilePath("gomod.debricked.lock"))
+
+	lockOutputDir := t.TempDir()
+	j.SetLockOutputDir(lockOutputDir)
+	assert.Equal(t, lockOutputDir, j.LockOutputDir())
+	assert.Equal(t, filepath.Join(lockOutputDir, "gomod.debricked.lock"), j.LockFilePath("gomod.debricked.lock"))
+}
+
 func TestReceiveStatus(t *testing.T) {
 	j := BaseJob{
 		file:   testFile,
@@ -61,6 +76,20 @@ func TestSendStatus(t *testing.T) {
 	j.SendStatus("status")
 }
 
+func TestSendStatusToolchains(t *testing.T) {
+	j := NewBaseJob(testFile)
+	j.SetToolchains([]toolchain.Toolchain{{Name: toolchain.Go, Version: "1.21.3", Source: "go.mod"}})
+	assert.Len(t, j.Toolchains(), 1)
+
+	statuses := make(chan string, 1)
+	go func() {
+		statuses <- <-j.ReceiveStatus()
+	}()
+	j.SendStatus("status")
+
+	assert.Equal(t, "status with go 1.21.3 (from go.mod)", <-statuses)
+}
+
 func TestDifferentNewBaseJob(t *testing.T) {
 	differentFileName := "testDifferentFile"
 	j := NewBaseJob(differentFileName)
@@ -86,3 +115,170 @@ func TestGetExitErrorWithNoneExitError(t *testing.T) {
 	exitErr := j.GetExitError(err, "")
 	assert.ErrorContains(t, exitErr, err.Error())
 }
+
+func TestSetContext(t *testing.T) {
+	j := NewBaseJob(testFile)
+	assert.Equal(t, context.Background(), j.Context())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	j.SetContext(ctx)
+	assert.Equal(t, ctx, j.Context())
+
+	j = BaseJob{}
+	assert.Equal(t, context.Background(), j.Context())
+}
+
+func TestSetTimeout(t *testing.T) {
+	j := NewBaseJob(testFile)
+	assert.Equal(t, time.Duration(0), j.GetTimeout())
+
+	j.SetTimeout(time.Minute)
+	assert.Equal(t, time.Minute, j.GetTimeout())
+}
+
+func TestOutput(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("sh is not available on Windows")
+	}
+	j := NewBaseJob(testFile)
+
+	output, err := j.Output(exec.Command("sh", "-c", "echo output"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "output\n", string(output))
+	assert.Len(t, j.Commands(), 1)
+	assert.Contains(t, j.Commands()[0], "echo output")
+}
+
+func TestOutputEnv(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("sh is not available on Windows")
+	}
+	t.Setenv("DEBRICKED_TEST_INHERITED", "inherited")
+	j := NewBaseJob(testFile)
+	j.SetEnv([]string{"DEBRICKED_TEST_ENV=env"})
+	assert.Equal(t, []string{"DEBRICKED_TEST_ENV=env"}, j.GetEnv())
+
+	output, err := j.Output(exec.Command("sh", "-c", "echo $DEBRICKED_TEST_INHERITED $DEBRICKED_TEST_ENV"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "inherited env\n", string(output))
+}
+
+func TestOutputToolchain(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("sh is not available on Windows")
+	}
+	sh, err := exec.LookPath("sh")
+	assert.NoError(t, err)
+	j := NewBaseJob(testFile)
+	j.SetToolchains([]toolchain.Toolchain{{Name: toolchain.Maven, Executable: sh}})
+
+	output, err := j.Output(exec.Command("mvn", "-c", "echo toolchain"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "toolchain\n", string(output))
+}
+
+func TestOutputExitErr(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("sh is not available on Windows")
+	}
+	j := NewBaseJob(testFile)
+
+	_, err := j.Output(exec.Command("sh", "-c", "echo stderr >&2; exit 1"))
+
+	var exitErr *exec.ExitError
+	assert.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, "stderr\n", string(exitErr.Stderr))
+}
+
+func TestOutputStdoutSet(t *testing.T) {
+	j := NewBaseJob(testFile)
+	cmd := exec.Command("sh")
+	cmd.Stdout = &errWriter{}
+
+	_, err := j.Output(cmd)
+
+	assert.ErrorContains(t, err, "Stdout already set")
+}
+
+func TestOutputTimeout(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("sh is not available on Windows")
+	}
+	j := NewBaseJob(testFile)
+	j.SetTimeout(100 * time.Millisecond)
+	ctx, cancel := context.WithTimeout(context.Background(), j.GetTimeout())
+	defer cancel()
+	j.SetContext(ctx)
+
+	start := time.Now()
+	// The background sleep keeps stdout open, so Output only returns if it is killed with the shell
+	_, err := j.Output(exec.Command("sh", "-c", "sleep 30 & sleep 30"))
+
+	assert.Less(t, time.Since(start), 10*time.Second)
+	assert.ErrorContains(t, err, "timed out after 100ms and was killed")
+
+	jobErr := NewBaseJobError(err.Error())
+	j.Errors().Critical(jobErr)
+	assert.True(t, jobErr.IsCritical())
+	assert.Contains(t, jobErr.Documentation(), "didn't finish within the timeout of 100ms")
+	assert.Contains(t, jobErr.Documentation(), "--pm-resolution-timeout")
+}
+
+func TestOutputTimeoutEscapedProcess(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("setsid is only available on Linux")
+	}
+	defaultWaitDelay := waitDelay
+	waitDelay = 100 * time.Millisecond
+	defer func() { waitDelay = defaultWaitDelay }()
+	j := NewBaseJob(testFile)
+	j.SetTimeout(100 * time.Millisecond)
+	ctx, cancel := context.WithTimeout(context.Background(), j.GetTimeout())
+	defer cancel()
+	j.SetContext(ctx)
+
+	start := time.Now()
+	// The sleep in a session of its own isn't killed with the process group, but keeps stdout open
+	_, err := j.Output(exec.Command("sh", "-c", "setsid sleep 30 & sleep 30"))
+
+	assert.Less(t, time.Since(start), 10*time.Second)
+	assert.ErrorContains(t, err, "timed out after 100ms and was killed")
+}
+
+func TestOutputCancelled(t *testing.T) {
+	j := NewBaseJob(testFile)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	j.SetContext(ctx)
+
+	_, err := j.Output(exec.Command("sh", "-c", "echo output"))
+
+	assert.ErrorContains(t, err, "was cancelled and killed")
+
+	jobErr := NewBaseJobError(err.Error())
+	jobErr.SetIsCritical(false)
+	j.Errors().Warning(jobErr)
+	assert.True(t, jobErr.IsCritical())
+	assert.Contains(t, jobErr.Documentation(), "Resolution was cancelled")
+}
+
+func TestErrorsNotInterrupted(t *testing.T) {
+	j := NewBaseJob(testFile)
+	jobErr := NewBaseJobError("error")
+	jobErr.SetDocumentation("documentation")
+
+	j.Errors().Append(jobErr)
+
+	assert.Equal(t, "documentation\n", jobErr.Documentation())
+	assert.Len(t, j.Errors().GetAll(), 1)
+}
+
+type errWriter struct{}
+
+func (errWriter) Write(_ []byte) (int, error) {
+	return 0, errors.New("write error")
+}
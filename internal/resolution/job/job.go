@@ -1,8 +1,24 @@
 package job
 
+import (
+	"context"
+	"time"
+
+	"github.com/debricked/cli/internal/resolution/toolchain"
+)
+
 type IJob interface {
 	GetFile() string
 	Errors() IErrors
 	Run()
 	ReceiveStatus() chan string
+	SetContext(ctx context.Context)
+	SetTimeout(timeout time.Duration)
+	GetTimeout() time.Duration
+	SetEnv(env []string)
+	SetToolchains(toolchains []toolchain.Toolchain)
+	Toolchains() []toolchain.Toolchain
+	SetLockOutputDir(dir string)
+	LockOutputDir() string
+	Commands() []string
 }
@@ -0,0 +1,34 @@
+package job
+
+import (
+	"os/exec"
+	"strings"
+)
+
+// Plan describes what a job does when it runs: the commands it runs and the lock files it writes
+type Plan struct {
+	Commands  []string
+	LockFiles []string
+}
+
+// IPlanner is implemented by jobs that can plan what they do without running any command
+type IPlanner interface {
+	Plan() Plan
+}
+
+// AddCommand adds the command made by a command factory. Commands whose executable wasn't found are added
+// by their arguments, since their path is empty
+func (p *Plan) AddCommand(cmd *exec.Cmd, _ error) {
+	if cmd == nil {
+		return
+	}
+	command := strings.TrimSpace(cmd.String())
+	if len(cmd.Path) == 0 {
+		command = strings.Join(cmd.Args, " ")
+	}
+	p.Commands = append(p.Commands, command)
+}
+
+func (p *Plan) AddLockFile(lockFile string) {
+	p.LockFiles = append(p.LockFiles, lockFile)
+}
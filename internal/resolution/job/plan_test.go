@@ -0,0 +1,25 @@
+package job
+
+import (
+	"errors"
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlanAddCommand(t *testing.T) {
+	var plan Plan
+	plan.AddCommand(&exec.Cmd{Path: "/usr/bin/go", Args: []string{"go", "mod", "graph"}}, nil)
+	plan.AddCommand(&exec.Cmd{Args: []string{"mvn", "dependency:tree"}}, errors.New("executable file not found"))
+	plan.AddCommand(nil, errors.New("error"))
+
+	assert.Equal(t, []string{"/usr/bin/go mod graph", "mvn dependency:tree"}, plan.Commands)
+}
+
+func TestPlanAddLockFile(t *testing.T) {
+	var plan Plan
+	plan.AddLockFile("gomod.debricked.lock")
+
+	assert.Equal(t, []string{"gomod.debricked.lock"}, plan.LockFiles)
+}
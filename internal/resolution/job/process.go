@@ -0,0 +1,30 @@
+//go:build !windows
+
+package job
+
+import (
+	"os"
+	"os/exec"
+	"syscall"
+)
+
+// SetProcessGroup starts the command in a process group of its own, so that the processes it starts can be killed with it
+func SetProcessGroup(cmd *exec.Cmd) {
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.Setpgid = true
+}
+
+// KillProcessGroup kills the process and the processes in its process group
+func KillProcessGroup(process *os.Process) error {
+	if process == nil {
+		return nil
+	}
+	err := syscall.Kill(-process.Pid, syscall.SIGKILL)
+	if err != nil {
+		return process.Kill()
+	}
+
+	return nil
+}
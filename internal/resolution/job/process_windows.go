@@ -0,0 +1,23 @@
+package job
+
+import (
+	"os"
+	"os/exec"
+	"strconv"
+)
+
+// SetProcessGroup does nothing, since KillProcessGroup finds the processes started by the command from its process id
+func SetProcessGroup(_ *exec.Cmd) {}
+
+// KillProcessGroup kills the process and the processes it started
+func KillProcessGroup(process *os.Process) error {
+	if process == nil {
+		return nil
+	}
+	err := exec.Command("taskkill", "/T", "/F", "/PID", strconv.Itoa(process.Pid)).Run() // #nosec G204
+	if err != nil {
+		return process.Kill()
+	}
+
+	return nil
+}
@@ -1,15 +1,25 @@
 package testdata
 
 import (
+	"context"
 	"fmt"
+	"path/filepath"
+	"time"
 
 	"github.com/debricked/cli/internal/resolution/job"
+	"github.com/debricked/cli/internal/resolution/toolchain"
 )
 
 type JobMock struct {
-	file   string
-	errs   job.IErrors
-	status chan string
+	file          string
+	errs          job.IErrors
+	status        chan string
+	ctx           context.Context
+	timeout       time.Duration
+	commands      []string
+	env           []string
+	toolchains    []toolchain.Toolchain
+	lockOutputDir string
 }
 
 func (j *JobMock) ReceiveStatus() chan string {
@@ -39,3 +49,56 @@ func NewJobMock(file string) *JobMock {
 func (j *JobMock) SetErr(err job.IError) {
 	j.errs.Critical(err)
 }
+
+func (j *JobMock) SetContext(ctx context.Context) {
+	j.ctx = ctx
+}
+
+func (j *JobMock) Context() context.Context {
+	return j.ctx
+}
+
+func (j *JobMock) SetTimeout(timeout time.Duration) {
+	j.timeout = timeout
+}
+
+func (j *JobMock) GetTimeout() time.Duration {
+	return j.timeout
+}
+
+func (j *JobMock) SetEnv(env []string) {
+	j.env = env
+}
+
+func (j *JobMock) GetEnv() []string {
+	return j.env
+}
+
+func (j *JobMock) SetToolchains(toolchains []toolchain.Toolchain) {
+	j.toolchains = toolchains
+}
+
+func (j *JobMock) Toolchains() []toolchain.Toolchain {
+	return j.toolchains
+}
+
+func (j *JobMock) Commands() []string {
+	return j.commands
+}
+
+func (j *JobMock) SetCommands(commands []string) {
+	j.commands = commands
+}
+
+func (j *JobMock) SetLockOutputDir(dir string) {
+	j.lockOutputDir = dir
+}
+
+func (j *JobMock) LockOutputDir() string {
+	return j.lockOutputDir
+}
+
+// Plan plans the lock file mock.debricked.lock next to the manifest file
+func (j *JobMock) Plan() job.Plan {
+	return job.Plan{LockFiles: []string{filepath.Join(filepath.Dir(j.file), "mock.debricked.lock")}}
+}
@@ -0,0 +1,119 @@
+package lockoutput
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/debricked/cli/internal/file"
+	"github.com/debricked/cli/internal/resolution/job"
+)
+
+const gitDir = ".git"
+
+// Job moves the lock files generated by Debricked that the wrapped job wrote next to manifest files to the lock
+// output dir. Jobs write lock files they generate themselves to the lock output dir directly, but package managers
+// such as Maven, Gradle, sbt and NuGet write them into the directories of the projects
+type Job struct {
+	job.IJob
+	exclusions []string
+}
+
+func NewJob(j job.IJob, exclusions []string) *Job {
+	return &Job{
+		IJob:       j,
+		exclusions: exclusions,
+	}
+}
+
+// Run runs the wrapped job and moves the lock files generated by Debricked that were created or modified while it ran,
+// in the directory of the manifest file and its subdirectories, to the lock output dir
+func (j *Job) Run() {
+	dir := filepath.Dir(j.GetFile())
+	before := j.lockFiles(dir)
+
+	j.IJob.Run()
+
+	for lockFile, modTime := range j.lockFiles(dir) {
+		if previous, ok := before[lockFile]; ok && previous.Equal(modTime) {
+			continue
+		}
+		mirrorPath, ok := file.MirrorPath(j.LockOutputDir(), lockFile)
+		if !ok {
+			continue
+		}
+		err := move(lockFile, mirrorPath)
+		if err != nil {
+			j.Errors().Critical(job.NewBaseJobError(fmt.Sprintf("failed to move lock file %s to lock output dir: %s", lockFile, err)))
+		}
+	}
+}
+
+// lockFiles returns the modification time of the lock files generated by Debricked in dir and its subdirectories,
+// except excluded ones and those in the lock output dir
+func (j *Job) lockFiles(dir string) map[string]time.Time {
+	lockFiles := map[string]time.Time{}
+	_ = filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if path != dir && (entry.Name() == gitDir || file.Excluded(j.exclusions, path) || file.InLockOutputDir(j.LockOutputDir(), path)) {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+		if !entry.Type().IsRegular() || !file.IsGeneratedLockFile(entry.Name()) || file.Excluded(j.exclusions, path) {
+			return nil
+		}
+		info, err := entry.Info()
+		if err == nil {
+			lockFiles[path] = info.ModTime()
+		}
+
+		return nil
+	})
+
+	return lockFiles
+}
+
+// move renames source to target, or copies it if they are on different file systems
+func move(source string, target string) error {
+	err := os.MkdirAll(filepath.Dir(target), 0750)
+	if err != nil {
+		return err
+	}
+	if os.Rename(source, target) == nil {
+		return nil
+	}
+	err = copyFile(source, target)
+	if err != nil {
+		return err
+	}
+
+	return os.Remove(source)
+}
+
+func copyFile(source string, target string) error {
+	sourceFile, err := os.Open(source)
+	if err != nil {
+		return err
+	}
+	defer sourceFile.Close()
+	targetFile, err := os.Create(target)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(targetFile, sourceFile)
+	if err != nil {
+		_ = targetFile.Close()
+
+		return err
+	}
+
+	return targetFile.Close()
+}
@@ -0,0 +1,98 @@
+package lockoutput
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/debricked/cli/internal/resolution/job"
+	"github.com/stretchr/testify/assert"
+)
+
+// writeJob writes files next to the manifest, like a package manager generating lock files
+type writeJob struct {
+	job.BaseJob
+	files []string
+}
+
+func newWriteJob(file string, files ...string) *writeJob {
+	return &writeJob{BaseJob: job.NewBaseJob(file), files: files}
+}
+
+func (j *writeJob) Run() {
+	time.Sleep(time.Millisecond)
+	for _, f := range j.files {
+		path := filepath.Join(filepath.Dir(j.GetFile()), f)
+		_ = os.MkdirAll(filepath.Dir(path), 0750)
+		_ = os.WriteFile(path, []byte("lock"), 0600)
+	}
+}
+
+// projectDir makes a directory in the working directory, which the lock output dir mirrors
+func projectDir(t *testing.T) string {
+	dir, err := os.MkdirTemp(".", "project-")
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+
+	return dir
+}
+
+func TestRun(t *testing.T) {
+	dir := projectDir(t)
+	lockOutputDir := t.TempDir()
+	manifest := filepath.Join(dir, "pom.xml")
+	inner := newWriteJob(manifest, "maven.debricked.lock", "sub/maven.debricked.lock", "target/classes.txt")
+	inner.SetLockOutputDir(lockOutputDir)
+	j := NewJob(inner, nil)
+	j.Run()
+
+	assert.False(t, j.Errors().HasError())
+	assert.NoFileExists(t, filepath.Join(dir, "maven.debricked.lock"))
+	assert.NoFileExists(t, filepath.Join(dir, "sub", "maven.debricked.lock"))
+	assert.FileExists(t, filepath.Join(lockOutputDir, dir, "maven.debricked.lock"))
+	assert.FileExists(t, filepath.Join(lockOutputDir, dir, "sub", "maven.debricked.lock"))
+	assert.FileExists(t, filepath.Join(dir, "target", "classes.txt"))
+}
+
+func TestRunUnchangedLockFile(t *testing.T) {
+	dir := projectDir(t)
+	lockOutputDir := t.TempDir()
+	lockFile := filepath.Join(dir, "maven.debricked.lock")
+	assert.NoError(t, os.WriteFile(lockFile, []byte("committed"), 0600))
+	inner := newWriteJob(filepath.Join(dir, "pom.xml"))
+	inner.SetLockOutputDir(lockOutputDir)
+	j := NewJob(inner, nil)
+	j.Run()
+
+	assert.False(t, j.Errors().HasError())
+	assert.FileExists(t, lockFile)
+	assert.NoFileExists(t, filepath.Join(lockOutputDir, dir, "maven.debricked.lock"))
+}
+
+func TestRunExcludedLockFile(t *testing.T) {
+	dir := projectDir(t)
+	lockOutputDir := t.TempDir()
+	inner := newWriteJob(filepath.Join(dir, "pom.xml"), "excluded/maven.debricked.lock")
+	inner.SetLockOutputDir(lockOutputDir)
+	j := NewJob(inner, []string{"**/excluded/**"})
+	j.Run()
+
+	assert.False(t, j.Errors().HasError())
+	assert.FileExists(t, filepath.Join(dir, "excluded", "maven.debricked.lock"))
+}
+
+func TestMove(t *testing.T) {
+	dir := t.TempDir()
+	source := filepath.Join(dir, "maven.debricked.lock")
+	assert.NoError(t, os.WriteFile(source, []byte("lock"), 0600))
+	target := filepath.Join(dir, "locks", "app", "maven.debricked.lock")
+
+	assert.NoError(t, move(source, target))
+	assert.NoFileExists(t, source)
+	content, err := os.ReadFile(target)
+	assert.NoError(t, err)
+	assert.Equal(t, "lock", string(content))
+
+	assert.Error(t, move(source, target))
+}
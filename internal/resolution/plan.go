@@ -0,0 +1,165 @@
+package resolution
+
+import (
+	"sort"
+
+	"github.com/debricked/cli/internal/file"
+	"github.com/debricked/cli/internal/resolution/job"
+	"github.com/debricked/cli/internal/resolution/pm/gobinary"
+	"github.com/debricked/cli/internal/resolution/pm/ospkg"
+	"github.com/debricked/cli/internal/resolution/scope"
+	"github.com/debricked/cli/internal/resolution/strategy"
+	"github.com/debricked/cli/internal/resolution/toolchain"
+)
+
+const (
+	noPackageManagerReason = "no package manager resolves the manifest file"
+	partOfOtherJobReason   = "resolved together with another manifest file, such as its workspace or multi-project build"
+	excludedScopeReason    = "dedicated to the dependencies of an excluded scope"
+)
+
+// PlannedJob is a job that resolution would run
+type PlannedJob struct {
+	Manifest       string   `json:"manifest"`
+	PackageManager string   `json:"packageManager"`
+	Commands       []string `json:"commands"`
+	LockFiles      []string `json:"lockFiles"`
+	Toolchains     []string `json:"toolchains,omitempty"`
+}
+
+// SkippedManifest is a manifest file that resolution would skip
+type SkippedManifest struct {
+	Manifest string `json:"manifest"`
+	Reason   string `json:"reason"`
+}
+
+// Plan is what resolution would do, without running any job
+type Plan struct {
+	Jobs    []PlannedJob      `json:"jobs"`
+	Skipped []SkippedManifest `json:"skipped"`
+}
+
+// Plan finds the manifest files of paths and invokes the strategies of their package managers, like Resolve,
+// but returns the jobs instead of running them. Strategies of some package managers, such as Gradle and sbt,
+// run commands to find the projects of builds
+func (r Resolver) Plan(paths []string, options IOptions) (Plan, error) {
+	plan := Plan{Jobs: []PlannedJob{}, Skipped: []SkippedManifest{}}
+	dOptions, ok := options.(DebrickedOptions)
+	if !ok {
+		return plan, ErrBadOpts
+	}
+	err := r.setJsPackageManager(dOptions)
+	if err != nil {
+		return plan, err
+	}
+	toolchainSelector, err := toolchain.NewSelector(dOptions.Toolchains)
+	if err != nil {
+		return plan, err
+	}
+	excludedScopes, err := scope.NewScopes(dOptions.ExcludeScopes, dOptions.ProductionOnly)
+	if err != nil {
+		return plan, err
+	}
+	var files []string
+	skipped := map[string]string{}
+	if !dOptions.SkipManifests {
+		files, skipped, _, err = r.refinePaths(paths, dOptions)
+		if err != nil {
+			return plan, err
+		}
+	}
+
+	if dOptions.OsPackages {
+		osPackageJobs, err := ospkg.NewStrategy(refineRoots(paths)).Invoke()
+		if err != nil {
+			return plan, err
+		}
+		plan.addJobs(osPackageJobs, ospkg.Name, dOptions.LockOutputDir)
+	}
+	if dOptions.GoBinaries {
+		goBinaryJobs, err := gobinary.NewStrategy(refineRoots(paths), dOptions.Exclusions).Invoke()
+		if err != nil {
+			return plan, err
+		}
+		plan.addJobs(goBinaryJobs, gobinary.Name, dOptions.LockOutputDir)
+	}
+
+	batched := make(map[string]bool)
+	for _, pmBatch := range r.batchFactory.Make(files) {
+		for _, batchFile := range pmBatch.Files() {
+			batched[batchFile] = true
+		}
+		s, strategyErr := r.strategyFactory.Make(pmBatch, paths, strategy.Options{LockfileOnly: dOptions.LockfileOnly, ExcludedScopes: excludedScopes})
+		if strategyErr != nil {
+			for _, batchFile := range pmBatch.Files() {
+				skipped[batchFile] = strategyErr.Error()
+			}
+
+			continue
+		}
+		jobs, err := s.Invoke()
+		if err != nil {
+			return plan, err
+		}
+		plan.addJobs(setToolchains(jobs, toolchainSelector, pmBatch.Pm().Name()), pmBatch.Pm().Name(), dOptions.LockOutputDir)
+
+		planned := make(map[string]bool)
+		for _, j := range jobs {
+			planned[j.GetFile()] = true
+		}
+		for _, batchFile := range pmBatch.Files() {
+			switch {
+			case planned[batchFile]:
+			case excludedScopes.ExcludesFile(batchFile):
+				skipped[batchFile] = excludedScopeReason
+			default:
+				skipped[batchFile] = partOfOtherJobReason
+			}
+		}
+	}
+	for _, f := range files {
+		if !batched[f] {
+			skipped[f] = noPackageManagerReason
+		}
+	}
+
+	for manifest, reason := range skipped {
+		plan.Skipped = append(plan.Skipped, SkippedManifest{Manifest: manifest, Reason: reason})
+	}
+	sort.Slice(plan.Jobs, func(i, j int) bool {
+		return plan.Jobs[i].Manifest < plan.Jobs[j].Manifest
+	})
+	sort.Slice(plan.Skipped, func(i, j int) bool {
+		return plan.Skipped[i].Manifest < plan.Skipped[j].Manifest
+	})
+
+	return plan, nil
+}
+
+// addJobs adds the plans of the jobs. Lock files generated by Debricked are planned in the lock output dir, if it is set
+func (p *Plan) addJobs(jobs []job.IJob, pmName string, lockOutputDir string) {
+	for _, j := range jobs {
+		plannedJob := PlannedJob{
+			Manifest:       j.GetFile(),
+			PackageManager: pmName,
+			Commands:       []string{},
+			LockFiles:      []string{},
+		}
+		for _, t := range j.Toolchains() {
+			plannedJob.Toolchains = append(plannedJob.Toolchains, t.String())
+		}
+		if planner, ok := j.(job.IPlanner); ok {
+			jobPlan := planner.Plan()
+			if jobPlan.Commands != nil {
+				plannedJob.Commands = jobPlan.Commands
+			}
+			for _, lockFile := range jobPlan.LockFiles {
+				if file.IsGeneratedLockFile(lockFile) {
+					lockFile, _ = file.MirrorPath(lockOutputDir, lockFile)
+				}
+				plannedJob.LockFiles = append(plannedJob.LockFiles, lockFile)
+			}
+		}
+		p.Jobs = append(p.Jobs, plannedJob)
+	}
+}
@@ -0,0 +1,155 @@
+package resolution
+
+import (
+	"testing"
+
+	"github.com/debricked/cli/internal/file"
+	"github.com/debricked/cli/internal/file/testdata"
+	resolutionFile "github.com/debricked/cli/internal/resolution/file"
+	fileTestdata "github.com/debricked/cli/internal/resolution/file/testdata"
+	"github.com/debricked/cli/internal/resolution/strategy"
+	strategyTestdata "github.com/debricked/cli/internal/resolution/strategy/testdata"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlan(t *testing.T) {
+	r := NewResolver(
+		&testdata.FinderMock{},
+		resolutionFile.NewBatchFactory(),
+		strategyTestdata.NewStrategyFactoryMock(),
+		SchedulerMock{},
+	)
+
+	plan, err := r.Plan([]string{"../../go.mod"}, DebrickedOptions{})
+
+	assert.NoError(t, err)
+	assert.Len(t, plan.Jobs, 1)
+	assert.Equal(t, "../../go.mod", plan.Jobs[0].Manifest)
+	assert.Equal(t, "go", plan.Jobs[0].PackageManager)
+	assert.Empty(t, plan.Skipped)
+}
+
+func TestPlanSkippedByRegenerate(t *testing.T) {
+	f := testdata.NewFinderMock()
+	groups := file.Groups{}
+	groups.Add(file.Group{ManifestFile: goModFile, LockFiles: []string{"gomod.debricked.lock"}})
+	groups.Add(file.Group{ManifestFile: "package.json", LockFiles: []string{"yarn.lock"}})
+	f.SetGetGroupsReturnMock(groups, nil)
+	r := NewResolver(
+		f,
+		resolutionFile.NewBatchFactory(),
+		strategyTestdata.NewStrategyFactoryMock(),
+		SchedulerMock{},
+	)
+
+	plan, err := r.Plan([]string{"."}, DebrickedOptions{Regenerate: 1})
+
+	assert.NoError(t, err)
+	assert.Len(t, plan.Jobs, 1)
+	assert.Equal(t, goModFile, plan.Jobs[0].Manifest)
+	assert.Len(t, plan.Skipped, 1)
+	assert.Equal(t, "package.json", plan.Skipped[0].Manifest)
+	assert.Contains(t, plan.Skipped[0].Reason, "yarn.lock")
+	assert.Contains(t, plan.Skipped[0].Reason, "--regenerate=2")
+}
+
+func TestPlanSkippedByDrift(t *testing.T) {
+	f := testdata.NewFinderMock()
+	groups := driftedGoModGroups(t)
+	f.SetGetGroupsReturnMock(groups, nil)
+	r := NewResolver(
+		f,
+		resolutionFile.NewBatchFactory(),
+		strategyTestdata.NewStrategyFactoryMock(),
+		SchedulerMock{},
+	)
+
+	plan, err := r.Plan([]string{"."}, DebrickedOptions{CheckDrift: true})
+
+	assert.NoError(t, err)
+	assert.Empty(t, plan.Jobs)
+	assert.Len(t, plan.Skipped, 1)
+	assert.Contains(t, plan.Skipped[0].Reason, "requirement github.com/pkg/errors v0.9.1 has no checksum")
+	assert.Contains(t, plan.Skipped[0].Reason, "--regenerate=drifted")
+
+	plan, err = r.Plan([]string{"."}, DebrickedOptions{Regenerate: RegenerateDrifted})
+
+	assert.NoError(t, err)
+	assert.Len(t, plan.Jobs, 1)
+	assert.Empty(t, plan.Skipped)
+}
+
+func TestPlanNoPackageManager(t *testing.T) {
+	r := NewResolver(
+		&testdata.FinderMock{},
+		fileTestdata.NewBatchFactoryMock(),
+		strategy.NewStrategyFactory(),
+		SchedulerMock{},
+	)
+
+	plan, err := r.Plan([]string{"../../go.mod"}, DebrickedOptions{})
+
+	assert.NoError(t, err)
+	assert.Empty(t, plan.Jobs)
+	assert.Equal(t, []SkippedManifest{{Manifest: "../../go.mod", Reason: noPackageManagerReason}}, plan.Skipped)
+}
+
+func TestPlanExcludedScope(t *testing.T) {
+	f := testdata.NewFinderMock()
+	groups := file.Groups{}
+	groups.Add(file.Group{ManifestFile: "requirements.txt"})
+	groups.Add(file.Group{ManifestFile: "requirements-dev.txt"})
+	f.SetGetGroupsReturnMock(groups, nil)
+	r := NewResolver(
+		f,
+		resolutionFile.NewBatchFactory(),
+		strategy.NewStrategyFactory(),
+		SchedulerMock{},
+	)
+
+	plan, err := r.Plan([]string{"."}, DebrickedOptions{ExcludeScopes: []string{"dev"}})
+
+	assert.NoError(t, err)
+	assert.Len(t, plan.Jobs, 1)
+	assert.Equal(t, "requirements.txt", plan.Jobs[0].Manifest)
+	assert.Equal(t, []SkippedManifest{{Manifest: "requirements-dev.txt", Reason: excludedScopeReason}}, plan.Skipped)
+}
+
+func TestPlanInvalidScope(t *testing.T) {
+	r := NewResolver(
+		&testdata.FinderMock{},
+		resolutionFile.NewBatchFactory(),
+		strategyTestdata.NewStrategyFactoryMock(),
+		SchedulerMock{},
+	)
+
+	_, err := r.Plan([]string{"../../go.mod"}, DebrickedOptions{ExcludeScopes: []string{"docs"}})
+
+	assert.ErrorContains(t, err, "invalid scope docs")
+}
+
+func TestPlanInvokeError(t *testing.T) {
+	r := NewResolver(
+		&testdata.FinderMock{},
+		resolutionFile.NewBatchFactory(),
+		strategyTestdata.NewStrategyFactoryErrorMock(),
+		SchedulerMock{},
+	)
+
+	_, err := r.Plan([]string{"../../go.mod"}, DebrickedOptions{})
+
+	assert.Error(t, err)
+}
+
+func TestPlanBadOpts(t *testing.T) {
+	r := NewResolver(
+		&testdata.FinderMock{},
+		resolutionFile.NewBatchFactory(),
+		strategyTestdata.NewStrategyFactoryMock(),
+		SchedulerMock{},
+	)
+
+	_, err := r.Plan([]string{"."}, nil)
+
+	assert.ErrorIs(t, err, ErrBadOpts)
+}
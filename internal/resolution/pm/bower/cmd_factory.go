@@ -23,6 +23,7 @@ func (ExecPath) LookPath(file string) (string, error) {
 
 type CmdFactory struct {
 	execPath IExecPath
+	offline  bool
 }
 
 func (cmdf CmdFactory) MakeInstallCmd(command string, file string) (*exec.Cmd, error) {
@@ -49,9 +50,14 @@ func (cmdf CmdFactory) MakeListCmd(command string, file string) (*exec.Cmd, erro
 
 	fileDir := filepath.Dir(file)
 
+	args := []string{command, "list"}
+	if cmdf.offline {
+		args = append(args, "--offline")
+	}
+
 	return &exec.Cmd{
 		Path: path,
-		Args: []string{command, "list"},
+		Args: args,
 		Dir:  fileDir,
 	}, err
 }
@@ -31,3 +31,13 @@ func TestMakeListCmd(t *testing.T) {
 	assert.Contains(t, args, "bower")
 	assert.Contains(t, args, "list")
 }
+
+func TestMakeListCmdOffline(t *testing.T) {
+	cmd, _ := CmdFactory{
+		execPath: ExecPath{},
+		offline:  true,
+	}.MakeListCmd("bower", "file")
+	assert.NotNil(t, cmd)
+	assert.Contains(t, cmd.Args, "list")
+	assert.Contains(t, cmd.Args, "--offline")
+}
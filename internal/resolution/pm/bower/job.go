@@ -1,6 +1,9 @@
 package bower
 
 import (
+	"encoding/json"
+	"os"
+	"path/filepath"
 	"regexp"
 	"strings"
 
@@ -12,6 +15,7 @@ import (
 const (
 	bower                       = "bower"
 	fileName                    = "bower.debricked.lock"
+	defaultComponentsDir        = "bower_components"
 	executableNotFoundErrRegex  = `executable file not found`
 	versionNotFoundErrRegex     = `([^"\s:]+)\s+ENORESTARGET No tag found`
 	dependencyNotFoundErrRegex  = `ENOTFOUND Package ([^"\s:]+) not found`
@@ -21,29 +25,44 @@ const (
 
 type Job struct {
 	job.BaseJob
+	install    bool
 	cmdFactory ICmdFactory
 	fileWriter writer.IFileWriter
 }
 
 func NewJob(
 	file string,
+	install bool,
 	cmdFactory ICmdFactory,
 	fileWriter writer.IFileWriter,
 ) *Job {
 	return &Job{
 		BaseJob:    job.NewBaseJob(file),
+		install:    install,
 		cmdFactory: cmdFactory,
 		fileWriter: fileWriter,
 	}
 }
 
+func (j *Job) Install() bool {
+	return j.install
+}
+
 func (j *Job) Run() {
 	status := "installing dependencies"
 	j.SendStatus(status)
 
-	cmd, err := j.runInstallCmd(j.GetFile())
-	if err != nil {
-		j.handleError(j.createError(err.Error(), cmd, status))
+	if j.install {
+		cmd, err := j.runInstallCmd(j.GetFile())
+		if err != nil {
+			j.handleError(j.createError(err.Error(), cmd, status))
+
+			return
+		}
+	} else if !hasInstalledComponents(j.GetFile()) {
+		cmdError := j.createError("no installed Bower components to resolve without installing", "", status)
+		cmdError.SetDocumentation(j.getNotInstalledErrorDocumentation())
+		j.Errors().Critical(cmdError)
 
 		return
 	}
@@ -59,7 +78,7 @@ func (j *Job) Run() {
 
 	status = "creating lock file"
 	j.SendStatus(status)
-	lockFile, err := j.fileWriter.Create(util.MakePathFromManifestFile(j.GetFile(), fileName))
+	lockFile, err := j.fileWriter.Create(j.LockFilePath(util.MakePathFromManifestFile(j.GetFile(), fileName)))
 	if err != nil {
 		j.handleError(j.createError(err.Error(), cmd, status))
 
@@ -73,13 +92,46 @@ func (j *Job) Run() {
 	}
 }
 
+// Plan returns the commands and lock file of the job, without running it
+func (j *Job) Plan() job.Plan {
+	var plan job.Plan
+	if j.install {
+		plan.AddCommand(j.cmdFactory.MakeInstallCmd(bower, j.GetFile()))
+	}
+	plan.AddCommand(j.cmdFactory.MakeListCmd(bower, j.GetFile()))
+	plan.AddLockFile(util.MakePathFromManifestFile(j.GetFile(), fileName))
+
+	return plan
+}
+
+// hasInstalledComponents returns true if the components directory of bower.json exists,
+// configured by the directory option of .bowerrc and bower_components by default
+func hasInstalledComponents(file string) bool {
+	directory := defaultComponentsDir
+	bowerrc, err := os.ReadFile(filepath.Join(filepath.Dir(file), ".bowerrc"))
+	if err == nil {
+		var config struct {
+			Directory string `json:"directory"`
+		}
+		if json.Unmarshal(bowerrc, &config) == nil && len(config.Directory) > 0 {
+			directory = config.Directory
+		}
+	}
+	if !filepath.IsAbs(directory) {
+		directory = filepath.Join(filepath.Dir(file), directory)
+	}
+	info, err := os.Stat(directory)
+
+	return err == nil && info.IsDir()
+}
+
 func (j *Job) runInstallCmd(file string) (string, error) {
 	installCmd, err := j.cmdFactory.MakeInstallCmd(bower, file)
 	if err != nil {
 		return installCmd.String(), err
 	}
 
-	_, err = installCmd.Output()
+	_, err = j.Output(installCmd)
 	if err != nil {
 		return installCmd.String(), j.GetExitError(err, "")
 	}
@@ -93,7 +145,7 @@ func (j *Job) runListCmd(file string) ([]byte, string, error) {
 		return nil, listCmd.String(), err
 	}
 
-	listCmdOutput, err := listCmd.Output()
+	listCmdOutput, err := j.Output(listCmd)
 	if err != nil {
 		return nil, listCmd.String(), j.GetExitError(err, "")
 	}
@@ -214,3 +266,12 @@ func (j *Job) getPermissionDeniedErrorDocumentation(matches [][]string) string {
 			"Please check permissions or try running this command again as root/Administrator.",
 		}, " ")
 }
+
+func (j *Job) getNotInstalledErrorDocumentation() string {
+	return strings.Join(
+		[]string{
+			"Bower can't resolve dependencies without installing them, which is disabled by --lockfile-only.",
+			"Please install the dependencies, for example by running \"bower install --config.interactive=false\", before running the CLI,",
+			"or resolve without --lockfile-only.",
+		}, " ")
+}
@@ -2,6 +2,8 @@ package bower
 
 import (
 	"errors"
+	"os"
+	"path/filepath"
 	"testing"
 
 	jobTestdata "github.com/debricked/cli/internal/resolution/job/testdata"
@@ -13,7 +15,7 @@ import (
 )
 
 func TestNewJob(t *testing.T) {
-	j := NewJob("file", CmdFactory{}, writer.FileWriter{})
+	j := NewJob("file", true, CmdFactory{}, writer.FileWriter{})
 	assert.Equal(t, "file", j.GetFile())
 	assert.False(t, j.Errors().HasError())
 }
@@ -68,7 +70,7 @@ func TestRunGraphCmdErr(t *testing.T) {
 			expectedError.SetStatus("installing dependencies")
 			expectedError.SetCommand(cmd.String())
 
-			j := NewJob("file", cmdFactoryMock, nil)
+			j := NewJob("file", true, cmdFactoryMock, nil)
 
 			go jobTestdata.WaitStatus(j)
 			j.Run()
@@ -84,7 +86,7 @@ func TestRunGraphCmdErr(t *testing.T) {
 func TestRunCmdOutputErr(t *testing.T) {
 	cmdFactoryMock := testdata.NewEchoCmdFactory()
 	cmdFactoryMock.InstallCmdName = "bad-name"
-	j := NewJob("file", cmdFactoryMock, nil)
+	j := NewJob("file", true, cmdFactoryMock, nil)
 
 	go jobTestdata.WaitStatus(j)
 
@@ -97,7 +99,7 @@ func TestRunListCmdErr(t *testing.T) {
 	cmdErr := errors.New("cmd-error")
 	cmdFactoryMock := testdata.NewEchoCmdFactory()
 	cmdFactoryMock.MakeListCmdErr = cmdErr
-	j := NewJob("file", cmdFactoryMock, nil)
+	j := NewJob("file", true, cmdFactoryMock, nil)
 
 	cmd, _ := cmdFactoryMock.MakeListCmd("echo", "")
 	expectedError := util.NewPMJobError(cmdErr.Error())
@@ -117,7 +119,7 @@ func TestRunListCmdErr(t *testing.T) {
 func TestRunListCmdOutputErr(t *testing.T) {
 	cmdFactoryMock := testdata.NewEchoCmdFactory()
 	cmdFactoryMock.ListCmdName = "bad-name"
-	j := NewJob("file", cmdFactoryMock, nil)
+	j := NewJob("file", true, cmdFactoryMock, nil)
 
 	go jobTestdata.WaitStatus(j)
 
@@ -130,7 +132,7 @@ func TestRunCreateErr(t *testing.T) {
 	createErr := errors.New("create-error")
 	fileWriterMock := &writerTestdata.FileWriterMock{CreateErr: createErr}
 	cmdFactoryMock := testdata.NewEchoCmdFactory()
-	j := NewJob("file", cmdFactoryMock, fileWriterMock)
+	j := NewJob("file", true, cmdFactoryMock, fileWriterMock)
 
 	cmd, _ := cmdFactoryMock.MakeListCmd("echo", "")
 	expectedError := util.NewPMJobError(createErr.Error())
@@ -151,7 +153,7 @@ func TestRunWriteErr(t *testing.T) {
 	writeErr := errors.New("write-error")
 	fileWriterMock := &writerTestdata.FileWriterMock{WriteErr: writeErr}
 	cmdFactoryMock := testdata.NewEchoCmdFactory()
-	j := NewJob("file", cmdFactoryMock, fileWriterMock)
+	j := NewJob("file", true, cmdFactoryMock, fileWriterMock)
 
 	cmd, _ := cmdFactoryMock.MakeListCmd("echo", "")
 	expectedError := util.NewPMJobError(writeErr.Error())
@@ -172,7 +174,7 @@ func TestRunCloseErr(t *testing.T) {
 	closeErr := errors.New("close-error")
 	fileWriterMock := &writerTestdata.FileWriterMock{CloseErr: closeErr}
 	cmdFactoryMock := testdata.NewEchoCmdFactory()
-	j := NewJob("file", cmdFactoryMock, fileWriterMock)
+	j := NewJob("file", true, cmdFactoryMock, fileWriterMock)
 
 	go jobTestdata.WaitStatus(j)
 
@@ -187,7 +189,7 @@ func TestRunCloseErr(t *testing.T) {
 func TestRun(t *testing.T) {
 	fileWriterMock := &writerTestdata.FileWriterMock{}
 	cmdFactoryMock := testdata.NewEchoCmdFactory()
-	j := NewJob("file", cmdFactoryMock, fileWriterMock)
+	j := NewJob("file", true, cmdFactoryMock, fileWriterMock)
 
 	go jobTestdata.WaitStatus(j)
 
@@ -196,3 +198,35 @@ func TestRun(t *testing.T) {
 	assert.Empty(t, j.Errors().GetAll())
 	assert.Equal(t, "MakeListCmd\n", string(fileWriterMock.Contents))
 }
+
+func TestRunWithoutInstall(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.Mkdir(filepath.Join(dir, "components"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, ".bowerrc"), []byte(`{"directory": "components"}`), 0600))
+	fileWriterMock := &writerTestdata.FileWriterMock{}
+	cmdFactoryMock := testdata.NewEchoCmdFactory()
+	j := NewJob(filepath.Join(dir, "bower.json"), false, cmdFactoryMock, fileWriterMock)
+
+	go jobTestdata.WaitStatus(j)
+
+	j.Run()
+
+	assert.False(t, j.Install())
+	assert.Empty(t, j.Errors().GetAll())
+	assert.Equal(t, "MakeListCmd\n", string(fileWriterMock.Contents))
+}
+
+func TestRunWithoutInstallNoComponents(t *testing.T) {
+	fileWriterMock := &writerTestdata.FileWriterMock{}
+	cmdFactoryMock := testdata.NewEchoCmdFactory()
+	j := NewJob(filepath.Join(t.TempDir(), "bower.json"), false, cmdFactoryMock, fileWriterMock)
+
+	go jobTestdata.WaitStatus(j)
+
+	j.Run()
+
+	allErrors := j.Errors().GetAll()
+	assert.Len(t, allErrors, 1)
+	assert.Contains(t, allErrors[0].Documentation(), "--lockfile-only")
+	assert.Empty(t, fileWriterMock.Contents)
+}
@@ -6,18 +6,26 @@ import (
 )
 
 type Strategy struct {
-	files []string
+	files        []string
+	lockfileOnly bool
 }
 
 func (s Strategy) Invoke() ([]job.IJob, error) {
 	var jobs []job.IJob
 	for _, file := range s.files {
-		jobs = append(jobs, NewJob(file, CmdFactory{execPath: ExecPath{}}, writer.FileWriter{}))
+		jobs = append(jobs, NewJob(
+			file,
+			!s.lockfileOnly,
+			CmdFactory{execPath: ExecPath{}, offline: s.lockfileOnly},
+			writer.FileWriter{},
+		))
 	}
 
 	return jobs, nil
 }
 
-func NewStrategy(files []string) Strategy {
-	return Strategy{files}
+// NewStrategy makes a strategy resolving bower.json files. Bower can't resolve dependencies without installing them,
+// so in lockfile-only mode the dependencies already installed are listed instead
+func NewStrategy(files []string, lockfileOnly bool) Strategy {
+	return Strategy{files, lockfileOnly}
 }
@@ -7,37 +7,45 @@ import (
 )
 
 func TestNewStrategy(t *testing.T) {
-	s := NewStrategy(nil)
+	s := NewStrategy(nil, false)
 	assert.NotNil(t, s)
 	assert.Len(t, s.files, 0)
 
-	s = NewStrategy([]string{})
+	s = NewStrategy([]string{}, false)
 	assert.NotNil(t, s)
 	assert.Len(t, s.files, 0)
 
-	s = NewStrategy([]string{"file"})
+	s = NewStrategy([]string{"file"}, false)
 	assert.NotNil(t, s)
 	assert.Len(t, s.files, 1)
 
-	s = NewStrategy([]string{"file-1", "file-2"})
+	s = NewStrategy([]string{"file-1", "file-2"}, false)
 	assert.NotNil(t, s)
 	assert.Len(t, s.files, 2)
 }
 
 func TestInvokeNoFiles(t *testing.T) {
-	s := NewStrategy([]string{})
+	s := NewStrategy([]string{}, false)
 	jobs, _ := s.Invoke()
 	assert.Empty(t, jobs)
 }
 
 func TestInvokeOneFile(t *testing.T) {
-	s := NewStrategy([]string{"file"})
+	s := NewStrategy([]string{"file"}, false)
 	jobs, _ := s.Invoke()
 	assert.Len(t, jobs, 1)
 }
 
 func TestInvokeManyFiles(t *testing.T) {
-	s := NewStrategy([]string{"file-1", "file-2"})
+	s := NewStrategy([]string{"file-1", "file-2"}, false)
 	jobs, _ := s.Invoke()
 	assert.Len(t, jobs, 2)
 }
+
+func TestInvokeLockfileOnly(t *testing.T) {
+	s := NewStrategy([]string{"file"}, true)
+	jobs, _ := s.Invoke()
+	assert.Len(t, jobs, 1)
+	j, _ := jobs[0].(*Job)
+	assert.False(t, j.Install())
+}
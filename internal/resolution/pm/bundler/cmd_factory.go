@@ -0,0 +1,46 @@
+package bundler
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+)
+
+const bundle = "bundle"
+
+type ICmdFactory interface {
+	MakeLockCmd(gemfile string) (*exec.Cmd, error)
+}
+
+type IExecPath interface {
+	LookPath(file string) (string, error)
+}
+
+type ExecPath struct {
+}
+
+func (ExecPath) LookPath(file string) (string, error) {
+	return exec.LookPath(file)
+}
+
+type CmdFactory struct {
+	execPath IExecPath
+}
+
+// MakeLockCmd makes a command resolving the Gemfile into a Gemfile.lock, without installing any gems
+func (cmdf CmdFactory) MakeLockCmd(gemfile string) (*exec.Cmd, error) {
+	path, err := cmdf.execPath.LookPath(bundle)
+	absGemfile, _ := filepath.Abs(gemfile)
+
+	return &exec.Cmd{
+		Path: path,
+		Args: []string{bundle, "lock"},
+		Dir:  filepath.Dir(gemfile),
+		Env: append(
+			os.Environ(),
+			"BUNDLE_GEMFILE="+absGemfile, // Don't pick up a Gemfile of a parent directory
+			"BUNDLE_FROZEN=false",        // Frozen or deployment settings prevent creating the lock file
+			"BUNDLE_DEPLOYMENT=false",
+		),
+	}, err
+}
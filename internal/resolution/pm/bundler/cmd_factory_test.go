@@ -0,0 +1,25 @@
+package bundler
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type execPathMock struct{}
+
+func (execPathMock) LookPath(file string) (string, error) {
+	return file, nil
+}
+
+func TestMakeLockCmd(t *testing.T) {
+	gemfilePath := filepath.Join("dir", "Gemfile")
+	cmd, err := CmdFactory{execPath: execPathMock{}}.MakeLockCmd(gemfilePath)
+	assert.NoError(t, err)
+	assert.NotNil(t, cmd)
+	assert.Equal(t, []string{"bundle", "lock"}, cmd.Args)
+	assert.Equal(t, "dir", cmd.Dir)
+	absGemfile, _ := filepath.Abs(gemfilePath)
+	assert.Contains(t, cmd.Env, "BUNDLE_GEMFILE="+absGemfile)
+}
@@ -0,0 +1,276 @@
+package bundler
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/debricked/cli/internal/resolution/job"
+	"github.com/debricked/cli/internal/resolution/pm/util"
+	"github.com/debricked/cli/internal/resolution/pm/writer"
+)
+
+const (
+	gemfile                          = "Gemfile"
+	gemfileLock                      = "Gemfile.lock"
+	gemspecExtension                 = ".gemspec"
+	bundleExecutableNotFoundErrRegex = `"bundle": executable file not found`
+	rubyNotFoundErrRegex             = `env: '?ruby'?: No such file or directory`
+	rubyVersionErrRegex              = `Your Ruby version is ([^,]+), but your Gemfile specified ([^\s]+)`
+	incompatiblePlatformsErrRegex    = `Could not find gems matching '([^']+)' valid for all resolution platforms \(([^)]+)\)`
+	gemNotFoundErrRegex              = `Could not find gem '([^']+)' in`
+	incompatibleVersionsErrRegex     = `[Cc]ould not find compatible versions(?: for gem "([^"]+)")?`
+	authenticationRequiredErrRegex   = `Authentication is required for ([^\s]+?)\.?(?:\s|$)`
+	badCredentialsErrRegex           = `Bad username or password for ([^\s]+?)\.?(?:\s|$)`
+	networkErrRegex                  = `Could not fetch specs from ([^\s]+)|Could not reach host ([^\s]+?)\.`
+	defaultGemSource                 = "https://rubygems.org"
+)
+
+var osRemove = os.Remove
+
+type Job struct {
+	job.BaseJob
+	cmdFactory ICmdFactory
+	fileWriter writer.IFileWriter
+}
+
+func NewJob(
+	file string,
+	cmdFactory ICmdFactory,
+	fileWriter writer.IFileWriter,
+) *Job {
+	return &Job{
+		BaseJob:    job.NewBaseJob(file),
+		cmdFactory: cmdFactory,
+		fileWriter: fileWriter,
+	}
+}
+
+func (j *Job) Run() {
+	gemfilePath := j.GetFile()
+	if strings.HasSuffix(gemfilePath, gemspecExtension) {
+		gemfilePath = filepath.Join(filepath.Dir(j.GetFile()), gemfile)
+		status := "creating Gemfile from gemspecs"
+		j.SendStatus(status)
+		err := j.writeGemfileFromGemspecs(gemfilePath)
+		if err != nil {
+			cmdErr := util.NewPMJobError(err.Error())
+			cmdErr.SetStatus(status)
+			j.Errors().Critical(cmdErr)
+			j.removeGemfileFromGemspecs(gemfilePath)
+
+			return
+		}
+		defer j.removeGemfileFromGemspecs(gemfilePath)
+	}
+
+	status := "locking dependencies"
+	j.SendStatus(status)
+	lockCmd, err := j.cmdFactory.MakeLockCmd(gemfilePath)
+	if err != nil {
+		cmdErr := util.NewPMJobError(err.Error())
+		cmdErr.SetStatus(status)
+		if lockCmd != nil {
+			cmdErr.SetCommand(lockCmd.String())
+		}
+		j.handleError(cmdErr)
+
+		return
+	}
+
+	output, err := j.Output(lockCmd)
+	if err != nil {
+		cmdErr := util.NewPMJobError(j.GetExitError(err, string(output)).Error())
+		cmdErr.SetStatus(status)
+		cmdErr.SetCommand(lockCmd.String())
+		j.handleError(cmdErr)
+	}
+}
+
+// Plan returns the command and lock file of the job, without running it. For gemspecs, the command locks the
+// Gemfile the job writes next to them
+func (j *Job) Plan() job.Plan {
+	var plan job.Plan
+	gemfilePath := j.GetFile()
+	if strings.HasSuffix(gemfilePath, gemspecExtension) {
+		gemfilePath = filepath.Join(filepath.Dir(j.GetFile()), gemfile)
+	}
+	plan.AddCommand(j.cmdFactory.MakeLockCmd(gemfilePath))
+	plan.AddLockFile(util.MakePathFromManifestFile(j.GetFile(), gemfileLock))
+
+	return plan
+}
+
+// writeGemfileFromGemspecs writes a temporary Gemfile depending on all gemspecs in the directory of the job file,
+// since Bundler can't lock a gemspec by itself
+func (j *Job) writeGemfileFromGemspecs(gemfilePath string) error {
+	gemspecs, err := filepath.Glob(filepath.Join(filepath.Dir(gemfilePath), "*"+gemspecExtension))
+	if err != nil {
+		return err
+	}
+	sort.Strings(gemspecs)
+
+	file, err := j.fileWriter.Create(gemfilePath)
+	if err != nil {
+		return err
+	}
+	defer util.CloseFile(j, j.fileWriter, file)
+
+	return j.fileWriter.Write(file, makeGemfileContent(gemspecs))
+}
+
+func makeGemfileContent(gemspecs []string) []byte {
+	lines := []string{"source \"" + defaultGemSource + "\"", ""}
+	for _, gemspec := range gemspecs {
+		name := strings.TrimSuffix(filepath.Base(gemspec), gemspecExtension)
+		lines = append(lines, "gemspec name: \""+name+"\"")
+	}
+
+	return []byte(strings.Join(lines, "\n") + "\n")
+}
+
+func (j *Job) removeGemfileFromGemspecs(gemfilePath string) {
+	err := osRemove(gemfilePath)
+	if err != nil && !os.IsNotExist(err) {
+		cmdErr := util.NewPMJobError(err.Error())
+		cmdErr.SetDocumentation("Error when trying to remove the Gemfile created from gemspecs")
+		cmdErr.SetStatus("removing Gemfile created from gemspecs")
+		j.Errors().Critical(cmdErr)
+	}
+}
+
+func (j *Job) handleError(cmdError job.IError) {
+	expressions := []string{
+		bundleExecutableNotFoundErrRegex,
+		rubyNotFoundErrRegex,
+		rubyVersionErrRegex,
+		incompatiblePlatformsErrRegex,
+		gemNotFoundErrRegex,
+		incompatibleVersionsErrRegex,
+		authenticationRequiredErrRegex,
+		badCredentialsErrRegex,
+		networkErrRegex,
+	}
+
+	for _, expression := range expressions {
+		regex := regexp.MustCompile(expression)
+		matches := regex.FindAllStringSubmatch(cmdError.Error(), -1)
+
+		if len(matches) > 0 {
+			cmdError = j.addDocumentation(expression, matches, cmdError)
+			j.Errors().Append(cmdError)
+
+			return
+		}
+	}
+
+	j.Errors().Append(cmdError)
+}
+
+func (j *Job) addDocumentation(expr string, matches [][]string, cmdError job.IError) job.IError {
+	documentation := cmdError.Documentation()
+
+	switch expr {
+	case bundleExecutableNotFoundErrRegex:
+		documentation = j.GetExecutableNotFoundErrorDocumentation("Bundler")
+	case rubyNotFoundErrRegex:
+		documentation = j.GetExecutableNotFoundErrorDocumentation("Ruby")
+	case rubyVersionErrRegex:
+		documentation = j.getRubyVersionErrorDocumentation(matches)
+	case incompatiblePlatformsErrRegex:
+		documentation = j.getIncompatiblePlatformsErrorDocumentation(matches)
+	case gemNotFoundErrRegex:
+		documentation = j.getGemNotFoundErrorDocumentation(matches)
+	case incompatibleVersionsErrRegex:
+		documentation = j.getIncompatibleVersionsErrorDocumentation(matches)
+	case authenticationRequiredErrRegex, badCredentialsErrRegex:
+		documentation = j.getCredentialsErrorDocumentation(matches)
+	case networkErrRegex:
+		documentation = j.getNetworkErrorDocumentation()
+	}
+
+	cmdError.SetDocumentation(documentation)
+
+	return cmdError
+}
+
+func firstMatch(matches [][]string) string {
+	if len(matches) > 0 && len(matches[0]) > 1 {
+		return matches[0][1]
+	}
+
+	return ""
+}
+
+func (j *Job) getRubyVersionErrorDocumentation(matches [][]string) string {
+	required := ""
+	if len(matches) > 0 && len(matches[0]) > 2 {
+		required = matches[0][2] + " "
+	}
+
+	return strings.Join(
+		[]string{
+			"Ruby ",
+			required,
+			"required by the Gemfile isn't the Ruby version used by Bundler. ",
+			"Please install the required Ruby version and make sure that it is the one accessible by the CLI.",
+		}, "")
+}
+
+func (j *Job) getIncompatiblePlatformsErrorDocumentation(matches [][]string) string {
+	platforms := ""
+	if len(matches) > 0 && len(matches[0]) > 2 {
+		platforms = " (" + matches[0][2] + ")"
+	}
+
+	return strings.Join(
+		[]string{
+			"Gem \"" + firstMatch(matches) + "\" isn't available for all platforms of the bundle" + platforms + ".",
+			"Please remove the incompatible platforms, for example by running \"bundle lock --remove-platform <platform>\",",
+			"or commit a Gemfile.lock generated on a supported platform.",
+		}, " ")
+}
+
+func (j *Job) getGemNotFoundErrorDocumentation(matches [][]string) string {
+	return strings.Join(
+		[]string{
+			"Failed to find gem \"" + firstMatch(matches) + "\" in the gem sources of the Gemfile.",
+			"Please check that the gem exists and is spelt correctly.",
+			"\n" + util.InstallPrivateDependencyMessage,
+		}, " ")
+}
+
+func (j *Job) getIncompatibleVersionsErrorDocumentation(matches [][]string) string {
+	gem := ""
+	if name := firstMatch(matches); len(name) > 0 {
+		gem = " of gem \"" + name + "\""
+	}
+
+	return strings.Join(
+		[]string{
+			"Failed to find compatible versions" + gem + ".",
+			"Please check that the version constraints in the Gemfile can be satisfied together.",
+		}, " ")
+}
+
+func (j *Job) getCredentialsErrorDocumentation(matches [][]string) string {
+	source := firstMatch(matches)
+
+	return strings.Join(
+		[]string{
+			"Failed to authenticate to gem source \"" + source + "\".",
+			"Please make sure that the CLI has credentials for the source, for example by running",
+			"\"bundle config set --global " + source + " <username>:<password>\" or setting BUNDLE_<source>.",
+			"\n" + util.InstallPrivateDependencyMessage,
+		}, " ")
+}
+
+func (j *Job) getNetworkErrorDocumentation() string {
+	return strings.Join(
+		[]string{
+			"We weren't able to retrieve one or more dependencies.",
+			"Please check your Internet connection and try again.",
+		}, " ")
+}
@@ -0,0 +1,211 @@
+package bundler
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	jobTestdata "github.com/debricked/cli/internal/resolution/job/testdata"
+	"github.com/debricked/cli/internal/resolution/pm/bundler/testdata"
+	"github.com/debricked/cli/internal/resolution/pm/util"
+	"github.com/debricked/cli/internal/resolution/pm/writer"
+	writerTestdata "github.com/debricked/cli/internal/resolution/pm/writer/testdata"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewJob(t *testing.T) {
+	j := NewJob("file", CmdFactory{execPath: ExecPath{}}, writer.FileWriter{})
+	assert.Equal(t, "file", j.GetFile())
+	assert.False(t, j.Errors().HasError())
+}
+
+func TestRunGemfile(t *testing.T) {
+	cmdFactoryMock := testdata.NewEchoCmdFactory()
+	fileWriterMock := &writerTestdata.FileWriterMock{}
+	j := NewJob("Gemfile", cmdFactoryMock, fileWriterMock)
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	assert.False(t, j.Errors().HasError())
+	assert.Equal(t, "Gemfile", cmdFactoryMock.Gemfile)
+	assert.Empty(t, fileWriterMock.Contents)
+}
+
+func TestRunGemspec(t *testing.T) {
+	dir := t.TempDir()
+	for _, gemspec := range []string{"beta.gemspec", "alpha.gemspec"} {
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, gemspec), []byte{}, 0600))
+	}
+	cmdFactoryMock := testdata.NewEchoCmdFactory()
+	fileWriterMock := &writerTestdata.FileWriterMock{}
+	j := NewJob(filepath.Join(dir, "alpha.gemspec"), cmdFactoryMock, fileWriterMock)
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	assert.False(t, j.Errors().HasError())
+	assert.Equal(t, filepath.Join(dir, "Gemfile"), cmdFactoryMock.Gemfile)
+	assert.Equal(
+		t,
+		"source \"https://rubygems.org\"\n\ngemspec name: \"alpha\"\ngemspec name: \"beta\"\n",
+		string(fileWriterMock.Contents),
+	)
+}
+
+func TestRunGemspecRemovesGemfile(t *testing.T) {
+	dir := t.TempDir()
+	gemspec := filepath.Join(dir, "alpha.gemspec")
+	assert.NoError(t, os.WriteFile(gemspec, []byte{}, 0600))
+	j := NewJob(gemspec, testdata.NewEchoCmdFactory(), writer.FileWriter{})
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	assert.False(t, j.Errors().HasError())
+	assert.NoFileExists(t, filepath.Join(dir, "Gemfile"))
+}
+
+func TestRunGemspecCreateErr(t *testing.T) {
+	createErr := errors.New("create-error")
+	fileWriterMock := &writerTestdata.FileWriterMock{CreateErr: createErr}
+	j := NewJob("alpha.gemspec", testdata.NewEchoCmdFactory(), fileWriterMock)
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	errs := j.Errors().GetAll()
+	assert.Len(t, errs, 1)
+	assert.ErrorContains(t, errs[0], createErr.Error())
+	assert.Equal(t, "creating Gemfile from gemspecs", errs[0].Status())
+}
+
+func TestRunGemspecWriteErr(t *testing.T) {
+	writeErr := errors.New("write-error")
+	fileWriterMock := &writerTestdata.FileWriterMock{WriteErr: writeErr}
+	j := NewJob("alpha.gemspec", testdata.NewEchoCmdFactory(), fileWriterMock)
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	errs := j.Errors().GetAll()
+	assert.Len(t, errs, 1)
+	assert.ErrorContains(t, errs[0], writeErr.Error())
+}
+
+func TestRunGemspecRemoveErr(t *testing.T) {
+	removeErr := errors.New("remove-error")
+	oldOsRemove := osRemove
+	osRemove = func(string) error {
+		return removeErr
+	}
+	defer func() {
+		osRemove = oldOsRemove
+	}()
+	j := NewJob("alpha.gemspec", testdata.NewEchoCmdFactory(), &writerTestdata.FileWriterMock{})
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	errs := j.Errors().GetAll()
+	assert.Len(t, errs, 1)
+	assert.ErrorContains(t, errs[0], removeErr.Error())
+	assert.Equal(t, "removing Gemfile created from gemspecs", errs[0].Status())
+}
+
+func TestRunLockCmdOutputErr(t *testing.T) {
+	cmdFactoryMock := testdata.NewEchoCmdFactory()
+	cmdFactoryMock.LockCmdName = "bad-name"
+	j := NewJob("Gemfile", cmdFactoryMock, &writerTestdata.FileWriterMock{})
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	jobTestdata.AssertPathErr(t, j.Errors())
+}
+
+func TestRunLockCmdErr(t *testing.T) {
+	cases := []struct {
+		name  string
+		error string
+		doc   string
+	}{
+		{
+			name:  "General error",
+			error: "cmd-error",
+			doc:   util.UnknownError,
+		},
+		{
+			name:  "Bundler not found",
+			error: "exec: \"bundle\": executable file not found in $PATH",
+			doc:   "Bundler wasn't found. Please check if it is installed and accessible by the CLI.",
+		},
+		{
+			name:  "Ruby not found",
+			error: "/usr/bin/env: 'ruby': No such file or directory",
+			doc:   "Ruby wasn't found. Please check if it is installed and accessible by the CLI.",
+		},
+		{
+			name:  "Ruby version mismatch",
+			error: "Your Ruby version is 2.7.8, but your Gemfile specified 3.2.2",
+			doc:   "Ruby 3.2.2 required by the Gemfile isn't the Ruby version used by Bundler. Please install the required Ruby version and make sure that it is the one accessible by the CLI.",
+		},
+		{
+			name:  "Incompatible platforms",
+			error: "Could not find gems matching 'nokogiri (= 1.15.0)' valid for all resolution platforms (x86_64-linux, java) in rubygems repository https://rubygems.org/ or installed locally.",
+			doc:   "Gem \"nokogiri (= 1.15.0)\" isn't available for all platforms of the bundle (x86_64-linux, java). Please remove the incompatible platforms, for example by running \"bundle lock --remove-platform <platform>\", or commit a Gemfile.lock generated on a supported platform.",
+		},
+		{
+			name:  "Gem not found",
+			error: "Could not find gem 'railz' in rubygems repository https://rubygems.org/ or installed locally.",
+			doc:   "Failed to find gem \"railz\" in the gem sources of the Gemfile. Please check that the gem exists and is spelt correctly. \n" + util.InstallPrivateDependencyMessage,
+		},
+		{
+			name:  "Incompatible versions",
+			error: "Could not find compatible versions\n\nBecause rails >= 7.0 depends on activesupport = 7.0.0",
+			doc:   "Failed to find compatible versions. Please check that the version constraints in the Gemfile can be satisfied together.",
+		},
+		{
+			name:  "Incompatible versions of gem",
+			error: "Bundler could not find compatible versions for gem \"activesupport\":",
+			doc:   "Failed to find compatible versions of gem \"activesupport\". Please check that the version constraints in the Gemfile can be satisfied together.",
+		},
+		{
+			name:  "Authentication required",
+			error: "Authentication is required for gems.example.com.\nPlease supply credentials for this source.",
+			doc:   "Failed to authenticate to gem source \"gems.example.com\". Please make sure that the CLI has credentials for the source, for example by running \"bundle config set --global gems.example.com <username>:<password>\" or setting BUNDLE_<source>. \n" + util.InstallPrivateDependencyMessage,
+		},
+		{
+			name:  "Bad credentials",
+			error: "Bad username or password for https://user@gems.example.com/.",
+			doc:   "Failed to authenticate to gem source \"https://user@gems.example.com/\". Please make sure that the CLI has credentials for the source, for example by running \"bundle config set --global https://user@gems.example.com/ <username>:<password>\" or setting BUNDLE_<source>. \n" + util.InstallPrivateDependencyMessage,
+		},
+		{
+			name:  "No network",
+			error: "Could not fetch specs from https://rubygems.org/ due to underlying error <getaddrinfo>",
+			doc:   "We weren't able to retrieve one or more dependencies. Please check your Internet connection and try again.",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cmdFactoryMock := testdata.NewEchoCmdFactory()
+			cmdFactoryMock.MakeLockErr = errors.New(c.error)
+			cmd, _ := cmdFactoryMock.MakeLockCmd("Gemfile")
+			j := NewJob("Gemfile", cmdFactoryMock, &writerTestdata.FileWriterMock{})
+
+			expectedError := util.NewPMJobError(c.error)
+			expectedError.SetDocumentation(c.doc)
+			expectedError.SetStatus("locking dependencies")
+			expectedError.SetCommand(cmd.String())
+
+			go jobTestdata.WaitStatus(j)
+			j.Run()
+
+			allErrors := j.Errors().GetAll()
+			assert.Len(t, allErrors, 1)
+			assert.Contains(t, allErrors, expectedError)
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package bundler
+
+const Name = "bundler"
+
+type Pm struct {
+	name string
+}
+
+func NewPm() Pm {
+	return Pm{
+		name: Name,
+	}
+}
+
+func (pm Pm) Name() string {
+	return pm.name
+}
+
+func (Pm) Manifests() []string {
+	return []string{
+		`^Gemfile$`,
+		`\.gemspec$`,
+	}
+}
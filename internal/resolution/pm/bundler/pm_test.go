@@ -0,0 +1,45 @@
+package bundler
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPm(t *testing.T) {
+	pm := NewPm()
+	assert.Equal(t, Name, pm.name)
+}
+
+func TestName(t *testing.T) {
+	pm := NewPm()
+	assert.Equal(t, Name, pm.Name())
+}
+
+func TestManifests(t *testing.T) {
+	pm := Pm{}
+	manifests := pm.Manifests()
+	assert.Len(t, manifests, 2)
+	assert.Equal(t, `^Gemfile$`, manifests[0])
+	assert.Equal(t, `\.gemspec$`, manifests[1])
+
+	cases := map[string]bool{
+		"Gemfile":        true,
+		"my-gem.gemspec": true,
+		"Gemfile.lock":   false,
+		"MyGemfile":      false,
+		"gemspec.rb":     false,
+	}
+	for file, isMatch := range cases {
+		t.Run(file, func(t *testing.T) {
+			matched := false
+			for _, manifest := range manifests {
+				m, err := regexp.MatchString(manifest, file)
+				assert.NoError(t, err)
+				matched = matched || m
+			}
+			assert.Equal(t, isMatch, matched)
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package bundler
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/debricked/cli/internal/resolution/job"
+	"github.com/debricked/cli/internal/resolution/pm/writer"
+)
+
+type Strategy struct {
+	files []string
+}
+
+// Invoke makes one job per Gemfile. Gemspecs are only resolved in directories lacking both Gemfile and Gemfile.lock,
+// with one job per directory since all gemspecs of a directory share the same Gemfile.lock
+func (s Strategy) Invoke() ([]job.IJob, error) {
+	var jobs []job.IJob
+	dirs := map[string]bool{}
+	for _, file := range s.files {
+		if filepath.Base(file) == gemfile {
+			dirs[filepath.Dir(file)] = true
+		}
+	}
+
+	for _, file := range s.files {
+		dir := filepath.Dir(file)
+		if strings.HasSuffix(file, gemspecExtension) {
+			if dirs[dir] || exists(filepath.Join(dir, gemfile)) || exists(filepath.Join(dir, gemfileLock)) {
+				continue
+			}
+			dirs[dir] = true
+		}
+		jobs = append(jobs, NewJob(
+			file,
+			CmdFactory{
+				execPath: ExecPath{},
+			},
+			writer.FileWriter{},
+		))
+	}
+
+	return jobs, nil
+}
+
+func NewStrategy(files []string) Strategy {
+	return Strategy{files}
+}
+
+func exists(file string) bool {
+	_, err := os.Stat(file)
+
+	return err == nil
+}
@@ -0,0 +1,47 @@
+package bundler
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewStrategy(t *testing.T) {
+	s := NewStrategy(nil)
+	assert.NotNil(t, s)
+	assert.Len(t, s.files, 0)
+
+	s = NewStrategy([]string{"file-1", "file-2"})
+	assert.NotNil(t, s)
+	assert.Len(t, s.files, 2)
+}
+
+func TestInvokeNoFiles(t *testing.T) {
+	s := NewStrategy([]string{})
+	jobs, _ := s.Invoke()
+	assert.Empty(t, jobs)
+}
+
+func TestInvokeManyFiles(t *testing.T) {
+	s := NewStrategy([]string{filepath.Join("dir-1", "Gemfile"), filepath.Join("dir-2", "Gemfile")})
+	jobs, _ := s.Invoke()
+	assert.Len(t, jobs, 2)
+}
+
+func TestInvokeGemspecs(t *testing.T) {
+	s := NewStrategy([]string{
+		filepath.Join("testdata", "gemspecs", "alpha.gemspec"),
+		filepath.Join("testdata", "gemspecs", "beta.gemspec"),
+		filepath.Join("testdata", "gemfile", "alpha.gemspec"),
+		filepath.Join("testdata", "locked", "alpha.gemspec"),
+		filepath.Join("testdata", "other", "Gemfile"),
+		filepath.Join("testdata", "other", "other.gemspec"),
+	})
+	jobs, err := s.Invoke()
+
+	assert.NoError(t, err)
+	assert.Len(t, jobs, 2)
+	assert.Equal(t, filepath.Join("testdata", "gemspecs", "alpha.gemspec"), jobs[0].GetFile())
+	assert.Equal(t, filepath.Join("testdata", "other", "Gemfile"), jobs[1].GetFile())
+}
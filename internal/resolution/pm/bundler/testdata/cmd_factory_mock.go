@@ -0,0 +1,23 @@
+package testdata
+
+import (
+	"os/exec"
+)
+
+type CmdFactoryMock struct {
+	LockCmdName string
+	MakeLockErr error
+	Gemfile     string
+}
+
+func NewEchoCmdFactory() *CmdFactoryMock {
+	return &CmdFactoryMock{
+		LockCmdName: "echo",
+	}
+}
+
+func (f *CmdFactoryMock) MakeLockCmd(gemfile string) (*exec.Cmd, error) {
+	f.Gemfile = gemfile
+
+	return exec.Command(f.LockCmdName, gemfile), f.MakeLockErr
+}
@@ -0,0 +1,51 @@
+package cargo
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+)
+
+const cargo = "cargo"
+
+type ICmdFactory interface {
+	MakeGenerateLockfileCmd(file string) (*exec.Cmd, error)
+	MakeMetadataCmd(file string) (*exec.Cmd, error)
+}
+
+type IExecPath interface {
+	LookPath(file string) (string, error)
+}
+
+type ExecPath struct {
+}
+
+func (ExecPath) LookPath(file string) (string, error) {
+	return exec.LookPath(file)
+}
+
+type CmdFactory struct {
+	execPath IExecPath
+}
+
+func (cmdf CmdFactory) MakeGenerateLockfileCmd(file string) (*exec.Cmd, error) {
+	path, err := cmdf.execPath.LookPath(cargo)
+
+	return &exec.Cmd{
+		Path: path,
+		Args: []string{cargo, "generate-lockfile", "--manifest-path", filepath.Base(file)},
+		Dir:  filepath.Dir(file),
+		Env:  os.Environ(),
+	}, err
+}
+
+func (cmdf CmdFactory) MakeMetadataCmd(file string) (*exec.Cmd, error) {
+	path, err := cmdf.execPath.LookPath(cargo)
+
+	return &exec.Cmd{
+		Path: path,
+		Args: []string{cargo, "metadata", "--format-version", "1", "--locked", "--manifest-path", filepath.Base(file)},
+		Dir:  filepath.Dir(file),
+		Env:  os.Environ(),
+	}, err
+}
@@ -0,0 +1,31 @@
+package cargo
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type execPathMock struct{}
+
+func (execPathMock) LookPath(file string) (string, error) {
+	return file, nil
+}
+
+func TestMakeGenerateLockfileCmd(t *testing.T) {
+	cmd, err := CmdFactory{execPath: execPathMock{}}.MakeGenerateLockfileCmd(filepath.Join("dir", "Cargo.toml"))
+	assert.NoError(t, err)
+	assert.NotNil(t, cmd)
+	assert.Equal(t, []string{"cargo", "generate-lockfile", "--manifest-path", "Cargo.toml"}, cmd.Args)
+	assert.Equal(t, "dir", cmd.Dir)
+}
+
+func TestMakeMetadataCmd(t *testing.T) {
+	cmd, err := CmdFactory{execPath: execPathMock{}}.MakeMetadataCmd(filepath.Join("dir", "Cargo.toml"))
+	assert.NoError(t, err)
+	assert.NotNil(t, cmd)
+	assert.Contains(t, cmd.Args, "metadata")
+	assert.Contains(t, cmd.Args, "--format-version")
+	assert.Equal(t, "dir", cmd.Dir)
+}
@@ -0,0 +1,201 @@
+package cargo
+
+import (
+	"os/exec"
+	"regexp"
+	"strings"
+
+	"github.com/debricked/cli/internal/resolution/job"
+	"github.com/debricked/cli/internal/resolution/pm/util"
+	"github.com/debricked/cli/internal/resolution/pm/writer"
+)
+
+const (
+	lockFileName                    = "cargo.debricked.lock"
+	cargoExecutableNotFoundErrRegex = `"cargo": executable file not found`
+	toolchainNotInstalledErrRegex   = `toolchain '([^']+)' is not installed`
+	noTokenErrRegex                 = "no token found for `([^`]+)`"
+	unauthorizedErrRegex            = "failed to get successful HTTP response from `([^`]+)`.*got 40[13]"
+	noMatchingPackageErrRegex       = "no matching package named `([^`]+)` found"
+	versionNotFoundErrRegex         = "failed to select a version for the requirement `([^`]+)`"
+	offlineErrRegex                 = `Couldn't resolve host name|spurious network error|failed to download from|--offline was specified`
+)
+
+type Job struct {
+	job.BaseJob
+	cmdFactory ICmdFactory
+	fileWriter writer.IFileWriter
+}
+
+func NewJob(
+	file string,
+	cmdFactory ICmdFactory,
+	fileWriter writer.IFileWriter,
+) *Job {
+	return &Job{
+		BaseJob:    job.NewBaseJob(file),
+		cmdFactory: cmdFactory,
+		fileWriter: fileWriter,
+	}
+}
+
+func (j *Job) Run() {
+	status := "generating Cargo.lock"
+	j.SendStatus(status)
+	_, cmd, err := j.runCmd(j.cmdFactory.MakeGenerateLockfileCmd(j.GetFile()))
+	if err != nil {
+		j.handleError(j.createError(err.Error(), cmd, status))
+
+		return
+	}
+
+	status = "creating dependency graph"
+	j.SendStatus(status)
+	metadataOutput, cmd, err := j.runCmd(j.cmdFactory.MakeMetadataCmd(j.GetFile()))
+	if err != nil {
+		j.handleError(j.createError(err.Error(), cmd, status))
+
+		return
+	}
+
+	status = "creating lock file"
+	j.SendStatus(status)
+	lockFile, err := j.fileWriter.Create(j.LockFilePath(util.MakePathFromManifestFile(j.GetFile(), lockFileName)))
+	if err != nil {
+		j.handleError(j.createError(err.Error(), cmd, status))
+
+		return
+	}
+	defer util.CloseFile(j, j.fileWriter, lockFile)
+
+	err = j.fileWriter.Write(lockFile, metadataOutput)
+	if err != nil {
+		j.handleError(j.createError(err.Error(), cmd, status))
+	}
+}
+
+// Plan returns the commands and lock files of the job, without running it
+func (j *Job) Plan() job.Plan {
+	var plan job.Plan
+	plan.AddCommand(j.cmdFactory.MakeGenerateLockfileCmd(j.GetFile()))
+	plan.AddCommand(j.cmdFactory.MakeMetadataCmd(j.GetFile()))
+	plan.AddLockFile(util.MakePathFromManifestFile(j.GetFile(), "Cargo.lock"))
+	plan.AddLockFile(util.MakePathFromManifestFile(j.GetFile(), lockFileName))
+
+	return plan
+}
+
+func (j *Job) runCmd(cmd *exec.Cmd, err error) ([]byte, string, error) {
+	if err != nil {
+		if cmd == nil {
+			return nil, "", err
+		}
+
+		return nil, cmd.String(), err
+	}
+
+	output, err := j.Output(cmd)
+	if err != nil {
+		return nil, cmd.String(), j.GetExitError(err, string(output))
+	}
+
+	return output, cmd.String(), nil
+}
+
+func (j *Job) createError(error string, cmd string, status string) job.IError {
+	cmdError := util.NewPMJobError(error)
+	cmdError.SetCommand(cmd)
+	cmdError.SetStatus(status)
+
+	return cmdError
+}
+
+func (j *Job) handleError(cmdError job.IError) {
+	expressions := []string{
+		cargoExecutableNotFoundErrRegex,
+		toolchainNotInstalledErrRegex,
+		noTokenErrRegex,
+		unauthorizedErrRegex,
+		noMatchingPackageErrRegex,
+		versionNotFoundErrRegex,
+		offlineErrRegex,
+	}
+
+	for _, expression := range expressions {
+		regex := regexp.MustCompile(expression)
+		matches := regex.FindAllStringSubmatch(cmdError.Error(), -1)
+
+		if len(matches) > 0 {
+			cmdError = j.addDocumentation(expression, matches, cmdError)
+			j.Errors().Append(cmdError)
+
+			return
+		}
+	}
+
+	j.Errors().Append(cmdError)
+}
+
+func (j *Job) addDocumentation(expr string, matches [][]string, cmdError job.IError) job.IError {
+	documentation := cmdError.Documentation()
+
+	switch expr {
+	case cargoExecutableNotFoundErrRegex:
+		documentation = j.GetExecutableNotFoundErrorDocumentation("Cargo")
+	case toolchainNotInstalledErrRegex:
+		documentation = j.getToolchainNotInstalledErrorDocumentation(matches)
+	case noTokenErrRegex, unauthorizedErrRegex:
+		documentation = j.getRegistryAuthErrorDocumentation(matches)
+	case noMatchingPackageErrRegex, versionNotFoundErrRegex:
+		documentation = j.getPackageNotFoundErrorDocumentation(matches)
+	case offlineErrRegex:
+		documentation = j.getOfflineErrorDocumentation()
+	}
+
+	cmdError.SetDocumentation(documentation)
+
+	return cmdError
+}
+
+func firstMatch(matches [][]string) string {
+	if len(matches) > 0 && len(matches[0]) > 1 {
+		return matches[0][1]
+	}
+
+	return ""
+}
+
+func (j *Job) getToolchainNotInstalledErrorDocumentation(matches [][]string) string {
+	toolchain := firstMatch(matches)
+
+	return strings.Join(
+		[]string{
+			"Rust toolchain \"" + toolchain + "\" isn't installed.",
+			"Please install it, for example by running \"rustup toolchain install " + toolchain + "\".",
+		}, " ")
+}
+
+func (j *Job) getRegistryAuthErrorDocumentation(matches [][]string) string {
+	return strings.Join(
+		[]string{
+			"Failed to authenticate to Cargo registry \"" + firstMatch(matches) + "\".",
+			"Please make sure that the CLI has access to the registry, for example by running \"cargo login\" or setting CARGO_REGISTRIES_<name>_TOKEN.",
+		}, " ")
+}
+
+func (j *Job) getPackageNotFoundErrorDocumentation(matches [][]string) string {
+	return strings.Join(
+		[]string{
+			"Failed to find package \"" + firstMatch(matches) + "\" that satisfies the requirements.",
+			"Please check that dependencies are correct in Cargo.toml.",
+			"\n" + util.InstallPrivateDependencyMessage,
+		}, " ")
+}
+
+func (j *Job) getOfflineErrorDocumentation() string {
+	return strings.Join(
+		[]string{
+			"We weren't able to retrieve one or more dependencies.",
+			"Please check your Internet connection and try again.",
+		}, " ")
+}
@@ -0,0 +1,150 @@
+package cargo
+
+import (
+	"errors"
+	"testing"
+
+	jobTestdata "github.com/debricked/cli/internal/resolution/job/testdata"
+	"github.com/debricked/cli/internal/resolution/pm/cargo/testdata"
+	"github.com/debricked/cli/internal/resolution/pm/util"
+	"github.com/debricked/cli/internal/resolution/pm/writer"
+	writerTestdata "github.com/debricked/cli/internal/resolution/pm/writer/testdata"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewJob(t *testing.T) {
+	j := NewJob("file", CmdFactory{execPath: ExecPath{}}, writer.FileWriter{})
+	assert.Equal(t, "file", j.GetFile())
+	assert.False(t, j.Errors().HasError())
+}
+
+func TestRun(t *testing.T) {
+	fileWriterMock := &writerTestdata.FileWriterMock{}
+	j := NewJob("file", testdata.NewEchoCmdFactory(), fileWriterMock)
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	assert.False(t, j.Errors().HasError())
+	assert.Equal(t, "{\"packages\":[],\"resolve\":{\"nodes\":[]}}\n", string(fileWriterMock.Contents))
+}
+
+func TestRunGenerateLockfileCmdErr(t *testing.T) {
+	cases := []struct {
+		name  string
+		error string
+		doc   string
+	}{
+		{
+			name:  "General error",
+			error: "cmd-error",
+			doc:   util.UnknownError,
+		},
+		{
+			name:  "Cargo not found",
+			error: "exec: \"cargo\": executable file not found in $PATH",
+			doc:   "Cargo wasn't found. Please check if it is installed and accessible by the CLI.",
+		},
+		{
+			name:  "Toolchain not installed",
+			error: "error: toolchain '1.70.0-x86_64-unknown-linux-gnu' is not installed",
+			doc:   "Rust toolchain \"1.70.0-x86_64-unknown-linux-gnu\" isn't installed. Please install it, for example by running \"rustup toolchain install 1.70.0-x86_64-unknown-linux-gnu\".",
+		},
+		{
+			name:  "No registry token",
+			error: "error: no token found for `my-registry`, please run `cargo login --registry my-registry`",
+			doc:   "Failed to authenticate to Cargo registry \"my-registry\". Please make sure that the CLI has access to the registry, for example by running \"cargo login\" or setting CARGO_REGISTRIES_<name>_TOKEN.",
+		},
+		{
+			name:  "Unauthorized",
+			error: "error: failed to get successful HTTP response from `https://crates.example.com/index/config.json` (10.0.0.1), got 401",
+			doc:   "Failed to authenticate to Cargo registry \"https://crates.example.com/index/config.json\". Please make sure that the CLI has access to the registry, for example by running \"cargo login\" or setting CARGO_REGISTRIES_<name>_TOKEN.",
+		},
+		{
+			name:  "Package not found",
+			error: "error: no matching package named `serde_jsonx` found\nlocation searched: registry `crates-io`",
+			doc:   "Failed to find package \"serde_jsonx\" that satisfies the requirements. Please check that dependencies are correct in Cargo.toml. \n" + util.InstallPrivateDependencyMessage,
+		},
+		{
+			name:  "Version not found",
+			error: "error: failed to select a version for the requirement `serde = \"^99\"`",
+			doc:   "Failed to find package \"serde = \"^99\"\" that satisfies the requirements. Please check that dependencies are correct in Cargo.toml. \n" + util.InstallPrivateDependencyMessage,
+		},
+		{
+			name:  "Offline",
+			error: "warning: spurious network error (3 tries remaining): [6] Couldn't resolve host name",
+			doc:   "We weren't able to retrieve one or more dependencies. Please check your Internet connection and try again.",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cmdFactoryMock := testdata.NewEchoCmdFactory()
+			cmdFactoryMock.MakeGenerateLockfileErr = errors.New(c.error)
+			cmd, _ := cmdFactoryMock.MakeGenerateLockfileCmd("file")
+			j := NewJob("file", cmdFactoryMock, &writerTestdata.FileWriterMock{})
+
+			expectedError := util.NewPMJobError(c.error)
+			expectedError.SetDocumentation(c.doc)
+			expectedError.SetStatus("generating Cargo.lock")
+			expectedError.SetCommand(cmd.String())
+
+			go jobTestdata.WaitStatus(j)
+			j.Run()
+
+			allErrors := j.Errors().GetAll()
+			assert.Len(t, allErrors, 1)
+			assert.Contains(t, allErrors, expectedError)
+		})
+	}
+}
+
+func TestRunMetadataCmdOutputErr(t *testing.T) {
+	cmdFactoryMock := testdata.NewEchoCmdFactory()
+	cmdFactoryMock.MetadataCmdName = "bad-name"
+	j := NewJob("file", cmdFactoryMock, &writerTestdata.FileWriterMock{})
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	jobTestdata.AssertPathErr(t, j.Errors())
+}
+
+func TestRunCreateErr(t *testing.T) {
+	createErr := errors.New("create-error")
+	fileWriterMock := &writerTestdata.FileWriterMock{CreateErr: createErr}
+	j := NewJob("file", testdata.NewEchoCmdFactory(), fileWriterMock)
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	errs := j.Errors().GetAll()
+	assert.Len(t, errs, 1)
+	assert.ErrorContains(t, errs[0], createErr.Error())
+	assert.Equal(t, "creating lock file", errs[0].Status())
+}
+
+func TestRunWriteErr(t *testing.T) {
+	writeErr := errors.New("write-error")
+	fileWriterMock := &writerTestdata.FileWriterMock{WriteErr: writeErr}
+	j := NewJob("file", testdata.NewEchoCmdFactory(), fileWriterMock)
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	errs := j.Errors().GetAll()
+	assert.Len(t, errs, 1)
+	assert.ErrorContains(t, errs[0], writeErr.Error())
+}
+
+func TestRunCloseErr(t *testing.T) {
+	closeErr := errors.New("close-error")
+	fileWriterMock := &writerTestdata.FileWriterMock{CloseErr: closeErr}
+	j := NewJob("file", testdata.NewEchoCmdFactory(), fileWriterMock)
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	assert.Len(t, j.Errors().GetAll(), 1)
+	assert.Contains(t, j.Errors().GetAll(), util.NewPMJobError(closeErr.Error()))
+}
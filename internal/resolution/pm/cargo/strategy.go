@@ -0,0 +1,52 @@
+package cargo
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/debricked/cli/internal/resolution/job"
+	"github.com/debricked/cli/internal/resolution/pm/writer"
+)
+
+const cargoLockFile = "Cargo.lock"
+
+type Strategy struct {
+	files                     []string
+	regenerateNativeLockFiles bool
+}
+
+// Invoke makes one job per workspace, since workspace members share the Cargo.lock of the workspace root.
+// Workspaces that already have a Cargo.lock are left out, unless native lock files are regenerated
+func (s Strategy) Invoke() ([]job.IJob, error) {
+	var jobs []job.IJob
+	roots := map[string]bool{}
+	for _, file := range s.files {
+		root := findWorkspaceRoot(filepath.Clean(file))
+		if roots[root] {
+			continue
+		}
+		if !s.regenerateNativeLockFiles && hasLockFile(root) {
+			continue
+		}
+		roots[root] = true
+		jobs = append(jobs, NewJob(
+			root,
+			CmdFactory{
+				execPath: ExecPath{},
+			},
+			writer.FileWriter{},
+		))
+	}
+
+	return jobs, nil
+}
+
+func NewStrategy(files []string, regenerateNativeLockFiles bool) Strategy {
+	return Strategy{files, regenerateNativeLockFiles}
+}
+
+func hasLockFile(file string) bool {
+	_, err := os.Stat(filepath.Join(filepath.Dir(file), cargoLockFile))
+
+	return err == nil
+}
@@ -0,0 +1,62 @@
+package cargo
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewStrategy(t *testing.T) {
+	s := NewStrategy(nil, false)
+	assert.NotNil(t, s)
+	assert.Len(t, s.files, 0)
+
+	s = NewStrategy([]string{"file-1", "file-2"}, false)
+	assert.NotNil(t, s)
+	assert.Len(t, s.files, 2)
+}
+
+func TestInvokeNoFiles(t *testing.T) {
+	s := NewStrategy([]string{}, false)
+	jobs, _ := s.Invoke()
+	assert.Empty(t, jobs)
+}
+
+func TestInvokeManyFiles(t *testing.T) {
+	s := NewStrategy([]string{"file-1", "file-2"}, false)
+	jobs, _ := s.Invoke()
+	assert.Len(t, jobs, 2)
+}
+
+func TestInvokeWorkspace(t *testing.T) {
+	s := NewStrategy([]string{
+		filepath.Join("testdata", "workspace", "crates", "core", "Cargo.toml"),
+		filepath.Join("testdata", "workspace", "Cargo.toml"),
+		filepath.Join("testdata", "locked", "member", "Cargo.toml"),
+		filepath.Join("testdata", "pointer", "nested", "app", "Cargo.toml"),
+	}, false)
+	jobs, err := s.Invoke()
+
+	assert.NoError(t, err)
+	assert.Len(t, jobs, 2)
+	assert.Equal(t, filepath.Join("testdata", "workspace", "Cargo.toml"), jobs[0].GetFile())
+	assert.Equal(t, filepath.Join("testdata", "pointer", "Cargo.toml"), jobs[1].GetFile())
+}
+
+func TestInvokeWorkspaceLockFileExists(t *testing.T) {
+	files := []string{
+		filepath.Join("testdata", "locked", "Cargo.toml"),
+		filepath.Join("testdata", "locked", "member", "Cargo.toml"),
+	}
+	s := NewStrategy(files, false)
+	jobs, err := s.Invoke()
+	assert.NoError(t, err)
+	assert.Empty(t, jobs)
+
+	s = NewStrategy(files, true)
+	jobs, err = s.Invoke()
+	assert.NoError(t, err)
+	assert.Len(t, jobs, 1)
+	assert.Equal(t, filepath.Join("testdata", "locked", "Cargo.toml"), jobs[0].GetFile())
+}
@@ -0,0 +1,27 @@
+package testdata
+
+import (
+	"os/exec"
+)
+
+type CmdFactoryMock struct {
+	GenerateLockfileCmdName string
+	MakeGenerateLockfileErr error
+	MetadataCmdName         string
+	MakeMetadataErr         error
+}
+
+func NewEchoCmdFactory() CmdFactoryMock {
+	return CmdFactoryMock{
+		GenerateLockfileCmdName: "echo",
+		MetadataCmdName:         "echo",
+	}
+}
+
+func (f CmdFactoryMock) MakeGenerateLockfileCmd(_ string) (*exec.Cmd, error) {
+	return exec.Command(f.GenerateLockfileCmdName, "generate-lockfile"), f.MakeGenerateLockfileErr
+}
+
+func (f CmdFactoryMock) MakeMetadataCmd(_ string) (*exec.Cmd, error) {
+	return exec.Command(f.MetadataCmdName, `{"packages":[],"resolve":{"nodes":[]}}`), f.MakeMetadataErr
+}
@@ -0,0 +1,93 @@
+package cargo
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/bmatcuk/doublestar/v4"
+	"github.com/pelletier/go-toml/v2"
+)
+
+const manifestFile = "Cargo.toml"
+
+type cargoToml struct {
+	Package *struct {
+		Workspace string `toml:"workspace"`
+	} `toml:"package"`
+	Workspace *struct {
+		Members []string `toml:"members"`
+		Exclude []string `toml:"exclude"`
+	} `toml:"workspace"`
+}
+
+func readCargoToml(file string) (*cargoToml, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var manifest cargoToml
+	err = toml.Unmarshal(content, &manifest)
+
+	return &manifest, err
+}
+
+// findWorkspaceRoot returns the manifest of the workspace the package belongs to, or the manifest itself if it is not a workspace member.
+// Like Cargo, the workspace root is either set by package.workspace, or is the closest parent manifest with a workspace table including the package
+func findWorkspaceRoot(file string) string {
+	manifest, err := readCargoToml(file)
+	if err != nil || manifest.Workspace != nil {
+		return file
+	}
+	dir := filepath.Dir(file)
+	if manifest.Package != nil && len(manifest.Package.Workspace) > 0 {
+		return filepath.Join(dir, filepath.FromSlash(manifest.Package.Workspace), manifestFile)
+	}
+
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return file
+	}
+	for parent := filepath.Dir(absDir); parent != filepath.Dir(parent); parent = filepath.Dir(parent) {
+		parentFile := filepath.Join(parent, manifestFile)
+		parentManifest, err := readCargoToml(parentFile)
+		if err != nil || parentManifest.Workspace == nil {
+			continue
+		}
+		member, err := filepath.Rel(parent, absDir)
+		if err != nil {
+			return file
+		}
+		if isWorkspaceMember(filepath.ToSlash(member), parentManifest.Workspace.Members, parentManifest.Workspace.Exclude) {
+			rel, err := filepath.Rel(absDir, parent)
+			if err != nil {
+				return file
+			}
+
+			return filepath.Join(dir, rel, manifestFile)
+		}
+	}
+
+	return file
+}
+
+func isWorkspaceMember(member string, members []string, exclude []string) bool {
+	for _, pattern := range exclude {
+		if matchMember(pattern, member) {
+			return false
+		}
+	}
+	for _, pattern := range members {
+		if matchMember(pattern, member) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchMember(pattern string, member string) bool {
+	matched, _ := doublestar.Match(path.Clean(pattern), member)
+
+	return matched
+}
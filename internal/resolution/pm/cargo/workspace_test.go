@@ -0,0 +1,34 @@
+package cargo
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindWorkspaceRoot(t *testing.T) {
+	workspaceRoot := filepath.Join("testdata", "workspace", "Cargo.toml")
+	cases := map[string]string{
+		workspaceRoot: workspaceRoot,
+		filepath.Join("testdata", "workspace", "crates", "core", "Cargo.toml"):     workspaceRoot,
+		filepath.Join("testdata", "workspace", "crates", "excluded", "Cargo.toml"): filepath.Join("testdata", "workspace", "crates", "excluded", "Cargo.toml"),
+		filepath.Join("testdata", "workspace", "tools", "cli", "Cargo.toml"):       filepath.Join("testdata", "workspace", "tools", "cli", "Cargo.toml"),
+		filepath.Join("testdata", "standalone", "Cargo.toml"):                      filepath.Join("testdata", "standalone", "Cargo.toml"),
+		filepath.Join("testdata", "pointer", "nested", "app", "Cargo.toml"):        filepath.Join("testdata", "pointer", "Cargo.toml"),
+		filepath.Join("testdata", "missing", "Cargo.toml"):                         filepath.Join("testdata", "missing", "Cargo.toml"),
+	}
+
+	for file, root := range cases {
+		t.Run(file, func(t *testing.T) {
+			assert.Equal(t, root, findWorkspaceRoot(file))
+		})
+	}
+}
+
+func TestIsWorkspaceMember(t *testing.T) {
+	assert.True(t, isWorkspaceMember("crates/core", []string{"crates/*"}, nil))
+	assert.True(t, isWorkspaceMember("crates/core", []string{"./crates/core"}, nil))
+	assert.False(t, isWorkspaceMember("crates/core", []string{"crates/*"}, []string{"crates/core"}))
+	assert.False(t, isWorkspaceMember("tools/cli", []string{"crates/*"}, nil))
+}
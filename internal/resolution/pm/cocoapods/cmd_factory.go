@@ -0,0 +1,44 @@
+package cocoapods
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+)
+
+const pod = "pod"
+
+type ICmdFactory interface {
+	MakeInstallCmd(podfile string) (*exec.Cmd, error)
+}
+
+type IExecPath interface {
+	LookPath(file string) (string, error)
+}
+
+type ExecPath struct {
+}
+
+func (ExecPath) LookPath(file string) (string, error) {
+	return exec.LookPath(file)
+}
+
+type CmdFactory struct {
+	execPath IExecPath
+}
+
+// MakeInstallCmd makes a command resolving the Podfile into a Podfile.lock, using the local spec repos as they are
+func (cmdf CmdFactory) MakeInstallCmd(podfile string) (*exec.Cmd, error) {
+	path, err := cmdf.execPath.LookPath(pod)
+
+	return &exec.Cmd{
+		Path: path,
+		Args: []string{pod, "install", "--no-repo-update", "--no-ansi"},
+		Dir:  filepath.Dir(podfile),
+		Env: append(
+			os.Environ(),
+			"COCOAPODS_DISABLE_STATS=true", // Don't send install statistics
+			"LANG=en_US.UTF-8",             // CocoaPods refuses to run without a UTF-8 locale
+		),
+	}, err
+}
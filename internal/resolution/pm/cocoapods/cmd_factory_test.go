@@ -0,0 +1,30 @@
+package cocoapods
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type execPathMock struct {
+	path string
+}
+
+func (e execPathMock) LookPath(file string) (string, error) {
+	if len(e.path) > 0 {
+		return e.path, nil
+	}
+
+	return file, nil
+}
+
+func TestMakeInstallCmd(t *testing.T) {
+	cmd, err := CmdFactory{execPath: execPathMock{}}.MakeInstallCmd(filepath.Join("dir", "Podfile"))
+	assert.NoError(t, err)
+	assert.NotNil(t, cmd)
+	assert.Equal(t, []string{"pod", "install", "--no-repo-update", "--no-ansi"}, cmd.Args)
+	assert.Equal(t, "dir", cmd.Dir)
+	assert.Contains(t, cmd.Env, "COCOAPODS_DISABLE_STATS=true")
+	assert.Contains(t, cmd.Env, "LANG=en_US.UTF-8")
+}
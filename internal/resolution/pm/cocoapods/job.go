@@ -0,0 +1,174 @@
+package cocoapods
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/debricked/cli/internal/resolution/job"
+	"github.com/debricked/cli/internal/resolution/pm/util"
+)
+
+const (
+	podExecutableNotFoundErrRegex = `"pod": executable file not found`
+	specNotFoundErrRegex          = "Unable to find a specification for `([^`]+)`"
+	incompatibleVersionsErrRegex  = "CocoaPods could not find compatible versions for pod \"([^\"]+)\""
+	xcodeProjectErrRegex          = `Could not automatically select an Xcode project|No Xcode project found`
+	authenticationErrRegex        = `could not read Username for '([^']+)'|Authentication failed for '([^']+)'`
+	networkErrRegex               = `Failed to connect to ([^\s]+)|Could not resolve host: ([^\s]+)`
+)
+
+type Job struct {
+	job.BaseJob
+	cmdFactory ICmdFactory
+}
+
+func NewJob(
+	file string,
+	cmdFactory ICmdFactory,
+) *Job {
+	return &Job{
+		BaseJob:    job.NewBaseJob(file),
+		cmdFactory: cmdFactory,
+	}
+}
+
+func (j *Job) Run() {
+	status := "installing pods"
+	j.SendStatus(status)
+	installCmd, err := j.cmdFactory.MakeInstallCmd(j.GetFile())
+	if err != nil {
+		cmdErr := util.NewPMJobError(err.Error())
+		cmdErr.SetStatus(status)
+		if installCmd != nil {
+			cmdErr.SetCommand(installCmd.String())
+		}
+		j.handleError(cmdErr)
+
+		return
+	}
+
+	output, err := j.Output(installCmd)
+	if err != nil {
+		// CocoaPods prints most errors to stdout, with only the exit status telling that it failed
+		cmdErr := util.NewPMJobError(j.GetExitError(err, string(output)).Error())
+		cmdErr.SetStatus(status)
+		cmdErr.SetCommand(installCmd.String())
+		j.handleError(cmdErr)
+	}
+}
+
+// Plan returns the command and lock file of the job, without running it
+func (j *Job) Plan() job.Plan {
+	var plan job.Plan
+	plan.AddCommand(j.cmdFactory.MakeInstallCmd(j.GetFile()))
+	plan.AddLockFile(util.MakePathFromManifestFile(j.GetFile(), "Podfile.lock"))
+
+	return plan
+}
+
+func (j *Job) handleError(cmdError job.IError) {
+	expressions := []string{
+		podExecutableNotFoundErrRegex,
+		specNotFoundErrRegex,
+		incompatibleVersionsErrRegex,
+		xcodeProjectErrRegex,
+		authenticationErrRegex,
+		networkErrRegex,
+	}
+
+	for _, expression := range expressions {
+		regex := regexp.MustCompile(expression)
+		matches := regex.FindAllStringSubmatch(cmdError.Error(), -1)
+
+		if len(matches) > 0 {
+			cmdError = j.addDocumentation(expression, matches, cmdError)
+			j.Errors().Append(cmdError)
+
+			return
+		}
+	}
+
+	j.Errors().Append(cmdError)
+}
+
+func (j *Job) addDocumentation(expr string, matches [][]string, cmdError job.IError) job.IError {
+	documentation := cmdError.Documentation()
+
+	switch expr {
+	case podExecutableNotFoundErrRegex:
+		documentation = j.GetExecutableNotFoundErrorDocumentation("CocoaPods")
+	case specNotFoundErrRegex:
+		documentation = j.getSpecNotFoundErrorDocumentation(matches)
+	case incompatibleVersionsErrRegex:
+		documentation = j.getIncompatibleVersionsErrorDocumentation(matches)
+	case xcodeProjectErrRegex:
+		documentation = j.getXcodeProjectErrorDocumentation()
+	case authenticationErrRegex:
+		documentation = j.getAuthenticationErrorDocumentation(matches)
+	case networkErrRegex:
+		documentation = j.getNetworkErrorDocumentation()
+	}
+
+	cmdError.SetDocumentation(documentation)
+
+	return cmdError
+}
+
+// firstMatch returns the first non-empty group of the first match, since alternatives have a group each
+func firstMatch(matches [][]string) string {
+	if len(matches) == 0 {
+		return ""
+	}
+	for _, group := range matches[0][1:] {
+		if len(group) > 0 {
+			return group
+		}
+	}
+
+	return ""
+}
+
+func (j *Job) getSpecNotFoundErrorDocumentation(matches [][]string) string {
+	return strings.Join(
+		[]string{
+			"Failed to find a specification for pod \"" + firstMatch(matches) + "\" in the local spec repos.",
+			"Since the spec repos aren't updated by the CLI, please run \"pod repo update\" before running the CLI,",
+			"or check that the pod exists and is spelt correctly.",
+			"\n" + util.InstallPrivateDependencyMessage,
+		}, " ")
+}
+
+func (j *Job) getIncompatibleVersionsErrorDocumentation(matches [][]string) string {
+	return strings.Join(
+		[]string{
+			"Failed to find compatible versions of pod \"" + firstMatch(matches) + "\".",
+			"Please check that the version requirements in the Podfile can be satisfied together,",
+			"or run \"pod repo update\" before running the CLI if the required versions are newer than the local spec repos.",
+		}, " ")
+}
+
+func (j *Job) getXcodeProjectErrorDocumentation() string {
+	return strings.Join(
+		[]string{
+			"Failed to find the Xcode project of the Podfile.",
+			"Please make sure that the Xcode project is in the directory of the Podfile,",
+			"or specify it with the project directive of the Podfile.",
+		}, " ")
+}
+
+func (j *Job) getAuthenticationErrorDocumentation(matches [][]string) string {
+	return strings.Join(
+		[]string{
+			"Failed to authenticate to repository \"" + firstMatch(matches) + "\".",
+			"Please make sure that the CLI has access to the repository, for example by configuring git credentials.",
+			"\n" + util.InstallPrivateDependencyMessage,
+		}, " ")
+}
+
+func (j *Job) getNetworkErrorDocumentation() string {
+	return strings.Join(
+		[]string{
+			"We weren't able to retrieve one or more dependencies.",
+			"Please check your Internet connection and try again.",
+		}, " ")
+}
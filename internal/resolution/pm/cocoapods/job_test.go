@@ -0,0 +1,143 @@
+package cocoapods
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	jobTestdata "github.com/debricked/cli/internal/resolution/job/testdata"
+	"github.com/debricked/cli/internal/resolution/pm/cocoapods/testdata"
+	"github.com/debricked/cli/internal/resolution/pm/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewJob(t *testing.T) {
+	j := NewJob("file", CmdFactory{execPath: ExecPath{}})
+	assert.Equal(t, "file", j.GetFile())
+	assert.False(t, j.Errors().HasError())
+}
+
+func TestRun(t *testing.T) {
+	j := NewJob("Podfile", testdata.NewEchoCmdFactory())
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	assert.False(t, j.Errors().HasError())
+}
+
+// writeFakePod writes a fake pod executable running script, since CocoaPods isn't available everywhere
+func writeFakePod(t *testing.T, script string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake executables are shell scripts")
+	}
+	fakePod := filepath.Join(t.TempDir(), "pod")
+	assert.NoError(t, os.WriteFile(fakePod, []byte("#!/bin/sh\n"+script+"\n"), 0700)) // #nosec G306
+
+	return fakePod
+}
+
+func TestRunFakePod(t *testing.T) {
+	fakePod := writeFakePod(t, `[ "$1 $2" = "install --no-repo-update" ] || exit 1
+printf 'PODS:\n  - Alamofire (5.8.1)\n' > Podfile.lock`)
+	dir := t.TempDir()
+	j := NewJob(filepath.Join(dir, "Podfile"), CmdFactory{execPath: execPathMock{path: fakePod}})
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	assert.False(t, j.Errors().HasError())
+	assert.FileExists(t, filepath.Join(dir, "Podfile.lock"))
+}
+
+func TestRunFakePodErr(t *testing.T) {
+	fakePod := writeFakePod(t, "echo '[!] Unable to find a specification for `Alamofier`'\nexit 1")
+	j := NewJob(filepath.Join(t.TempDir(), "Podfile"), CmdFactory{execPath: execPathMock{path: fakePod}})
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	errs := j.Errors().GetAll()
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "installing pods", errs[0].Status())
+	assert.Contains(t, errs[0].Documentation(), "Failed to find a specification for pod \"Alamofier\"")
+}
+
+func TestRunInstallCmdOutputErr(t *testing.T) {
+	cmdFactoryMock := testdata.NewEchoCmdFactory()
+	cmdFactoryMock.InstallCmdName = "bad-name"
+	j := NewJob("Podfile", cmdFactoryMock)
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	jobTestdata.AssertPathErr(t, j.Errors())
+}
+
+func TestRunInstallCmdErr(t *testing.T) {
+	cases := []struct {
+		name  string
+		error string
+		doc   string
+	}{
+		{
+			name:  "General error",
+			error: "cmd-error",
+			doc:   util.UnknownError,
+		},
+		{
+			name:  "CocoaPods not found",
+			error: "exec: \"pod\": executable file not found in $PATH",
+			doc:   "CocoaPods wasn't found. Please check if it is installed and accessible by the CLI.",
+		},
+		{
+			name:  "Specification not found",
+			error: "[!] Unable to find a specification for `Alamofier`",
+			doc:   "Failed to find a specification for pod \"Alamofier\" in the local spec repos. Since the spec repos aren't updated by the CLI, please run \"pod repo update\" before running the CLI, or check that the pod exists and is spelt correctly. \n" + util.InstallPrivateDependencyMessage,
+		},
+		{
+			name:  "Incompatible versions",
+			error: "[!] CocoaPods could not find compatible versions for pod \"Alamofire\":\n  In Podfile:\n    Alamofire (~> 9.0)",
+			doc:   "Failed to find compatible versions of pod \"Alamofire\". Please check that the version requirements in the Podfile can be satisfied together, or run \"pod repo update\" before running the CLI if the required versions are newer than the local spec repos.",
+		},
+		{
+			name:  "Xcode project",
+			error: "[!] Could not automatically select an Xcode project. Specify one in your Podfile like so:",
+			doc:   "Failed to find the Xcode project of the Podfile. Please make sure that the Xcode project is in the directory of the Podfile, or specify it with the project directive of the Podfile.",
+		},
+		{
+			name:  "Authentication",
+			error: "[!] Error installing Private\n fatal: could not read Username for 'https://github.com': terminal prompts disabled",
+			doc:   "Failed to authenticate to repository \"https://github.com\". Please make sure that the CLI has access to the repository, for example by configuring git credentials. \n" + util.InstallPrivateDependencyMessage,
+		},
+		{
+			name:  "No network",
+			error: "[!] Error installing Alamofire\n fatal: unable to access 'https://github.com/Alamofire/Alamofire.git/': Could not resolve host: github.com",
+			doc:   "We weren't able to retrieve one or more dependencies. Please check your Internet connection and try again.",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cmdFactoryMock := testdata.NewEchoCmdFactory()
+			cmdFactoryMock.MakeInstallErr = errors.New(c.error)
+			cmd, _ := cmdFactoryMock.MakeInstallCmd("Podfile")
+			j := NewJob("Podfile", cmdFactoryMock)
+
+			expectedError := util.NewPMJobError(c.error)
+			expectedError.SetDocumentation(c.doc)
+			expectedError.SetStatus("installing pods")
+			expectedError.SetCommand(cmd.String())
+
+			go jobTestdata.WaitStatus(j)
+			j.Run()
+
+			errs := j.Errors().GetAll()
+			assert.Len(t, errs, 1)
+			assert.Contains(t, errs, expectedError)
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package cocoapods
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPm(t *testing.T) {
+	pm := NewPm()
+	assert.Equal(t, Name, pm.name)
+}
+
+func TestName(t *testing.T) {
+	pm := NewPm()
+	assert.Equal(t, Name, pm.Name())
+}
+
+func TestManifests(t *testing.T) {
+	pm := Pm{}
+	manifests := pm.Manifests()
+	assert.Len(t, manifests, 1)
+	assert.Equal(t, `^Podfile$`, manifests[0])
+
+	cases := map[string]bool{
+		"Podfile":      true,
+		"Podfile.lock": false,
+		"MyPodfile":    false,
+		"App.podspec":  false,
+	}
+	for file, isMatch := range cases {
+		t.Run(file, func(t *testing.T) {
+			matched, err := regexp.MatchString(manifests[0], file)
+			assert.NoError(t, err)
+			assert.Equal(t, isMatch, matched)
+		})
+	}
+}
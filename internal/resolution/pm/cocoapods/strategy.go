@@ -0,0 +1,44 @@
+package cocoapods
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/debricked/cli/internal/resolution/job"
+)
+
+const podfileLockFile = "Podfile.lock"
+
+type Strategy struct {
+	files                     []string
+	regenerateNativeLockFiles bool
+}
+
+// Invoke makes one job per Podfile. A Podfile which already has a Podfile.lock is left out,
+// unless native lock files are regenerated
+func (s Strategy) Invoke() ([]job.IJob, error) {
+	var jobs []job.IJob
+	for _, file := range s.files {
+		if !s.regenerateNativeLockFiles && hasLockFile(file) {
+			continue
+		}
+		jobs = append(jobs, NewJob(
+			file,
+			CmdFactory{
+				execPath: ExecPath{},
+			},
+		))
+	}
+
+	return jobs, nil
+}
+
+func NewStrategy(files []string, regenerateNativeLockFiles bool) Strategy {
+	return Strategy{files, regenerateNativeLockFiles}
+}
+
+func hasLockFile(file string) bool {
+	_, err := os.Stat(filepath.Join(filepath.Dir(file), podfileLockFile))
+
+	return err == nil
+}
@@ -0,0 +1,50 @@
+package cocoapods
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewStrategy(t *testing.T) {
+	s := NewStrategy(nil, false)
+	assert.NotNil(t, s)
+	assert.Len(t, s.files, 0)
+
+	s = NewStrategy([]string{"file-1", "file-2"}, false)
+	assert.NotNil(t, s)
+	assert.Len(t, s.files, 2)
+}
+
+func TestInvokeNoFiles(t *testing.T) {
+	s := NewStrategy([]string{}, false)
+	jobs, _ := s.Invoke()
+	assert.Empty(t, jobs)
+}
+
+func TestInvokeManyFiles(t *testing.T) {
+	s := NewStrategy([]string{filepath.Join("dir-1", "Podfile"), filepath.Join("dir-2", "Podfile")}, false)
+	jobs, _ := s.Invoke()
+	assert.Len(t, jobs, 2)
+}
+
+func TestInvokeLockFileExists(t *testing.T) {
+	dir := t.TempDir()
+	lockedManifest := filepath.Join(dir, "locked", "Podfile")
+	manifest := filepath.Join(dir, "Podfile")
+	assert.NoError(t, os.MkdirAll(filepath.Dir(lockedManifest), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "locked", podfileLockFile), []byte{}, 0600))
+
+	s := NewStrategy([]string{lockedManifest, manifest}, false)
+	jobs, err := s.Invoke()
+	assert.NoError(t, err)
+	assert.Len(t, jobs, 1)
+	assert.Equal(t, manifest, jobs[0].GetFile())
+
+	s = NewStrategy([]string{lockedManifest, manifest}, true)
+	jobs, err = s.Invoke()
+	assert.NoError(t, err)
+	assert.Len(t, jobs, 2)
+}
@@ -4,6 +4,8 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+
+	"github.com/debricked/cli/internal/resolution/scope"
 )
 
 type ICmdFactory interface {
@@ -22,26 +24,33 @@ func (ExecPath) LookPath(file string) (string, error) {
 }
 
 type CmdFactory struct {
-	execPath IExecPath
+	execPath       IExecPath
+	excludedScopes scope.Scopes
 }
 
+// MakeInstallCmd makes a command updating composer.lock. Excluding the dev scope leaves out the packages of require-dev
 func (cmdf CmdFactory) MakeInstallCmd(command string, file string) (*exec.Cmd, error) {
 	path, err := cmdf.execPath.LookPath(command)
 
 	fileDir := filepath.Dir(file)
 
+	args := []string{command, "update",
+		"--no-interaction",       // We can't answer any prompts...
+		"--no-scripts",           // Avoid risky scripts
+		"--ignore-platform-reqs", // We won't run the code, so we don't care about the platform
+		"--no-autoloader",        // We won't execute any code, no need for autoloader
+		"--no-install",           // No need to install packages
+		"--no-plugins",           // We won't run the code, so no plugins needed
+		"--no-audit",             // We don't want to run an audit
+	}
+	if cmdf.excludedScopes.Excludes(scope.Dev) {
+		args = append(args, "--no-dev")
+	}
+
 	return &exec.Cmd{
 		Path: path,
-		Args: []string{command, "update",
-			"--no-interaction",       // We can't answer any prompts...
-			"--no-scripts",           // Avoid risky scripts
-			"--ignore-platform-reqs", // We won't run the code, so we don't care about the platform
-			"--no-autoloader",        // We won't execute any code, no need for autoloader
-			"--no-install",           // No need to install packages
-			"--no-plugins",           // We won't run the code, so no plugins needed
-			"--no-audit",             // We don't want to run an audit
-		},
-		Dir: fileDir,
-		Env: os.Environ(),
+		Args: args,
+		Dir:  fileDir,
+		Env:  os.Environ(),
 	}, err
 }
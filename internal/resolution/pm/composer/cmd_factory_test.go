@@ -3,6 +3,7 @@ package composer
 import (
 	"testing"
 
+	"github.com/debricked/cli/internal/resolution/scope"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -17,3 +18,18 @@ func TestMakeInstallCmd(t *testing.T) {
 	assert.Contains(t, args, "composer")
 	assert.Contains(t, args, "update")
 }
+
+func TestMakeInstallCmdExcludedScopes(t *testing.T) {
+	cmd, _ := CmdFactory{
+		execPath:       ExecPath{},
+		excludedScopes: scope.Scopes{scope.Dev},
+	}.MakeInstallCmd("composer", "file")
+	assert.NotNil(t, cmd)
+	assert.Contains(t, cmd.Args, "--no-dev")
+
+	cmd, _ = CmdFactory{
+		execPath: ExecPath{},
+	}.MakeInstallCmd("composer", "file")
+	assert.NotNil(t, cmd)
+	assert.NotContains(t, cmd.Args, "--no-dev")
+}
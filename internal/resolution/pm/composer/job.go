@@ -56,6 +56,17 @@ func (j *Job) Run() {
 
 }
 
+// Plan returns the command and lock file of the job, without running it
+func (j *Job) Plan() job.Plan {
+	var plan job.Plan
+	if j.install {
+		plan.AddCommand(j.cmdFactory.MakeInstallCmd(composer, j.GetFile()))
+		plan.AddLockFile(util.MakePathFromManifestFile(j.GetFile(), "composer.lock"))
+	}
+
+	return plan
+}
+
 func (j *Job) runInstallCmd() ([]byte, error) {
 	j.composerCommand = composer
 	installCmd, err := j.cmdFactory.MakeInstallCmd(j.composerCommand, j.GetFile())
@@ -63,7 +74,7 @@ func (j *Job) runInstallCmd() ([]byte, error) {
 		return nil, err
 	}
 
-	installCmdOutput, err := installCmd.Output()
+	installCmdOutput, err := j.Output(installCmd)
 	if err != nil {
 		return nil, j.GetExitError(err, string(installCmdOutput))
 	}
@@ -2,10 +2,12 @@ package composer
 
 import (
 	"github.com/debricked/cli/internal/resolution/job"
+	"github.com/debricked/cli/internal/resolution/scope"
 )
 
 type Strategy struct {
-	files []string
+	files          []string
+	excludedScopes scope.Scopes
 }
 
 func (s Strategy) Invoke() ([]job.IJob, error) {
@@ -15,7 +17,8 @@ func (s Strategy) Invoke() ([]job.IJob, error) {
 			file,
 			true,
 			CmdFactory{
-				execPath: ExecPath{},
+				execPath:       ExecPath{},
+				excludedScopes: s.excludedScopes,
 			},
 		),
 		)
@@ -24,6 +27,6 @@ func (s Strategy) Invoke() ([]job.IJob, error) {
 	return jobs, nil
 }
 
-func NewStrategy(files []string) Strategy {
-	return Strategy{files}
+func NewStrategy(files []string, excludedScopes scope.Scopes) Strategy {
+	return Strategy{files, excludedScopes}
 }
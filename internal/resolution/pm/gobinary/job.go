@@ -0,0 +1,120 @@
+package gobinary
+
+import (
+	"debug/buildinfo"
+	"fmt"
+	"path/filepath"
+	"runtime/debug"
+	"strings"
+
+	"github.com/debricked/cli/internal/resolution/job"
+	"github.com/debricked/cli/internal/resolution/pm/gomod"
+	"github.com/debricked/cli/internal/resolution/pm/util"
+	"github.com/debricked/cli/internal/resolution/pm/writer"
+)
+
+const commandLineArguments = "command-line-arguments"
+
+type Job struct {
+	job.BaseJob
+	fileWriter writer.IFileWriter
+}
+
+func NewJob(
+	file string,
+	fileWriter writer.IFileWriter,
+) *Job {
+	return &Job{
+		BaseJob:    job.NewBaseJob(file),
+		fileWriter: fileWriter,
+	}
+}
+
+func (j *Job) Run() {
+	status := "reading build info"
+	j.SendStatus(status)
+	info, err := buildinfo.ReadFile(j.GetFile())
+	if err != nil {
+		j.handleError(err, status)
+
+		return
+	}
+
+	status = "creating lock file"
+	j.SendStatus(status)
+	lockFile, err := j.fileWriter.Create(j.LockFilePath(MakeLockFilePath(j.GetFile())))
+	if err != nil {
+		j.handleError(err, status)
+
+		return
+	}
+	defer util.CloseFile(j, j.fileWriter, lockFile)
+
+	err = j.fileWriter.Write(lockFile, makeLockContent(info))
+	if err != nil {
+		j.handleError(err, status)
+	}
+}
+
+// Plan returns the lock file of the job, which reads the build information of the binary without running any command
+func (j *Job) Plan() job.Plan {
+	return job.Plan{LockFiles: []string{MakeLockFilePath(j.GetFile())}}
+}
+
+func (j *Job) handleError(err error, status string) {
+	jobError := util.NewPMJobError(err.Error())
+	jobError.SetStatus(status)
+	j.Errors().Append(jobError)
+}
+
+// MakeLockFilePath returns the path of the lock file of the binary, for example "bin/app.gomod.debricked.lock".
+// The binary name is kept since a directory often contains several binaries
+func MakeLockFilePath(binary string) string {
+	return util.MakePathFromManifestFile(binary, filepath.Base(binary)+"."+gomod.LockFileName)
+}
+
+// makeLockContent formats the modules compiled into the binary like the gomod lock file,
+// a "go mod graph" followed by a "go list -m all" separated by an empty line.
+// Build info lacks the module graph, so all modules are listed as dependencies of the main module
+func makeLockContent(info *buildinfo.BuildInfo) []byte {
+	main := mainModule(info)
+	var graph []string
+	list := []string{main}
+	if len(info.GoVersion) > 0 {
+		graph = append(graph, fmt.Sprintf("%s toolchain@%s", main, info.GoVersion))
+	}
+	for _, dependency := range info.Deps {
+		module := resolvedModule(dependency)
+		if module == nil {
+			continue
+		}
+		graph = append(graph, fmt.Sprintf("%s %s@%s", main, module.Path, module.Version))
+		list = append(list, fmt.Sprintf("%s %s", module.Path, module.Version))
+	}
+
+	return []byte(strings.Join(graph, "\n") + "\n\n" + strings.Join(list, "\n") + "\n")
+}
+
+func mainModule(info *buildinfo.BuildInfo) string {
+	if len(info.Main.Path) > 0 {
+		return info.Main.Path
+	}
+	if len(info.Path) > 0 && info.Path != commandLineArguments {
+		return info.Path
+	}
+
+	return commandLineArguments
+}
+
+// resolvedModule returns the replacement of the module, if any.
+// Modules replaced by local directories have no version and are left out
+func resolvedModule(module *debug.Module) *debug.Module {
+	if module.Replace != nil {
+		module = module.Replace
+	}
+	if len(module.Version) == 0 {
+		return nil
+	}
+
+	return module
+}
@@ -0,0 +1,120 @@
+package gobinary
+
+import (
+	"debug/buildinfo"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"testing"
+
+	jobTestdata "github.com/debricked/cli/internal/resolution/job/testdata"
+	"github.com/debricked/cli/internal/resolution/pm/util"
+	"github.com/debricked/cli/internal/resolution/pm/writer"
+	writerTestdata "github.com/debricked/cli/internal/resolution/pm/writer/testdata"
+	"github.com/stretchr/testify/assert"
+)
+
+func testBinary(t *testing.T) string {
+	binary, err := os.Executable()
+	assert.NoError(t, err)
+
+	return binary
+}
+
+func TestNewJob(t *testing.T) {
+	j := NewJob("file", writer.FileWriter{})
+	assert.Equal(t, "file", j.GetFile())
+	assert.False(t, j.Errors().HasError())
+}
+
+func TestRun(t *testing.T) {
+	fileWriterMock := &writerTestdata.FileWriterMock{}
+	j := NewJob(testBinary(t), fileWriterMock)
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	assert.False(t, j.Errors().HasError())
+	assert.Contains(t, string(fileWriterMock.Contents), " github.com/stretchr/testify@v")
+	assert.Contains(t, string(fileWriterMock.Contents), "\ngithub.com/stretchr/testify v")
+}
+
+func TestRunNotGoBinary(t *testing.T) {
+	j := NewJob(filepath.Join("testdata", "script.sh"), &writerTestdata.FileWriterMock{})
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	errs := j.Errors().GetAll()
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "reading build info", errs[0].Status())
+}
+
+func TestRunCreateErr(t *testing.T) {
+	createErr := errors.New("create-error")
+	fileWriterMock := &writerTestdata.FileWriterMock{CreateErr: createErr}
+	j := NewJob(testBinary(t), fileWriterMock)
+
+	expectedError := util.NewPMJobError(createErr.Error())
+	expectedError.SetStatus("creating lock file")
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	assert.Len(t, j.Errors().GetAll(), 1)
+	assert.Contains(t, j.Errors().GetAll(), expectedError)
+}
+
+func TestRunWriteErr(t *testing.T) {
+	writeErr := errors.New("write-error")
+	fileWriterMock := &writerTestdata.FileWriterMock{WriteErr: writeErr}
+	j := NewJob(testBinary(t), fileWriterMock)
+
+	expectedError := util.NewPMJobError(writeErr.Error())
+	expectedError.SetStatus("creating lock file")
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	assert.Len(t, j.Errors().GetAll(), 1)
+	assert.Contains(t, j.Errors().GetAll(), expectedError)
+}
+
+func TestMakeLockFilePath(t *testing.T) {
+	assert.Equal(t, filepath.Join("bin", "app.gomod.debricked.lock"), MakeLockFilePath(filepath.Join("bin", "app")))
+}
+
+func TestMakeLockContent(t *testing.T) {
+	info := &buildinfo.BuildInfo{
+		GoVersion: "go1.21.5",
+		Path:      "example.com/app/cmd/app",
+		Main:      debug.Module{Path: "example.com/app", Version: "(devel)"},
+		Deps: []*debug.Module{
+			{Path: "github.com/spf13/cobra", Version: "v1.7.0"},
+			{Path: "github.com/old/module", Version: "v1.0.0", Replace: &debug.Module{Path: "github.com/new/module", Version: "v1.1.0"}},
+			{Path: "example.com/local", Version: "v0.0.0", Replace: &debug.Module{Path: "../local"}},
+		},
+	}
+
+	content := makeLockContent(info)
+
+	assert.Equal(t, `example.com/app toolchain@go1.21.5
+example.com/app github.com/spf13/cobra@v1.7.0
+example.com/app github.com/new/module@v1.1.0
+
+example.com/app
+github.com/spf13/cobra v1.7.0
+github.com/new/module v1.1.0
+`, string(content))
+}
+
+func TestMakeLockContentWithoutMainModule(t *testing.T) {
+	info := &buildinfo.BuildInfo{Path: "command-line-arguments"}
+
+	assert.Equal(t, "\n\ncommand-line-arguments\n", string(makeLockContent(info)))
+
+	info = &buildinfo.BuildInfo{Path: "example.com/app"}
+
+	assert.Equal(t, "\n\nexample.com/app\n", string(makeLockContent(info)))
+}
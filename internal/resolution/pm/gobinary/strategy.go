@@ -0,0 +1,71 @@
+package gobinary
+
+import (
+	"debug/buildinfo"
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	"github.com/debricked/cli/internal/file"
+	"github.com/debricked/cli/internal/resolution/job"
+	"github.com/debricked/cli/internal/resolution/pm/writer"
+)
+
+const Name = "gobinary"
+
+// Strategy collects the modules compiled into the Go executables found below roots
+type Strategy struct {
+	roots      []string
+	exclusions []string
+}
+
+func (s Strategy) Invoke() ([]job.IJob, error) {
+	var jobs []job.IJob
+	for _, root := range s.roots {
+		err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+			// Unreadable directories, which are common in container images, are skipped
+			if err != nil {
+				if entry != nil && entry.IsDir() {
+					return filepath.SkipDir
+				}
+
+				return nil
+			}
+			if entry.IsDir() || file.Excluded(s.exclusions, path) {
+				return nil
+			}
+			if isExecutable(entry) && isGoBinary(path) {
+				jobs = append(jobs, NewJob(path, writer.FileWriter{}))
+			}
+
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return jobs, nil
+}
+
+func NewStrategy(roots []string, exclusions []string) Strategy {
+	return Strategy{roots, exclusions}
+}
+
+func isExecutable(entry fs.DirEntry) bool {
+	if !entry.Type().IsRegular() {
+		return false
+	}
+	if strings.EqualFold(filepath.Ext(entry.Name()), ".exe") {
+		return true
+	}
+	info, err := entry.Info()
+
+	return err == nil && info.Mode().Perm()&0111 != 0 && info.Size() > 0
+}
+
+func isGoBinary(path string) bool {
+	_, err := buildinfo.ReadFile(path)
+
+	return err == nil
+}
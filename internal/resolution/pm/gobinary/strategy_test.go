@@ -0,0 +1,63 @@
+package gobinary
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func copyTestBinary(t *testing.T, destination string) {
+	content, err := os.ReadFile(testBinary(t))
+	assert.NoError(t, err)
+	assert.NoError(t, os.MkdirAll(filepath.Dir(destination), 0755))
+	assert.NoError(t, os.WriteFile(destination, content, 0755))
+}
+
+func TestNewStrategy(t *testing.T) {
+	s := NewStrategy(nil, nil)
+	assert.NotNil(t, s)
+	assert.Len(t, s.roots, 0)
+
+	s = NewStrategy([]string{"root"}, []string{"exclusion"})
+	assert.NotNil(t, s)
+	assert.Len(t, s.roots, 1)
+	assert.Len(t, s.exclusions, 1)
+}
+
+func TestInvokeNoBinaries(t *testing.T) {
+	s := NewStrategy([]string{"testdata"}, nil)
+	jobs, err := s.Invoke()
+	assert.NoError(t, err)
+	assert.Empty(t, jobs)
+}
+
+func TestInvoke(t *testing.T) {
+	root := t.TempDir()
+	binary := filepath.Join(root, "usr", "local", "bin", "app")
+	if runtime.GOOS == "windows" {
+		binary += ".exe"
+	}
+	copyTestBinary(t, binary)
+	copyTestBinary(t, filepath.Join(root, "node_modules", "esbuild", "bin", "esbuild"))
+	content, err := os.ReadFile(testBinary(t))
+	assert.NoError(t, err)
+	// Not executable
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "app.bin"), content, 0600))
+
+	s := NewStrategy([]string{root}, []string{filepath.Join("**", "node_modules", "**")})
+	jobs, err := s.Invoke()
+
+	assert.NoError(t, err)
+	assert.Len(t, jobs, 1)
+	assert.Equal(t, binary, jobs[0].GetFile())
+}
+
+func TestInvokeMissingRoot(t *testing.T) {
+	s := NewStrategy([]string{filepath.Join(t.TempDir(), "missing")}, nil)
+	jobs, err := s.Invoke()
+	assert.NoError(t, err)
+	assert.Empty(t, jobs)
+}
@@ -1,30 +1,37 @@
 package gomod
 
-import "os/exec"
+import (
+	"os"
+	"os/exec"
+)
 
 type ICmdFactory interface {
-	MakeGraphCmd(workingDirectory string) (*exec.Cmd, error)
-	MakeListCmd(workingDirectory string) (*exec.Cmd, error)
+	MakeGraphCmd(workingDirectory string, goWork string) (*exec.Cmd, error)
+	MakeListCmd(workingDirectory string, goWork string) (*exec.Cmd, error)
 }
 
 type CmdFactory struct{}
 
-func (_ CmdFactory) MakeGraphCmd(workingDirectory string) (*exec.Cmd, error) {
+// MakeGraphCmd makes a command printing the module requirement graph. goWork is passed as GOWORK,
+// either the go.work file of the workspace to resolve or off to resolve the module on its own
+func (_ CmdFactory) MakeGraphCmd(workingDirectory string, goWork string) (*exec.Cmd, error) {
 	path, err := exec.LookPath("go")
 
 	return &exec.Cmd{
 		Path: path,
 		Args: []string{"go", "mod", "graph"},
 		Dir:  workingDirectory,
+		Env:  append(os.Environ(), goWorkEnv+"="+goWork),
 	}, err
 }
 
-func (_ CmdFactory) MakeListCmd(workingDirectory string) (*exec.Cmd, error) {
+func (_ CmdFactory) MakeListCmd(workingDirectory string, goWork string) (*exec.Cmd, error) {
 	path, err := exec.LookPath("go")
 
 	return &exec.Cmd{
 		Path: path,
 		Args: []string{"go", "list", "-mod=readonly", "-e", "-m", "all"},
 		Dir:  workingDirectory,
+		Env:  append(os.Environ(), goWorkEnv+"="+goWork),
 	}, err
 }
@@ -11,7 +11,7 @@ import (
 )
 
 const (
-	fileName                   = "gomod.debricked.lock"
+	LockFileName               = "gomod.debricked.lock"
 	executableNotFoundErrRegex = `executable file not found`
 	versionNotFoundErrRegex    = `require ([^"'\s:]+): version "[^"'\s:]+" invalid: ([^"'\n:]+)`
 	revisionNotFoundErrRegex   = `([^"'\s\n:]+): reading [^"'\n:]+ at revision [^"'\n:]+: unknown revision ([^"'\n:]+)`
@@ -24,6 +24,7 @@ const (
 
 type Job struct {
 	job.BaseJob
+	members    []string
 	cmdFactory ICmdFactory
 	fileWriter writer.IFileWriter
 }
@@ -40,13 +41,30 @@ func NewJob(
 	}
 }
 
+// NewWorkspaceJob makes a job resolving the go.work workspace once, writing a lock file for each member go.mod
+// with the part of the workspace's dependencies the member module requires
+func NewWorkspaceJob(
+	workFile string,
+	members []string,
+	cmdFactory ICmdFactory,
+	fileWriter writer.IFileWriter,
+) *Job {
+	return &Job{
+		BaseJob:    job.NewBaseJob(workFile),
+		members:    members,
+		cmdFactory: cmdFactory,
+		fileWriter: fileWriter,
+	}
+}
+
 func (j *Job) Run() {
 	status := "creating dependency graph"
 	j.SendStatus(status)
 
 	workingDirectory := filepath.Dir(filepath.Clean(j.GetFile()))
+	goWork := j.goWork()
 
-	graphCmdOutput, cmd, err := j.runGraphCmd(workingDirectory)
+	graphCmdOutput, cmd, err := j.runGraphCmd(workingDirectory, goWork)
 	if err != nil {
 		j.handleError(j.createError(err.Error(), cmd, status))
 
@@ -55,7 +73,7 @@ func (j *Job) Run() {
 
 	status = "creating dependency version list"
 	j.SendStatus(status)
-	listCmdOutput, cmd, err := j.runListCmd(workingDirectory)
+	listCmdOutput, cmd, err := j.runListCmd(workingDirectory, goWork)
 	if err != nil {
 		j.handleError(j.createError(err.Error(), cmd, status))
 
@@ -64,7 +82,74 @@ func (j *Job) Run() {
 
 	status = "creating lock file"
 	j.SendStatus(status)
-	lockFile, err := j.fileWriter.Create(util.MakePathFromManifestFile(j.GetFile(), fileName))
+	if !j.isWorkspace() {
+		j.writeLockFile(j.GetFile(), graphCmdOutput, listCmdOutput, cmd, status)
+
+		return
+	}
+
+	workspaceModules := j.readWorkspaceModules()
+	for _, member := range j.members {
+		module, err := readModulePath(member)
+		if err != nil {
+			j.handleError(j.createError(err.Error(), cmd, status))
+
+			continue
+		}
+		memberGraph, memberList := attributeToModule(graphCmdOutput, listCmdOutput, module, workspaceModules)
+		j.writeLockFile(member, memberGraph, memberList, cmd, status)
+	}
+}
+
+// Plan returns the commands and lock files of the job, without running it
+func (j *Job) Plan() job.Plan {
+	var plan job.Plan
+	workingDirectory := filepath.Dir(filepath.Clean(j.GetFile()))
+	plan.AddCommand(j.cmdFactory.MakeGraphCmd(workingDirectory, j.goWork()))
+	plan.AddCommand(j.cmdFactory.MakeListCmd(workingDirectory, j.goWork()))
+	manifests := []string{j.GetFile()}
+	if j.isWorkspace() {
+		manifests = j.members
+	}
+	for _, manifest := range manifests {
+		plan.AddLockFile(util.MakePathFromManifestFile(manifest, LockFileName))
+	}
+
+	return plan
+}
+
+// goWork returns the GOWORK of the commands, which is the absolute path of go.work for workspaces
+func (j *Job) goWork() string {
+	if !j.isWorkspace() {
+		return goWorkOff
+	}
+	goWork := j.GetFile()
+	if absWorkFile, err := filepath.Abs(goWork); err == nil {
+		goWork = absWorkFile
+	}
+
+	return goWork
+}
+
+func (j *Job) isWorkspace() bool {
+	return len(j.members) > 0
+}
+
+// readWorkspaceModules returns the paths of all modules used by the workspace, which are main modules
+func (j *Job) readWorkspaceModules() map[string]bool {
+	modules := map[string]bool{}
+	uses, _ := readUseDirectives(j.GetFile())
+	for _, use := range uses {
+		if module, err := readModulePath(filepath.Join(use, "go.mod")); err == nil && len(module) > 0 {
+			modules[module] = true
+		}
+	}
+
+	return modules
+}
+
+func (j *Job) writeLockFile(manifest string, graphCmdOutput []byte, listCmdOutput []byte, cmd string, status string) {
+	lockFile, err := j.fileWriter.Create(j.LockFilePath(util.MakePathFromManifestFile(manifest, LockFileName)))
 	if err != nil {
 		j.handleError(j.createError(err.Error(), cmd, status))
 
@@ -83,13 +168,13 @@ func (j *Job) Run() {
 	}
 }
 
-func (j *Job) runGraphCmd(workingDirectory string) ([]byte, string, error) {
-	graphCmd, err := j.cmdFactory.MakeGraphCmd(workingDirectory)
+func (j *Job) runGraphCmd(workingDirectory string, goWork string) ([]byte, string, error) {
+	graphCmd, err := j.cmdFactory.MakeGraphCmd(workingDirectory, goWork)
 	if err != nil {
 		return nil, graphCmd.String(), err
 	}
 
-	graphCmdOutput, err := graphCmd.Output()
+	graphCmdOutput, err := j.Output(graphCmd)
 	if err != nil {
 		return nil, graphCmd.String(), j.GetExitError(err, "")
 	}
@@ -97,13 +182,13 @@ func (j *Job) runGraphCmd(workingDirectory string) ([]byte, string, error) {
 	return graphCmdOutput, graphCmd.String(), nil
 }
 
-func (j *Job) runListCmd(workingDirectory string) ([]byte, string, error) {
-	listCmd, err := j.cmdFactory.MakeListCmd(workingDirectory)
+func (j *Job) runListCmd(workingDirectory string, goWork string) ([]byte, string, error) {
+	listCmd, err := j.cmdFactory.MakeListCmd(workingDirectory, goWork)
 	if err != nil {
 		return nil, listCmd.String(), err
 	}
 
-	listCmdOutput, err := listCmd.Output()
+	listCmdOutput, err := j.Output(listCmd)
 	if err != nil {
 		return nil, listCmd.String(), j.GetExitError(err, "")
 	}
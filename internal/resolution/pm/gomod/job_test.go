@@ -2,6 +2,7 @@ package gomod
 
 import (
 	"errors"
+	"path/filepath"
 	"testing"
 
 	jobTestdata "github.com/debricked/cli/internal/resolution/job/testdata"
@@ -76,7 +77,7 @@ func TestRunGraphCmdErr(t *testing.T) {
 			cmdErr := errors.New(c.error)
 			cmdFactoryMock := testdata.NewEchoCmdFactory()
 			cmdFactoryMock.MakeGraphCmdErr = cmdErr
-			cmd, _ := cmdFactoryMock.MakeGraphCmd("echo")
+			cmd, _ := cmdFactoryMock.MakeGraphCmd("echo", goWorkOff)
 
 			expectedError := util.NewPMJobError(c.error)
 			expectedError.SetDocumentation(c.doc)
@@ -114,7 +115,7 @@ func TestRunListCmdErr(t *testing.T) {
 	cmdFactoryMock.MakeListCmdErr = cmdErr
 	j := NewJob("file", cmdFactoryMock, nil)
 
-	cmd, _ := cmdFactoryMock.MakeListCmd("echo")
+	cmd, _ := cmdFactoryMock.MakeListCmd("echo", goWorkOff)
 	expectedError := util.NewPMJobError(cmdErr.Error())
 	expectedError.SetStatus("creating dependency version list")
 	expectedError.SetCommand(cmd.String())
@@ -147,7 +148,7 @@ func TestRunCreateErr(t *testing.T) {
 	cmdFactoryMock := testdata.NewEchoCmdFactory()
 	j := NewJob("file", cmdFactoryMock, fileWriterMock)
 
-	cmd, _ := cmdFactoryMock.MakeListCmd("echo")
+	cmd, _ := cmdFactoryMock.MakeListCmd("echo", goWorkOff)
 	expectedError := util.NewPMJobError(createErr.Error())
 	expectedError.SetStatus("creating lock file")
 	expectedError.SetCommand(cmd.String())
@@ -168,7 +169,7 @@ func TestRunWriteErr(t *testing.T) {
 	cmdFactoryMock := testdata.NewEchoCmdFactory()
 	j := NewJob("file", cmdFactoryMock, fileWriterMock)
 
-	cmd, _ := cmdFactoryMock.MakeListCmd("echo")
+	cmd, _ := cmdFactoryMock.MakeListCmd("echo", goWorkOff)
 	expectedError := util.NewPMJobError(writeErr.Error())
 	expectedError.SetStatus("creating lock file")
 	expectedError.SetCommand(cmd.String())
@@ -210,3 +211,50 @@ func TestRun(t *testing.T) {
 	assert.Empty(t, j.Errors().GetAll())
 	assert.Equal(t, fileContents, fileWriterMock.Contents)
 }
+
+func TestRunWorkspace(t *testing.T) {
+	fileWriterMock := &writerTestdata.FileWriterMock{}
+	cmdFactoryMock := testdata.NewEchoCmdFactory()
+	cmdFactoryMock.GraphCmdOutput = "example.com/lib golang.org/x/text@v0.3.0\nexample.com/app rsc.io/quote@v1.5.2"
+	cmdFactoryMock.ListCmdOutput = "example.com/app\nexample.com/lib\ngolang.org/x/text v0.3.0\nrsc.io/quote v1.5.2"
+	j := NewWorkspaceJob(workspaceWorkFile, []string{libModFile}, cmdFactoryMock, fileWriterMock)
+
+	go jobTestdata.WaitStatus(j)
+
+	j.Run()
+
+	assert.Empty(t, j.Errors().GetAll())
+	expected := "example.com/lib golang.org/x/text@v0.3.0\n\nexample.com/lib\ngolang.org/x/text v0.3.0\n"
+	assert.Equal(t, expected, string(fileWriterMock.Contents))
+}
+
+func TestRunWorkspaceMissingMember(t *testing.T) {
+	fileWriterMock := &writerTestdata.FileWriterMock{}
+	j := NewWorkspaceJob(workspaceWorkFile, []string{filepath.Join("testdata", "missing", "go.mod")}, testdata.NewEchoCmdFactory(), fileWriterMock)
+
+	go jobTestdata.WaitStatus(j)
+
+	j.Run()
+
+	assert.Len(t, j.Errors().GetAll(), 1)
+	assert.Empty(t, fileWriterMock.Contents)
+}
+
+func TestPlan(t *testing.T) {
+	j := NewJob(filepath.Join("dir", "go.mod"), testdata.NewEchoCmdFactory(), nil)
+
+	plan := j.Plan()
+
+	assert.Len(t, plan.Commands, 2)
+	assert.Equal(t, []string{filepath.Join("dir", LockFileName)}, plan.LockFiles)
+}
+
+func TestPlanWorkspace(t *testing.T) {
+	members := []string{filepath.Join("a", "go.mod"), filepath.Join("b", "go.mod")}
+	j := NewWorkspaceJob("go.work", members, testdata.NewEchoCmdFactory(), nil)
+
+	plan := j.Plan()
+
+	assert.Len(t, plan.Commands, 2)
+	assert.Equal(t, []string{filepath.Join("a", LockFileName), filepath.Join("b", LockFileName)}, plan.LockFiles)
+}
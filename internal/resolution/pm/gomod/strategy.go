@@ -9,10 +9,27 @@ type Strategy struct {
 	files []string
 }
 
+// Invoke makes one job per go.work workspace, resolving all member modules at once with the workspace's
+// module set and replace directives. Modules outside any workspace are resolved on their own
 func (s Strategy) Invoke() ([]job.IJob, error) {
 	var jobs []job.IJob
+	var workspaces []string
+	members := map[string][]string{}
 	for _, file := range s.files {
-		jobs = append(jobs, NewJob(file, CmdFactory{}, writer.FileWriter{}))
+		workspace := findWorkspace(file)
+		if len(workspace) == 0 {
+			jobs = append(jobs, NewJob(file, CmdFactory{}, writer.FileWriter{}))
+
+			continue
+		}
+		if _, ok := members[workspace]; !ok {
+			workspaces = append(workspaces, workspace)
+		}
+		members[workspace] = append(members[workspace], file)
+	}
+
+	for _, workspace := range workspaces {
+		jobs = append(jobs, NewWorkspaceJob(workspace, members[workspace], CmdFactory{}, writer.FileWriter{}))
 	}
 
 	return jobs, nil
@@ -1,6 +1,7 @@
 package gomod
 
 import (
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -41,3 +42,22 @@ func TestInvokeManyFiles(t *testing.T) {
 	jobs, _ := s.Invoke()
 	assert.Len(t, jobs, 2)
 }
+
+func TestInvokeWorkspace(t *testing.T) {
+	s := NewStrategy([]string{
+		filepath.Join("testdata", "workspace", "app", "go.mod"),
+		filepath.Join("testdata", "standalone", "go.mod"),
+		filepath.Join("testdata", "workspace", "lib", "go.mod"),
+	})
+	jobs, err := s.Invoke()
+
+	assert.NoError(t, err)
+	assert.Len(t, jobs, 2)
+	assert.Equal(t, filepath.Join("testdata", "standalone", "go.mod"), jobs[0].GetFile())
+	workspaceJob, _ := jobs[1].(*Job)
+	assert.Equal(t, filepath.Join("testdata", "workspace", "go.work"), workspaceJob.GetFile())
+	assert.Equal(t, []string{
+		filepath.Join("testdata", "workspace", "app", "go.mod"),
+		filepath.Join("testdata", "workspace", "lib", "go.mod"),
+	}, workspaceJob.members)
+}
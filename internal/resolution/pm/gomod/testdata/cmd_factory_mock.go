@@ -4,22 +4,26 @@ import "os/exec"
 
 type CmdFactoryMock struct {
 	GraphCmdName    string
+	GraphCmdOutput  string
 	MakeGraphCmdErr error
 	ListCmdName     string
+	ListCmdOutput   string
 	MakeListCmdErr  error
 }
 
 func NewEchoCmdFactory() CmdFactoryMock {
 	return CmdFactoryMock{
-		GraphCmdName: "echo",
-		ListCmdName:  "echo",
+		GraphCmdName:   "echo",
+		GraphCmdOutput: "MakeGraphCmd",
+		ListCmdName:    "echo",
+		ListCmdOutput:  "MakeListCmd",
 	}
 }
 
-func (f CmdFactoryMock) MakeGraphCmd(_ string) (*exec.Cmd, error) {
-	return exec.Command(f.GraphCmdName, "MakeGraphCmd"), f.MakeGraphCmdErr
+func (f CmdFactoryMock) MakeGraphCmd(_ string, _ string) (*exec.Cmd, error) {
+	return exec.Command(f.GraphCmdName, f.GraphCmdOutput), f.MakeGraphCmdErr
 }
 
-func (f CmdFactoryMock) MakeListCmd(_ string) (*exec.Cmd, error) {
-	return exec.Command(f.ListCmdName, "MakeListCmd"), f.MakeListCmdErr
+func (f CmdFactoryMock) MakeListCmd(_ string, _ string) (*exec.Cmd, error) {
+	return exec.Command(f.ListCmdName, f.ListCmdOutput), f.MakeListCmdErr
 }
@@ -0,0 +1,8 @@
+module example.com/app
+
+go 1.21
+
+require (
+	example.com/lib v1.0.0
+	github.com/google/uuid v1.5.0
+)
@@ -0,0 +1,213 @@
+package gomod
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	workFile      = "go.work"
+	goWorkEnv     = "GOWORK"
+	goWorkOff     = "off"
+	moduleKeyword = "module"
+	useKeyword    = "use"
+)
+
+// findWorkspace returns the go.work file of the workspace that uses the module, or an empty string if the module is
+// resolved on its own. Like the go command, GOWORK selects the go.work file, or disables workspace mode if set to off.
+// Otherwise, the closest go.work in the module directory or its parents is used
+func findWorkspace(file string) string {
+	absDir, err := filepath.Abs(filepath.Dir(filepath.Clean(file)))
+	if err != nil {
+		return ""
+	}
+
+	workspace := os.Getenv(goWorkEnv)
+	switch workspace {
+	case goWorkOff:
+		return ""
+	case "":
+		workspace = findWorkFile(absDir)
+	default:
+		workspace, err = filepath.Abs(workspace)
+		if err != nil {
+			return ""
+		}
+	}
+	if len(workspace) == 0 {
+		return ""
+	}
+
+	uses, err := readUseDirectives(workspace)
+	if err != nil {
+		return ""
+	}
+	for _, use := range uses {
+		if use != absDir {
+			continue
+		}
+		// Keep the go.work path relative like the go.mod path, as it is reported as the resolved file
+		rel, err := filepath.Rel(absDir, workspace)
+		if err != nil {
+			return workspace
+		}
+
+		return filepath.Join(filepath.Dir(file), rel)
+	}
+
+	return ""
+}
+
+func findWorkFile(dir string) string {
+	for ; ; dir = filepath.Dir(dir) {
+		file := filepath.Join(dir, workFile)
+		if info, err := os.Stat(file); err == nil && !info.IsDir() {
+			return file
+		}
+		if dir == filepath.Dir(dir) {
+			return ""
+		}
+	}
+}
+
+// readUseDirectives returns the module directories used by the go.work file, joined with the directory of the file
+func readUseDirectives(file string) ([]string, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var uses []string
+	inBlock := false
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		fields := strings.Fields(stripComment(scanner.Text()))
+		if len(fields) == 0 {
+			continue
+		}
+
+		var dir string
+		switch {
+		case inBlock && fields[0] == ")":
+			inBlock = false
+
+			continue
+		case inBlock:
+			dir = fields[0]
+		case fields[0] == useKeyword && len(fields) > 1 && fields[1] == "(":
+			inBlock = true
+
+			continue
+		case fields[0] == useKeyword && len(fields) > 1:
+			dir = fields[1]
+		default:
+			continue
+		}
+
+		dir = filepath.FromSlash(unquote(dir))
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(filepath.Dir(file), dir)
+		}
+		uses = append(uses, filepath.Clean(dir))
+	}
+
+	return uses, scanner.Err()
+}
+
+// readModulePath returns the module path declared by the go.mod file
+func readModulePath(file string) (string, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return "", err
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		fields := strings.Fields(stripComment(scanner.Text()))
+		if len(fields) > 1 && fields[0] == moduleKeyword {
+			return unquote(fields[1]), nil
+		}
+	}
+
+	return "", scanner.Err()
+}
+
+// attributeToModule filters the output of "go mod graph" and "go list -m all", run for the whole workspace,
+// down to the modules reachable from the workspace module with the given path.
+// The other workspace modules are main modules as well, listed and required without version
+func attributeToModule(graph []byte, list []byte, module string, workspaceModules map[string]bool) ([]byte, []byte) {
+	edges := map[string][]string{}
+	var edgeOrder [][]string
+	for _, line := range strings.Split(string(graph), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		edges[fields[0]] = append(edges[fields[0]], fields[1])
+		edgeOrder = append(edgeOrder, fields)
+	}
+
+	reachable := map[string]bool{module: true}
+	queue := []string{module}
+	for len(queue) > 0 {
+		vertex := queue[0]
+		queue = queue[1:]
+		next := edges[vertex]
+		if path, _, found := strings.Cut(vertex, "@"); found && workspaceModules[path] {
+			next = append(next, path)
+		}
+		for _, dependency := range next {
+			if !reachable[dependency] {
+				reachable[dependency] = true
+				queue = append(queue, dependency)
+			}
+		}
+	}
+
+	var graphLines []string
+	for _, edge := range edgeOrder {
+		if reachable[edge[0]] {
+			graphLines = append(graphLines, strings.Join(edge, " "))
+		}
+	}
+
+	var listLines []string
+	for _, line := range strings.Split(string(list), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		vertex := fields[0]
+		if len(fields) > 1 {
+			vertex += "@" + fields[1]
+		}
+		if reachable[vertex] {
+			listLines = append(listLines, line)
+		}
+	}
+
+	return joinLines(graphLines), joinLines(listLines)
+}
+
+func joinLines(lines []string) []byte {
+	if len(lines) == 0 {
+		return nil
+	}
+
+	return []byte(strings.Join(lines, "\n") + "\n")
+}
+
+func stripComment(line string) string {
+	if index := strings.Index(line, "//"); index >= 0 {
+		return line[:index]
+	}
+
+	return line
+}
+
+func unquote(value string) string {
+	return strings.Trim(value, "\"`")
+}
@@ -0,0 +1,113 @@
+package gomod
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	workspaceWorkFile = filepath.Join("testdata", "workspace", "go.work")
+	appModFile        = filepath.Join("testdata", "workspace", "app", "go.mod")
+	libModFile        = filepath.Join("testdata", "workspace", "lib", "go.mod")
+	unusedModFile     = filepath.Join("testdata", "workspace", "unused", "go.mod")
+	standaloneModFile = filepath.Join("testdata", "standalone", "go.mod")
+)
+
+const (
+	workspaceGraph = `example.com/app example.com/lib@v1.0.0
+example.com/app github.com/google/uuid@v1.5.0
+example.com/lib golang.org/x/text@v0.3.0
+example.com/other rsc.io/quote@v1.5.2
+golang.org/x/text@v0.3.0 golang.org/x/tools@v0.0.0-20180917221912-90fa682c2a6e
+`
+	workspaceList = `example.com/app
+example.com/lib
+example.com/other
+github.com/google/uuid v1.5.0
+golang.org/x/text v0.3.0 => golang.org/x/text v0.14.0
+golang.org/x/tools v0.0.0-20180917221912-90fa682c2a6e
+rsc.io/quote v1.5.2
+`
+)
+
+func TestFindWorkspace(t *testing.T) {
+	cases := map[string]string{
+		appModFile:        workspaceWorkFile,
+		libModFile:        workspaceWorkFile,
+		unusedModFile:     "",
+		standaloneModFile: "",
+		filepath.Join("testdata", "missing", "go.mod"): "",
+	}
+
+	for file, workspace := range cases {
+		t.Run(file, func(t *testing.T) {
+			assert.Equal(t, workspace, findWorkspace(file))
+		})
+	}
+}
+
+func TestFindWorkspaceGoWork(t *testing.T) {
+	t.Setenv(goWorkEnv, goWorkOff)
+	assert.Empty(t, findWorkspace(appModFile))
+
+	absWorkFile, err := filepath.Abs(workspaceWorkFile)
+	assert.NoError(t, err)
+	t.Setenv(goWorkEnv, absWorkFile)
+	assert.Equal(t, workspaceWorkFile, findWorkspace(appModFile))
+
+	t.Setenv(goWorkEnv, filepath.Join(t.TempDir(), workFile))
+	assert.Empty(t, findWorkspace(appModFile))
+}
+
+func TestReadUseDirectives(t *testing.T) {
+	uses, err := readUseDirectives(workspaceWorkFile)
+	assert.NoError(t, err)
+
+	workspaceDir := filepath.Dir(workspaceWorkFile)
+	expected := []string{
+		filepath.Join(workspaceDir, "app"),
+		filepath.Join(workspaceDir, "lib"),
+		filepath.Join(workspaceDir, "missing"),
+	}
+	assert.Equal(t, expected, uses)
+
+	_, err = readUseDirectives(filepath.Join("testdata", "missing", workFile))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestReadModulePath(t *testing.T) {
+	module, err := readModulePath(appModFile)
+	assert.NoError(t, err)
+	assert.Equal(t, "example.com/app", module)
+
+	module, err = readModulePath(libModFile)
+	assert.NoError(t, err)
+	assert.Equal(t, "example.com/lib", module)
+}
+
+func TestAttributeToModule(t *testing.T) {
+	workspaceModules := map[string]bool{"example.com/app": true, "example.com/lib": true, "example.com/other": true}
+
+	graph, list := attributeToModule([]byte(workspaceGraph), []byte(workspaceList), "example.com/app", workspaceModules)
+
+	expectedGraph := `example.com/app example.com/lib@v1.0.0
+example.com/app github.com/google/uuid@v1.5.0
+example.com/lib golang.org/x/text@v0.3.0
+golang.org/x/text@v0.3.0 golang.org/x/tools@v0.0.0-20180917221912-90fa682c2a6e
+`
+	expectedList := `example.com/app
+example.com/lib
+github.com/google/uuid v1.5.0
+golang.org/x/text v0.3.0 => golang.org/x/text v0.14.0
+golang.org/x/tools v0.0.0-20180917221912-90fa682c2a6e
+`
+	assert.Equal(t, expectedGraph, string(graph))
+	assert.Equal(t, expectedList, string(list))
+
+	graph, list = attributeToModule([]byte(workspaceGraph), []byte(workspaceList), "example.com/other", workspaceModules)
+	assert.Equal(t, "example.com/other rsc.io/quote@v1.5.2\n", string(graph))
+	assert.Equal(t, "example.com/other\nrsc.io/quote v1.5.2\n", string(list))
+}
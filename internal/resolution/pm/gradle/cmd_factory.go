@@ -2,6 +2,8 @@ package gradle
 
 import (
 	"os/exec"
+
+	"github.com/debricked/cli/internal/resolution/scope"
 )
 
 type ICmdFactory interface {
@@ -9,7 +11,11 @@ type ICmdFactory interface {
 	MakeDependenciesGraphCmd(workingDirectory string, gradlew string, initScript string) (*exec.Cmd, error)
 }
 
-type CmdFactory struct{}
+const excludedScopesProperty = "debrickedExcludedScopes"
+
+type CmdFactory struct {
+	excludedScopes scope.Scopes
+}
 
 func (cf CmdFactory) MakeFindSubGraphCmd(workingDirectory string, gradlew string, initScript string) (*exec.Cmd, error) {
 	path, err := exec.LookPath(gradlew)
@@ -21,12 +27,18 @@ func (cf CmdFactory) MakeFindSubGraphCmd(workingDirectory string, gradlew string
 	}, err
 }
 
+// MakeDependenciesGraphCmd makes the command writing the dependencies report of the project.
+// The init script leaves the configurations of excluded scopes, such as testImplementation or compileOnly, out of the report
 func (cf CmdFactory) MakeDependenciesGraphCmd(workingDirectory string, gradlew string, initScript string) (*exec.Cmd, error) {
 	path, err := exec.LookPath(gradlew)
+	args := []string{gradlew, "--init-script", initScript, "debrickedAllDeps"}
+	if len(cf.excludedScopes) > 0 {
+		args = append(args, "-P"+excludedScopesProperty+"="+cf.excludedScopes.String())
+	}
 
 	return &exec.Cmd{
 		Path: path,
-		Args: []string{gradlew, "--init-script", initScript, "debrickedAllDeps"},
+		Args: args,
 		Dir:  workingDirectory,
 	}, err
 }
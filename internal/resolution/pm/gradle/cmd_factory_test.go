@@ -3,6 +3,7 @@ package gradle
 import (
 	"testing"
 
+	"github.com/debricked/cli/internal/resolution/scope"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -24,4 +25,11 @@ func TestMakeDependenciesGraphCmd(t *testing.T) {
 	assert.Contains(t, args, "--init-script")
 	assert.Contains(t, args, "init.gradle")
 	assert.Contains(t, args, "debrickedAllDeps")
+	assert.Len(t, args, 4)
+}
+
+func TestMakeDependenciesGraphCmdExcludedScopes(t *testing.T) {
+	cmd, _ := CmdFactory{excludedScopes: scope.Scopes{scope.Dev, scope.Test}}.MakeDependenciesGraphCmd(".", "gradlew", "init.gradle")
+	assert.NotNil(t, cmd)
+	assert.Contains(t, cmd.Args, "-PdebrickedExcludedScopes=dev,test")
 }
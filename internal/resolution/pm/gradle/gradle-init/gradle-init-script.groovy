@@ -1,5 +1,13 @@
 def debrickedOutputFile = new File('.debricked.multiprojects.txt')
 
+// Configurations of the excluded scopes, passed as -PdebrickedExcludedScopes=dev,test, are left out of the report
+def debrickedExcludedConfiguration = { String name, List<String> excludedScopes ->
+    def lowerName = name.toLowerCase()
+    def devConfigurations = ['compileonly', 'annotationprocessor', 'developmentonly', 'kapt']
+    (excludedScopes.contains('test') && lowerName.contains('test')) ||
+        (excludedScopes.contains('dev') && devConfigurations.any { lowerName.contains(it) })
+}
+
 allprojects {
     task debrickedFindSubProjectPaths() {
         String output = project.projectDir 
@@ -14,5 +22,11 @@ allprojects {
 allprojects {
     task debrickedAllDeps(type: DependencyReportTask) {
         outputFile = file('./gradle.debricked.lock')
+        doFirst {
+            def excludedScopes = (project.findProperty('debrickedExcludedScopes') ?: '').tokenize(',')
+            if (!excludedScopes.isEmpty()) {
+                configurations = project.configurations.findAll { !debrickedExcludedConfiguration(it.name, excludedScopes) } as Set
+            }
+        }
     }
 }
@@ -17,11 +17,13 @@ const (
 	notRootDirErrRegex         = "Error: (Could not find or load main class .*)"
 	unrelatedBuildErrRegex     = "(Project directory '.*' is not part of the build defined by settings file '.*')"
 	unknownPropertyErrRegex    = "(Could not get unknown property .*)"
+	lockFileName               = "gradle.debricked.lock"
 )
 
 type Job struct {
 	job.BaseJob
 	dir              string
+	subprojectDirs   []string
 	gradlew          string
 	groovyInitScript string
 	cmdFactory       ICmdFactory
@@ -73,7 +75,7 @@ func (j *Job) Run() {
 
 	status := "creating dependency graph"
 	j.SendStatus(status)
-	_, err = dependenciesCmd.Output()
+	_, err = j.Output(dependenciesCmd)
 
 	if permissionErr != nil {
 		cmdErr := util.NewPMJobError(permissionErr.Error())
@@ -90,6 +92,19 @@ func (j *Job) Run() {
 	}
 }
 
+// Plan returns the command and lock file of the job, without running it. The init script also writes lock files
+// of the subprojects of the build, in their directories
+func (j *Job) Plan() job.Plan {
+	var plan job.Plan
+	plan.AddCommand(j.cmdFactory.MakeDependenciesGraphCmd(filepath.Clean(j.GetDir()), j.gradlew, j.groovyInitScript))
+	plan.AddLockFile(util.MakePathFromManifestFile(j.GetFile(), lockFileName))
+	for _, subprojectDir := range j.subprojectDirs {
+		plan.AddLockFile(filepath.Join(subprojectDir, lockFileName))
+	}
+
+	return plan
+}
+
 func (j *Job) GetDir() string {
 	return j.dir
 }
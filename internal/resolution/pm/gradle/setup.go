@@ -9,7 +9,9 @@ import (
 	"runtime"
 	"sort"
 	"strings"
+	"time"
 
+	"github.com/debricked/cli/internal/resolution/pm/util"
 	"github.com/debricked/cli/internal/resolution/pm/writer"
 	internalOs "github.com/debricked/cli/internal/runtime/os"
 )
@@ -34,6 +36,7 @@ type Setup struct {
 	groovyScriptPath  string
 	gradlewOsName     string
 	settingsFilenames []string
+	timeout           time.Duration
 	GradleProjects    []Project
 	CmdFactory        ICmdFactory
 	MetaFileFinder    IMetaFileFinder
@@ -131,7 +134,7 @@ func (gs *Setup) setupSubProjectPaths(gp Project) error {
 	dependenciesCmd, _ := gs.CmdFactory.MakeFindSubGraphCmd(gp.dir, gp.gradlew, gs.groovyScriptPath)
 	var stderr bytes.Buffer
 	dependenciesCmd.Stderr = &stderr
-	_, err := dependenciesCmd.Output()
+	_, err := util.OutputWithTimeout(dependenciesCmd, gs.timeout)
 	dependenciesCmd.Stderr = os.Stderr
 	if err != nil {
 		errorOutput := stderr.String()
@@ -160,6 +163,19 @@ func (gs *Setup) setupSubProjectPaths(gp Project) error {
 	return nil
 }
 
+// subprojectDirs returns the directories of the subprojects of the build in dir, except dir itself
+func (gs *Setup) subprojectDirs(dir string) []string {
+	var dirs []string
+	for subprojectDir, mainDir := range gs.subProjectMap {
+		if mainDir == dir && subprojectDir != dir {
+			dirs = append(dirs, subprojectDir)
+		}
+	}
+	sort.Strings(dirs)
+
+	return dirs
+}
+
 func (gs *Setup) GetGradleW(dir string) string {
 	gradlew := initGradle
 	val, ok := gs.gradlewMap[dir]
@@ -5,24 +5,27 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/fatih/color"
 
 	"github.com/debricked/cli/internal/resolution/job"
 	"github.com/debricked/cli/internal/resolution/pm/writer"
+	"github.com/debricked/cli/internal/resolution/scope"
 )
 
 type Strategy struct {
-	files       []string
-	paths       []string
-	ErrorWriter io.Writer
-	GradleSetup ISetup
+	files          []string
+	paths          []string
+	ErrorWriter    io.Writer
+	GradleSetup    ISetup
+	excludedScopes scope.Scopes
 }
 
 func (s Strategy) Invoke() ([]job.IJob, error) {
 	var jobs []job.IJob
 	fileWriter := writer.FileWriter{}
-	factory := CmdFactory{}
+	factory := CmdFactory{excludedScopes: s.excludedScopes}
 	gradleSetup, err := s.GradleSetup.Configure(s.files, s.paths)
 	if err != nil {
 		if _, ok := err.(SetupSubprojectError); ok {
@@ -42,7 +45,9 @@ func (s Strategy) Invoke() ([]job.IJob, error) {
 			continue
 		}
 		gradleMainDirs[dir] = true
-		jobs = append(jobs, NewJob(gradleProject.mainBuildFile, dir, gradleProject.gradlew, gradleSetup.groovyScriptPath, factory, fileWriter))
+		j := NewJob(gradleProject.mainBuildFile, dir, gradleProject.gradlew, gradleSetup.groovyScriptPath, factory, fileWriter)
+		j.subprojectDirs = gradleSetup.subprojectDirs(dir)
+		jobs = append(jobs, j)
 
 	}
 	for _, file := range s.files {
@@ -61,6 +66,18 @@ func (s Strategy) Invoke() ([]job.IJob, error) {
 	return jobs, nil
 }
 
-func NewStrategy(files []string, paths []string) Strategy {
-	return Strategy{files, paths, os.Stdout, NewGradleSetup()}
+func NewStrategy(files []string, paths []string, excludedScopes scope.Scopes, setupTimeout time.Duration) Strategy {
+	gradleSetup := NewGradleSetup()
+	gradleSetup.timeout = setupTimeout
+
+	return Strategy{files, paths, os.Stdout, gradleSetup, excludedScopes}
+}
+
+// NewIsolatedStrategy makes a strategy writing the init script to scratchDir instead of the working directory
+func NewIsolatedStrategy(files []string, paths []string, scratchDir string, excludedScopes scope.Scopes, setupTimeout time.Duration) Strategy {
+	gradleSetup := NewGradleSetup()
+	gradleSetup.timeout = setupTimeout
+	gradleSetup.groovyScriptPath = filepath.Join(scratchDir, gradleInitScriptFileName)
+
+	return Strategy{files, paths, os.Stdout, gradleSetup, excludedScopes}
 }
@@ -2,44 +2,60 @@ package gradle
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/debricked/cli/internal/resolution/scope"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
 func TestNewStrategy(t *testing.T) {
-	s := NewStrategy(nil, nil)
+	s := NewStrategy(nil, nil, nil, 0)
 	assert.NotNil(t, s)
 	assert.Len(t, s.files, 0)
 
-	s = NewStrategy([]string{}, nil)
+	s = NewStrategy([]string{}, nil, nil, 0)
 	assert.NotNil(t, s)
 	assert.Len(t, s.files, 0)
 
-	s = NewStrategy([]string{"file"}, nil)
+	s = NewStrategy([]string{"file"}, nil, nil, 0)
 	assert.NotNil(t, s)
 	assert.Len(t, s.files, 1)
 
-	s = NewStrategy([]string{"file-1", "file-2"}, nil)
+	s = NewStrategy([]string{"file-1", "file-2"}, nil, nil, time.Minute)
 	assert.NotNil(t, s)
 	assert.Len(t, s.files, 2)
+	setup, ok := s.GradleSetup.(*Setup)
+	assert.True(t, ok)
+	assert.Equal(t, time.Minute, setup.timeout)
+}
+
+func TestNewIsolatedStrategy(t *testing.T) {
+	s := NewIsolatedStrategy([]string{"file"}, nil, "scratch", scope.Scopes{scope.Test}, time.Minute)
+	assert.Len(t, s.files, 1)
+	setup, ok := s.GradleSetup.(*Setup)
+	assert.True(t, ok)
+	assert.Equal(t, filepath.Join("scratch", gradleInitScriptFileName), setup.groovyScriptPath)
+	assert.Equal(t, time.Minute, setup.timeout)
+	assert.Equal(t, scope.Scopes{scope.Test}, s.excludedScopes)
 }
 
 func TestInvokeNoFiles(t *testing.T) {
-	s := NewStrategy([]string{}, nil)
+	s := NewStrategy([]string{}, nil, nil, 0)
 	jobs, _ := s.Invoke()
 	assert.Empty(t, jobs)
 }
 
 func TestInvokeOneFile(t *testing.T) {
-	s := NewStrategy([]string{"file"}, nil)
+	s := NewStrategy([]string{"file"}, nil, nil, 0)
 	jobs, _ := s.Invoke()
 	assert.Len(t, jobs, 1)
 }
 
 func TestInvokeManyFiles(t *testing.T) {
-	s := NewStrategy([]string{"test/file-1", "test/file-2", "test2/file-2"}, nil)
+	s := NewStrategy([]string{"test/file-1", "test/file-2", "test2/file-2"}, nil, nil, 0)
 	jobs, _ := s.Invoke()
 	assert.Len(t, jobs, 2)
 }
@@ -57,7 +73,7 @@ func (m *mockGradleSetup) Configure(_ []string, _ []string) (Setup, error) {
 }
 
 func TestInvokeWalkError(t *testing.T) {
-	s := NewStrategy([]string{"file"}, []string{"path"})
+	s := NewStrategy([]string{"file"}, []string{"path"}, nil, 0)
 	mocked := &mockGradleSetup{}
 	mocked.On("Configure").Return(Setup{}, SetupWalkError{})
 
@@ -68,7 +84,7 @@ func TestInvokeWalkError(t *testing.T) {
 }
 
 func TestInvokeSubprojectError(t *testing.T) {
-	s := NewStrategy([]string{"file"}, []string{"path"})
+	s := NewStrategy([]string{"file"}, []string{"path"}, nil, 0)
 	mocked := &mockGradleSetup{}
 	mocked.On("Configure").Return(Setup{}, SetupSubprojectError{})
 	s.GradleSetup = mocked
@@ -78,8 +94,19 @@ func TestInvokeSubprojectError(t *testing.T) {
 	assert.Equal(t, s.ErrorWriter, os.Stdout)
 }
 
+func TestInvokeExcludedScopes(t *testing.T) {
+	s := NewStrategy([]string{"file"}, []string{"path"}, scope.Scopes{scope.Dev}, 0)
+	mocked := &mockGradleSetup{}
+	mocked.On("Configure").Return(Setup{}, nil)
+	s.GradleSetup = mocked
+	jobs, err := s.Invoke()
+	assert.Nil(t, err)
+	assert.Len(t, jobs, 1)
+	assert.Equal(t, scope.Scopes{scope.Dev}, jobs[0].(*Job).cmdFactory.(CmdFactory).excludedScopes)
+}
+
 func TestInvokeFoundProject(t *testing.T) {
-	s := NewStrategy([]string{"file"}, []string{"file"})
+	s := NewStrategy([]string{"file"}, []string{"file"}, nil, 0)
 	subprojectMap := make(map[string]string)
 	dir, _ := os.Getwd()
 	subprojectMap[dir] = ""
@@ -91,3 +118,19 @@ func TestInvokeFoundProject(t *testing.T) {
 
 	assert.Len(t, jobs, 1)
 }
+
+func TestInvokeFoundProjectPlansSubprojectLockFiles(t *testing.T) {
+	s := NewStrategy([]string{"file"}, []string{"file"}, nil, 0)
+	dir, _ := os.Getwd()
+	subprojectDir := filepath.Join(dir, "core")
+	subprojectMap := map[string]string{dir: dir, subprojectDir: dir}
+	mocked := &mockGradleSetup{}
+	mocked.On("Configure").Return(Setup{GradleProjects: []Project{{dir: dir, gradlew: "gradlew", mainBuildFile: filepath.Join(dir, "settings.gradle")}}, subProjectMap: subprojectMap}, nil)
+
+	s.GradleSetup = mocked
+	jobs, _ := s.Invoke()
+
+	assert.Len(t, jobs, 1)
+	plan := jobs[0].(*Job).Plan()
+	assert.Equal(t, []string{filepath.Join(dir, lockFileName), filepath.Join(subprojectDir, lockFileName)}, plan.LockFiles)
+}
@@ -1,25 +1,58 @@
 package maven
 
-import "os/exec"
+import (
+	"os/exec"
+	"path/filepath"
+
+	"github.com/debricked/cli/internal/resolution/scope"
+	"github.com/debricked/cli/internal/resolution/toolchain"
+)
 
 type ICmdFactory interface {
 	MakeDependencyTreeCmd(workingDirectory string) (*exec.Cmd, error)
 }
 
-type CmdFactory struct{}
+type CmdFactory struct {
+	excludedScopes scope.Scopes
+}
+
+// MakeDependencyTreeCmd makes the command writing the dependency tree of the project, run by the Maven wrapper
+// of the project if there is one
+func (cmdf CmdFactory) MakeDependencyTreeCmd(workingDirectory string) (*exec.Cmd, error) {
+	command := "mvn"
+	path, err := exec.LookPath(command)
+	if wrapper := toolchain.FindMavenWrapper(workingDirectory); len(wrapper) > 0 {
+		command, path, err = filepath.Base(wrapper), wrapper, nil
+	}
 
-func (_ CmdFactory) MakeDependencyTreeCmd(workingDirectory string) (*exec.Cmd, error) {
-	path, err := exec.LookPath("mvn")
+	args := []string{
+		command,
+		"dependency:tree",
+		"-DoutputFile=" + lockFileExtension,
+		"-DoutputType=tgf",
+		"--fail-at-end",
+	}
+	if dependencyScope := cmdf.dependencyScope(); len(dependencyScope) > 0 {
+		args = append(args, "-Dscope="+dependencyScope)
+	}
 
 	return &exec.Cmd{
 		Path: path,
-		Args: []string{
-			"mvn",
-			"dependency:tree",
-			"-DoutputFile=" + lockFileExtension,
-			"-DoutputType=tgf",
-			"--fail-at-end",
-		},
-		Dir: workingDirectory,
+		Args: args,
+		Dir:  workingDirectory,
 	}, err
 }
+
+// dependencyScope returns the scope the dependency tree is resolved with, or an empty string to resolve all scopes.
+// Maven has no scope of development dependencies, but provided dependencies aren't shipped with the project either.
+// The tree can only be filtered by one scope, so provided dependencies are left out only together with test dependencies
+func (cmdf CmdFactory) dependencyScope() string {
+	if !cmdf.excludedScopes.Excludes(scope.Test) {
+		return ""
+	}
+	if cmdf.excludedScopes.Excludes(scope.Dev) {
+		return "runtime"
+	}
+
+	return "compile+runtime"
+}
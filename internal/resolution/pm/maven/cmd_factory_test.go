@@ -1,8 +1,12 @@
 package maven
 
 import (
+	"os"
+	"path/filepath"
+	"runtime"
 	"testing"
 
+	"github.com/debricked/cli/internal/resolution/scope"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -15,4 +19,45 @@ func TestMakeDependencyTreeCmd(t *testing.T) {
 	assert.Contains(t, args, "-DoutputFile=maven.debricked.lock")
 	assert.Contains(t, args, "-DoutputType=tgf")
 	assert.Contains(t, args, "--fail-at-end")
+	for _, arg := range args {
+		assert.NotContains(t, arg, "-Dscope")
+	}
+}
+
+func TestMakeDependencyTreeCmdExcludedScopes(t *testing.T) {
+	cases := []struct {
+		excludedScopes scope.Scopes
+		scopeArg       string
+	}{
+		{scope.Scopes{scope.Test}, "-Dscope=compile+runtime"},
+		{scope.Scopes{scope.Dev, scope.Test}, "-Dscope=runtime"},
+	}
+	for _, c := range cases {
+		t.Run(c.excludedScopes.String(), func(t *testing.T) {
+			cmd, _ := CmdFactory{excludedScopes: c.excludedScopes}.MakeDependencyTreeCmd(".")
+			assert.NotNil(t, cmd)
+			assert.Contains(t, cmd.Args, c.scopeArg)
+		})
+	}
+
+	cmd, _ := CmdFactory{excludedScopes: scope.Scopes{scope.Dev}}.MakeDependencyTreeCmd(".")
+	assert.Len(t, cmd.Args, 5)
+}
+
+func TestMakeDependencyTreeCmdWrapper(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the wrapper of Windows is mvnw.cmd")
+	}
+	dir := t.TempDir()
+	module := filepath.Join(dir, "module")
+	assert.NoError(t, os.Mkdir(module, 0700))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "pom.xml"), []byte("<project/>"), 0600))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "mvnw"), []byte("#!/bin/sh\n"), 0700)) // #nosec G306
+
+	cmd, err := CmdFactory{}.MakeDependencyTreeCmd(module)
+
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "mvnw"), cmd.Path)
+	assert.Equal(t, "mvnw", cmd.Args[0])
+	assert.Equal(t, module, cmd.Dir)
 }
@@ -66,7 +66,7 @@ func (j *Job) Run() {
 	status = "creating dependency graph"
 	j.SendStatus(status)
 	var output []byte
-	output, err = cmd.Output()
+	output, err = j.Output(cmd)
 	if err != nil {
 		errContent := err.Error()
 		if output != nil {
@@ -80,6 +80,16 @@ func (j *Job) Run() {
 	}
 }
 
+// Plan returns the command and lock file of the job, without running it. Maven also writes lock files
+// of the modules of the project, in their directories
+func (j *Job) Plan() job.Plan {
+	var plan job.Plan
+	plan.AddCommand(j.cmdFactory.MakeDependencyTreeCmd(filepath.Dir(filepath.Clean(j.GetFile()))))
+	plan.AddLockFile(util.MakePathFromManifestFile(j.GetFile(), lockFileExtension))
+
+	return plan
+}
+
 func (j *Job) handleError(cmdError job.IError) {
 	expressions := []string{
 		executableNotFoundErrRegex,
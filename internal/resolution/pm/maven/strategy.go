@@ -2,6 +2,7 @@ package maven
 
 import (
 	"github.com/debricked/cli/internal/resolution/job"
+	"github.com/debricked/cli/internal/resolution/scope"
 )
 
 type Strategy struct {
@@ -9,8 +10,8 @@ type Strategy struct {
 	cmdFactory ICmdFactory
 }
 
-func NewStrategy(files []string) Strategy {
-	return Strategy{files, CmdFactory{}}
+func NewStrategy(files []string, excludedScopes scope.Scopes) Strategy {
+	return Strategy{files, CmdFactory{excludedScopes: excludedScopes}}
 }
 
 func (s Strategy) Invoke() ([]job.IJob, error) {
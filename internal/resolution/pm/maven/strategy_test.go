@@ -3,6 +3,7 @@ package maven
 import (
 	"testing"
 
+	"github.com/debricked/cli/internal/resolution/scope"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -17,25 +18,25 @@ func (p PomServiceMock) ParsePomModules(_ string) ([]string, error) {
 }
 
 func TestNewStrategy(t *testing.T) {
-	s := NewStrategy(nil)
+	s := NewStrategy(nil, nil)
 	assert.NotNil(t, s)
 	assert.Len(t, s.files, 0)
 
-	s = NewStrategy([]string{})
+	s = NewStrategy([]string{}, nil)
 	assert.NotNil(t, s)
 	assert.Len(t, s.files, 0)
 
-	s = NewStrategy([]string{"file"})
+	s = NewStrategy([]string{"file"}, nil)
 	assert.NotNil(t, s)
 	assert.Len(t, s.files, 1)
 
-	s = NewStrategy([]string{"file-1", "file-2"})
+	s = NewStrategy([]string{"file-1", "file-2"}, nil)
 	assert.NotNil(t, s)
 	assert.Len(t, s.files, 2)
 }
 
 func TestInvokeNoFiles(t *testing.T) {
-	s := NewStrategy([]string{})
+	s := NewStrategy([]string{}, nil)
 
 	jobs, _ := s.Invoke()
 
@@ -43,7 +44,7 @@ func TestInvokeNoFiles(t *testing.T) {
 }
 
 func TestInvokeOneFile(t *testing.T) {
-	s := NewStrategy([]string{"file"})
+	s := NewStrategy([]string{"file"}, nil)
 
 	jobs, _ := s.Invoke()
 
@@ -51,9 +52,18 @@ func TestInvokeOneFile(t *testing.T) {
 }
 
 func TestInvokeManyFiles(t *testing.T) {
-	s := NewStrategy([]string{"file-1", "file-2"})
+	s := NewStrategy([]string{"file-1", "file-2"}, nil)
 
 	jobs, _ := s.Invoke()
 
 	assert.Len(t, jobs, 2)
 }
+
+func TestInvokeExcludedScopes(t *testing.T) {
+	s := NewStrategy([]string{"file"}, scope.Scopes{scope.Test})
+
+	jobs, _ := s.Invoke()
+
+	assert.Len(t, jobs, 1)
+	assert.Equal(t, scope.Scopes{scope.Test}, jobs[0].(*Job).cmdFactory.(CmdFactory).excludedScopes)
+}
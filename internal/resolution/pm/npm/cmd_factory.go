@@ -3,6 +3,8 @@ package npm
 import (
 	"os/exec"
 	"path/filepath"
+
+	"github.com/debricked/cli/internal/resolution/scope"
 )
 
 type ICmdFactory interface {
@@ -21,24 +23,38 @@ func (ExecPath) LookPath(file string) (string, error) {
 }
 
 type CmdFactory struct {
-	execPath IExecPath
+	execPath       IExecPath
+	lockfileOnly   bool
+	excludedScopes scope.Scopes
 }
 
+// MakeInstallCmd makes a command installing the dependencies of package.json.
+// In lockfile-only mode package-lock.json is resolved without downloading packages to node_modules.
+// Excluding the dev scope omits devDependencies, which package-lock.json still marks as dev
 func (cmdf CmdFactory) MakeInstallCmd(command string, file string) (*exec.Cmd, error) {
 	path, err := cmdf.execPath.LookPath(command)
 
 	fileDir := filepath.Dir(file)
 
+	args := []string{
+		//"yes |", // Answer 'y' to any prompts...
+		command,
+		"install",
+		"--ignore-scripts", // Avoid risky scripts
+		"--audit=false",    // Do not run audit
+	}
+	if cmdf.lockfileOnly {
+		args = append(args, "--package-lock-only")
+	} else {
+		args = append(args, "--bin-links=false") // We don't need symlinks to binaries as we won't run any code
+	}
+	if cmdf.excludedScopes.Excludes(scope.Dev) {
+		args = append(args, "--omit=dev")
+	}
+
 	return &exec.Cmd{
 		Path: path,
-		Args: []string{
-			//"yes |", // Answer 'y' to any prompts...
-			command,
-			"install",
-			"--ignore-scripts",  // Avoid risky scripts
-			"--audit=false",     // Do not run audit
-			"--bin-links=false", // We don't need symlinks to binaries as we won't run any code
-		},
-		Dir: fileDir,
+		Args: args,
+		Dir:  fileDir,
 	}, err
 }
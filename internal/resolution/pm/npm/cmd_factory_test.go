@@ -3,6 +3,7 @@ package npm
 import (
 	"testing"
 
+	"github.com/debricked/cli/internal/resolution/scope"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -17,3 +18,31 @@ func TestMakeInstallCmd(t *testing.T) {
 	assert.Contains(t, args, "npm")
 	assert.Contains(t, args, "install")
 }
+
+func TestMakeInstallCmdLockfileOnly(t *testing.T) {
+	cmd, _ := CmdFactory{
+		execPath:     ExecPath{},
+		lockfileOnly: true,
+	}.MakeInstallCmd("npm", "file")
+	assert.NotNil(t, cmd)
+	args := cmd.Args
+	assert.Contains(t, args, "--package-lock-only")
+	assert.Contains(t, args, "--ignore-scripts")
+	assert.NotContains(t, args, "--bin-links=false")
+}
+
+func TestMakeInstallCmdExcludedScopes(t *testing.T) {
+	cmd, _ := CmdFactory{
+		execPath:       ExecPath{},
+		excludedScopes: scope.Scopes{scope.Dev, scope.Test},
+	}.MakeInstallCmd("npm", "file")
+	assert.NotNil(t, cmd)
+	assert.Contains(t, cmd.Args, "--omit=dev")
+
+	cmd, _ = CmdFactory{
+		execPath:       ExecPath{},
+		excludedScopes: scope.Scopes{scope.Test},
+	}.MakeInstallCmd("npm", "file")
+	assert.NotNil(t, cmd)
+	assert.NotContains(t, cmd.Args, "--omit=dev")
+}
@@ -54,7 +54,7 @@ func (j *Job) Run() {
 			return
 		}
 
-		if output, err := installCmd.Output(); err != nil {
+		if output, err := j.Output(installCmd); err != nil {
 			error := strings.Join([]string{string(output), j.GetExitError(err, "").Error()}, "")
 			j.handleError(j.createError(error, installCmd.String(), status))
 
@@ -63,6 +63,17 @@ func (j *Job) Run() {
 	}
 }
 
+// Plan returns the command and lock file of the job, without running it
+func (j *Job) Plan() job.Plan {
+	var plan job.Plan
+	if j.install {
+		plan.AddCommand(j.cmdFactory.MakeInstallCmd(npm, j.GetFile()))
+		plan.AddLockFile(util.MakePathFromManifestFile(j.GetFile(), "package-lock.json"))
+	}
+
+	return plan
+}
+
 func (j *Job) createError(error string, cmd string, status string) job.IError {
 	cmdError := util.NewPMJobError(error)
 	cmdError.SetCommand(cmd)
@@ -1,21 +1,31 @@
 package npm
 
 import (
+	"github.com/debricked/cli/internal/file"
 	"github.com/debricked/cli/internal/resolution/job"
+	"github.com/debricked/cli/internal/resolution/scope"
 )
 
+var lockFiles = []string{"package-lock.json", "npm-shrinkwrap.json"}
+
 type Strategy struct {
-	files []string
+	files          []string
+	lockfileOnly   bool
+	excludedScopes scope.Scopes
 }
 
+// Invoke makes one job per workspace, since workspace members share the lock file of the workspace root.
+// Members of a workspace that already has a lock file are left out
 func (s Strategy) Invoke() ([]job.IJob, error) {
 	var jobs []job.IJob
-	for _, file := range s.files {
+	for _, manifest := range file.FindJsWorkspaceRoots(s.files, lockFiles) {
 		jobs = append(jobs, NewJob(
-			file,
+			manifest,
 			true,
 			CmdFactory{
-				execPath: ExecPath{},
+				execPath:       ExecPath{},
+				lockfileOnly:   s.lockfileOnly,
+				excludedScopes: s.excludedScopes,
 			},
 		),
 		)
@@ -24,6 +34,6 @@ func (s Strategy) Invoke() ([]job.IJob, error) {
 	return jobs, nil
 }
 
-func NewStrategy(files []string) Strategy {
-	return Strategy{files}
+func NewStrategy(files []string, lockfileOnly bool, excludedScopes scope.Scopes) Strategy {
+	return Strategy{files, lockfileOnly, excludedScopes}
 }
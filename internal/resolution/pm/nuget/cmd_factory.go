@@ -2,6 +2,7 @@ package nuget
 
 import (
 	"bytes"
+	"context"
 	"encoding/xml"
 	"html/template"
 	"io"
@@ -11,13 +12,16 @@ import (
 	"regexp"
 	"sort"
 	"strings"
+
+	"github.com/debricked/cli/internal/resolution/job"
+	"github.com/debricked/cli/internal/resolution/scope"
 )
 
 const packagesConfigLockfile = "packages.config.nuget.debricked.lock"
 const nugetLockfile = "packages.lock.json"
 
 type ICmdFactory interface {
-	MakeInstallCmd(command string, file string) (*exec.Cmd, error)
+	MakeInstallCmd(ctx context.Context, command string, file string) (*exec.Cmd, error)
 	GetTempoCsproj() string
 }
 
@@ -50,14 +54,16 @@ type CmdFactory struct {
 	packageConfigRegex     string
 	packagesConfigTemplate string
 	tempoCsproj            string
+	excludedScopes         scope.Scopes
 }
 
-func NewCmdFactory(execPath IExecPath) *CmdFactory {
+func NewCmdFactory(execPath IExecPath, excludedScopes scope.Scopes) *CmdFactory {
 	return &CmdFactory{
 		execPath:               execPath,
 		packageConfigRegex:     PackagesConfigRegex,
 		packagesConfigTemplate: packagesConfigTemplate,
 		tempoCsproj:            "",
+		excludedScopes:         excludedScopes,
 	}
 }
 
@@ -65,7 +71,9 @@ func (cmdf *CmdFactory) GetTempoCsproj() string {
 	return cmdf.tempoCsproj
 }
 
-func (cmdf *CmdFactory) MakeInstallCmd(command string, file string) (*exec.Cmd, error) {
+// MakeInstallCmd makes the restore command of file. Commands run while making it, such as dotnet --version
+// for packages.config files, are killed when ctx is done
+func (cmdf *CmdFactory) MakeInstallCmd(ctx context.Context, command string, file string) (*exec.Cmd, error) {
 
 	path, err := cmdf.execPath.LookPath(command)
 
@@ -82,7 +90,7 @@ func (cmdf *CmdFactory) MakeInstallCmd(command string, file string) (*exec.Cmd,
 
 	fileLockName := nugetLockfile
 	if packageConfig.MatchString(file) {
-		file, err = cmdf.convertPackagesConfigToCsproj(file, command)
+		file, err = cmdf.convertPackagesConfigToCsproj(ctx, file, command)
 		cmdf.tempoCsproj = file
 		if err != nil {
 			return nil, err
@@ -95,24 +103,34 @@ func (cmdf *CmdFactory) MakeInstallCmd(command string, file string) (*exec.Cmd,
 
 	return &exec.Cmd{
 		Path: path,
-		Args: []string{command, "restore",
-			file,
-			"--use-lock-file",
-			"--lock-file-path",
-			fileLockName,
-		},
-		Dir: fileDir,
+		Args: makeRestoreArgs(command, file, fileLockName),
+		Dir:  fileDir,
 	}, err
 }
 
+func makeRestoreArgs(command string, file string, lockFile string) []string {
+	return []string{command, "restore",
+		file,
+		"--use-lock-file",
+		"--lock-file-path",
+		lockFile,
+	}
+}
+
+// makePackagesConfigCsprojPath returns the path of the temporary .csproj file converted from packages.config
+func makePackagesConfigCsprojPath(packagesConfig string) string {
+	return packagesConfig + ".nuget.debricked.csproj.temp"
+}
+
 type Packages struct {
 	Packages []Package `xml:"package"`
 }
 
 type Package struct {
-	ID              string `xml:"id,attr"`
-	Version         string `xml:"version,attr"`
-	TargetFramework string `xml:"targetFramework,attr"`
+	ID                    string `xml:"id,attr"`
+	Version               string `xml:"version,attr"`
+	TargetFramework       string `xml:"targetFramework,attr"`
+	DevelopmentDependency bool   `xml:"developmentDependency,attr"`
 }
 
 // convertPackagesConfigtoCsproj converts a packages.config file to a .csproj file
@@ -120,22 +138,22 @@ type Package struct {
 // that enables debricked to parse out transitive dependencies.
 // This may add some additional framework dependencies that will not show up if
 // we only scan the packages.config file.
-func (cmdf *CmdFactory) convertPackagesConfigToCsproj(filePath string, command string) (string, error) {
+func (cmdf *CmdFactory) convertPackagesConfigToCsproj(ctx context.Context, filePath string, command string) (string, error) {
 	packages, err := parsePackagesConfig(filePath)
 	if err != nil {
 		return "", err
 	}
 
-	targetFrameworksStr, err := collectUniqueTargetFrameworks(packages.Packages, command)
+	targetFrameworksStr, err := collectUniqueTargetFrameworks(ctx, packages.Packages, command)
 	if err != nil {
 		return "", err
 	}
-	csprojContent, err := cmdf.createCsprojContentWithTemplate(targetFrameworksStr, packages.Packages)
+	csprojContent, err := cmdf.createCsprojContentWithTemplate(targetFrameworksStr, cmdf.includedPackages(packages.Packages))
 	if err != nil {
 		return "", err
 	}
 
-	newFilename := filePath + ".nuget.debricked.csproj.temp"
+	newFilename := makePackagesConfigCsprojPath(filePath)
 	err = writeContentToCsprojFile(newFilename, csprojContent)
 	if err != nil {
 		return "", err
@@ -144,6 +162,21 @@ func (cmdf *CmdFactory) convertPackagesConfigToCsproj(filePath string, command s
 	return newFilename, nil
 }
 
+// includedPackages returns the packages to restore, leaving out development dependencies if the dev scope is excluded
+func (cmdf *CmdFactory) includedPackages(packages []Package) []Package {
+	if !cmdf.excludedScopes.Excludes(scope.Dev) {
+		return packages
+	}
+	var included []Package
+	for _, pkg := range packages {
+		if !pkg.DevelopmentDependency {
+			included = append(included, pkg)
+		}
+	}
+
+	return included
+}
+
 func (cmdf *CmdFactory) createCsprojContentWithTemplate(targetFrameworksStr string, packages []Package) (string, error) {
 	tmplParsed, err := template.New("csproj").Parse(cmdf.packagesConfigTemplate)
 	if err != nil {
@@ -164,8 +197,14 @@ func (cmdf *CmdFactory) createCsprojContentWithTemplate(targetFrameworksStr stri
 
 var ioReadAllCsproj = io.ReadAll
 
-func getDotnetVersion(command string) (string, error) {
-	cmd := exec.Command(command, "--version")
+// getDotnetVersion returns the version of .NET, killing the version command along with the processes it started
+// when ctx is done
+func getDotnetVersion(ctx context.Context, command string) (string, error) {
+	cmd := exec.CommandContext(ctx, command, "--version") // #nosec G204
+	job.SetProcessGroup(cmd)
+	cmd.Cancel = func() error {
+		return job.KillProcessGroup(cmd.Process)
+	}
 	var out bytes.Buffer
 	cmd.Stdout = &out
 	err := cmd.Run()
@@ -209,7 +248,7 @@ func parsePackagesConfig(filePath string) (*Packages, error) {
 	return &packages, nil
 }
 
-func collectUniqueTargetFrameworks(packages []Package, command string) (string, error) {
+func collectUniqueTargetFrameworks(ctx context.Context, packages []Package, command string) (string, error) {
 	uniqueTargetFrameworks := make(map[string]struct{})
 	for _, pkg := range packages {
 		uniqueTargetFrameworks[pkg.TargetFramework] = struct{}{}
@@ -225,7 +264,7 @@ func collectUniqueTargetFrameworks(packages []Package, command string) (string,
 	sort.Strings(targetFrameworks) // Sort the targetFrameworks slice
 
 	if len(targetFrameworks) == 0 {
-		dotnetVersion, err := getDotnetVersion(command)
+		dotnetVersion, err := getDotnetVersion(ctx, command)
 		if err != nil {
 			return "", err
 		}
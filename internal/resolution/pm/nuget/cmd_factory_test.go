@@ -1,6 +1,7 @@
 package nuget
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
@@ -8,14 +9,16 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/debricked/cli/internal/resolution/scope"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestMakeInstallCmd(t *testing.T) {
 	cmdf := NewCmdFactory(
 		ExecPath{},
+		nil,
 	)
-	cmd, err := cmdf.MakeInstallCmd(nuget, "file")
+	cmd, err := cmdf.MakeInstallCmd(context.Background(), nuget, "file")
 	assert.NoError(t, err)
 	assert.NotNil(t, cmd)
 	args := cmd.Args
@@ -33,8 +36,9 @@ func TestMakeInstallCmdPackagsConfig(t *testing.T) {
 
 	cmdf := NewCmdFactory(
 		ExecPath{},
+		nil,
 	)
-	cmd, err := cmdf.MakeInstallCmd(nuget, "testdata/valid/packages.config")
+	cmd, err := cmdf.MakeInstallCmd(context.Background(), nuget, "testdata/valid/packages.config")
 	assert.NoError(t, err)
 	assert.NotNil(t, cmd)
 	args := cmd.Args
@@ -53,6 +57,19 @@ func TestMakeInstallCmdPackagsConfig(t *testing.T) {
 	assert.Equal(t, "testdata/valid/packages.config.nuget.debricked.csproj.temp", tmp)
 }
 
+func TestIncludedPackages(t *testing.T) {
+	packages := []Package{
+		{ID: "Newtonsoft.Json", Version: "13.0.3"},
+		{ID: "StyleCop.Analyzers", Version: "1.1.118", DevelopmentDependency: true},
+	}
+
+	cmdf := NewCmdFactory(ExecPath{}, nil)
+	assert.Equal(t, packages, cmdf.includedPackages(packages))
+
+	cmdf = NewCmdFactory(ExecPath{}, scope.Scopes{scope.Dev})
+	assert.Equal(t, packages[:1], cmdf.includedPackages(packages))
+}
+
 func MockReadAll(r io.Reader) ([]byte, error) {
 	return nil, fmt.Errorf("mock error")
 }
@@ -154,7 +171,7 @@ func TestCollectUniqueTargetFrameworks(t *testing.T) {
 		{TargetFramework: "net46"},
 		{TargetFramework: "net45"},
 	}
-	got, err := collectUniqueTargetFrameworks(packages, nuget)
+	got, err := collectUniqueTargetFrameworks(context.Background(), packages, nuget)
 	if err != nil {
 		t.Errorf("collectUniqueTargetFrameworks() error = %v", err)
 	}
@@ -319,7 +336,7 @@ func TestMakeInstallCmdBadPackagesConfigRegex(t *testing.T) {
 	cmd, err := (&CmdFactory{
 		execPath:           ExecPath{},
 		packageConfigRegex: "[",
-	}).MakeInstallCmd(nuget, "file")
+	}).MakeInstallCmd(context.Background(), nuget, "file")
 
 	assert.Error(t, err)
 	assert.Nil(t, cmd)
@@ -349,7 +366,8 @@ func TestMakeInstallCmdNotAccessToFile(t *testing.T) {
 
 	_, err = NewCmdFactory(
 		ExecPath{},
-	).MakeInstallCmd(nuget, file.Name())
+		nil,
+	).MakeInstallCmd(context.Background(), nuget, file.Name())
 
 	assert.Error(t, err)
 }
@@ -366,7 +384,7 @@ func TestMakeInstallCmdExecPathError(t *testing.T) {
 	cmd, err := (&CmdFactory{
 		execPath:           ExecPathErr{},
 		packageConfigRegex: PackagesConfigRegex,
-	}).MakeInstallCmd(nuget, "file")
+	}).MakeInstallCmd(context.Background(), nuget, "file")
 
 	assert.Error(t, err)
 	assert.Nil(t, cmd)
@@ -424,7 +442,7 @@ func TestConvertPackagesConfigToCsproj(t *testing.T) {
 				packageConfigRegex:     PackagesConfigRegex,
 				packagesConfigTemplate: tt.packagesConfigTemplate,
 			}
-			_, err := cmd.convertPackagesConfigToCsproj(tt.filePath, nugetCommand)
+			_, err := cmd.convertPackagesConfigToCsproj(context.Background(), tt.filePath, nugetCommand)
 			if (err != nil) != tt.wantError {
 				t.Errorf("convertPackagesConfigToCsproj(%q) = %v, want error: %v", tt.filePath, err, tt.wantError)
 			}
@@ -439,7 +457,7 @@ func TestConvertPackagesConfigToCsproj(t *testing.T) {
 }
 
 func TestGetDotnetVersion(t *testing.T) {
-	version, err := getDotnetVersion(nuget)
+	version, err := getDotnetVersion(context.Background(), nuget)
 	if err != nil {
 		t.Errorf("getDotnetVersion returned an error: %v", err)
 	}
@@ -448,12 +466,21 @@ func TestGetDotnetVersion(t *testing.T) {
 	}
 
 	// Test with a non-existent command
-	_, err = getDotnetVersion("non-existent-command")
+	_, err = getDotnetVersion(context.Background(), "non-existent-command")
 	if err == nil {
 		t.Errorf("getDotnetVersion did not return an error")
 	}
 }
 
+func TestGetDotnetVersionCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := getDotnetVersion(ctx, nuget)
+
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
 func TestGetDefaultFrameworkOfDotnetVersion(t *testing.T) {
 	tests := []struct {
 		version string
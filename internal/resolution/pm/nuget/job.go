@@ -59,11 +59,28 @@ func (j *Job) Run() {
 
 }
 
+// Plan returns the command and lock file of the job, without running it. The command isn't made by the command
+// factory, since it converts packages.config files to temporary .csproj files
+func (j *Job) Plan() job.Plan {
+	var plan job.Plan
+	if !j.install {
+		return plan
+	}
+	file, lockFile := j.GetFile(), nugetLockfile
+	if regexp.MustCompile(PackagesConfigRegex).MatchString(file) {
+		file, lockFile = makePackagesConfigCsprojPath(file), packagesConfigLockfile
+	}
+	plan.Commands = append(plan.Commands, strings.Join(makeRestoreArgs(nuget, filepath.Base(file), lockFile), " "))
+	plan.AddLockFile(filepath.Join(filepath.Dir(file), lockFile))
+
+	return plan
+}
+
 var osRemoveAll = os.RemoveAll
 
 func (j *Job) runInstallCmd() ([]byte, string, error) {
 	j.nugetCommand = nuget
-	installCmd, err := j.cmdFactory.MakeInstallCmd(j.nugetCommand, j.GetFile())
+	installCmd, err := j.cmdFactory.MakeInstallCmd(j.Context(), j.nugetCommand, j.GetFile())
 
 	if err != nil {
 		command := ""
@@ -74,7 +91,7 @@ func (j *Job) runInstallCmd() ([]byte, string, error) {
 		return nil, command, err
 	}
 
-	installCmdOutput, err := installCmd.Output()
+	installCmdOutput, err := j.Output(installCmd)
 	if err != nil {
 		return installCmdOutput, installCmd.String(), j.GetExitError(err, "")
 	}
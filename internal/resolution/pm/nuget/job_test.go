@@ -1,6 +1,7 @@
 package nuget
 
 import (
+	"context"
 	"errors"
 	"os"
 	"path/filepath"
@@ -124,7 +125,7 @@ func TestRunInstallCmdErr(t *testing.T) {
 			cmdErr := errors.New(c.error)
 			cmdFactoryMock := testdata.NewEchoCmdFactory()
 			cmdFactoryMock.MakeInstallErr = cmdErr
-			cmd, _ := cmdFactoryMock.MakeInstallCmd("echo", "package.json")
+			cmd, _ := cmdFactoryMock.MakeInstallCmd(context.Background(), "echo", "package.json")
 
 			expectedError := util.NewPMJobError("\n" + c.error)
 			expectedError.SetDocumentation(c.doc)
@@ -146,7 +147,7 @@ func TestRunInstallCmdErr(t *testing.T) {
 
 func TestInstallCmdErrCleansUpLockFile(t *testing.T) {
 	dir := "testdata/invalid_dependency"
-	cmdFactory := NewCmdFactory(ExecPath{})
+	cmdFactory := NewCmdFactory(ExecPath{}, nil)
 
 	j := NewJob(filepath.Join(dir, "packages.config"), true, cmdFactory)
 
@@ -163,7 +164,7 @@ func TestInstallCmdErrCleansUpLockFile(t *testing.T) {
 
 func TestSuccessfulInstallCmdWontDeleteLockFile(t *testing.T) {
 	dir := "testdata/valid"
-	cmdFactory := NewCmdFactory(ExecPath{})
+	cmdFactory := NewCmdFactory(ExecPath{}, nil)
 
 	j := NewJob(filepath.Join(dir, "packages.config"), true, cmdFactory)
 
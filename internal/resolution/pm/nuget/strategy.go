@@ -2,10 +2,12 @@ package nuget
 
 import (
 	"github.com/debricked/cli/internal/resolution/job"
+	"github.com/debricked/cli/internal/resolution/scope"
 )
 
 type Strategy struct {
-	files []string
+	files          []string
+	excludedScopes scope.Scopes
 }
 
 func (s Strategy) Invoke() ([]job.IJob, error) {
@@ -14,7 +16,7 @@ func (s Strategy) Invoke() ([]job.IJob, error) {
 		jobs = append(jobs, NewJob(
 			file,
 			true,
-			NewCmdFactory(ExecPath{}),
+			NewCmdFactory(ExecPath{}, s.excludedScopes),
 		),
 		)
 	}
@@ -22,6 +24,6 @@ func (s Strategy) Invoke() ([]job.IJob, error) {
 	return jobs, nil
 }
 
-func NewStrategy(files []string) Strategy {
-	return Strategy{files}
+func NewStrategy(files []string, excludedScopes scope.Scopes) Strategy {
+	return Strategy{files, excludedScopes}
 }
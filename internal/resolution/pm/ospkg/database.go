@@ -0,0 +1,130 @@
+package ospkg
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+)
+
+const (
+	dpkg = "dpkg"
+	apk  = "apk"
+)
+
+type Package struct {
+	Name         string `json:"name"`
+	Version      string `json:"version"`
+	Architecture string `json:"architecture,omitempty"`
+	Source       string `json:"source,omitempty"`
+}
+
+// database is an installed package database of an operating system package manager, located relative to the root directory
+type database struct {
+	packageManager string
+	path           string
+	parse          func(content []byte) []Package
+}
+
+// databases are ordered by priority. Only the first found database of each package manager is collected,
+// since distroless images replace the dpkg status file with one file per package in status.d
+var databases = []database{
+	{packageManager: dpkg, path: "var/lib/dpkg/status", parse: parseDpkg},
+	{packageManager: dpkg, path: "var/lib/dpkg/status.d", parse: parseDpkg},
+	{packageManager: apk, path: "lib/apk/db/installed", parse: parseApk},
+}
+
+// parseDpkg parses paragraphs of "Field: value" lines, as found in /var/lib/dpkg/status.
+// Only packages with status "installed" are included
+func parseDpkg(content []byte) []Package {
+	var packages []Package
+	for _, paragraph := range parseParagraphs(content, ": ") {
+		status := strings.Fields(paragraph["Status"])
+		if len(status) > 0 && status[len(status)-1] != "installed" {
+			continue
+		}
+		if len(paragraph["Package"]) == 0 || len(paragraph["Version"]) == 0 {
+			continue
+		}
+		// Source may contain the source version, for example "glibc (2.36-9)"
+		source, _, _ := strings.Cut(paragraph["Source"], " ")
+		packages = append(packages, Package{
+			Name:         paragraph["Package"],
+			Version:      paragraph["Version"],
+			Architecture: paragraph["Architecture"],
+			Source:       source,
+		})
+	}
+
+	return packages
+}
+
+// parseApk parses paragraphs of "K:value" lines, as found in /lib/apk/db/installed
+func parseApk(content []byte) []Package {
+	var packages []Package
+	for _, paragraph := range parseParagraphs(content, ":") {
+		if len(paragraph["P"]) == 0 || len(paragraph["V"]) == 0 {
+			continue
+		}
+		packages = append(packages, Package{
+			Name:         paragraph["P"],
+			Version:      paragraph["V"],
+			Architecture: paragraph["A"],
+			Source:       paragraph["o"],
+		})
+	}
+
+	return packages
+}
+
+// parseParagraphs splits content into blank line separated paragraphs of fields.
+// Continuation lines, starting with whitespace, are ignored
+func parseParagraphs(content []byte, separator string) []map[string]string {
+	var paragraphs []map[string]string
+	paragraph := map[string]string{}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if len(strings.TrimSpace(line)) == 0 {
+			if len(paragraph) > 0 {
+				paragraphs = append(paragraphs, paragraph)
+				paragraph = map[string]string{}
+			}
+
+			continue
+		}
+		if strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t") {
+			continue
+		}
+		key, value, found := strings.Cut(line, separator)
+		if found {
+			paragraph[key] = strings.TrimSpace(value)
+		}
+	}
+	if len(paragraph) > 0 {
+		paragraphs = append(paragraphs, paragraph)
+	}
+
+	return paragraphs
+}
+
+// parseOsRelease parses the ID and VERSION_ID of an os-release file
+func parseOsRelease(content []byte) Distribution {
+	var distribution Distribution
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		key, value, found := strings.Cut(strings.TrimSpace(scanner.Text()), "=")
+		if !found {
+			continue
+		}
+		value = strings.Trim(value, `"'`)
+		switch key {
+		case "ID":
+			distribution.Id = value
+		case "VERSION_ID":
+			distribution.VersionId = value
+		}
+	}
+
+	return distribution
+}
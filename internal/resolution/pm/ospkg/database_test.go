@@ -0,0 +1,48 @@
+package ospkg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDpkg(t *testing.T) {
+	content := []byte(`Package: libc6
+Status: install ok installed
+Architecture: amd64
+Source: glibc (2.36-9)
+Version: 2.36-9
+Description: GNU C Library
+ Version: not-a-field
+
+Package: removed
+Status: deinstall ok config-files
+Version: 1.0
+
+Package: tzdata
+Version: 2024a-0+deb12u1
+Architecture: all
+`)
+
+	packages := parseDpkg(content)
+
+	assert.Equal(t, []Package{
+		{Name: "libc6", Version: "2.36-9", Architecture: "amd64", Source: "glibc"},
+		{Name: "tzdata", Version: "2024a-0+deb12u1", Architecture: "all"},
+	}, packages)
+}
+
+func TestParseApk(t *testing.T) {
+	content := []byte("C:Q1+s6Z=\r\nP:musl\r\nV:1.2.4-r4\r\nA:x86_64\r\no:musl\r\n\r\nP:incomplete\r\n")
+
+	packages := parseApk(content)
+
+	assert.Equal(t, []Package{{Name: "musl", Version: "1.2.4-r4", Architecture: "x86_64", Source: "musl"}}, packages)
+}
+
+func TestParseOsRelease(t *testing.T) {
+	content := []byte("NAME=\"Alpine Linux\"\nID=alpine\nVERSION_ID='3.19.1'\n")
+
+	assert.Equal(t, Distribution{Id: "alpine", VersionId: "3.19.1"}, parseOsRelease(content))
+	assert.Equal(t, Distribution{}, parseOsRelease(nil))
+}
@@ -0,0 +1,146 @@
+package ospkg
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/debricked/cli/internal/resolution/job"
+	"github.com/debricked/cli/internal/resolution/pm/util"
+	"github.com/debricked/cli/internal/resolution/pm/writer"
+)
+
+const lockFileExtension = ".debricked.lock"
+
+var osReleaseFiles = []string{"etc/os-release", "usr/lib/os-release"}
+
+type Distribution struct {
+	Id        string `json:"id,omitempty"`
+	VersionId string `json:"versionId,omitempty"`
+}
+
+type LockFile struct {
+	PackageManager string       `json:"packageManager"`
+	Distribution   Distribution `json:"distribution"`
+	Packages       []Package    `json:"packages"`
+}
+
+type Job struct {
+	job.BaseJob
+	root       string
+	database   database
+	fileWriter writer.IFileWriter
+}
+
+func NewJob(
+	root string,
+	database database,
+	fileWriter writer.IFileWriter,
+) *Job {
+	return &Job{
+		BaseJob:    job.NewBaseJob(filepath.Join(root, filepath.FromSlash(database.path))),
+		root:       root,
+		database:   database,
+		fileWriter: fileWriter,
+	}
+}
+
+func (j *Job) Run() {
+	status := "reading package database"
+	j.SendStatus(status)
+	content, err := j.readDatabase()
+	if err != nil {
+		j.handleError(err, status, "Failed to read the package database. Please check that the CLI has permission to read it.")
+
+		return
+	}
+
+	lockFile := LockFile{
+		PackageManager: j.database.packageManager,
+		Distribution:   j.readDistribution(),
+		Packages:       j.database.parse(content),
+	}
+	if lockFile.Packages == nil {
+		lockFile.Packages = []Package{}
+	}
+	fileContents, err := json.MarshalIndent(lockFile, "", "  ")
+	if err != nil {
+		j.handleError(err, status, "")
+
+		return
+	}
+
+	status = "creating lock file"
+	j.SendStatus(status)
+	file, err := j.fileWriter.Create(j.LockFilePath(util.MakePathFromManifestFile(j.GetFile(), j.database.packageManager+lockFileExtension)))
+	if err != nil {
+		j.handleError(err, status, "")
+
+		return
+	}
+	defer util.CloseFile(j, j.fileWriter, file)
+
+	err = j.fileWriter.Write(file, fileContents)
+	if err != nil {
+		j.handleError(err, status, "")
+	}
+}
+
+// Plan returns the lock file of the job, which reads the package database without running any command
+func (j *Job) Plan() job.Plan {
+	return job.Plan{
+		LockFiles: []string{util.MakePathFromManifestFile(j.GetFile(), j.database.packageManager+lockFileExtension)},
+	}
+}
+
+// readDatabase reads the database file, or all package files of a database directory
+func (j *Job) readDatabase() ([]byte, error) {
+	info, err := os.Stat(j.GetFile())
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return os.ReadFile(j.GetFile())
+	}
+
+	entries, err := os.ReadDir(j.GetFile())
+	if err != nil {
+		return nil, err
+	}
+	var content []byte
+	for _, entry := range entries {
+		// status.d contains checksum files next to the package files
+		if entry.IsDir() || strings.Contains(entry.Name(), ".") {
+			continue
+		}
+		packageContent, err := os.ReadFile(filepath.Join(j.GetFile(), entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		content = append(content, packageContent...)
+		content = append(content, '\n', '\n')
+	}
+
+	return content, nil
+}
+
+func (j *Job) readDistribution() Distribution {
+	for _, osReleaseFile := range osReleaseFiles {
+		content, err := os.ReadFile(filepath.Join(j.root, filepath.FromSlash(osReleaseFile)))
+		if err == nil {
+			return parseOsRelease(content)
+		}
+	}
+
+	return Distribution{}
+}
+
+func (j *Job) handleError(err error, status string, documentation string) {
+	jobError := util.NewPMJobError(err.Error())
+	jobError.SetStatus(status)
+	if len(documentation) > 0 {
+		jobError.SetDocumentation(documentation)
+	}
+	j.Errors().Append(jobError)
+}
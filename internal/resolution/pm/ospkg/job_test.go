@@ -0,0 +1,117 @@
+package ospkg
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	jobTestdata "github.com/debricked/cli/internal/resolution/job/testdata"
+	"github.com/debricked/cli/internal/resolution/pm/util"
+	"github.com/debricked/cli/internal/resolution/pm/writer"
+	writerTestdata "github.com/debricked/cli/internal/resolution/pm/writer/testdata"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewJob(t *testing.T) {
+	j := NewJob("root", databases[0], writer.FileWriter{})
+	assert.Equal(t, filepath.Join("root", "var", "lib", "dpkg", "status"), j.GetFile())
+	assert.False(t, j.Errors().HasError())
+}
+
+func runJob(t *testing.T, root string, db database) LockFile {
+	fileWriterMock := &writerTestdata.FileWriterMock{}
+	j := NewJob(root, db, fileWriterMock)
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	assert.False(t, j.Errors().HasError())
+	var lockFile LockFile
+	assert.NoError(t, json.Unmarshal(fileWriterMock.Contents, &lockFile))
+
+	return lockFile
+}
+
+func TestRunDpkg(t *testing.T) {
+	lockFile := runJob(t, filepath.Join("testdata", "dpkg"), databases[0])
+
+	assert.Equal(t, dpkg, lockFile.PackageManager)
+	assert.Equal(t, Distribution{Id: "debian", VersionId: "12"}, lockFile.Distribution)
+	assert.Equal(t, []Package{
+		{Name: "libc6", Version: "2.36-9+deb12u4", Architecture: "amd64", Source: "glibc"},
+		{Name: "base-files", Version: "12.4+deb12u5", Architecture: "amd64"},
+	}, lockFile.Packages)
+}
+
+func TestRunDistroless(t *testing.T) {
+	lockFile := runJob(t, filepath.Join("testdata", "distroless"), databases[1])
+
+	assert.Equal(t, dpkg, lockFile.PackageManager)
+	assert.Equal(t, Distribution{}, lockFile.Distribution)
+	assert.Equal(t, []Package{{Name: "tzdata", Version: "2024a-0+deb12u1", Architecture: "all"}}, lockFile.Packages)
+}
+
+func TestRunApk(t *testing.T) {
+	lockFile := runJob(t, filepath.Join("testdata", "alpine"), databases[2])
+
+	assert.Equal(t, apk, lockFile.PackageManager)
+	assert.Equal(t, Distribution{Id: "alpine", VersionId: "3.19.1"}, lockFile.Distribution)
+	assert.Len(t, lockFile.Packages, 2)
+}
+
+func TestRunWritesLockFile(t *testing.T) {
+	root := t.TempDir()
+	dbDir := filepath.Join(root, "lib", "apk", "db")
+	assert.NoError(t, os.MkdirAll(dbDir, 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dbDir, "installed"), []byte("P:musl\nV:1.2.4-r4\n"), 0600))
+	j := NewJob(root, databases[2], writer.FileWriter{})
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	assert.False(t, j.Errors().HasError())
+	assert.FileExists(t, filepath.Join(dbDir, "apk.debricked.lock"))
+}
+
+func TestRunReadErr(t *testing.T) {
+	j := NewJob(t.TempDir(), databases[0], &writerTestdata.FileWriterMock{})
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	errs := j.Errors().GetAll()
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "reading package database", errs[0].Status())
+}
+
+func TestRunCreateErr(t *testing.T) {
+	createErr := errors.New("create-error")
+	fileWriterMock := &writerTestdata.FileWriterMock{CreateErr: createErr}
+	j := NewJob(filepath.Join("testdata", "alpine"), databases[2], fileWriterMock)
+
+	expectedError := util.NewPMJobError(createErr.Error())
+	expectedError.SetStatus("creating lock file")
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	assert.Len(t, j.Errors().GetAll(), 1)
+	assert.Contains(t, j.Errors().GetAll(), expectedError)
+}
+
+func TestRunWriteErr(t *testing.T) {
+	writeErr := errors.New("write-error")
+	fileWriterMock := &writerTestdata.FileWriterMock{WriteErr: writeErr}
+	j := NewJob(filepath.Join("testdata", "alpine"), databases[2], fileWriterMock)
+
+	expectedError := util.NewPMJobError(writeErr.Error())
+	expectedError.SetStatus("creating lock file")
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	assert.Len(t, j.Errors().GetAll(), 1)
+	assert.Contains(t, j.Errors().GetAll(), expectedError)
+}
@@ -0,0 +1,40 @@
+package ospkg
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/debricked/cli/internal/resolution/job"
+	"github.com/debricked/cli/internal/resolution/pm/writer"
+)
+
+const Name = "ospkg"
+
+// Strategy collects the installed operating system packages of root directories,
+// such as "/" or a flattened container image
+type Strategy struct {
+	roots []string
+}
+
+func (s Strategy) Invoke() ([]job.IJob, error) {
+	var jobs []job.IJob
+	for _, root := range s.roots {
+		collected := map[string]bool{}
+		for _, db := range databases {
+			if collected[db.packageManager] {
+				continue
+			}
+			if _, err := os.Stat(filepath.Join(root, filepath.FromSlash(db.path))); err != nil {
+				continue
+			}
+			collected[db.packageManager] = true
+			jobs = append(jobs, NewJob(root, db, writer.FileWriter{}))
+		}
+	}
+
+	return jobs, nil
+}
+
+func NewStrategy(roots []string) Strategy {
+	return Strategy{roots}
+}
@@ -0,0 +1,39 @@
+package ospkg
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewStrategy(t *testing.T) {
+	s := NewStrategy(nil)
+	assert.NotNil(t, s)
+	assert.Len(t, s.roots, 0)
+
+	s = NewStrategy([]string{"root-1", "root-2"})
+	assert.NotNil(t, s)
+	assert.Len(t, s.roots, 2)
+}
+
+func TestInvokeNoDatabases(t *testing.T) {
+	s := NewStrategy([]string{t.TempDir()})
+	jobs, err := s.Invoke()
+	assert.NoError(t, err)
+	assert.Empty(t, jobs)
+}
+
+func TestInvoke(t *testing.T) {
+	s := NewStrategy([]string{
+		filepath.Join("testdata", "dpkg"),
+		filepath.Join("testdata", "distroless"),
+		filepath.Join("testdata", "alpine"),
+	})
+	jobs, err := s.Invoke()
+	assert.NoError(t, err)
+	assert.Len(t, jobs, 3)
+	assert.Equal(t, filepath.Join("testdata", "dpkg", "var", "lib", "dpkg", "status"), jobs[0].GetFile())
+	assert.Equal(t, filepath.Join("testdata", "distroless", "var", "lib", "dpkg", "status.d"), jobs[1].GetFile())
+	assert.Equal(t, filepath.Join("testdata", "alpine", "lib", "apk", "db", "installed"), jobs[2].GetFile())
+}
@@ -14,6 +14,7 @@ type ICmdFactory interface {
 	MakeCatCmd(file string) (*exec.Cmd, error)
 	MakeListCmd(command string) (*exec.Cmd, error)
 	MakeShowCmd(command string, list []string) (*exec.Cmd, error)
+	MakeReportCmd(file string) (*exec.Cmd, error)
 }
 
 type IExecPath interface {
@@ -53,6 +54,30 @@ func (cmdf CmdFactory) MakeCreateVenvCmd(fpath string) (*exec.Cmd, error) {
 	}, nil
 }
 
+// MakeReportCmd makes a command resolving the requirements without installing anything,
+// writing an installation report (https://pip.pypa.io/en/stable/reference/installation-report/) to stdout
+func (cmdf CmdFactory) MakeReportCmd(file string) (*exec.Cmd, error) {
+	pythonCommand := "python3"
+	path, err := cmdf.execPath.LookPath(pythonCommand)
+	if err != nil && strings.Contains(err.Error(), "executable file not found") {
+		// Python 3 not found, try Python
+		pythonCommand = "python"
+		path, err = cmdf.execPath.LookPath(pythonCommand)
+	}
+
+	return &exec.Cmd{
+		Path: path,
+		Args: []string{
+			pythonCommand, "-m", "pip", "install",
+			"--dry-run",          // Resolve without installing
+			"--ignore-installed", // Resolve all dependencies, also those already installed
+			"--quiet",            // Only write the report to stdout
+			"--report", "-",
+			"-r", file,
+		},
+	}, err
+}
+
 func (cmdf CmdFactory) MakeInstallCmd(command string, file string) (*exec.Cmd, error) {
 	path, err := cmdf.execPath.LookPath(command)
 
@@ -123,3 +123,22 @@ func TestMakeShowCmd(t *testing.T) {
 	assert.Contains(t, args, "package1")
 	assert.Contains(t, args, "package2")
 }
+
+func TestMakeReportCmd(t *testing.T) {
+	cmd, err := CmdFactory{
+		execPath: ExecPathMock{},
+	}.MakeReportCmd("requirements.txt")
+	assert.NoError(t, err)
+	assert.Equal(t, "python3", cmd.Args[0])
+	assert.Contains(t, cmd.Args, "--dry-run")
+	assert.Contains(t, cmd.Args, "--report")
+	assert.Equal(t, []string{"-r", "requirements.txt"}, cmd.Args[len(cmd.Args)-2:])
+}
+
+func TestMakeReportCmdPythonFallback(t *testing.T) {
+	cmd, err := CmdFactory{
+		execPath: ExecPathMock{python3Error: errors.New("exec: \"python3\": executable file not found in $PATH")},
+	}.MakeReportCmd("requirements.txt")
+	assert.NoError(t, err)
+	assert.Equal(t, "python", cmd.Args[0])
+}
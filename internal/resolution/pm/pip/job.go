@@ -15,41 +15,45 @@ import (
 )
 
 const (
-	lockFileExtension                 = ".pip.debricked.lock"
+	LockFileExtension                 = ".pip.debricked.lock"
 	pip                               = "pip"
-	lockFileDelimiter                 = "***"
+	LockFileDelimiter                 = "***"
 	pythonExecutableNotFoundErrRegex  = `"python": executable file not found`
 	python3ExecutableNotFoundErrRegex = `"python3": executable file not found`
 	pipExecutableNotFoundErrRegex     = `"pip": executable file not found`
 	buildErrRegex                     = `setup.py[ install for]*(?P<dependency>[^ ]*) did not run successfully.`
 	couldNotFindVersionErrRegex       = `Could not find a version that satisfies the requirement`
+	reportOptionErrRegex              = `no such option: --report`
 	//nolint:all
 	invalidCredentialsErrRegex = `WARNING: 401 Error, Credentials not correct for`
 )
 
 type Job struct {
 	job.BaseJob
-	install    bool
-	venvPath   string
-	pipCommand string
-	cmdFactory ICmdFactory
-	fileWriter writer.IFileWriter
-	pipCleaner IPipCleaner
+	install      bool
+	lockfileOnly bool
+	venvPath     string
+	pipCommand   string
+	cmdFactory   ICmdFactory
+	fileWriter   writer.IFileWriter
+	pipCleaner   IPipCleaner
 }
 
 func NewJob(
 	file string,
 	install bool,
+	lockfileOnly bool,
 	cmdFactory ICmdFactory,
 	fileWriter writer.IFileWriter,
 	pipCleaner IPipCleaner,
 ) *Job {
 	return &Job{
-		BaseJob:    job.NewBaseJob(file),
-		install:    install,
-		cmdFactory: cmdFactory,
-		fileWriter: fileWriter,
-		pipCleaner: pipCleaner,
+		BaseJob:      job.NewBaseJob(file),
+		install:      install,
+		lockfileOnly: lockfileOnly,
+		cmdFactory:   cmdFactory,
+		fileWriter:   fileWriter,
+		pipCleaner:   pipCleaner,
 	}
 }
 
@@ -68,6 +72,12 @@ func (j *Job) Install() bool {
 }
 
 func (j *Job) Run() {
+	if j.lockfileOnly {
+		j.runReport()
+
+		return
+	}
+
 	if j.install {
 		defer func() {
 			if j.venvPath == "" {
@@ -111,6 +121,69 @@ func (j *Job) Run() {
 	}
 }
 
+// Plan returns the commands and lock file of the job, without running it. The packages shown by pip show
+// are the packages listed by pip list
+func (j *Job) Plan() job.Plan {
+	var plan job.Plan
+	if j.lockfileOnly {
+		plan.AddCommand(j.cmdFactory.MakeReportCmd(j.GetFile()))
+		plan.AddCommand(j.cmdFactory.MakeCatCmd(j.GetFile()))
+		plan.AddLockFile(j.makeLockFilePath())
+
+		return plan
+	}
+
+	venvPath := ""
+	if j.install {
+		venvPath = j.makeVenvPath()
+		plan.AddCommand(j.cmdFactory.MakeCreateVenvCmd(venvPath))
+		plan.AddCommand(j.cmdFactory.MakeInstallCmd(makePipCommand(venvPath), j.GetFile()))
+	}
+	plan.AddCommand(j.cmdFactory.MakeCatCmd(j.GetFile()))
+	plan.AddCommand(j.cmdFactory.MakeListCmd(makePipCommand(venvPath)))
+	plan.AddCommand(j.cmdFactory.MakeShowCmd(makePipCommand(venvPath), []string{"<listed packages>"}))
+	plan.AddLockFile(j.makeLockFilePath())
+
+	return plan
+}
+
+// runReport resolves the requirements with a pip installation report instead of installing them in a venv
+func (j *Job) runReport() {
+	status := "resolving dependencies"
+	j.SendStatus(status)
+	reportOutput, cmdErr := j.runReportCmd()
+	if cmdErr != nil {
+		cmdErr.SetStatus(status)
+		j.handleError(cmdErr, cmdErr.Documentation())
+
+		return
+	}
+
+	status = "generating lock file"
+	j.SendStatus(status)
+	catCmdOutput, cmdErr := j.runCatCmd()
+	if cmdErr != nil {
+		cmdErr.SetStatus(status)
+		j.Errors().Critical(cmdErr)
+
+		return
+	}
+
+	lockContent, err := MakeLockContentFromReport(reportOutput, catCmdOutput)
+	if err != nil {
+		cmdErr = util.NewPMJobError(err.Error())
+		cmdErr.SetStatus(status)
+		j.Errors().Critical(cmdErr)
+
+		return
+	}
+
+	cmdErr = j.writeLockFile(lockContent)
+	if cmdErr != nil {
+		j.Errors().Critical(cmdErr)
+	}
+}
+
 func (j *Job) handleError(cmdError job.IError, defaultError string) {
 	expressions := []string{
 		pythonExecutableNotFoundErrRegex,
@@ -119,6 +192,7 @@ func (j *Job) handleError(cmdError job.IError, defaultError string) {
 		buildErrRegex,
 		invalidCredentialsErrRegex,
 		couldNotFindVersionErrRegex,
+		reportOptionErrRegex,
 	}
 
 	for _, expression := range expressions {
@@ -153,6 +227,8 @@ func (j *Job) addDocumentation(expr string, matches [][]string, cmdError job.IEr
 		documentation = j.getCredentialErrorDocumentation(cmdError)
 	case couldNotFindVersionErrRegex:
 		documentation = j.getCouldNotFindVersionErrorDocumentation(cmdError)
+	case reportOptionErrRegex:
+		documentation = j.getReportOptionErrorDocumentation()
 	}
 
 	cmdError.SetDocumentation(documentation)
@@ -212,6 +288,14 @@ func (j *Job) getCouldNotFindVersionErrorDocumentation(cmdError job.IError) stri
 		}, "")
 }
 
+func (j *Job) getReportOptionErrorDocumentation() string {
+	return strings.Join(
+		[]string{
+			"Resolving requirements without installing them, using --lockfile-only, requires pip 22.2 or later.",
+			"Please upgrade pip, for example by running \"python3 -m pip install --upgrade pip\".",
+		}, " ")
+}
+
 func (j *Job) writeLockContent() job.IError {
 	status := "generating lock file"
 	j.SendStatus(status)
@@ -237,29 +321,32 @@ func (j *Job) writeLockContent() job.IError {
 		return cmdErr
 	}
 
-	lockFileName := fmt.Sprintf("%s%s", filepath.Base(j.GetFile()), lockFileExtension)
-	lockFile, err := j.fileWriter.Create(util.MakePathFromManifestFile(j.GetFile(), lockFileName))
+	var fileContents []string
+	fileContents = append(fileContents, string(catCmdOutput))
+	fileContents = append(fileContents, LockFileDelimiter)
+	fileContents = append(fileContents, string(listCmdOutput))
+	fileContents = append(fileContents, LockFileDelimiter)
+	fileContents = append(fileContents, string(ShowCmdOutput))
+
+	return j.writeLockFile([]byte(strings.Join(fileContents, "\n")))
+}
+
+func (j *Job) writeLockFile(content []byte) job.IError {
+	status := "generating lock file"
+	lockFile, err := j.fileWriter.Create(j.makeLockFilePath())
 	if err != nil {
-		cmdErr = util.NewPMJobError(err.Error())
+		cmdErr := util.NewPMJobError(err.Error())
 		cmdErr.SetStatus(status)
 
 		return cmdErr
 	}
 	defer closeFile(j, lockFile)
 
-	var fileContents []string
-	fileContents = append(fileContents, string(catCmdOutput))
-	fileContents = append(fileContents, lockFileDelimiter)
-	fileContents = append(fileContents, string(listCmdOutput))
-	fileContents = append(fileContents, lockFileDelimiter)
-	fileContents = append(fileContents, string(ShowCmdOutput))
-	res := []byte(strings.Join(fileContents, "\n"))
-
 	status = "writing lock file"
 	j.SendStatus(status)
-	err = j.fileWriter.Write(lockFile, res)
+	err = j.fileWriter.Write(lockFile, content)
 	if err != nil {
-		cmdErr = util.NewPMJobError(err.Error())
+		cmdErr := util.NewPMJobError(err.Error())
 		cmdErr.SetStatus(status)
 
 		return cmdErr
@@ -268,10 +355,33 @@ func (j *Job) writeLockContent() job.IError {
 	return nil
 }
 
-func (j *Job) runCreateVenvCmd() ([]byte, job.IError) {
+func (j *Job) makeVenvPath() string {
 	venvName := fmt.Sprintf("%s.venv", filepath.Base(j.GetFile()))
-	fpath := filepath.Join(filepath.Dir(j.GetFile()), venvName)
-	j.venvPath = fpath
+
+	return filepath.Join(filepath.Dir(j.GetFile()), venvName)
+}
+
+// makePipCommand returns the pip of the virtual environment, or pip in PATH if there is none
+func makePipCommand(venvPath string) string {
+	if venvPath == "" {
+		return pip
+	}
+	binDir := "bin"
+	if runtime.GOOS == internalOs.Windows {
+		binDir = "Scripts"
+	}
+
+	return filepath.Join(venvPath, binDir, pip)
+}
+
+func (j *Job) makeLockFilePath() string {
+	lockFileName := fmt.Sprintf("%s%s", filepath.Base(j.GetFile()), LockFileExtension)
+
+	return j.LockFilePath(util.MakePathFromManifestFile(j.GetFile(), lockFileName))
+}
+
+func (j *Job) runCreateVenvCmd() ([]byte, job.IError) {
+	j.venvPath = j.makeVenvPath()
 
 	createVenvCmd, err := j.cmdFactory.MakeCreateVenvCmd(j.venvPath)
 	if err != nil {
@@ -284,7 +394,7 @@ func (j *Job) runCreateVenvCmd() ([]byte, job.IError) {
 		return nil, cmdErr
 	}
 
-	createVenvCmdOutput, err := createVenvCmd.Output()
+	createVenvCmdOutput, err := j.Output(createVenvCmd)
 	if err != nil {
 		cmdErr := util.NewPMJobError(j.GetExitError(err, "").Error())
 		cmdErr.SetCommand(createVenvCmd.String())
@@ -296,17 +406,7 @@ func (j *Job) runCreateVenvCmd() ([]byte, job.IError) {
 }
 
 func (j *Job) runInstallCmd() ([]byte, job.IError) {
-	var command string
-	if j.venvPath != "" {
-		binDir := "bin"
-		if runtime.GOOS == internalOs.Windows {
-			binDir = "Scripts"
-		}
-		command = filepath.Join(j.venvPath, binDir, pip)
-	} else {
-		command = pip
-	}
-	j.pipCommand = command
+	j.pipCommand = makePipCommand(j.venvPath)
 	installCmd, err := j.cmdFactory.MakeInstallCmd(j.pipCommand, j.GetFile())
 	if err != nil {
 		cmdErr := util.NewPMJobError(err.Error())
@@ -315,7 +415,7 @@ func (j *Job) runInstallCmd() ([]byte, job.IError) {
 		return nil, cmdErr
 	}
 
-	installCmdOutput, err := installCmd.Output()
+	installCmdOutput, err := j.Output(installCmd)
 	if err != nil {
 		cmdErr := util.NewPMJobError(j.GetExitError(err, "").Error())
 		cmdErr.SetCommand(installCmd.String())
@@ -326,6 +426,26 @@ func (j *Job) runInstallCmd() ([]byte, job.IError) {
 	return installCmdOutput, nil
 }
 
+func (j *Job) runReportCmd() ([]byte, job.IError) {
+	reportCmd, err := j.cmdFactory.MakeReportCmd(j.GetFile())
+	if err != nil {
+		cmdErr := util.NewPMJobError(err.Error())
+		cmdErr.SetCommand(reportCmd.String())
+
+		return nil, cmdErr
+	}
+
+	reportCmdOutput, err := j.Output(reportCmd)
+	if err != nil {
+		cmdErr := util.NewPMJobError(j.GetExitError(err, "").Error())
+		cmdErr.SetCommand(reportCmd.String())
+
+		return nil, cmdErr
+	}
+
+	return reportCmdOutput, nil
+}
+
 func (j *Job) runCatCmd() ([]byte, job.IError) {
 	listCmd, err := j.cmdFactory.MakeCatCmd(j.GetFile())
 	if err != nil {
@@ -335,7 +455,7 @@ func (j *Job) runCatCmd() ([]byte, job.IError) {
 		return nil, cmdErr
 	}
 
-	listCmdOutput, err := listCmd.Output()
+	listCmdOutput, err := j.Output(listCmd)
 	if err != nil {
 		cmdErr := util.NewPMJobError(j.GetExitError(err, "").Error())
 		cmdErr.SetCommand(listCmd.String())
@@ -355,7 +475,7 @@ func (j *Job) runListCmd() ([]byte, job.IError) {
 		return nil, cmdErr
 	}
 
-	listCmdOutput, err := listCmd.Output()
+	listCmdOutput, err := j.Output(listCmd)
 	if err != nil {
 		cmdErr := util.NewPMJobError(j.GetExitError(err, "").Error())
 		cmdErr.SetCommand(listCmd.String())
@@ -375,7 +495,7 @@ func (j *Job) runShowCmd(packages []string) ([]byte, job.IError) {
 		return nil, cmdErr
 	}
 
-	listCmdOutput, err := listCmd.Output()
+	listCmdOutput, err := j.Output(listCmd)
 	if err != nil {
 		cmdErr := util.NewPMJobError(j.GetExitError(err, "").Error())
 		cmdErr.SetCommand(listCmd.String())
@@ -22,7 +22,7 @@ const (
 )
 
 func TestNewJob(t *testing.T) {
-	j := NewJob("file", false, CmdFactory{
+	j := NewJob("file", false, false, CmdFactory{
 		execPath: ExecPath{},
 	}, writer.FileWriter{}, pipCleaner{})
 	assert.Equal(t, "file", j.GetFile())
@@ -62,7 +62,7 @@ func TestRunCreateVenvCmdErr(t *testing.T) {
 			cmdFactoryMock.MakeCreateVenvErr = cmdErr
 			cmd, _ := cmdFactoryMock.MakeCreateVenvCmd("file.venv")
 			fileWriterMock := &writerTestdata.FileWriterMock{}
-			j := NewJob("file", true, cmdFactoryMock, fileWriterMock, pipCleaner{})
+			j := NewJob("file", true, false, cmdFactoryMock, fileWriterMock, pipCleaner{})
 
 			expectedError := util.NewPMJobError(c.error)
 			expectedError.SetDocumentation(c.doc)
@@ -83,7 +83,7 @@ func TestRunCreateVenvCmdErr(t *testing.T) {
 func TestRunCreateVenvCmdOutputErr(t *testing.T) {
 	cmdMock := testdata.NewEchoCmdFactory()
 	cmdMock.CreateVenvCmdName = badName
-	j := NewJob("file", true, cmdMock, nil, pipCleaner{})
+	j := NewJob("file", true, false, cmdMock, nil, pipCleaner{})
 
 	go jobTestdata.WaitStatus(j)
 	j.Run()
@@ -96,7 +96,7 @@ func TestRunInstallCmdErr(t *testing.T) {
 	cmdFactoryMock := testdata.NewEchoCmdFactory()
 	cmdFactoryMock.MakeInstallErr = cmdErr
 	fileWriterMock := &writerTestdata.FileWriterMock{}
-	j := NewJob("file", true, cmdFactoryMock, fileWriterMock, pipCleaner{})
+	j := NewJob("file", true, false, cmdFactoryMock, fileWriterMock, pipCleaner{})
 
 	go jobTestdata.WaitStatus(j)
 	j.Run()
@@ -155,7 +155,7 @@ func TestRunInstallCmdErrors(t *testing.T) {
 			expectedError.SetStatus("installing dependencies")
 			expectedError.SetCommand(cmd.String())
 
-			j := NewJob("file", true, cmdFactoryMock, fileWriterMock, pipCleaner{})
+			j := NewJob("file", true, false, cmdFactoryMock, fileWriterMock, pipCleaner{})
 
 			go jobTestdata.WaitStatus(j)
 			j.Run()
@@ -171,7 +171,7 @@ func TestRunInstallCmdErrors(t *testing.T) {
 func TestRunInstallCmdOutputErr(t *testing.T) {
 	cmdMock := testdata.NewEchoCmdFactory()
 	cmdMock.InstallCmdName = badName
-	j := NewJob("file", true, cmdMock, nil, pipCleaner{})
+	j := NewJob("file", true, false, cmdMock, nil, pipCleaner{})
 
 	go jobTestdata.WaitStatus(j)
 	j.Run()
@@ -184,7 +184,7 @@ func TestRunCatCmdErr(t *testing.T) {
 	cmdFactoryMock := testdata.NewEchoCmdFactory()
 	cmdFactoryMock.MakeCatErr = cmdErr
 	fileWriterMock := &writerTestdata.FileWriterMock{}
-	j := NewJob("file", true, cmdFactoryMock, fileWriterMock, pipCleaner{})
+	j := NewJob("file", true, false, cmdFactoryMock, fileWriterMock, pipCleaner{})
 
 	go jobTestdata.WaitStatus(j)
 	j.Run()
@@ -195,7 +195,7 @@ func TestRunCatCmdErr(t *testing.T) {
 func TestRunCatCmdOutputErr(t *testing.T) {
 	cmdMock := testdata.NewEchoCmdFactory()
 	cmdMock.CatCmdName = badName
-	j := NewJob("file", false, cmdMock, nil, nil)
+	j := NewJob("file", false, false, cmdMock, nil, nil)
 
 	go jobTestdata.WaitStatus(j)
 	j.Run()
@@ -208,7 +208,7 @@ func TestRunListCmdErr(t *testing.T) {
 	cmdFactoryMock := testdata.NewEchoCmdFactory()
 	cmdFactoryMock.MakeListErr = cmdErr
 	fileWriterMock := &writerTestdata.FileWriterMock{}
-	j := NewJob("file", true, cmdFactoryMock, fileWriterMock, pipCleaner{})
+	j := NewJob("file", true, false, cmdFactoryMock, fileWriterMock, pipCleaner{})
 
 	go jobTestdata.WaitStatus(j)
 	j.Run()
@@ -219,7 +219,7 @@ func TestRunListCmdErr(t *testing.T) {
 func TestRunListCmdOutputErr(t *testing.T) {
 	cmdMock := testdata.NewEchoCmdFactory()
 	cmdMock.ListCmdName = badName
-	j := NewJob("file", false, cmdMock, nil, nil)
+	j := NewJob("file", false, false, cmdMock, nil, nil)
 
 	go jobTestdata.WaitStatus(j)
 	j.Run()
@@ -232,7 +232,7 @@ func TestRunShowCmdErr(t *testing.T) {
 	cmdFactoryMock := testdata.NewEchoCmdFactory()
 	cmdFactoryMock.MakeShowErr = cmdErr
 	fileWriterMock := &writerTestdata.FileWriterMock{}
-	j := NewJob("file", true, cmdFactoryMock, fileWriterMock, pipCleaner{})
+	j := NewJob("file", true, false, cmdFactoryMock, fileWriterMock, pipCleaner{})
 
 	go jobTestdata.WaitStatus(j)
 	j.Run()
@@ -243,7 +243,7 @@ func TestRunShowCmdErr(t *testing.T) {
 func TestRunShowCmdOutputErr(t *testing.T) {
 	cmdMock := testdata.NewEchoCmdFactory()
 	cmdMock.ShowCmdName = badName
-	j := NewJob("file", false, cmdMock, nil, nil)
+	j := NewJob("file", false, false, cmdMock, nil, nil)
 
 	go jobTestdata.WaitStatus(j)
 	j.Run()
@@ -262,15 +262,15 @@ func TestRun(t *testing.T) {
 
 	var fileContents []string
 	fileContents = append(fileContents, string(req)+"\n")
-	fileContents = append(fileContents, lockFileDelimiter)
+	fileContents = append(fileContents, LockFileDelimiter)
 	fileContents = append(fileContents, string(list)+"\n")
-	fileContents = append(fileContents, lockFileDelimiter)
+	fileContents = append(fileContents, LockFileDelimiter)
 	fileContents = append(fileContents, string(show)+"\n")
 	res := []byte(strings.Join(fileContents, "\n"))
 
 	fileWriterMock := &writerTestdata.FileWriterMock{}
 	cmdFactoryMock := testdata.NewEchoCmdFactory()
-	j := NewJob("file", true, cmdFactoryMock, fileWriterMock, pipCleaner{})
+	j := NewJob("file", true, false, cmdFactoryMock, fileWriterMock, pipCleaner{})
 
 	go jobTestdata.WaitStatus(j)
 	j.Run()
@@ -283,7 +283,7 @@ func TestRun(t *testing.T) {
 func TestRunInstall(t *testing.T) {
 	cmdFactoryMock := testdata.NewEchoCmdFactory()
 	fileWriterMock := &writerTestdata.FileWriterMock{}
-	j := NewJob("file", false, cmdFactoryMock, fileWriterMock, nil)
+	j := NewJob("file", false, false, cmdFactoryMock, fileWriterMock, nil)
 
 	_, err := j.runInstallCmd()
 	assert.NoError(t, err)
@@ -294,7 +294,7 @@ func TestRunInstall(t *testing.T) {
 func TestRunInstallWVenvPath(t *testing.T) {
 	cmdFactoryMock := testdata.NewEchoCmdFactory()
 	fileWriterMock := &writerTestdata.FileWriterMock{}
-	j := NewJob("file", false, cmdFactoryMock, fileWriterMock, nil)
+	j := NewJob("file", false, false, cmdFactoryMock, fileWriterMock, nil)
 	j.venvPath = "test-path"
 
 	_, err := j.runInstallCmd()
@@ -314,7 +314,7 @@ func TestRunInstallWVenvPath(t *testing.T) {
 }
 
 func TestParsePipList(t *testing.T) {
-	j := NewJob("file", false, CmdFactory{
+	j := NewJob("file", false, false, CmdFactory{
 		execPath: ExecPath{},
 	}, writer.FileWriter{}, pipCleaner{})
 	file, err := os.ReadFile("testdata/list.txt")
@@ -330,7 +330,7 @@ func TestRunCreateErr(t *testing.T) {
 	createErr := errors.New("create-error")
 	fileWriterMock := &writerTestdata.FileWriterMock{CreateErr: createErr}
 	cmdMock := testdata.NewEchoCmdFactory()
-	j := NewJob("file", true, cmdMock, fileWriterMock, pipCleaner{})
+	j := NewJob("file", true, false, cmdMock, fileWriterMock, pipCleaner{})
 
 	go jobTestdata.WaitStatus(j)
 	j.Run()
@@ -342,7 +342,7 @@ func TestRunWriteErr(t *testing.T) {
 	writeErr := errors.New("write-error")
 	fileWriterMock := &writerTestdata.FileWriterMock{WriteErr: writeErr}
 	cmdMock := testdata.NewEchoCmdFactory()
-	j := NewJob("file", true, cmdMock, fileWriterMock, pipCleaner{})
+	j := NewJob("file", true, false, cmdMock, fileWriterMock, pipCleaner{})
 
 	go jobTestdata.WaitStatus(j)
 	j.Run()
@@ -354,7 +354,7 @@ func TestRunCloseErr(t *testing.T) {
 	closeErr := errors.New("close-error")
 	fileWriterMock := &writerTestdata.FileWriterMock{CloseErr: closeErr}
 	cmdMock := testdata.NewEchoCmdFactory()
-	j := NewJob("file", true, cmdMock, fileWriterMock, pipCleaner{})
+	j := NewJob("file", true, false, cmdMock, fileWriterMock, pipCleaner{})
 
 	go jobTestdata.WaitStatus(j)
 	j.Run()
@@ -376,7 +376,7 @@ func TestRunCleanErr(t *testing.T) {
 	CleanErr := errors.New("clean-error")
 	fileWriterMock := &writerTestdata.FileWriterMock{}
 	cmdMock := testdata.NewEchoCmdFactory()
-	j := NewJob("file", true, cmdMock, fileWriterMock, nil)
+	j := NewJob("file", true, false, cmdMock, fileWriterMock, nil)
 	j.pipCleaner = &pipCleanerMock{CleanErr: CleanErr}
 
 	go jobTestdata.WaitStatus(j)
@@ -405,7 +405,7 @@ func TestErrorStillClean(t *testing.T) {
 
 	wasCalled = false
 	cleaner := pipCleanerMockCalled{}
-	j := NewJob("file", true, cmdFactoryMock, fileWriterMock, cleaner)
+	j := NewJob("file", true, false, cmdFactoryMock, fileWriterMock, cleaner)
 
 	go jobTestdata.WaitStatus(j)
 	j.Run()
@@ -413,3 +413,43 @@ func TestErrorStillClean(t *testing.T) {
 	assert.Len(t, j.Errors().GetAll(), 1)
 	assert.True(t, wasCalled)
 }
+
+func TestRunLockfileOnly(t *testing.T) {
+	fileWriterMock := &writerTestdata.FileWriterMock{}
+	cmdFactoryMock := testdata.NewEchoCmdFactory()
+	j := NewJob("file", true, true, cmdFactoryMock, fileWriterMock, pipCleaner{})
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	assert.Empty(t, j.Errors().GetAll())
+	lockFile := string(fileWriterMock.Contents)
+	assert.Contains(t, lockFile, LockFileDelimiter)
+	assert.Contains(t, lockFile, "Name: requests\nVersion: 2.31.0")
+}
+
+func TestRunLockfileOnlyReportCmdErr(t *testing.T) {
+	cmdFactoryMock := testdata.NewEchoCmdFactory()
+	cmdFactoryMock.ReportCmdName = badName
+	fileWriterMock := &writerTestdata.FileWriterMock{}
+	j := NewJob("file", true, true, cmdFactoryMock, fileWriterMock, pipCleaner{})
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	jobTestdata.AssertPathErr(t, j.Errors())
+	assert.Empty(t, fileWriterMock.Contents)
+}
+
+func TestRunLockfileOnlyReportOptionErr(t *testing.T) {
+	cmdFactoryMock := testdata.NewEchoCmdFactory()
+	cmdFactoryMock.MakeReportErr = errors.New("ERROR: no such option: --report")
+	j := NewJob("file", true, true, cmdFactoryMock, &writerTestdata.FileWriterMock{}, pipCleaner{})
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	allErrors := j.Errors().GetAll()
+	assert.Len(t, allErrors, 1)
+	assert.Contains(t, allErrors[0].Documentation(), "requires pip 22.2 or later")
+}
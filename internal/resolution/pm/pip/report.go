@@ -0,0 +1,170 @@
+package pip
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var (
+	requirementNameRegex = regexp.MustCompile(`^\s*([A-Za-z0-9][A-Za-z0-9._-]*)`)
+	extraMarkerRegex     = regexp.MustCompile(`;.*\bextra\s*==`)
+	nameSeparatorRegex   = regexp.MustCompile(`[-_.]+`)
+)
+
+type installationReport struct {
+	Install []installationReportItem `json:"install"`
+}
+
+type installationReportItem struct {
+	IsDirect     bool `json:"is_direct"`
+	DownloadInfo struct {
+		DirInfo *struct{} `json:"dir_info"`
+	} `json:"download_info"`
+	Metadata struct {
+		Name         string   `json:"name"`
+		Version      string   `json:"version"`
+		RequiresDist []string `json:"requires_dist"`
+	} `json:"metadata"`
+}
+
+// isProject returns true if the item is the resolved project itself, which is installed from its directory
+func (item installationReportItem) isProject() bool {
+	return item.IsDirect && item.DownloadInfo.DirInfo != nil
+}
+
+// LockPackage is a resolved package of a pip lock file, with its requirements, such as "idna>=2.5,<4".
+// Requirements of resolved packages are the relations of the package
+type LockPackage struct {
+	Name     string
+	Version  string
+	Requires []string
+}
+
+// MakeLockContentFromReport converts a pip installation report to the sections of the pip lock file,
+// the requirements, a "pip list" and a "pip show" of the resolved packages.
+// Without requirements, the requirements of the resolved project itself are used
+func MakeLockContentFromReport(reportOutput []byte, requirements []byte) ([]byte, error) {
+	var report installationReport
+	err := json.Unmarshal(reportOutput, &report)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse pip installation report. Error: %s", err)
+	}
+
+	var projectRequirements []string
+	var packages []LockPackage
+	for _, item := range report.Install {
+		if item.isProject() {
+			for _, requirement := range item.Metadata.RequiresDist {
+				if !extraMarkerRegex.MatchString(requirement) {
+					projectRequirements = append(projectRequirements, requirement)
+				}
+			}
+
+			continue
+		}
+		packages = append(packages, LockPackage{
+			Name:     item.Metadata.Name,
+			Version:  item.Metadata.Version,
+			Requires: item.Metadata.RequiresDist,
+		})
+	}
+
+	if requirements == nil {
+		requirements = []byte(strings.Join(projectRequirements, "\n"))
+	}
+
+	return MakeLockContent(requirements, packages), nil
+}
+
+// MakeLockContent makes the sections of the pip lock file of the resolved packages,
+// the requirements, a "pip list" and a "pip show" of the packages.
+// Requirements only needed by extras of a package aren't relations of the package
+func MakeLockContent(requirements []byte, packages []LockPackage) []byte {
+	packages = append([]LockPackage(nil), packages...)
+	names := map[string]string{}
+	for _, p := range packages {
+		names[NormalizeName(p.Name)] = p.Name
+	}
+	sort.Slice(packages, func(i, j int) bool {
+		return NormalizeName(packages[i].Name) < NormalizeName(packages[j].Name)
+	})
+
+	requires := map[string][]string{}
+	requiredBy := map[string][]string{}
+	for _, p := range packages {
+		for _, requirement := range p.Requires {
+			requirementName, ok := RequirementName(requirement)
+			if !ok || extraMarkerRegex.MatchString(requirement) {
+				continue
+			}
+			name, ok := names[NormalizeName(requirementName)]
+			if !ok {
+				continue
+			}
+			requires[p.Name] = append(requires[p.Name], name)
+			requiredBy[name] = append(requiredBy[name], p.Name)
+		}
+	}
+
+	sections := []string{
+		string(requirements),
+		makeList(packages),
+		makeShow(packages, requires, requiredBy),
+	}
+
+	return []byte(strings.Join(sections, "\n"+LockFileDelimiter+"\n"))
+}
+
+func makeList(packages []LockPackage) string {
+	nameWidth, versionWidth := len("Package"), len("Version")
+	for _, p := range packages {
+		if len(p.Name) > nameWidth {
+			nameWidth = len(p.Name)
+		}
+		if len(p.Version) > versionWidth {
+			versionWidth = len(p.Version)
+		}
+	}
+
+	lines := []string{
+		fmt.Sprintf("%-*s %s", nameWidth, "Package", "Version"),
+		strings.Repeat("-", nameWidth) + " " + strings.Repeat("-", versionWidth),
+	}
+	for _, p := range packages {
+		lines = append(lines, fmt.Sprintf("%-*s %s", nameWidth, p.Name, p.Version))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func makeShow(packages []LockPackage, requires map[string][]string, requiredBy map[string][]string) string {
+	var blocks []string
+	for _, p := range packages {
+		blocks = append(blocks, strings.Join([]string{
+			"Name: " + p.Name,
+			"Version: " + p.Version,
+			"Requires: " + strings.Join(requires[p.Name], ", "),
+			"Required-by: " + strings.Join(requiredBy[p.Name], ", "),
+		}, "\n"))
+	}
+
+	return strings.Join(blocks, "\n---\n")
+}
+
+// RequirementName returns the name of the package of the requirement, such as idna of "idna>=2.5,<4"
+func RequirementName(requirement string) (string, bool) {
+	match := requirementNameRegex.FindStringSubmatch(requirement)
+	if match == nil {
+		return "", false
+	}
+
+	return match[1], true
+}
+
+// NormalizeName normalizes package names according to PEP 503
+func NormalizeName(name string) string {
+	return nameSeparatorRegex.ReplaceAllString(strings.ToLower(name), "-")
+}
@@ -0,0 +1,112 @@
+package pip
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const expectedPipLockContent = `requests>=2.31
+***
+Package            Version
+------------------ ----------
+certifi            2023.11.17
+charset-normalizer 3.3.2
+idna               3.6
+requests           2.31.0
+urllib3            2.1.0
+***
+Name: certifi
+Version: 2023.11.17
+Requires: 
+Required-by: requests
+---
+Name: charset-normalizer
+Version: 3.3.2
+Requires: 
+Required-by: requests
+---
+Name: idna
+Version: 3.6
+Requires: 
+Required-by: requests
+---
+Name: requests
+Version: 2.31.0
+Requires: charset-normalizer, idna, urllib3, certifi
+Required-by: 
+---
+Name: urllib3
+Version: 2.1.0
+Requires: 
+Required-by: requests`
+
+func TestMakeLockContentFromReport(t *testing.T) {
+	report, err := os.ReadFile(filepath.Join("testdata", "report.json"))
+	assert.NoError(t, err)
+
+	content, err := MakeLockContentFromReport(report, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedPipLockContent, string(content))
+}
+
+func TestMakeLockContentFromReportWithRequirements(t *testing.T) {
+	report, err := os.ReadFile(filepath.Join("testdata", "report.json"))
+	assert.NoError(t, err)
+
+	content, err := MakeLockContentFromReport(report, []byte("requests==2.31.0\n"))
+
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(content), "requests==2.31.0\n\n***\nPackage"))
+}
+
+func TestMakeLockContentFromReportInvalidReport(t *testing.T) {
+	_, err := MakeLockContentFromReport([]byte("Collecting requests"), nil)
+
+	assert.ErrorContains(t, err, "failed to parse pip installation report")
+}
+
+func TestMakeLockContent(t *testing.T) {
+	packages := []LockPackage{
+		{Name: "requests", Version: "2.31.0", Requires: []string{"idna>=2.5,<4", "PySocks!=1.5.7; extra == \"socks\""}},
+		{Name: "idna", Version: "3.6"},
+	}
+
+	content := MakeLockContent([]byte("requests==2.31.0"), packages)
+
+	assert.Equal(t, `requests==2.31.0
+***
+Package  Version
+-------- -------
+idna     3.6
+requests 2.31.0
+***
+Name: idna
+Version: 3.6
+Requires: 
+Required-by: requests
+---
+Name: requests
+Version: 2.31.0
+Requires: idna
+Required-by: `, string(content))
+	assert.Equal(t, "requests", packages[0].Name, "the packages are sorted without reordering the argument")
+}
+
+func TestNormalizeName(t *testing.T) {
+	assert.Equal(t, "charset-normalizer", NormalizeName("Charset_Normalizer"))
+	assert.Equal(t, "zope-interface", NormalizeName("zope.interface"))
+}
+
+func TestRequirementName(t *testing.T) {
+	name, ok := RequirementName("idna>=2.5,<4")
+	assert.True(t, ok)
+	assert.Equal(t, "idna", name)
+
+	_, ok = RequirementName("--index-url https://pypi.example.com")
+	assert.False(t, ok)
+}
@@ -3,18 +3,27 @@ package pip
 import (
 	"github.com/debricked/cli/internal/resolution/job"
 	"github.com/debricked/cli/internal/resolution/pm/writer"
+	"github.com/debricked/cli/internal/resolution/scope"
 )
 
 type Strategy struct {
-	files []string
+	files          []string
+	lockfileOnly   bool
+	excludedScopes scope.Scopes
 }
 
+// Invoke makes one job per requirements file. Files dedicated to an excluded scope, such as requirements-dev.txt
+// or test-requirements.txt, aren't resolved
 func (s Strategy) Invoke() ([]job.IJob, error) {
 	var jobs []job.IJob
 	for _, file := range s.files {
+		if s.excludedScopes.ExcludesFile(file) {
+			continue
+		}
 		jobs = append(jobs, NewJob(
 			file,
 			true,
+			s.lockfileOnly,
 			CmdFactory{
 				execPath: ExecPath{},
 			},
@@ -27,6 +36,6 @@ func (s Strategy) Invoke() ([]job.IJob, error) {
 	return jobs, nil
 }
 
-func NewStrategy(files []string) Strategy {
-	return Strategy{files}
+func NewStrategy(files []string, lockfileOnly bool, excludedScopes scope.Scopes) Strategy {
+	return Strategy{files, lockfileOnly, excludedScopes}
 }
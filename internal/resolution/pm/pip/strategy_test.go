@@ -3,41 +3,81 @@ package pip
 import (
 	"testing"
 
+	"github.com/debricked/cli/internal/resolution/scope"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestNewStrategy(t *testing.T) {
-	s := NewStrategy(nil)
+	s := NewStrategy(nil, false, nil)
 	assert.NotNil(t, s)
 	assert.Len(t, s.files, 0)
 
-	s = NewStrategy([]string{})
+	s = NewStrategy([]string{}, false, nil)
 	assert.NotNil(t, s)
 	assert.Len(t, s.files, 0)
 
-	s = NewStrategy([]string{"file"})
+	s = NewStrategy([]string{"file"}, false, nil)
 	assert.NotNil(t, s)
 	assert.Len(t, s.files, 1)
 
-	s = NewStrategy([]string{"file-1", "file-2"})
+	s = NewStrategy([]string{"file-1", "file-2"}, false, nil)
 	assert.NotNil(t, s)
 	assert.Len(t, s.files, 2)
 }
 
 func TestInvokeNoFiles(t *testing.T) {
-	s := NewStrategy([]string{})
+	s := NewStrategy([]string{}, false, nil)
 	jobs, _ := s.Invoke()
 	assert.Empty(t, jobs)
 }
 
 func TestInvokeOneFile(t *testing.T) {
-	s := NewStrategy([]string{"file"})
+	s := NewStrategy([]string{"file"}, false, nil)
 	jobs, _ := s.Invoke()
 	assert.Len(t, jobs, 1)
 }
 
 func TestInvokeManyFiles(t *testing.T) {
-	s := NewStrategy([]string{"file-1", "file-2"})
+	s := NewStrategy([]string{"file-1", "file-2"}, false, nil)
 	jobs, _ := s.Invoke()
 	assert.Len(t, jobs, 2)
 }
+
+func TestInvokeLockfileOnly(t *testing.T) {
+	s := NewStrategy([]string{"file"}, true, nil)
+	jobs, _ := s.Invoke()
+	assert.Len(t, jobs, 1)
+	j, _ := jobs[0].(*Job)
+	assert.True(t, j.lockfileOnly)
+}
+
+func TestInvokeExcludedScopes(t *testing.T) {
+	files := []string{
+		"requirements.txt",
+		"requirements-dev.txt",
+		"dev-requirements.txt",
+		"requirements_test.txt",
+		"requirements.tests.txt",
+		"requirements-devices.txt",
+	}
+	cases := []struct {
+		excludedScopes scope.Scopes
+		files          []string
+	}{
+		{nil, files},
+		{scope.Scopes{scope.Dev}, []string{"requirements.txt", "requirements_test.txt", "requirements.tests.txt", "requirements-devices.txt"}},
+		{scope.Scopes{scope.Test}, []string{"requirements.txt", "requirements-dev.txt", "dev-requirements.txt", "requirements-devices.txt"}},
+		{scope.Scopes{scope.Dev, scope.Test}, []string{"requirements.txt", "requirements-devices.txt"}},
+	}
+	for _, c := range cases {
+		t.Run(c.excludedScopes.String(), func(t *testing.T) {
+			jobs, err := NewStrategy(files, false, c.excludedScopes).Invoke()
+			assert.NoError(t, err)
+			var resolved []string
+			for _, j := range jobs {
+				resolved = append(resolved, j.GetFile())
+			}
+			assert.Equal(t, c.files, resolved)
+		})
+	}
+}
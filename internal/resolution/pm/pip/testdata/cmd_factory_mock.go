@@ -16,6 +16,8 @@ type CmdFactoryMock struct {
 	MakeListErr       error
 	ShowCmdName       string
 	MakeShowErr       error
+	ReportCmdName     string
+	MakeReportErr     error
 }
 
 func NewEchoCmdFactory() CmdFactoryMock {
@@ -25,6 +27,7 @@ func NewEchoCmdFactory() CmdFactoryMock {
 		CatCmdName:        "echo",
 		ListCmdName:       "echo",
 		ShowCmdName:       "echo",
+		ReportCmdName:     "echo",
 	}
 }
 
@@ -62,3 +65,12 @@ func (f CmdFactoryMock) MakeShowCmd(command string, list []string) (*exec.Cmd, e
 	show := string(fileContent)
 	return exec.Command(f.ShowCmdName, show), f.MakeShowErr
 }
+
+func (f CmdFactoryMock) MakeReportCmd(file string) (*exec.Cmd, error) {
+	fileContent, err := os.ReadFile("testdata/report.json")
+	if err != nil {
+		return nil, err
+	}
+	report := string(fileContent)
+	return exec.Command(f.ReportCmdName, report), f.MakeReportErr
+}
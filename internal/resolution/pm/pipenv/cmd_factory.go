@@ -0,0 +1,50 @@
+package pipenv
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+)
+
+const pipenv = "pipenv"
+
+type ICmdFactory interface {
+	MakeLockCmd(file string, virtualEnvsDir string) (*exec.Cmd, error)
+}
+
+type IExecPath interface {
+	LookPath(file string) (string, error)
+}
+
+type ExecPath struct {
+}
+
+func (ExecPath) LookPath(file string) (string, error) {
+	return exec.LookPath(file)
+}
+
+type CmdFactory struct {
+	execPath IExecPath
+}
+
+// MakeLockCmd makes a command locking the Pipfile. The virtual environment Pipenv creates to resolve
+// dependencies is put in virtualEnvsDir, isolated from any activated or previously created virtual environment
+func (cmdf CmdFactory) MakeLockCmd(file string, virtualEnvsDir string) (*exec.Cmd, error) {
+	path, err := cmdf.execPath.LookPath(pipenv)
+	pipfile, _ := filepath.Abs(file)
+
+	return &exec.Cmd{
+		Path: path,
+		Args: []string{pipenv, "lock"},
+		Dir:  filepath.Dir(file),
+		Env: append(
+			os.Environ(),
+			"PIPENV_PIPFILE="+pipfile,
+			"WORKON_HOME="+virtualEnvsDir,
+			"PIPENV_VENV_IN_PROJECT=0",    // Otherwise the virtual environment is created next to the Pipfile
+			"PIPENV_IGNORE_VIRTUALENVS=1", // Don't use an activated virtual environment
+			"PIPENV_NOSPIN=1",
+			"PIPENV_YES=1",
+		),
+	}, err
+}
@@ -0,0 +1,27 @@
+package pipenv
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type execPathMock struct{}
+
+func (execPathMock) LookPath(file string) (string, error) {
+	return file, nil
+}
+
+func TestMakeLockCmd(t *testing.T) {
+	file := filepath.Join("dir", "Pipfile")
+	cmd, err := CmdFactory{execPath: execPathMock{}}.MakeLockCmd(file, "venvs")
+	assert.NoError(t, err)
+	assert.NotNil(t, cmd)
+	assert.Equal(t, []string{"pipenv", "lock"}, cmd.Args)
+	assert.Equal(t, "dir", cmd.Dir)
+	assert.Contains(t, cmd.Env, "WORKON_HOME=venvs")
+	assert.Contains(t, cmd.Env, "PIPENV_IGNORE_VIRTUALENVS=1")
+	pipfile, _ := filepath.Abs(file)
+	assert.Contains(t, cmd.Env, "PIPENV_PIPFILE="+pipfile)
+}
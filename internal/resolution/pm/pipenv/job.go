@@ -0,0 +1,197 @@
+package pipenv
+
+import (
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/debricked/cli/internal/resolution/job"
+	"github.com/debricked/cli/internal/resolution/pm/util"
+)
+
+const (
+	pipenvExecutableNotFoundErrRegex = `"pipenv": executable file not found`
+	pythonNotFoundErrRegex           = `Python ([^\s]+) was not found on your system`
+	couldNotFindVersionErrRegex      = `Could not find a version that (?:satisfies the requirement|matches) ([^\s(]+)`
+	resolutionFailureErrRegex        = `ResolutionFailure`
+	//nolint:all
+	invalidCredentialsErrRegex = `401 Error, Credentials not correct for|401 Client Error`
+)
+
+type IVirtualEnvs interface {
+	MakeDir() (string, error)
+	RemoveAll(path string) error
+}
+
+// virtualEnvs creates a temporary directory for the virtual environments of each job, removed when the job is done
+type virtualEnvs struct{}
+
+func (virtualEnvs) MakeDir() (string, error) {
+	return os.MkdirTemp("", "debricked-pipenv-")
+}
+
+func (virtualEnvs) RemoveAll(path string) error {
+	return os.RemoveAll(path)
+}
+
+type Job struct {
+	job.BaseJob
+	cmdFactory  ICmdFactory
+	virtualEnvs IVirtualEnvs
+}
+
+func NewJob(
+	file string,
+	cmdFactory ICmdFactory,
+	virtualEnvs IVirtualEnvs,
+) *Job {
+	return &Job{
+		BaseJob:     job.NewBaseJob(file),
+		cmdFactory:  cmdFactory,
+		virtualEnvs: virtualEnvs,
+	}
+}
+
+func (j *Job) Run() {
+	status := "creating virtual environment directory"
+	j.SendStatus(status)
+	virtualEnvsDir, err := j.virtualEnvs.MakeDir()
+	if err != nil {
+		cmdErr := util.NewPMJobError(err.Error())
+		cmdErr.SetStatus(status)
+		j.Errors().Critical(cmdErr)
+
+		return
+	}
+	defer func() {
+		err := j.virtualEnvs.RemoveAll(virtualEnvsDir)
+		if err != nil {
+			cmdErr := util.NewPMJobError(err.Error())
+			cmdErr.SetDocumentation("Error when trying to remove the virtual environment created by Pipenv")
+			cmdErr.SetStatus("removing virtual environment")
+			j.Errors().Critical(cmdErr)
+		}
+	}()
+
+	status = "locking dependencies"
+	j.SendStatus(status)
+	lockCmd, err := j.cmdFactory.MakeLockCmd(j.GetFile(), virtualEnvsDir)
+	if err != nil {
+		cmdErr := util.NewPMJobError(err.Error())
+		cmdErr.SetStatus(status)
+		if lockCmd != nil {
+			cmdErr.SetCommand(lockCmd.String())
+		}
+		j.handleError(cmdErr)
+
+		return
+	}
+
+	output, err := j.Output(lockCmd)
+	if err != nil {
+		cmdErr := util.NewPMJobError(j.GetExitError(err, string(output)).Error())
+		cmdErr.SetStatus(status)
+		cmdErr.SetCommand(lockCmd.String())
+		j.handleError(cmdErr)
+	}
+}
+
+// Plan returns the command and lock file of the job, without running it
+func (j *Job) Plan() job.Plan {
+	var plan job.Plan
+	plan.AddCommand(j.cmdFactory.MakeLockCmd(j.GetFile(), os.TempDir()))
+	plan.AddLockFile(util.MakePathFromManifestFile(j.GetFile(), "Pipfile.lock"))
+
+	return plan
+}
+
+func (j *Job) handleError(cmdError job.IError) {
+	expressions := []string{
+		pipenvExecutableNotFoundErrRegex,
+		pythonNotFoundErrRegex,
+		couldNotFindVersionErrRegex,
+		resolutionFailureErrRegex,
+		invalidCredentialsErrRegex,
+	}
+
+	for _, expression := range expressions {
+		regex := regexp.MustCompile(expression)
+		matches := regex.FindAllStringSubmatch(cmdError.Error(), -1)
+
+		if len(matches) > 0 {
+			cmdError = j.addDocumentation(expression, matches, cmdError)
+			j.Errors().Append(cmdError)
+
+			return
+		}
+	}
+
+	j.Errors().Append(cmdError)
+}
+
+func (j *Job) addDocumentation(expr string, matches [][]string, cmdError job.IError) job.IError {
+	documentation := cmdError.Documentation()
+
+	switch expr {
+	case pipenvExecutableNotFoundErrRegex:
+		documentation = j.GetExecutableNotFoundErrorDocumentation("Pipenv")
+	case pythonNotFoundErrRegex:
+		documentation = j.getPythonNotFoundErrorDocumentation(matches)
+	case couldNotFindVersionErrRegex:
+		documentation = j.getCouldNotFindVersionErrorDocumentation(matches)
+	case resolutionFailureErrRegex:
+		documentation = j.getResolutionFailureErrorDocumentation()
+	case invalidCredentialsErrRegex:
+		documentation = j.getCredentialErrorDocumentation()
+	}
+
+	cmdError.SetDocumentation(documentation)
+
+	return cmdError
+}
+
+func (j *Job) getPythonNotFoundErrorDocumentation(matches [][]string) string {
+	version := ""
+	if len(matches) > 0 && len(matches[0]) > 1 {
+		version = matches[0][1] + " "
+	}
+
+	return strings.Join(
+		[]string{
+			"Python ",
+			version,
+			"required by the Pipfile wasn't found. ",
+			"Please check that it is installed and accessible by the CLI, or update python_version in the Pipfile.",
+		}, "")
+}
+
+func (j *Job) getCouldNotFindVersionErrorDocumentation(matches [][]string) string {
+	dependencyName := ""
+	if len(matches) > 0 && len(matches[0]) > 1 {
+		dependencyName = "\"" + matches[0][1] + "\" "
+	}
+
+	return strings.Join(
+		[]string{
+			"Failed to find a version that satisfies the requirement for python dependency ",
+			dependencyName,
+			"This could mean that the package or version does not exist.\n",
+			util.InstallPrivateDependencyMessage,
+		}, "")
+}
+
+func (j *Job) getResolutionFailureErrorDocumentation() string {
+	return strings.Join(
+		[]string{
+			"Failed to resolve the dependencies of the Pipfile.",
+			"Please check that the version constraints in the Pipfile can be satisfied together.",
+		}, " ")
+}
+
+func (j *Job) getCredentialErrorDocumentation() string {
+	return strings.Join(
+		[]string{
+			"Failed to install python dependency due to authorization.",
+			util.InstallPrivateDependencyMessage,
+		}, "\n")
+}
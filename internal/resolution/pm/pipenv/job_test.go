@@ -0,0 +1,140 @@
+package pipenv
+
+import (
+	"errors"
+	"testing"
+
+	jobTestdata "github.com/debricked/cli/internal/resolution/job/testdata"
+	"github.com/debricked/cli/internal/resolution/pm/pipenv/testdata"
+	"github.com/debricked/cli/internal/resolution/pm/util"
+	"github.com/stretchr/testify/assert"
+)
+
+type virtualEnvsMock struct {
+	makeDirErr   error
+	removeAllErr error
+	removed      []string
+}
+
+func (v *virtualEnvsMock) MakeDir() (string, error) {
+	return "venvs", v.makeDirErr
+}
+
+func (v *virtualEnvsMock) RemoveAll(path string) error {
+	v.removed = append(v.removed, path)
+
+	return v.removeAllErr
+}
+
+func TestNewJob(t *testing.T) {
+	j := NewJob("file", CmdFactory{execPath: ExecPath{}}, virtualEnvs{})
+	assert.Equal(t, "file", j.GetFile())
+	assert.False(t, j.Errors().HasError())
+}
+
+func TestRun(t *testing.T) {
+	venvs := &virtualEnvsMock{}
+	j := NewJob("file", testdata.NewEchoCmdFactory(), venvs)
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	assert.False(t, j.Errors().HasError())
+	assert.Equal(t, []string{"venvs"}, venvs.removed)
+}
+
+func TestRunLockCmdErr(t *testing.T) {
+	cases := []struct {
+		name  string
+		error string
+		doc   string
+	}{
+		{
+			name:  "General error",
+			error: "cmd-error",
+			doc:   util.UnknownError,
+		},
+		{
+			name:  "Pipenv not found",
+			error: "exec: \"pipenv\": executable file not found in $PATH",
+			doc:   "Pipenv wasn't found. Please check if it is installed and accessible by the CLI.",
+		},
+		{
+			name:  "Python not found",
+			error: "Warning: Python 3.8 was not found on your system...\nNeither 'pyenv' nor 'asdf' could be found to install Python.",
+			doc:   "Python 3.8 required by the Pipfile wasn't found. Please check that it is installed and accessible by the CLI, or update python_version in the Pipfile.",
+		},
+		{
+			name:  "Version not found",
+			error: "CRITICAL:pipenv.patched.pip._internal.resolution.resolvelib.factory:Could not find a version that satisfies the requirement django==99.0 (from versions: 1.1.3)\n[ResolutionFailure]: Your dependencies could not be resolved.",
+			doc:   "Failed to find a version that satisfies the requirement for python dependency \"django==99.0\" This could mean that the package or version does not exist.\n" + util.InstallPrivateDependencyMessage,
+		},
+		{
+			name:  "Resolution failure",
+			error: "[ResolutionFailure]: pipenv.exceptions.ResolutionFailure: Warning: Your dependencies could not be resolved.",
+			doc:   "Failed to resolve the dependencies of the Pipfile. Please check that the version constraints in the Pipfile can be satisfied together.",
+		},
+		{
+			name:  "Invalid credentials",
+			error: "401 Client Error: Unauthorized for url: https://pypi.example.com/simple/private-package/",
+			doc:   "Failed to install python dependency due to authorization.\n" + util.InstallPrivateDependencyMessage,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cmdFactoryMock := testdata.NewEchoCmdFactory()
+			cmdFactoryMock.MakeLockErr = errors.New(c.error)
+			cmd, _ := cmdFactoryMock.MakeLockCmd("file", "venvs")
+			venvs := &virtualEnvsMock{}
+			j := NewJob("file", cmdFactoryMock, venvs)
+
+			expectedError := util.NewPMJobError(c.error)
+			expectedError.SetDocumentation(c.doc)
+			expectedError.SetStatus("locking dependencies")
+			expectedError.SetCommand(cmd.String())
+
+			go jobTestdata.WaitStatus(j)
+			j.Run()
+
+			allErrors := j.Errors().GetAll()
+			assert.Len(t, allErrors, 1)
+			assert.Contains(t, allErrors, expectedError)
+			assert.Equal(t, []string{"venvs"}, venvs.removed)
+		})
+	}
+}
+
+func TestRunLockCmdOutputErr(t *testing.T) {
+	cmdFactoryMock := testdata.NewEchoCmdFactory()
+	cmdFactoryMock.LockCmdName = "bad-name"
+	j := NewJob("file", cmdFactoryMock, &virtualEnvsMock{})
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	jobTestdata.AssertPathErr(t, j.Errors())
+}
+
+func TestRunMakeDirErr(t *testing.T) {
+	venvs := &virtualEnvsMock{makeDirErr: errors.New("make-dir-error")}
+	j := NewJob("file", testdata.NewEchoCmdFactory(), venvs)
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	assert.Len(t, j.Errors().GetAll(), 1)
+	assert.Empty(t, venvs.removed)
+}
+
+func TestRunRemoveAllErr(t *testing.T) {
+	venvs := &virtualEnvsMock{removeAllErr: errors.New("remove-error")}
+	j := NewJob("file", testdata.NewEchoCmdFactory(), venvs)
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	allErrors := j.Errors().GetAll()
+	assert.Len(t, allErrors, 1)
+	assert.Equal(t, "removing virtual environment", allErrors[0].Status())
+}
@@ -0,0 +1,64 @@
+package pipenv
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewStrategy(t *testing.T) {
+	s := NewStrategy(nil, false)
+	assert.NotNil(t, s)
+	assert.Len(t, s.files, 0)
+
+	s = NewStrategy([]string{}, false)
+	assert.NotNil(t, s)
+	assert.Len(t, s.files, 0)
+
+	s = NewStrategy([]string{"file"}, false)
+	assert.NotNil(t, s)
+	assert.Len(t, s.files, 1)
+
+	s = NewStrategy([]string{"file-1", "file-2"}, false)
+	assert.NotNil(t, s)
+	assert.Len(t, s.files, 2)
+}
+
+func TestInvokeNoFiles(t *testing.T) {
+	s := NewStrategy([]string{}, false)
+	jobs, _ := s.Invoke()
+	assert.Empty(t, jobs)
+}
+
+func TestInvokeOneFile(t *testing.T) {
+	s := NewStrategy([]string{"file"}, false)
+	jobs, _ := s.Invoke()
+	assert.Len(t, jobs, 1)
+}
+
+func TestInvokeManyFiles(t *testing.T) {
+	s := NewStrategy([]string{"file-1", "file-2"}, false)
+	jobs, _ := s.Invoke()
+	assert.Len(t, jobs, 2)
+}
+
+func TestInvokeLockFileExists(t *testing.T) {
+	dir := t.TempDir()
+	lockedPipfile := filepath.Join(dir, "locked", "Pipfile")
+	pipfile := filepath.Join(dir, "Pipfile")
+	assert.NoError(t, os.MkdirAll(filepath.Dir(lockedPipfile), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "locked", pipfileLockFile), []byte("{}"), 0600))
+
+	s := NewStrategy([]string{lockedPipfile, pipfile}, false)
+	jobs, err := s.Invoke()
+	assert.NoError(t, err)
+	assert.Len(t, jobs, 1)
+	assert.Equal(t, pipfile, jobs[0].GetFile())
+
+	s = NewStrategy([]string{lockedPipfile, pipfile}, true)
+	jobs, err = s.Invoke()
+	assert.NoError(t, err)
+	assert.Len(t, jobs, 2)
+}
@@ -0,0 +1,20 @@
+package testdata
+
+import (
+	"os/exec"
+)
+
+type CmdFactoryMock struct {
+	LockCmdName string
+	MakeLockErr error
+}
+
+func NewEchoCmdFactory() CmdFactoryMock {
+	return CmdFactoryMock{
+		LockCmdName: "echo",
+	}
+}
+
+func (f CmdFactoryMock) MakeLockCmd(file string, _ string) (*exec.Cmd, error) {
+	return exec.Command(f.LockCmdName, file), f.MakeLockErr
+}
@@ -2,6 +2,9 @@ package pm
 
 import (
 	"github.com/debricked/cli/internal/resolution/pm/bower"
+	"github.com/debricked/cli/internal/resolution/pm/bundler"
+	"github.com/debricked/cli/internal/resolution/pm/cargo"
+	"github.com/debricked/cli/internal/resolution/pm/cocoapods"
 	"github.com/debricked/cli/internal/resolution/pm/composer"
 	"github.com/debricked/cli/internal/resolution/pm/gomod"
 	"github.com/debricked/cli/internal/resolution/pm/gradle"
@@ -9,6 +12,11 @@ import (
 	"github.com/debricked/cli/internal/resolution/pm/npm"
 	"github.com/debricked/cli/internal/resolution/pm/nuget"
 	"github.com/debricked/cli/internal/resolution/pm/pip"
+	"github.com/debricked/cli/internal/resolution/pm/pipenv"
+	"github.com/debricked/cli/internal/resolution/pm/pnpm"
+	"github.com/debricked/cli/internal/resolution/pm/pyproject"
+	"github.com/debricked/cli/internal/resolution/pm/sbt"
+	"github.com/debricked/cli/internal/resolution/pm/swift"
 	"github.com/debricked/cli/internal/resolution/pm/yarn"
 )
 
@@ -25,8 +33,16 @@ func Pms() []IPm {
 		pip.NewPm(),
 		yarn.NewPm(),
 		npm.NewPm(),
+		pnpm.NewPm(),
 		bower.NewPm(),
 		nuget.NewPm(),
 		composer.NewPm(),
+		pyproject.NewPm(),
+		pipenv.NewPm(),
+		cargo.NewPm(),
+		bundler.NewPm(),
+		sbt.NewPm(),
+		swift.NewPm(),
+		cocoapods.NewPm(),
 	}
 }
@@ -3,6 +3,7 @@ package pm
 import (
 	"testing"
 
+	"github.com/debricked/cli/internal/file"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -13,6 +14,14 @@ func TestPms(t *testing.T) {
 		"go",
 		"gradle",
 		"composer",
+		"pyproject",
+		"pipenv",
+		"cargo",
+		"bundler",
+		"pnpm",
+		"sbt",
+		"swift",
+		"cocoapods",
 	}
 
 	for _, pmName := range pmNames {
@@ -25,3 +34,9 @@ func TestPms(t *testing.T) {
 		})
 	}
 }
+
+func TestPmsEcosystem(t *testing.T) {
+	for _, pm := range Pms() {
+		assert.NotEqualf(t, file.UnknownEcosystem, file.PmEcosystem(pm.Name()), "failed to assert that %s has an ecosystem", pm.Name())
+	}
+}
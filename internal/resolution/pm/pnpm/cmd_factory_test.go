@@ -0,0 +1,27 @@
+package pnpm
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type execPathMock struct{}
+
+func (execPathMock) LookPath(file string) (string, error) {
+	return file, nil
+}
+
+func TestMakeInstallCmd(t *testing.T) {
+	cmd, err := CmdFactory{
+		execPath: execPathMock{},
+	}.MakeInstallCmd("pnpm", filepath.Join("dir", "package.json"))
+	assert.NoError(t, err)
+	assert.NotNil(t, cmd)
+	args := cmd.Args
+	assert.Contains(t, args, "pnpm")
+	assert.Contains(t, args, "install")
+	assert.Contains(t, args, "--lockfile-only")
+	assert.Equal(t, "dir", cmd.Dir)
+}
@@ -0,0 +1,190 @@
+package pnpm
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/debricked/cli/internal/resolution/job"
+	"github.com/debricked/cli/internal/resolution/pm/util"
+)
+
+const (
+	pnpm                        = "pnpm"
+	executableNotFoundErrRegex  = `executable file not found`
+	versionNotFoundErrRegex     = `ERR_PNPM_NO_MATCHING_VERSION\s+No matching version found for ([^\s]+)`
+	dependencyNotFoundErrRegex  = `([^\s]+) is not in the npm registry`
+	unauthorizedErrRegex        = `ERR_PNPM_FETCH_40[13]\s+GET ([^\s]+?):? `
+	registryUnavailableErrRegex = `getaddrinfo (?:EAI_AGAIN|ENOTFOUND) ([\w\.\-]+)`
+	unsupportedEngineErrRegex   = `ERR_PNPM_UNSUPPORTED_ENGINE`
+)
+
+type Job struct {
+	job.BaseJob
+	install     bool
+	pnpmCommand string
+	cmdFactory  ICmdFactory
+}
+
+func NewJob(
+	file string,
+	install bool,
+	cmdFactory ICmdFactory,
+) *Job {
+	return &Job{
+		BaseJob:    job.NewBaseJob(file),
+		install:    install,
+		cmdFactory: cmdFactory,
+	}
+}
+
+func (j *Job) Install() bool {
+	return j.install
+}
+
+func (j *Job) Run() {
+	if j.install {
+		status := "installing dependencies"
+		j.SendStatus(status)
+		j.pnpmCommand = pnpm
+
+		installCmd, err := j.cmdFactory.MakeInstallCmd(j.pnpmCommand, j.GetFile())
+
+		if err != nil {
+			j.handleError(j.createError(err.Error(), installCmd.String(), status))
+
+			return
+		}
+
+		if output, err := j.Output(installCmd); err != nil {
+			error := strings.Join([]string{string(output), j.GetExitError(err, "").Error()}, "")
+			j.handleError(j.createError(error, installCmd.String(), status))
+
+			return
+		}
+	}
+}
+
+// Plan returns the command and lock file of the job, without running it
+func (j *Job) Plan() job.Plan {
+	var plan job.Plan
+	if j.install {
+		plan.AddCommand(j.cmdFactory.MakeInstallCmd(pnpm, j.GetFile()))
+		plan.AddLockFile(util.MakePathFromManifestFile(j.GetFile(), "pnpm-lock.yaml"))
+	}
+
+	return plan
+}
+
+func (j *Job) createError(error string, cmd string, status string) job.IError {
+	cmdError := util.NewPMJobError(error)
+	cmdError.SetCommand(cmd)
+	cmdError.SetStatus(status)
+
+	return cmdError
+}
+
+func (j *Job) handleError(cmdError job.IError) {
+	expressions := []string{
+		executableNotFoundErrRegex,
+		versionNotFoundErrRegex,
+		dependencyNotFoundErrRegex,
+		unauthorizedErrRegex,
+		registryUnavailableErrRegex,
+		unsupportedEngineErrRegex,
+	}
+
+	for _, expression := range expressions {
+		regex := regexp.MustCompile(expression)
+		matches := regex.FindAllStringSubmatch(cmdError.Error(), -1)
+
+		if len(matches) > 0 {
+			cmdError = j.addDocumentation(expression, matches, cmdError)
+			j.Errors().Append(cmdError)
+
+			return
+		}
+	}
+
+	j.Errors().Append(cmdError)
+}
+
+func (j *Job) addDocumentation(expr string, matches [][]string, cmdError job.IError) job.IError {
+	documentation := cmdError.Documentation()
+
+	switch expr {
+	case executableNotFoundErrRegex:
+		documentation = j.GetExecutableNotFoundErrorDocumentation("pnpm")
+	case versionNotFoundErrRegex:
+		documentation = j.getVersionNotFoundErrorDocumentation(matches)
+	case dependencyNotFoundErrRegex:
+		documentation = j.getDependencyNotFoundErrorDocumentation(matches)
+	case unauthorizedErrRegex:
+		documentation = j.getUnauthorizedErrorDocumentation(matches)
+	case registryUnavailableErrRegex:
+		documentation = j.getRegistryUnavailableErrorDocumentation(matches)
+	case unsupportedEngineErrRegex:
+		documentation = j.getUnsupportedEngineErrorDocumentation()
+	}
+
+	cmdError.SetDocumentation(documentation)
+
+	return cmdError
+}
+
+func firstMatch(matches [][]string) string {
+	if len(matches) > 0 && len(matches[0]) > 1 {
+		return matches[0][1]
+	}
+
+	return ""
+}
+
+func (j *Job) getVersionNotFoundErrorDocumentation(matches [][]string) string {
+	return strings.Join(
+		[]string{
+			"Failed to find package",
+			"\"" + firstMatch(matches) + "\"",
+			"that satisfies the requirement from package.json file.",
+			"In most cases you or one of your dependencies are requesting a package version that doesn't exist.",
+			"Please check that package versions are correct in your package.json file.",
+		}, " ")
+}
+
+func (j *Job) getDependencyNotFoundErrorDocumentation(matches [][]string) string {
+	return strings.Join(
+		[]string{
+			"Failed to find package",
+			"\"" + firstMatch(matches) + "\"",
+			"that satisfies the requirement from dependencies.",
+			"Please check that dependencies are correct in your package.json file.",
+			"\n" + util.InstallPrivateDependencyMessage,
+		}, " ")
+}
+
+func (j *Job) getUnauthorizedErrorDocumentation(matches [][]string) string {
+	return strings.Join(
+		[]string{
+			"Failed to authenticate when fetching",
+			"\"" + firstMatch(matches) + "\".",
+			"Please make sure that the registry credentials in .npmrc are available to the CLI.",
+			"\n" + util.InstallPrivateDependencyMessage,
+		}, " ")
+}
+
+func (j *Job) getRegistryUnavailableErrorDocumentation(matches [][]string) string {
+	return strings.Join(
+		[]string{
+			"Package registry",
+			"\"" + firstMatch(matches) + "\"",
+			"is not available at the moment.",
+			"There might be a trouble with your network connection.",
+		}, " ")
+}
+
+func (j *Job) getUnsupportedEngineErrorDocumentation() string {
+	return strings.Join(
+		[]string{
+			"The pnpm or Node.js version accessible by the CLI doesn't satisfy the engines field of package.json.",
+			"Please install a supported version, or set the packageManager field of package.json to a pnpm version that corepack can use.",
+		}, " ")
+}
@@ -0,0 +1,110 @@
+package pnpm
+
+import (
+	"errors"
+	"testing"
+
+	jobTestdata "github.com/debricked/cli/internal/resolution/job/testdata"
+	"github.com/debricked/cli/internal/resolution/pm/pnpm/testdata"
+	"github.com/debricked/cli/internal/resolution/pm/util"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	badName = "bad-name"
+)
+
+func TestNewJob(t *testing.T) {
+	j := NewJob("file", false, CmdFactory{
+		execPath: ExecPath{},
+	})
+	assert.Equal(t, "file", j.GetFile())
+	assert.False(t, j.Errors().HasError())
+}
+
+func TestInstall(t *testing.T) {
+	j := Job{install: true}
+	assert.Equal(t, true, j.Install())
+
+	j = Job{install: false}
+	assert.Equal(t, false, j.Install())
+}
+
+func TestRunInstallCmdErr(t *testing.T) {
+	cases := []struct {
+		name  string
+		error string
+		doc   string
+	}{
+		{
+			name:  "General error",
+			error: "cmd-error",
+			doc:   util.UnknownError,
+		},
+		{
+			name:  "pnpm not found",
+			error: "        |exec: \"pnpm\": executable file not found in $PATH",
+			doc:   "pnpm wasn't found. Please check if it is installed and accessible by the CLI.",
+		},
+		{
+			name:  "Invalid package version",
+			error: " ERR_PNPM_NO_MATCHING_VERSION  No matching version found for chalk@^113.0.0\n\nThis error happened while installing a direct dependency of /app",
+			doc:   "Failed to find package \"chalk@^113.0.0\" that satisfies the requirement from package.json file. In most cases you or one of your dependencies are requesting a package version that doesn't exist. Please check that package versions are correct in your package.json file.",
+		},
+		{
+			name:  "Invalid package name or private package",
+			error: " ERR_PNPM_FETCH_404  GET https://registry.npmjs.org/chalke: Not Found - 404\n\nThis error happened while installing a direct dependency of /app\n\nchalke is not in the npm registry, or you have no permission to fetch it.",
+			doc:   "Failed to find package \"chalke\" that satisfies the requirement from dependencies. Please check that dependencies are correct in your package.json file. \n" + util.InstallPrivateDependencyMessage,
+		},
+		{
+			name:  "Unauthorized",
+			error: " ERR_PNPM_FETCH_401  GET https://npm.example.com/@acme%2Fui: Unauthorized - 401\n\nNo authorization header was set for the request.",
+			doc:   "Failed to authenticate when fetching \"https://npm.example.com/@acme%2Fui\". Please make sure that the registry credentials in .npmrc are available to the CLI. \n" + util.InstallPrivateDependencyMessage,
+		},
+		{
+			name:  "No internet connection",
+			error: " WARN  GET https://registry.npmjs.org/chalk error (EAI_AGAIN). Will retry in 10 seconds.\n ERR_PNPM_META_FETCH_FAIL  GET https://registry.npmjs.org/chalk: request to https://registry.npmjs.org/chalk failed, reason: getaddrinfo EAI_AGAIN registry.npmjs.org",
+			doc:   "Package registry \"registry.npmjs.org\" is not available at the moment. There might be a trouble with your network connection.",
+		},
+		{
+			name:  "Unsupported engine",
+			error: " ERR_PNPM_UNSUPPORTED_ENGINE  Unsupported environment (bad pnpm and/or Node.js version)",
+			doc:   "The pnpm or Node.js version accessible by the CLI doesn't satisfy the engines field of package.json. Please install a supported version, or set the packageManager field of package.json to a pnpm version that corepack can use.",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cmdErr := errors.New(c.error)
+			cmdFactoryMock := testdata.NewEchoCmdFactory()
+			cmdFactoryMock.MakeInstallErr = cmdErr
+			cmd, _ := cmdFactoryMock.MakeInstallCmd("echo", "package.json")
+
+			expectedError := util.NewPMJobError(c.error)
+			expectedError.SetDocumentation(c.doc)
+			expectedError.SetStatus("installing dependencies")
+			expectedError.SetCommand(cmd.String())
+
+			j := NewJob("file", true, cmdFactoryMock)
+
+			go jobTestdata.WaitStatus(j)
+			j.Run()
+
+			allErrors := j.Errors().GetAll()
+
+			assert.Len(t, j.Errors().GetAll(), 1)
+			assert.Contains(t, allErrors, expectedError)
+		})
+	}
+}
+
+func TestRunInstallCmdOutputErr(t *testing.T) {
+	cmdMock := testdata.NewEchoCmdFactory()
+	cmdMock.InstallCmdName = badName
+	j := NewJob("file", true, cmdMock)
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	jobTestdata.AssertPathErr(t, j.Errors())
+}
@@ -0,0 +1,94 @@
+package pyproject
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	poetry  = "poetry"
+	python  = "python"
+	python3 = "python3"
+)
+
+type ICmdFactory interface {
+	MakeLockCmd(file string, noUpdate bool) (*exec.Cmd, error)
+	MakeShowCmd(file string, withoutGroups []string) (*exec.Cmd, error)
+	MakeReportCmd(file string) (*exec.Cmd, error)
+}
+
+type IExecPath interface {
+	LookPath(file string) (string, error)
+}
+
+type ExecPath struct {
+}
+
+func (ExecPath) LookPath(file string) (string, error) {
+	return exec.LookPath(file)
+}
+
+type CmdFactory struct {
+	execPath IExecPath
+}
+
+// MakeLockCmd makes a command locking the Poetry project without updating already locked versions.
+// Poetry 2 removed the --no-update option, since it is the default behaviour
+func (cmdf CmdFactory) MakeLockCmd(file string, noUpdate bool) (*exec.Cmd, error) {
+	path, err := cmdf.execPath.LookPath(poetry)
+	args := []string{poetry, "lock", "--no-interaction"}
+	if noUpdate {
+		args = append(args, "--no-update")
+	}
+
+	return &exec.Cmd{
+		Path: path,
+		Args: args,
+		Dir:  filepath.Dir(file),
+		Env:  os.Environ(),
+	}, err
+}
+
+// MakeShowCmd makes a command printing the dependency tree of the Poetry project, without the packages only needed by withoutGroups
+func (cmdf CmdFactory) MakeShowCmd(file string, withoutGroups []string) (*exec.Cmd, error) {
+	path, err := cmdf.execPath.LookPath(poetry)
+	args := []string{poetry, "show", "--tree", "--no-ansi", "--no-interaction"}
+	if len(withoutGroups) > 0 {
+		args = append(args, "--without", strings.Join(withoutGroups, ","))
+	}
+
+	return &exec.Cmd{
+		Path: path,
+		Args: args,
+		Dir:  filepath.Dir(file),
+		Env:  os.Environ(),
+	}, err
+}
+
+// MakeReportCmd makes a command resolving the project without installing anything,
+// writing an installation report (https://pip.pypa.io/en/stable/reference/installation-report/) to stdout
+func (cmdf CmdFactory) MakeReportCmd(file string) (*exec.Cmd, error) {
+	pythonCommand := python3
+	path, err := cmdf.execPath.LookPath(pythonCommand)
+	if err != nil && strings.Contains(err.Error(), "executable file not found") {
+		// Python 3 not found, try Python
+		pythonCommand = python
+		path, err = cmdf.execPath.LookPath(pythonCommand)
+	}
+
+	return &exec.Cmd{
+		Path: path,
+		Args: []string{
+			pythonCommand, "-m", "pip", "install",
+			"--dry-run",          // Resolve without installing
+			"--ignore-installed", // Resolve all dependencies, also those already installed
+			"--quiet",            // Only write the report to stdout
+			"--report", "-",
+			".",
+		},
+		Dir: filepath.Dir(file),
+		Env: os.Environ(),
+	}, err
+}
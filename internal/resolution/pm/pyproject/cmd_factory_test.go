@@ -0,0 +1,65 @@
+package pyproject
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type execPathMock struct {
+	python3Error error
+}
+
+func (epm execPathMock) LookPath(file string) (string, error) {
+	if epm.python3Error != nil && file == python3 {
+		return "", epm.python3Error
+	}
+
+	return file, nil
+}
+
+func TestMakeLockCmd(t *testing.T) {
+	file := filepath.Join("dir", "pyproject.toml")
+	cmd, _ := CmdFactory{execPath: execPathMock{}}.MakeLockCmd(file, true)
+	assert.NotNil(t, cmd)
+	assert.Equal(t, []string{"poetry", "lock", "--no-interaction", "--no-update"}, cmd.Args)
+	assert.Equal(t, "dir", cmd.Dir)
+
+	cmd, _ = CmdFactory{execPath: execPathMock{}}.MakeLockCmd(file, false)
+	assert.NotContains(t, cmd.Args, "--no-update")
+}
+
+func TestMakeShowCmd(t *testing.T) {
+	cmd, _ := CmdFactory{execPath: execPathMock{}}.MakeShowCmd(filepath.Join("dir", "pyproject.toml"), nil)
+	assert.NotNil(t, cmd)
+	assert.Contains(t, cmd.Args, "poetry")
+	assert.Contains(t, cmd.Args, "show")
+	assert.Contains(t, cmd.Args, "--tree")
+	assert.NotContains(t, cmd.Args, "--without")
+	assert.Equal(t, "dir", cmd.Dir)
+}
+
+func TestMakeShowCmdWithoutGroups(t *testing.T) {
+	cmd, _ := CmdFactory{execPath: execPathMock{}}.MakeShowCmd("pyproject.toml", []string{"dev", "tests"})
+	assert.NotNil(t, cmd)
+	assert.Equal(t, []string{"--without", "dev,tests"}, cmd.Args[len(cmd.Args)-2:])
+}
+
+func TestMakeReportCmd(t *testing.T) {
+	cmd, err := CmdFactory{execPath: execPathMock{}}.MakeReportCmd(filepath.Join("dir", "pyproject.toml"))
+	assert.NoError(t, err)
+	assert.NotNil(t, cmd)
+	assert.Equal(t, "python3", cmd.Args[0])
+	assert.Contains(t, cmd.Args, "--dry-run")
+	assert.Contains(t, cmd.Args, "--report")
+	assert.Equal(t, "dir", cmd.Dir)
+}
+
+func TestMakeReportCmdPython3Error(t *testing.T) {
+	execPath := execPathMock{python3Error: errors.New("executable file not found in $PATH")}
+	cmd, err := CmdFactory{execPath: execPath}.MakeReportCmd("pyproject.toml")
+	assert.NoError(t, err)
+	assert.Equal(t, "python", cmd.Args[0])
+}
@@ -0,0 +1,342 @@
+package pyproject
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/debricked/cli/internal/resolution/job"
+	"github.com/debricked/cli/internal/resolution/pm/pip"
+	"github.com/debricked/cli/internal/resolution/pm/util"
+	"github.com/debricked/cli/internal/resolution/pm/writer"
+	"github.com/debricked/cli/internal/resolution/scope"
+	"github.com/pelletier/go-toml/v2"
+)
+
+const (
+	lockFileName                     = "pyproject.debricked.lock"
+	poetryExecutableNotFoundErrRegex = `"poetry": executable file not found`
+	pythonExecutableNotFoundErrRegex = `"python3?": executable file not found`
+	noUpdateOptionErrRegex           = `The option "--no-update" does not exist`
+	versionSolvingErrRegex           = `version solving failed`
+	resolutionImpossibleErrRegex     = `ResolutionImpossible`
+	noMatchingDistributionErrRegex   = `No matching distribution found for ([^\s]+)`
+	reportOptionErrRegex             = `no such option: --report`
+	invalidCredentialsErrRegex       = `401 Error, Credentials not correct for|HTTP error 401`
+)
+
+type pyprojectToml struct {
+	Project struct {
+		Dependencies []string `toml:"dependencies"`
+	} `toml:"project"`
+	Tool struct {
+		Poetry map[string]any `toml:"poetry"`
+	} `toml:"tool"`
+	BuildSystem struct {
+		BuildBackend string `toml:"build-backend"`
+	} `toml:"build-system"`
+}
+
+type Job struct {
+	job.BaseJob
+	excludedScopes scope.Scopes
+	cmdFactory     ICmdFactory
+	fileWriter     writer.IFileWriter
+}
+
+func NewJob(
+	file string,
+	excludedScopes scope.Scopes,
+	cmdFactory ICmdFactory,
+	fileWriter writer.IFileWriter,
+) *Job {
+	return &Job{
+		BaseJob:        job.NewBaseJob(file),
+		excludedScopes: excludedScopes,
+		cmdFactory:     cmdFactory,
+		fileWriter:     fileWriter,
+	}
+}
+
+func (j *Job) Run() {
+	status := "reading pyproject.toml"
+	j.SendStatus(status)
+	isPoetry, err := j.isPoetryProject()
+	if err != nil {
+		j.handleError(j.createError(err.Error(), "", status))
+
+		return
+	}
+
+	if isPoetry {
+		j.runPoetry()
+	} else {
+		j.runPep621()
+	}
+}
+
+// Plan returns the commands and lock files of the job, without running it. Poetry without the --no-update option
+// of its lock command is locked again without it
+func (j *Job) Plan() job.Plan {
+	var plan job.Plan
+	isPoetry, err := j.isPoetryProject()
+	if err != nil {
+		return plan
+	}
+
+	if isPoetry {
+		plan.AddCommand(j.cmdFactory.MakeLockCmd(j.GetFile(), true))
+		plan.AddCommand(j.cmdFactory.MakeShowCmd(j.GetFile(), j.excludedGroups()))
+		plan.AddLockFile(util.MakePathFromManifestFile(j.GetFile(), poetryLockFile))
+	} else {
+		plan.AddCommand(j.cmdFactory.MakeReportCmd(j.GetFile()))
+	}
+	plan.AddLockFile(util.MakePathFromManifestFile(j.GetFile(), lockFileName))
+
+	return plan
+}
+
+// isPoetryProject returns true if the project is managed by Poetry, either by a [tool.poetry] table or by its build backend
+func (j *Job) isPoetryProject() (bool, error) {
+	pyproject, err := j.readPyproject()
+	if err != nil {
+		return false, err
+	}
+
+	return pyproject.Tool.Poetry != nil || strings.HasPrefix(pyproject.BuildSystem.BuildBackend, "poetry"), nil
+}
+
+// excludedGroups returns the dependency groups of the Poetry project named after an excluded scope, such as dev or tests.
+// The dev-dependencies table of Poetry before 1.2 is the dev group
+func (j *Job) excludedGroups() []string {
+	if len(j.excludedScopes) == 0 {
+		return nil
+	}
+	pyproject, err := j.readPyproject()
+	if err != nil {
+		return nil
+	}
+	groups := map[string]bool{}
+	if _, ok := pyproject.Tool.Poetry["dev-dependencies"]; ok {
+		groups[scope.Dev] = true
+	}
+	if poetryGroups, ok := pyproject.Tool.Poetry["group"].(map[string]any); ok {
+		for group := range poetryGroups {
+			groups[group] = true
+		}
+	}
+	var excludedGroups []string
+	for group := range groups {
+		for _, excludedScope := range j.excludedScopes {
+			if strings.HasPrefix(strings.ToLower(group), excludedScope) {
+				excludedGroups = append(excludedGroups, group)
+
+				break
+			}
+		}
+	}
+	sort.Strings(excludedGroups)
+
+	return excludedGroups
+}
+
+func (j *Job) readPyproject() (pyprojectToml, error) {
+	var pyproject pyprojectToml
+	content, err := os.ReadFile(j.GetFile())
+	if err != nil {
+		return pyproject, err
+	}
+	err = toml.Unmarshal(content, &pyproject)
+	if err != nil {
+		return pyproject, fmt.Errorf("failed to parse %s. Error: %s", j.GetFile(), err)
+	}
+
+	return pyproject, nil
+}
+
+// runPoetry creates or updates poetry.lock and writes the locked packages of the project to a pip lock file
+func (j *Job) runPoetry() {
+	status := "locking dependencies"
+	j.SendStatus(status)
+	_, cmd, err := j.runCmd(j.cmdFactory.MakeLockCmd(j.GetFile(), true))
+	if err != nil && regexp.MustCompile(noUpdateOptionErrRegex).MatchString(err.Error()) {
+		_, cmd, err = j.runCmd(j.cmdFactory.MakeLockCmd(j.GetFile(), false))
+	}
+	if err != nil {
+		j.handleError(j.createError(err.Error(), cmd, status))
+
+		return
+	}
+
+	status = "creating dependency graph"
+	j.SendStatus(status)
+	treeOutput, cmd, err := j.runCmd(j.cmdFactory.MakeShowCmd(j.GetFile(), j.excludedGroups()))
+	if err != nil {
+		j.handleError(j.createError(err.Error(), cmd, status))
+
+		return
+	}
+
+	lockContent, err := j.makePoetryLockContent(treeOutput)
+	if err != nil {
+		j.handleError(j.createError(err.Error(), "", status))
+
+		return
+	}
+
+	j.writeLockFile(lockContent)
+}
+
+// runPep621 resolves the project with pip and writes the resolved packages to a pip lock file
+func (j *Job) runPep621() {
+	status := "resolving dependencies"
+	j.SendStatus(status)
+	reportOutput, cmd, err := j.runCmd(j.cmdFactory.MakeReportCmd(j.GetFile()))
+	if err != nil {
+		j.handleError(j.createError(err.Error(), cmd, status))
+
+		return
+	}
+
+	lockContent, err := pip.MakeLockContentFromReport(reportOutput, nil)
+	if err != nil {
+		j.handleError(j.createError(err.Error(), cmd, status))
+
+		return
+	}
+
+	j.writeLockFile(lockContent)
+}
+
+func (j *Job) writeLockFile(content []byte) {
+	status := "creating lock file"
+	j.SendStatus(status)
+	lockFile, err := j.fileWriter.Create(j.LockFilePath(util.MakePathFromManifestFile(j.GetFile(), lockFileName)))
+	if err != nil {
+		j.handleError(j.createError(err.Error(), "", status))
+
+		return
+	}
+	defer util.CloseFile(j, j.fileWriter, lockFile)
+
+	err = j.fileWriter.Write(lockFile, content)
+	if err != nil {
+		j.handleError(j.createError(err.Error(), "", status))
+	}
+}
+
+func (j *Job) runCmd(cmd *exec.Cmd, err error) ([]byte, string, error) {
+	if err != nil {
+		if cmd == nil {
+			return nil, "", err
+		}
+
+		return nil, cmd.String(), err
+	}
+
+	output, err := j.Output(cmd)
+	if err != nil {
+		return nil, cmd.String(), j.GetExitError(err, string(output))
+	}
+
+	return output, cmd.String(), nil
+}
+
+func (j *Job) createError(error string, cmd string, status string) job.IError {
+	cmdError := util.NewPMJobError(error)
+	cmdError.SetCommand(cmd)
+	cmdError.SetStatus(status)
+
+	return cmdError
+}
+
+func (j *Job) handleError(cmdError job.IError) {
+	expressions := []string{
+		poetryExecutableNotFoundErrRegex,
+		pythonExecutableNotFoundErrRegex,
+		versionSolvingErrRegex,
+		resolutionImpossibleErrRegex,
+		noMatchingDistributionErrRegex,
+		reportOptionErrRegex,
+		invalidCredentialsErrRegex,
+	}
+
+	for _, expression := range expressions {
+		regex := regexp.MustCompile(expression)
+		matches := regex.FindAllStringSubmatch(cmdError.Error(), -1)
+
+		if len(matches) > 0 {
+			cmdError = j.addDocumentation(expression, matches, cmdError)
+			j.Errors().Append(cmdError)
+
+			return
+		}
+	}
+
+	j.Errors().Append(cmdError)
+}
+
+func (j *Job) addDocumentation(expr string, matches [][]string, cmdError job.IError) job.IError {
+	documentation := cmdError.Documentation()
+
+	switch expr {
+	case poetryExecutableNotFoundErrRegex:
+		documentation = j.GetExecutableNotFoundErrorDocumentation("Poetry")
+	case pythonExecutableNotFoundErrRegex:
+		documentation = j.GetExecutableNotFoundErrorDocumentation("Python")
+	case versionSolvingErrRegex, resolutionImpossibleErrRegex:
+		documentation = j.getVersionSolvingErrorDocumentation()
+	case noMatchingDistributionErrRegex:
+		documentation = j.getNoMatchingDistributionErrorDocumentation(matches)
+	case reportOptionErrRegex:
+		documentation = j.getReportOptionErrorDocumentation()
+	case invalidCredentialsErrRegex:
+		documentation = j.getCredentialErrorDocumentation()
+	}
+
+	cmdError.SetDocumentation(documentation)
+
+	return cmdError
+}
+
+func (j *Job) getVersionSolvingErrorDocumentation() string {
+	return strings.Join(
+		[]string{
+			"Failed to resolve the dependencies of the project.",
+			"Please check that the version constraints in pyproject.toml can be satisfied together.",
+		}, " ")
+}
+
+func (j *Job) getNoMatchingDistributionErrorDocumentation(matches [][]string) string {
+	dependencyName := ""
+	if len(matches) > 0 && len(matches[0]) > 1 {
+		dependencyName = "\"" + matches[0][1] + "\" "
+	}
+
+	return strings.Join(
+		[]string{
+			"Failed to find a version that satisfies the requirement for python dependency ",
+			dependencyName,
+			"This could mean that the package or version does not exist.\n",
+			util.InstallPrivateDependencyMessage,
+		}, "")
+}
+
+func (j *Job) getReportOptionErrorDocumentation() string {
+	return strings.Join(
+		[]string{
+			"Resolving pyproject.toml without Poetry requires pip 22.2 or later.",
+			"Please upgrade pip, for example by running \"python3 -m pip install --upgrade pip\".",
+		}, " ")
+}
+
+func (j *Job) getCredentialErrorDocumentation() string {
+	return strings.Join(
+		[]string{
+			"Failed to install python dependency due to authorization.",
+			util.InstallPrivateDependencyMessage,
+		}, "\n")
+}
@@ -0,0 +1,369 @@
+package pyproject
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	jobTestdata "github.com/debricked/cli/internal/resolution/job/testdata"
+	"github.com/debricked/cli/internal/resolution/pm/pyproject/testdata"
+	"github.com/debricked/cli/internal/resolution/pm/util"
+	"github.com/debricked/cli/internal/resolution/pm/writer"
+	writerTestdata "github.com/debricked/cli/internal/resolution/pm/writer/testdata"
+	"github.com/debricked/cli/internal/resolution/scope"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	poetryFile = filepath.Join("testdata", "poetry", "pyproject.toml")
+	pep621File = filepath.Join("testdata", "pep621", "pyproject.toml")
+)
+
+const expectedPipLockContent = `requests>=2.31
+***
+Package            Version
+------------------ ----------
+certifi            2023.11.17
+charset-normalizer 3.3.2
+idna               3.6
+requests           2.31.0
+urllib3            2.1.0
+***
+Name: certifi
+Version: 2023.11.17
+Requires: 
+Required-by: requests
+---
+Name: charset-normalizer
+Version: 3.3.2
+Requires: 
+Required-by: requests
+---
+Name: idna
+Version: 3.6
+Requires: 
+Required-by: requests
+---
+Name: requests
+Version: 2.31.0
+Requires: charset-normalizer, idna, urllib3, certifi
+Required-by: 
+---
+Name: urllib3
+Version: 2.1.0
+Requires: 
+Required-by: requests`
+
+const expectedPoetryLockContent = `requests==2.31.0
+***
+Package            Version
+------------------ ----------
+certifi            2023.11.17
+charset-normalizer 3.3.2
+idna               3.6
+requests           2.31.0
+urllib3            2.1.0
+***
+Name: certifi
+Version: 2023.11.17
+Requires: 
+Required-by: requests
+---
+Name: charset-normalizer
+Version: 3.3.2
+Requires: 
+Required-by: requests
+---
+Name: idna
+Version: 3.6
+Requires: 
+Required-by: requests
+---
+Name: requests
+Version: 2.31.0
+Requires: certifi, charset-normalizer, idna, urllib3
+Required-by: 
+---
+Name: urllib3
+Version: 2.1.0
+Requires: 
+Required-by: requests`
+
+func TestNewJob(t *testing.T) {
+	j := NewJob("file", nil, CmdFactory{execPath: ExecPath{}}, writer.FileWriter{})
+	assert.Equal(t, "file", j.GetFile())
+	assert.False(t, j.Errors().HasError())
+}
+
+func TestRunPoetry(t *testing.T) {
+	fileWriterMock := &writerTestdata.FileWriterMock{}
+	j := NewJob(poetryFile, nil, testdata.NewEchoCmdFactory(), fileWriterMock)
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	assert.False(t, j.Errors().HasError())
+	assert.Equal(t, expectedPoetryLockContent, string(fileWriterMock.Contents))
+}
+
+func TestRunPoetryLockFileNotFound(t *testing.T) {
+	j := NewJob(filepath.Join("testdata", "poetry-groups", "pyproject.toml"), nil, testdata.NewEchoCmdFactory(), &writerTestdata.FileWriterMock{})
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	errs := j.Errors().GetAll()
+	assert.Len(t, errs, 1)
+	assert.ErrorContains(t, errs[0], "poetry.lock")
+	assert.Equal(t, "creating dependency graph", errs[0].Status())
+}
+
+func TestParsePoetryTree(t *testing.T) {
+	treeOutput := `black (!) 23.1.0 The uncompromising code formatter.
+├── click >=8.0.0
+│   └── colorama *
+└── mypy-extensions >=0.4.3
+Requests 2.31.0 Python HTTP for Humans.
+└── urllib3 >=1.21.1,<3
+`
+	names, versions, dependencies := parsePoetryTree([]byte(treeOutput))
+
+	assert.Equal(t, []string{"Requests", "black", "click", "colorama", "mypy-extensions", "urllib3"}, names)
+	assert.Equal(t, map[string]string{"black": "23.1.0", "requests": "2.31.0"}, versions)
+	assert.Equal(t, map[string][]string{
+		"black":    {"click", "mypy-extensions"},
+		"click":    {"colorama"},
+		"requests": {"urllib3"},
+	}, dependencies)
+}
+
+func TestDeclaredDependencies(t *testing.T) {
+	file := filepath.Join("testdata", "poetry-groups", "pyproject.toml")
+	j := NewJob(file, nil, testdata.NewEchoCmdFactory(), &writerTestdata.FileWriterMock{})
+	assert.Equal(t, []string{"black", "mkdocs", "pytest", "requests"}, j.declaredDependencies())
+
+	j = NewJob(file, scope.Scopes{scope.Dev, scope.Test}, testdata.NewEchoCmdFactory(), &writerTestdata.FileWriterMock{})
+	assert.Equal(t, []string{"mkdocs", "requests"}, j.declaredDependencies())
+
+	j = NewJob(pep621File, nil, testdata.NewEchoCmdFactory(), &writerTestdata.FileWriterMock{})
+	assert.Equal(t, []string{"requests"}, j.declaredDependencies())
+}
+
+func TestPlan(t *testing.T) {
+	plan := NewJob(poetryFile, nil, testdata.NewEchoCmdFactory(), &writerTestdata.FileWriterMock{}).Plan()
+	assert.Len(t, plan.Commands, 2)
+	assert.Equal(t, []string{
+		filepath.Join("testdata", "poetry", "poetry.lock"),
+		filepath.Join("testdata", "poetry", "pyproject.debricked.lock"),
+	}, plan.LockFiles)
+
+	plan = NewJob(pep621File, nil, testdata.NewEchoCmdFactory(), &writerTestdata.FileWriterMock{}).Plan()
+	assert.Len(t, plan.Commands, 1)
+	assert.Equal(t, []string{filepath.Join("testdata", "pep621", "pyproject.debricked.lock")}, plan.LockFiles)
+}
+
+func TestExcludedGroups(t *testing.T) {
+	file := filepath.Join("testdata", "poetry-groups", "pyproject.toml")
+	cases := []struct {
+		excludedScopes scope.Scopes
+		groups         []string
+	}{
+		{nil, nil},
+		{scope.Scopes{scope.Dev}, []string{"dev"}},
+		{scope.Scopes{scope.Test}, []string{"tests"}},
+		{scope.Scopes{scope.Dev, scope.Test}, []string{"dev", "tests"}},
+	}
+	for _, c := range cases {
+		t.Run(c.excludedScopes.String(), func(t *testing.T) {
+			j := NewJob(file, c.excludedScopes, testdata.NewEchoCmdFactory(), &writerTestdata.FileWriterMock{})
+			assert.Equal(t, c.groups, j.excludedGroups())
+		})
+	}
+
+	j := NewJob(poetryFile, scope.Scopes{scope.Dev, scope.Test}, testdata.NewEchoCmdFactory(), &writerTestdata.FileWriterMock{})
+	assert.Empty(t, j.excludedGroups())
+}
+
+func TestRunPoetryWithoutNoUpdateOption(t *testing.T) {
+	cmdFactoryMock := testdata.NewEchoCmdFactory()
+	cmdFactoryMock.MakeLockNoUpdateErr = errors.New(`The option "--no-update" does not exist`)
+	fileWriterMock := &writerTestdata.FileWriterMock{}
+	j := NewJob(poetryFile, nil, cmdFactoryMock, fileWriterMock)
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	assert.False(t, j.Errors().HasError())
+	assert.NotEmpty(t, fileWriterMock.Contents)
+}
+
+func TestRunPep621(t *testing.T) {
+	fileWriterMock := &writerTestdata.FileWriterMock{}
+	j := NewJob(pep621File, nil, testdata.NewEchoCmdFactory(), fileWriterMock)
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	assert.False(t, j.Errors().HasError())
+	assert.Equal(t, expectedPipLockContent, string(fileWriterMock.Contents))
+}
+
+func TestRunInvalidPyproject(t *testing.T) {
+	j := NewJob(filepath.Join("testdata", "invalid", "pyproject.toml"), nil, testdata.NewEchoCmdFactory(), &writerTestdata.FileWriterMock{})
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	errs := j.Errors().GetAll()
+	assert.Len(t, errs, 1)
+	assert.ErrorContains(t, errs[0], "failed to parse")
+	assert.Equal(t, "reading pyproject.toml", errs[0].Status())
+}
+
+func TestRunLockCmdErr(t *testing.T) {
+	cases := []struct {
+		name  string
+		error string
+		doc   string
+	}{
+		{
+			name:  "General error",
+			error: "cmd-error",
+			doc:   util.UnknownError,
+		},
+		{
+			name:  "Poetry not found",
+			error: "exec: \"poetry\": executable file not found in $PATH",
+			doc:   "Poetry wasn't found. Please check if it is installed and accessible by the CLI.",
+		},
+		{
+			name:  "Version solving failed",
+			error: "Because service depends on requests (^99.0) which doesn't match any versions, version solving failed.",
+			doc:   "Failed to resolve the dependencies of the project. Please check that the version constraints in pyproject.toml can be satisfied together.",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cmdFactoryMock := testdata.NewEchoCmdFactory()
+			cmdFactoryMock.MakeLockErr = errors.New(c.error)
+			cmd, _ := cmdFactoryMock.MakeLockCmd(poetryFile, true)
+			j := NewJob(poetryFile, nil, cmdFactoryMock, &writerTestdata.FileWriterMock{})
+
+			expectedError := util.NewPMJobError(c.error)
+			expectedError.SetDocumentation(c.doc)
+			expectedError.SetStatus("locking dependencies")
+			expectedError.SetCommand(cmd.String())
+
+			go jobTestdata.WaitStatus(j)
+			j.Run()
+
+			allErrors := j.Errors().GetAll()
+			assert.Len(t, allErrors, 1)
+			assert.Contains(t, allErrors, expectedError)
+		})
+	}
+}
+
+func TestRunShowCmdOutputErr(t *testing.T) {
+	cmdFactoryMock := testdata.NewEchoCmdFactory()
+	cmdFactoryMock.ShowCmdName = "bad-name"
+	j := NewJob(poetryFile, nil, cmdFactoryMock, &writerTestdata.FileWriterMock{})
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	jobTestdata.AssertPathErr(t, j.Errors())
+}
+
+func TestRunReportCmdErr(t *testing.T) {
+	cases := []struct {
+		name  string
+		error string
+		doc   string
+	}{
+		{
+			name:  "Python not found",
+			error: "exec: \"python3\": executable file not found in $PATH",
+			doc:   "Python wasn't found. Please check if it is installed and accessible by the CLI.",
+		},
+		{
+			name:  "No matching distribution",
+			error: "ERROR: No matching distribution found for private-package==1.0",
+			doc:   "Failed to find a version that satisfies the requirement for python dependency \"private-package==1.0\" This could mean that the package or version does not exist.\n" + util.InstallPrivateDependencyMessage,
+		},
+		{
+			name:  "Old pip",
+			error: "no such option: --report",
+			doc:   "Resolving pyproject.toml without Poetry requires pip 22.2 or later. Please upgrade pip, for example by running \"python3 -m pip install --upgrade pip\".",
+		},
+		{
+			name:  "Invalid credentials",
+			error: "WARNING: 401 Error, Credentials not correct for https://pypi.example.com/simple/private-package/",
+			doc:   "Failed to install python dependency due to authorization.\n" + util.InstallPrivateDependencyMessage,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cmdFactoryMock := testdata.NewEchoCmdFactory()
+			cmdFactoryMock.MakeReportErr = errors.New(c.error)
+			cmd, _ := cmdFactoryMock.MakeReportCmd(pep621File)
+			j := NewJob(pep621File, nil, cmdFactoryMock, &writerTestdata.FileWriterMock{})
+
+			expectedError := util.NewPMJobError(c.error)
+			expectedError.SetDocumentation(c.doc)
+			expectedError.SetStatus("resolving dependencies")
+			expectedError.SetCommand(cmd.String())
+
+			go jobTestdata.WaitStatus(j)
+			j.Run()
+
+			allErrors := j.Errors().GetAll()
+			assert.Len(t, allErrors, 1)
+			assert.Contains(t, allErrors, expectedError)
+		})
+	}
+}
+
+func TestRunCreateErr(t *testing.T) {
+	createErr := errors.New("create-error")
+	fileWriterMock := &writerTestdata.FileWriterMock{CreateErr: createErr}
+	j := NewJob(pep621File, nil, testdata.NewEchoCmdFactory(), fileWriterMock)
+
+	expectedError := util.NewPMJobError(createErr.Error())
+	expectedError.SetStatus("creating lock file")
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	assert.Len(t, j.Errors().GetAll(), 1)
+	assert.Contains(t, j.Errors().GetAll(), expectedError)
+}
+
+func TestRunWriteErr(t *testing.T) {
+	writeErr := errors.New("write-error")
+	fileWriterMock := &writerTestdata.FileWriterMock{WriteErr: writeErr}
+	j := NewJob(pep621File, nil, testdata.NewEchoCmdFactory(), fileWriterMock)
+
+	expectedError := util.NewPMJobError(writeErr.Error())
+	expectedError.SetStatus("creating lock file")
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	assert.Len(t, j.Errors().GetAll(), 1)
+	assert.Contains(t, j.Errors().GetAll(), expectedError)
+}
+
+func TestRunCloseErr(t *testing.T) {
+	closeErr := errors.New("close-error")
+	fileWriterMock := &writerTestdata.FileWriterMock{CloseErr: closeErr}
+	j := NewJob(pep621File, nil, testdata.NewEchoCmdFactory(), fileWriterMock)
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	assert.Len(t, j.Errors().GetAll(), 1)
+	assert.Contains(t, j.Errors().GetAll(), util.NewPMJobError(closeErr.Error()))
+}
@@ -0,0 +1,23 @@
+package pyproject
+
+const Name = "pyproject"
+
+type Pm struct {
+	name string
+}
+
+func NewPm() Pm {
+	return Pm{
+		name: Name,
+	}
+}
+
+func (pm Pm) Name() string {
+	return pm.name
+}
+
+func (Pm) Manifests() []string {
+	return []string{
+		`pyproject\.toml$`,
+	}
+}
@@ -0,0 +1,183 @@
+package pyproject
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/debricked/cli/internal/resolution/pm/pip"
+	"github.com/pelletier/go-toml/v2"
+)
+
+const (
+	poetryLockFile   = "poetry.lock"
+	notInstalledMark = "(!)"
+)
+
+type poetryLock struct {
+	Package []struct {
+		Name    string `toml:"name"`
+		Version string `toml:"version"`
+	} `toml:"package"`
+}
+
+// treeLevelWidth is the width in runes of each level of indentation in the output of poetry show --tree,
+// such as "├── " or "│   "
+const treeLevelWidth = 4
+
+// makePoetryLockContent makes the content of the pip lock file of the Poetry project from the output of
+// poetry show --tree, which has the dependency graph of the groups that aren't excluded, and the locked versions of
+// the packages in poetry.lock. The requirements are the locked versions of the dependencies declared by pyproject.toml
+func (j *Job) makePoetryLockContent(treeOutput []byte) ([]byte, error) {
+	content, err := os.ReadFile(filepath.Join(filepath.Dir(j.GetFile()), poetryLockFile))
+	if err != nil {
+		return nil, err
+	}
+	var lock poetryLock
+	err = toml.Unmarshal(content, &lock)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s. Error: %s", poetryLockFile, err)
+	}
+	versions := map[string]string{}
+	for _, p := range lock.Package {
+		versions[pip.NormalizeName(p.Name)] = p.Version
+	}
+
+	names, topLevelVersions, dependencies := parsePoetryTree(treeOutput)
+	var packages []pip.LockPackage
+	for _, name := range names {
+		normalizedName := pip.NormalizeName(name)
+		version, ok := versions[normalizedName]
+		if !ok {
+			version = topLevelVersions[normalizedName]
+		}
+		versions[normalizedName] = version
+		packages = append(packages, pip.LockPackage{Name: name, Version: version, Requires: dependencies[normalizedName]})
+	}
+
+	var requirements []string
+	for _, name := range j.declaredDependencies() {
+		if version, ok := topLevelVersions[pip.NormalizeName(name)]; ok {
+			requirements = append(requirements, name+"=="+version)
+		}
+	}
+
+	return pip.MakeLockContent([]byte(strings.Join(requirements, "\n")), packages), nil
+}
+
+// parsePoetryTree parses the output of poetry show --tree. The top-level lines have the name and version of
+// the dependencies of the project, while the nested lines have the name and version constraint of the dependencies
+// of the package on the line above them at one level less of indentation.
+// It returns the sorted names of all packages, the versions of the top-level packages and the sorted dependencies
+// of each package, keyed by normalized name
+func parsePoetryTree(treeOutput []byte) ([]string, map[string]string, map[string][]string) {
+	names := map[string]string{}
+	topLevelVersions := map[string]string{}
+	edges := map[string]map[string]bool{}
+	var parents []string
+	scanner := bufio.NewScanner(bytes.NewReader(treeOutput))
+	for scanner.Scan() {
+		line := scanner.Text()
+		nameStart := strings.IndexFunc(line, isPackageNameRune)
+		if nameStart < 0 {
+			continue
+		}
+		depth := utf8.RuneCountInString(line[:nameStart]) / treeLevelWidth
+		fields := strings.Fields(line[nameStart:])
+		name := fields[0]
+		normalizedName := pip.NormalizeName(name)
+		if _, ok := names[normalizedName]; !ok {
+			names[normalizedName] = name
+		}
+		if depth == 0 {
+			if len(fields) > 1 && fields[1] == notInstalledMark {
+				fields = append(fields[:1], fields[2:]...)
+			}
+			if len(fields) > 1 {
+				topLevelVersions[normalizedName] = fields[1]
+			}
+		} else if depth <= len(parents) {
+			parent := parents[depth-1]
+			if edges[parent] == nil {
+				edges[parent] = map[string]bool{}
+			}
+			edges[parent][name] = true
+		}
+		if depth > len(parents) {
+			depth = len(parents)
+		}
+		parents = append(parents[:depth], normalizedName)
+	}
+
+	var sortedNames []string
+	for _, name := range names {
+		sortedNames = append(sortedNames, name)
+	}
+	sort.Strings(sortedNames)
+	dependencies := map[string][]string{}
+	for parent, children := range edges {
+		for child := range children {
+			dependencies[parent] = append(dependencies[parent], child)
+		}
+		sort.Strings(dependencies[parent])
+	}
+
+	return sortedNames, topLevelVersions, dependencies
+}
+
+func isPackageNameRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// declaredDependencies returns the sorted names of the dependencies declared by pyproject.toml, both in the project
+// table and in the Poetry tables of the groups that aren't excluded
+func (j *Job) declaredDependencies() []string {
+	pyproject, err := j.readPyproject()
+	if err != nil {
+		return nil
+	}
+	names := map[string]bool{}
+	for _, requirement := range pyproject.Project.Dependencies {
+		if name, ok := pip.RequirementName(requirement); ok {
+			names[name] = true
+		}
+	}
+	tables := []any{pyproject.Tool.Poetry["dependencies"]}
+	excludedGroups := map[string]bool{}
+	for _, group := range j.excludedGroups() {
+		excludedGroups[group] = true
+	}
+	if !excludedGroups["dev"] {
+		tables = append(tables, pyproject.Tool.Poetry["dev-dependencies"])
+	}
+	if groups, ok := pyproject.Tool.Poetry["group"].(map[string]any); ok {
+		for name, group := range groups {
+			if groupTable, ok := group.(map[string]any); ok && !excludedGroups[name] {
+				tables = append(tables, groupTable["dependencies"])
+			}
+		}
+	}
+	for _, table := range tables {
+		if dependencies, ok := table.(map[string]any); ok {
+			for name := range dependencies {
+				if name != "python" {
+					names[name] = true
+				}
+			}
+		}
+	}
+
+	var sortedNames []string
+	for name := range names {
+		sortedNames = append(sortedNames, name)
+	}
+	sort.Strings(sortedNames)
+
+	return sortedNames
+}
@@ -0,0 +1,32 @@
+package pyproject
+
+import (
+	"github.com/debricked/cli/internal/resolution/job"
+	"github.com/debricked/cli/internal/resolution/pm/writer"
+	"github.com/debricked/cli/internal/resolution/scope"
+)
+
+type Strategy struct {
+	files          []string
+	excludedScopes scope.Scopes
+}
+
+func (s Strategy) Invoke() ([]job.IJob, error) {
+	var jobs []job.IJob
+	for _, file := range s.files {
+		jobs = append(jobs, NewJob(
+			file,
+			s.excludedScopes,
+			CmdFactory{
+				execPath: ExecPath{},
+			},
+			writer.FileWriter{},
+		))
+	}
+
+	return jobs, nil
+}
+
+func NewStrategy(files []string, excludedScopes scope.Scopes) Strategy {
+	return Strategy{files, excludedScopes}
+}
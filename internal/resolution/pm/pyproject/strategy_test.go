@@ -0,0 +1,51 @@
+package pyproject
+
+import (
+	"testing"
+
+	"github.com/debricked/cli/internal/resolution/scope"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewStrategy(t *testing.T) {
+	s := NewStrategy(nil, nil)
+	assert.NotNil(t, s)
+	assert.Len(t, s.files, 0)
+
+	s = NewStrategy([]string{}, nil)
+	assert.NotNil(t, s)
+	assert.Len(t, s.files, 0)
+
+	s = NewStrategy([]string{"file"}, nil)
+	assert.NotNil(t, s)
+	assert.Len(t, s.files, 1)
+
+	s = NewStrategy([]string{"file-1", "file-2"}, nil)
+	assert.NotNil(t, s)
+	assert.Len(t, s.files, 2)
+}
+
+func TestInvokeNoFiles(t *testing.T) {
+	s := NewStrategy([]string{}, nil)
+	jobs, _ := s.Invoke()
+	assert.Empty(t, jobs)
+}
+
+func TestInvokeOneFile(t *testing.T) {
+	s := NewStrategy([]string{"file"}, nil)
+	jobs, _ := s.Invoke()
+	assert.Len(t, jobs, 1)
+}
+
+func TestInvokeManyFiles(t *testing.T) {
+	s := NewStrategy([]string{"file-1", "file-2"}, nil)
+	jobs, _ := s.Invoke()
+	assert.Len(t, jobs, 2)
+}
+
+func TestInvokeExcludedScopes(t *testing.T) {
+	s := NewStrategy([]string{"file"}, scope.Scopes{scope.Dev})
+	jobs, _ := s.Invoke()
+	assert.Len(t, jobs, 1)
+	assert.Equal(t, scope.Scopes{scope.Dev}, jobs[0].(*Job).excludedScopes)
+}
@@ -0,0 +1,50 @@
+package testdata
+
+import (
+	"os"
+	"os/exec"
+)
+
+type CmdFactoryMock struct {
+	LockCmdName         string
+	MakeLockErr         error
+	MakeLockNoUpdateErr error
+	ShowCmdName         string
+	MakeShowErr         error
+	ReportCmdName       string
+	MakeReportErr       error
+}
+
+func NewEchoCmdFactory() CmdFactoryMock {
+	return CmdFactoryMock{
+		LockCmdName:   "echo",
+		ShowCmdName:   "echo",
+		ReportCmdName: "echo",
+	}
+}
+
+func (f CmdFactoryMock) MakeLockCmd(_ string, noUpdate bool) (*exec.Cmd, error) {
+	if noUpdate && f.MakeLockNoUpdateErr != nil {
+		return exec.Command(f.LockCmdName, "lock", "--no-update"), f.MakeLockNoUpdateErr
+	}
+
+	return exec.Command(f.LockCmdName, "lock"), f.MakeLockErr
+}
+
+func (f CmdFactoryMock) MakeShowCmd(_ string, _ []string) (*exec.Cmd, error) {
+	fileContent, err := os.ReadFile("testdata/tree.txt")
+	if err != nil {
+		return nil, err
+	}
+
+	return exec.Command(f.ShowCmdName, string(fileContent)), f.MakeShowErr
+}
+
+func (f CmdFactoryMock) MakeReportCmd(_ string) (*exec.Cmd, error) {
+	fileContent, err := os.ReadFile("testdata/report.json")
+	if err != nil {
+		return nil, err
+	}
+
+	return exec.Command(f.ReportCmdName, string(fileContent)), f.MakeReportErr
+}
@@ -0,0 +1,77 @@
+package sbt
+
+import (
+	"fmt"
+	"os/exec"
+)
+
+const sbt = "sbt"
+
+type ICmdFactory interface {
+	MakeProjectsCmd(dir string, pluginFile string) (*exec.Cmd, error)
+	MakeBaseDirectoriesCmd(dir string, pluginFile string, ids []string) (*exec.Cmd, error)
+	MakeDependencyTreeCmd(dir string, pluginFile string, ids []string, lockFiles []string) (*exec.Cmd, error)
+}
+
+type IExecPath interface {
+	LookPath(file string) (string, error)
+}
+
+type ExecPath struct {
+}
+
+func (ExecPath) LookPath(file string) (string, error) {
+	return exec.LookPath(file)
+}
+
+type CmdFactory struct {
+	execPath IExecPath
+}
+
+// MakeProjectsCmd makes a command listing the ids of the projects of the build
+func (cmdf CmdFactory) MakeProjectsCmd(dir string, pluginFile string) (*exec.Cmd, error) {
+	return cmdf.makeCmd(dir, pluginFile, []string{"projects"})
+}
+
+// MakeBaseDirectoriesCmd makes a command printing the base directory of each project, in the order of ids
+func (cmdf CmdFactory) MakeBaseDirectoriesCmd(dir string, pluginFile string, ids []string) (*exec.Cmd, error) {
+	var commands []string
+	for _, id := range ids {
+		commands = append(commands, fmt.Sprintf("show %s / baseDirectory", id))
+	}
+
+	return cmdf.makeCmd(dir, pluginFile, commands)
+}
+
+// MakeDependencyTreeCmd makes a command writing the dependency tree of each project to its lock file, in the order of ids.
+// An empty id is the current project of the build
+func (cmdf CmdFactory) MakeDependencyTreeCmd(dir string, pluginFile string, ids []string, lockFiles []string) (*exec.Cmd, error) {
+	var commands []string
+	for i, id := range ids {
+		command := "dependencyTree / toFile " + lockFiles[i] + " -f"
+		if len(id) > 0 {
+			command = id + " / " + command
+		}
+		commands = append(commands, command)
+	}
+
+	return cmdf.makeCmd(dir, pluginFile, commands)
+}
+
+func (cmdf CmdFactory) makeCmd(dir string, pluginFile string, commands []string) (*exec.Cmd, error) {
+	path, err := cmdf.execPath.LookPath(sbt)
+
+	return &exec.Cmd{
+		Path: path,
+		Args: append(
+			[]string{
+				sbt,
+				"-batch",     // We can't answer any prompts...
+				"-no-colors", // Output is parsed
+				"--addPluginSbtFile=" + pluginFile,
+			},
+			commands...,
+		),
+		Dir: dir,
+	}, err
+}
@@ -0,0 +1,40 @@
+package sbt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMakeProjectsCmd(t *testing.T) {
+	cmd, _ := CmdFactory{execPath: ExecPath{}}.MakeProjectsCmd("dir", "plugins.sbt")
+	assert.NotNil(t, cmd)
+	args := cmd.Args
+	assert.Contains(t, args, "sbt")
+	assert.Contains(t, args, "-batch")
+	assert.Contains(t, args, "--addPluginSbtFile=plugins.sbt")
+	assert.Contains(t, args, "projects")
+	assert.Equal(t, "dir", cmd.Dir)
+}
+
+func TestMakeBaseDirectoriesCmd(t *testing.T) {
+	cmd, _ := CmdFactory{execPath: ExecPath{}}.MakeBaseDirectoriesCmd("dir", "plugins.sbt", []string{"root", "core"})
+	assert.NotNil(t, cmd)
+	args := cmd.Args
+	assert.Contains(t, args, "show root / baseDirectory")
+	assert.Contains(t, args, "show core / baseDirectory")
+}
+
+func TestMakeDependencyTreeCmd(t *testing.T) {
+	cmd, _ := CmdFactory{execPath: ExecPath{}}.MakeDependencyTreeCmd(
+		"dir",
+		"plugins.sbt",
+		[]string{"root", ""},
+		[]string{"/build/sbt.debricked.lock", "/core/sbt.debricked.lock"},
+	)
+	assert.NotNil(t, cmd)
+	args := cmd.Args
+	assert.Contains(t, args, "--addPluginSbtFile=plugins.sbt")
+	assert.Contains(t, args, "root / dependencyTree / toFile /build/sbt.debricked.lock -f")
+	assert.Contains(t, args, "dependencyTree / toFile /core/sbt.debricked.lock -f")
+}
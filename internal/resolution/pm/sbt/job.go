@@ -0,0 +1,185 @@
+package sbt
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/debricked/cli/internal/resolution/job"
+	"github.com/debricked/cli/internal/resolution/pm/util"
+	"github.com/debricked/cli/internal/resolution/pm/writer"
+)
+
+const (
+	lockFileName                  = "sbt.debricked.lock"
+	sbtExecutableNotFoundErrRegex = `"sbt": executable file not found`
+	dependencyTreePluginErrRegex  = `not found: value addDependencyTreePlugin|Not a valid (?:command|key): dependencyTree`
+	unresolvedDependencyErrRegex  = `(?:Error downloading|unresolved dependency:) ([^\s]+)`
+	unauthorizedErrRegex          = `(?:401|403) (?:Unauthorized|Forbidden).*?(https?://[^\s]+)`
+)
+
+type Job struct {
+	job.BaseJob
+	dir               string
+	projects          []Project
+	cmdFactory        ICmdFactory
+	pluginFileHandler IPluginFileHandler
+	fileWriter        writer.IFileWriter
+}
+
+// NewJob makes a job writing the dependency tree of each project of the build to sbt.debricked.lock in the project's directory
+func NewJob(
+	file string,
+	dir string,
+	projects []Project,
+	cmdFactory ICmdFactory,
+	fileWriter writer.IFileWriter,
+) *Job {
+	return &Job{
+		BaseJob:           job.NewBaseJob(file),
+		dir:               dir,
+		projects:          projects,
+		cmdFactory:        cmdFactory,
+		pluginFileHandler: PluginFileHandler{},
+		fileWriter:        fileWriter,
+	}
+}
+
+func (j *Job) Run() {
+	status := "writing plugin file"
+	j.SendStatus(status)
+
+	pluginFile, removePluginFile, err := writeTempPluginFile(j.pluginFileHandler, j.fileWriter)
+	if err != nil {
+		j.handleError(j.createError(err.Error(), "", status))
+
+		return
+	}
+	defer removePluginFile()
+
+	status = "creating dependency graph"
+	j.SendStatus(status)
+
+	ids, lockFiles := j.projectLockFiles()
+	dependencyTreeCmd, err := j.cmdFactory.MakeDependencyTreeCmd(j.dir, pluginFile, ids, lockFiles)
+	if err != nil {
+		j.handleError(j.createError(err.Error(), dependencyTreeCmd.String(), status))
+
+		return
+	}
+
+	output, err := j.Output(dependencyTreeCmd)
+	if err != nil {
+		j.handleError(j.createError(j.GetExitError(err, string(output)).Error(), dependencyTreeCmd.String(), status))
+	}
+}
+
+// Plan returns the command and lock files of the job, without running it.
+// The plugin file is written to a temporary directory once the job runs
+func (j *Job) Plan() job.Plan {
+	ids, lockFiles := j.projectLockFiles()
+	plan := job.Plan{LockFiles: lockFiles}
+	pluginFile := filepath.Join(os.TempDir(), sbtPluginFileName)
+	plan.AddCommand(j.cmdFactory.MakeDependencyTreeCmd(j.dir, pluginFile, ids, lockFiles))
+
+	return plan
+}
+
+// projectLockFiles returns the ids of the projects of the job, and the paths of their lock files
+func (j *Job) projectLockFiles() ([]string, []string) {
+	var ids, lockFiles []string
+	for _, project := range j.projects {
+		ids = append(ids, project.id)
+		lockFiles = append(lockFiles, filepath.Join(project.dir, lockFileName))
+	}
+
+	return ids, lockFiles
+}
+
+func (j *Job) GetDir() string {
+	return j.dir
+}
+
+func (j *Job) createError(error string, cmd string, status string) job.IError {
+	cmdError := util.NewPMJobError(error)
+	cmdError.SetCommand(cmd)
+	cmdError.SetStatus(status)
+
+	return cmdError
+}
+
+func (j *Job) handleError(cmdError job.IError) {
+	expressions := []string{
+		sbtExecutableNotFoundErrRegex,
+		dependencyTreePluginErrRegex,
+		unresolvedDependencyErrRegex,
+		unauthorizedErrRegex,
+	}
+
+	for _, expression := range expressions {
+		regex := regexp.MustCompile(expression)
+		matches := regex.FindAllStringSubmatch(cmdError.Error(), -1)
+
+		if len(matches) > 0 {
+			cmdError = j.addDocumentation(expression, matches, cmdError)
+			j.Errors().Append(cmdError)
+
+			return
+		}
+	}
+
+	j.Errors().Append(cmdError)
+}
+
+func (j *Job) addDocumentation(expr string, matches [][]string, cmdError job.IError) job.IError {
+	documentation := cmdError.Documentation()
+
+	switch expr {
+	case sbtExecutableNotFoundErrRegex:
+		documentation = j.GetExecutableNotFoundErrorDocumentation("sbt")
+	case dependencyTreePluginErrRegex:
+		documentation = j.getDependencyTreePluginErrorDocumentation()
+	case unresolvedDependencyErrRegex:
+		documentation = j.getUnresolvedDependencyErrorDocumentation(matches)
+	case unauthorizedErrRegex:
+		documentation = j.getUnauthorizedErrorDocumentation(matches)
+	}
+
+	cmdError.SetDocumentation(documentation)
+
+	return cmdError
+}
+
+func firstMatch(matches [][]string) string {
+	if len(matches) > 0 && len(matches[0]) > 1 {
+		return matches[0][1]
+	}
+
+	return ""
+}
+
+func (j *Job) getDependencyTreePluginErrorDocumentation() string {
+	return strings.Join(
+		[]string{
+			"Failed to add the dependency tree plugin to the build.",
+			"The plugin is included in sbt 1.4 and later, please upgrade sbt.version in project/build.properties.",
+		}, " ")
+}
+
+func (j *Job) getUnresolvedDependencyErrorDocumentation(matches [][]string) string {
+	return strings.Join(
+		[]string{
+			"Failed to find package \"" + firstMatch(matches) + "\" that satisfies the requirements.",
+			"Please check that dependencies are correct in build.sbt.",
+			"\n" + util.InstallPrivateDependencyMessage,
+		}, " ")
+}
+
+func (j *Job) getUnauthorizedErrorDocumentation(matches [][]string) string {
+	return strings.Join(
+		[]string{
+			"Failed to authenticate to repository \"" + firstMatch(matches) + "\".",
+			"Please make sure that the CLI has access to the repository, for example by configuring credentials in ~/.sbt/1.0/credentials.sbt.",
+		}, " ")
+}
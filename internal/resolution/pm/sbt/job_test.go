@@ -0,0 +1,137 @@
+package sbt
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	jobTestdata "github.com/debricked/cli/internal/resolution/job/testdata"
+	"github.com/debricked/cli/internal/resolution/pm/sbt/testdata"
+	"github.com/debricked/cli/internal/resolution/pm/util"
+	"github.com/debricked/cli/internal/resolution/pm/writer"
+	writerTestdata "github.com/debricked/cli/internal/resolution/pm/writer/testdata"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewJob(t *testing.T) {
+	j := NewJob("file", "dir", []Project{{dir: "dir"}}, CmdFactory{}, writer.FileWriter{})
+	assert.Equal(t, "file", j.GetFile())
+	assert.Equal(t, "dir", j.GetDir())
+	assert.False(t, j.Errors().HasError())
+}
+
+func TestRun(t *testing.T) {
+	j := NewJob("file", "dir", []Project{{id: "root", dir: "dir"}}, testdata.NewEchoCmdFactory(), writer.FileWriter{})
+
+	go jobTestdata.WaitStatus(j)
+
+	j.Run()
+
+	assert.False(t, j.Errors().HasError())
+}
+
+func TestRunPluginFileErr(t *testing.T) {
+	createErr := errors.New("create-error")
+	j := NewJob("file", "dir", []Project{{dir: "dir"}}, testdata.NewEchoCmdFactory(), &writerTestdata.FileWriterMock{CreateErr: createErr})
+
+	go jobTestdata.WaitStatus(j)
+
+	j.Run()
+
+	assert.Len(t, j.Errors().GetAll(), 1)
+	assert.Contains(t, j.Errors().GetAll()[0].Error(), createErr.Error())
+	assert.Equal(t, "writing plugin file", j.Errors().GetAll()[0].Status())
+}
+
+func TestPlanPluginFile(t *testing.T) {
+	j := NewJob("file", "dir", []Project{{id: "root", dir: "dir"}}, CmdFactory{execPath: ExecPath{}}, writer.FileWriter{})
+
+	plan := j.Plan()
+
+	assert.Len(t, plan.Commands, 1)
+	assert.Contains(t, plan.Commands[0], "--addPluginSbtFile="+filepath.Join(os.TempDir(), sbtPluginFileName))
+}
+
+func TestRunMakeDependencyTreeCmdErr(t *testing.T) {
+	cmdErr := errors.New("cmd-error")
+	cmdFactoryMock := testdata.NewEchoCmdFactory()
+	cmdFactoryMock.MakeDependencyTreeErr = cmdErr
+	cmd, _ := cmdFactoryMock.MakeDependencyTreeCmd("", "", nil, nil)
+
+	expectedError := util.NewPMJobError(cmdErr.Error())
+	expectedError.SetCommand(cmd.String())
+	expectedError.SetStatus("creating dependency graph")
+	expectedError.SetDocumentation(util.UnknownError)
+
+	j := NewJob("file", "dir", []Project{{dir: "dir"}}, cmdFactoryMock, writer.FileWriter{})
+
+	go jobTestdata.WaitStatus(j)
+
+	j.Run()
+
+	assert.Len(t, j.Errors().GetAll(), 1)
+	assert.Contains(t, j.Errors().GetAll(), expectedError)
+}
+
+func TestRunCmdOutputErr(t *testing.T) {
+	cmdFactoryMock := testdata.NewEchoCmdFactory()
+	cmdFactoryMock.DependencyTreeCmdName = "bad-name"
+
+	j := NewJob("file", "dir", []Project{{dir: "dir"}}, cmdFactoryMock, writer.FileWriter{})
+
+	go jobTestdata.WaitStatus(j)
+
+	j.Run()
+
+	jobTestdata.AssertPathErr(t, j.Errors())
+}
+
+func TestRunCmdErrDocumentation(t *testing.T) {
+	cases := []struct {
+		name  string
+		error string
+		doc   string
+	}{
+		{
+			name:  "General error",
+			error: "cmd-error",
+			doc:   util.UnknownError,
+		},
+		{
+			name:  "sbt not found",
+			error: "exec: \"sbt\": executable file not found in $PATH",
+			doc:   "sbt wasn't found. Please check if it is installed and accessible by the CLI.",
+		},
+		{
+			name:  "Dependency tree plugin not found",
+			error: "[error] /home/project/.sbt-plugins.debricked.sbt:2: error: not found: value addDependencyTreePlugin",
+			doc:   "Failed to add the dependency tree plugin to the build. The plugin is included in sbt 1.4 and later, please upgrade sbt.version in project/build.properties.",
+		},
+		{
+			name:  "Unresolved dependency",
+			error: "[error] (update) sbt.librarymanagement.ResolveException: Error downloading org.typelevel:cats-core_2.13:9.9.9",
+			doc:   "Failed to find package \"org.typelevel:cats-core_2.13:9.9.9\" that satisfies the requirements. Please check that dependencies are correct in build.sbt. \n" + util.InstallPrivateDependencyMessage,
+		},
+		{
+			name:  "Unauthorized",
+			error: "[error]   not found: https://repo.example.com/releases/org/example/lib_2.13/1.0.0/lib_2.13-1.0.0.pom (401 Unauthorized) https://repo.example.com/releases",
+			doc:   "Failed to authenticate to repository \"https://repo.example.com/releases\". Please make sure that the CLI has access to the repository, for example by configuring credentials in ~/.sbt/1.0/credentials.sbt.",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			expectedError := util.NewPMJobError(c.error)
+			expectedError.SetCommand("cmd")
+			expectedError.SetStatus("status")
+			expectedError.SetDocumentation(c.doc)
+
+			j := NewJob("file", "dir", []Project{{dir: "dir"}}, testdata.NewEchoCmdFactory(), writer.FileWriter{})
+			j.handleError(j.createError(c.error, "cmd", "status"))
+
+			assert.Len(t, j.Errors().GetAll(), 1)
+			assert.Contains(t, j.Errors().GetAll(), expectedError)
+		})
+	}
+}
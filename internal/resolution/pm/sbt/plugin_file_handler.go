@@ -0,0 +1,62 @@
+package sbt
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/debricked/cli/internal/resolution/pm/writer"
+)
+
+type IPluginFileHandler interface {
+	ReadPluginFile() ([]byte, error)
+	WritePluginFile(targetFileName string, fileWriter writer.IFileWriter) error
+}
+
+type PluginFileHandler struct{}
+
+func (_ PluginFileHandler) ReadPluginFile() ([]byte, error) {
+	return sbtPluginFile.ReadFile("sbt-plugins/debricked-plugins.sbt")
+}
+
+func (p PluginFileHandler) WritePluginFile(targetFileName string, fileWriter writer.IFileWriter) error {
+	content, err := p.ReadPluginFile()
+	if err != nil {
+
+		return SetupPluginError{message: err.Error()}
+	}
+	pluginFile, err := fileWriter.Create(targetFileName)
+	if err != nil {
+
+		return SetupPluginError{message: err.Error()}
+	}
+	defer pluginFile.Close()
+	err = fileWriter.Write(pluginFile, content)
+	if err != nil {
+
+		return SetupPluginError{message: err.Error()}
+	}
+
+	return nil
+}
+
+// writeTempPluginFile writes the plugin file to a temporary directory, rather than the working directory which may be
+// read-only, and returns its path together with a function removing the directory
+func writeTempPluginFile(handler IPluginFileHandler, fileWriter writer.IFileWriter) (string, func(), error) {
+	dir, err := os.MkdirTemp("", "debricked-sbt-")
+	if err != nil {
+
+		return "", func() {}, SetupPluginError{message: err.Error()}
+	}
+	remove := func() {
+		_ = os.RemoveAll(dir)
+	}
+	pluginFilePath := filepath.Join(dir, sbtPluginFileName)
+	err = handler.WritePluginFile(pluginFilePath, fileWriter)
+	if err != nil {
+		remove()
+
+		return "", func() {}, err
+	}
+
+	return pluginFilePath, remove, nil
+}
@@ -0,0 +1,42 @@
+package sbt
+
+import (
+	"embed"
+	"errors"
+	"testing"
+
+	writerTestdata "github.com/debricked/cli/internal/resolution/pm/writer/testdata"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWritePluginFile(t *testing.T) {
+	fileWriterMock := &writerTestdata.FileWriterMock{}
+
+	err := PluginFileHandler{}.WritePluginFile("file", fileWriterMock)
+	assert.NoError(t, err)
+	assert.Contains(t, string(fileWriterMock.Contents), "addDependencyTreePlugin")
+}
+
+func TestWritePluginFileErr(t *testing.T) {
+	createErr := errors.New("create-error")
+	fileWriterMock := &writerTestdata.FileWriterMock{CreateErr: createErr}
+
+	err := PluginFileHandler{}.WritePluginFile("file", fileWriterMock)
+	assert.Equal(t, SetupPluginError{createErr.Error()}, err)
+
+	fileWriterMock = &writerTestdata.FileWriterMock{WriteErr: createErr}
+	err = PluginFileHandler{}.WritePluginFile("file", fileWriterMock)
+	assert.Equal(t, SetupPluginError{createErr.Error()}, err)
+}
+
+func TestWritePluginFileNoPluginFile(t *testing.T) {
+	oldSbtPluginFile := sbtPluginFile
+	defer func() {
+		sbtPluginFile = oldSbtPluginFile
+	}()
+	sbtPluginFile = embed.FS{}
+
+	err := PluginFileHandler{}.WritePluginFile("file", nil)
+	readErr := errors.New("open sbt-plugins/debricked-plugins.sbt: file does not exist")
+	assert.Equal(t, SetupPluginError{readErr.Error()}, err)
+}
@@ -0,0 +1,23 @@
+package sbt
+
+const Name = "sbt"
+
+type Pm struct {
+	name string
+}
+
+func NewPm() Pm {
+	return Pm{
+		name: Name,
+	}
+}
+
+func (pm Pm) Name() string {
+	return pm.name
+}
+
+func (_ Pm) Manifests() []string {
+	return []string{
+		`^build\.sbt$`,
+	}
+}
@@ -0,0 +1,14 @@
+package sbt
+
+// Project is an sbt project, identified by its id within the build
+type Project struct {
+	id  string
+	dir string
+}
+
+// Build is an sbt build, run from the directory of its root build.sbt, with the projects it is made of
+type Build struct {
+	dir       string
+	buildFile string
+	projects  []Project
+}
@@ -0,0 +1,215 @@
+package sbt
+
+import (
+	"bufio"
+	"bytes"
+	"embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/debricked/cli/internal/resolution/pm/util"
+	"github.com/debricked/cli/internal/resolution/pm/writer"
+)
+
+const (
+	buildFileName        = "build.sbt"
+	metaBuildDirName     = "project"
+	sbtPluginFileName    = ".sbt-plugins.debricked.sbt"
+	projectsSectionStart = "In file:"
+)
+
+//go:embed sbt-plugins/debricked-plugins.sbt
+var sbtPluginFile embed.FS
+
+var projectIdRegex = regexp.MustCompile(`^\[info\]\s+\*?\s*([\w.-]+)\s*$`)
+
+type ISetup interface {
+	Configure(files []string) (Setup, error)
+}
+
+type Setup struct {
+	subProjectMap     map[string]string
+	pluginFilePath    string
+	timeout           time.Duration
+	Builds            []Build
+	CmdFactory        ICmdFactory
+	PluginFileHandler IPluginFileHandler
+	Writer            writer.IFileWriter
+}
+
+func NewSetup() *Setup {
+	return &Setup{
+		subProjectMap:     map[string]string{},
+		Builds:            []Build{},
+		CmdFactory:        CmdFactory{execPath: ExecPath{}},
+		PluginFileHandler: PluginFileHandler{},
+		Writer:            writer.FileWriter{},
+	}
+}
+
+// Configure discovers the projects of the build each build.sbt belongs to, with the plugin file written to a temporary
+// directory which is removed afterwards
+func (s *Setup) Configure(files []string) (Setup, error) {
+	pluginFilePath, removePluginFile, err := writeTempPluginFile(s.PluginFileHandler, s.Writer)
+	if err != nil {
+
+		return *s, err
+	}
+	defer removePluginFile()
+	s.pluginFilePath = pluginFilePath
+
+	buildFiles := map[string]string{}
+	for _, file := range files {
+		buildDir := findBuildDir(file)
+		buildFiles[buildDir] = filepath.Join(buildDir, buildFileName)
+	}
+	var buildDirs []string
+	for buildDir := range buildFiles {
+		buildDirs = append(buildDirs, buildDir)
+	}
+	sort.Strings(buildDirs)
+
+	var errors SetupError
+	for _, buildDir := range buildDirs {
+		if _, ok := s.subProjectMap[buildDir]; ok {
+			continue
+		}
+		build := Build{dir: buildDir, buildFile: buildFiles[buildDir]}
+		build.projects, err = s.findProjects(buildDir)
+		if err != nil {
+			errors = append(errors, err)
+
+			continue
+		}
+		for _, project := range build.projects {
+			s.subProjectMap[project.dir] = buildDir
+		}
+		s.Builds = append(s.Builds, build)
+	}
+	if len(errors) > 0 {
+
+		return *s, SetupSubprojectError{message: errors.Error()}
+	}
+
+	return *s, nil
+}
+
+// findProjects runs sbt to list the projects of the build and their base directories
+func (s *Setup) findProjects(buildDir string) ([]Project, error) {
+	projectsCmd, err := s.CmdFactory.MakeProjectsCmd(buildDir, s.pluginFilePath)
+	if err != nil {
+
+		return nil, SetupSubprojectError{message: err.Error()}
+	}
+	output, err := util.OutputWithTimeout(projectsCmd, s.timeout)
+	if err != nil {
+
+		return nil, SetupSubprojectError{message: string(output) + err.Error()}
+	}
+	ids := parseProjectIds(output)
+	if len(ids) == 0 {
+
+		return nil, SetupSubprojectError{message: "no sbt projects found in " + buildDir}
+	}
+
+	baseDirectoriesCmd, err := s.CmdFactory.MakeBaseDirectoriesCmd(buildDir, s.pluginFilePath, ids)
+	if err != nil {
+
+		return nil, SetupSubprojectError{message: err.Error()}
+	}
+	output, err = util.OutputWithTimeout(baseDirectoriesCmd, s.timeout)
+	if err != nil {
+
+		return nil, SetupSubprojectError{message: string(output) + err.Error()}
+	}
+	dirs := parseBaseDirectories(output)
+	if len(dirs) != len(ids) {
+
+		return nil, SetupSubprojectError{message: fmt.Sprintf("found %d base directories for %d sbt projects in %s", len(dirs), len(ids), buildDir)}
+	}
+
+	projects := make([]Project, 0, len(ids))
+	for i, id := range ids {
+		projects = append(projects, Project{id: id, dir: dirs[i]})
+	}
+
+	return projects, nil
+}
+
+// parseProjectIds parses the output of "sbt projects", listing the projects of the build after "In file:<build>"
+func parseProjectIds(output []byte) []string {
+	var ids []string
+	inBuild := false
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.Contains(line, projectsSectionStart) {
+			if inBuild {
+				// Projects of other builds, referenced by the build, are left out
+				break
+			}
+			inBuild = true
+
+			continue
+		}
+		if !inBuild {
+			continue
+		}
+		if match := projectIdRegex.FindStringSubmatch(line); match != nil {
+			ids = append(ids, match[1])
+		}
+	}
+
+	return ids
+}
+
+// parseBaseDirectories parses the output of "show <id> / baseDirectory", the absolute paths printed
+func parseBaseDirectories(output []byte) []string {
+	var dirs []string
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		dir := strings.TrimSpace(strings.TrimPrefix(scanner.Text(), "[info]"))
+		if filepath.IsAbs(dir) {
+			dirs = append(dirs, filepath.Clean(dir))
+		}
+	}
+
+	return dirs
+}
+
+// findBuildDir returns the directory sbt is run from to resolve build.sbt. It is the directory of build.sbt if it has
+// a project directory, otherwise the closest parent directory with both build.sbt and a project directory.
+// A build.sbt without any is a build of its own
+func findBuildDir(file string) string {
+	dir, err := filepath.Abs(filepath.Dir(file))
+	if err != nil {
+		return filepath.Dir(file)
+	}
+	if isDir(filepath.Join(dir, metaBuildDirName)) {
+		return dir
+	}
+	for parent := filepath.Dir(dir); parent != filepath.Dir(parent); parent = filepath.Dir(parent) {
+		if isDir(filepath.Join(parent, metaBuildDirName)) && isFile(filepath.Join(parent, buildFileName)) {
+			return parent
+		}
+	}
+
+	return dir
+}
+
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+
+	return err == nil && info.IsDir()
+}
+
+func isFile(path string) bool {
+	info, err := os.Stat(path)
+
+	return err == nil && !info.IsDir()
+}
@@ -0,0 +1,30 @@
+package sbt
+
+type SetupPluginError struct {
+	message string
+}
+
+type SetupSubprojectError struct {
+	message string
+}
+
+func (e SetupPluginError) Error() string {
+
+	return e.message
+}
+
+func (e SetupSubprojectError) Error() string {
+
+	return e.message
+}
+
+type SetupError []error
+
+func (e SetupError) Error() string {
+	var s string
+	for _, err := range e {
+		s += err.Error() + "\n"
+	}
+
+	return s
+}
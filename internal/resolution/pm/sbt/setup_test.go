@@ -0,0 +1,172 @@
+package sbt
+
+import (
+	"errors"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/debricked/cli/internal/resolution/pm/sbt/testdata"
+	writerTestdata "github.com/debricked/cli/internal/resolution/pm/writer/testdata"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSetup(t *testing.T) {
+	s := NewSetup()
+	assert.NotNil(t, s)
+}
+
+func TestConfigureRemovesPluginFile(t *testing.T) {
+	s := NewSetup()
+	s.CmdFactory = testdata.NewEchoCmdFactory()
+
+	setup, _ := s.Configure([]string{filepath.Join("testdata", "standalone", "build.sbt")})
+
+	assert.Equal(t, sbtPluginFileName, filepath.Base(setup.pluginFilePath))
+	assert.NotEqual(t, filepath.Dir(setup.pluginFilePath), filepath.Clean("."))
+	assert.NoFileExists(t, setup.pluginFilePath)
+	assert.NoFileExists(t, sbtPluginFileName)
+}
+
+func TestErrors(t *testing.T) {
+	pluginError := SetupPluginError{message: "test"}
+	assert.Equal(t, "test", pluginError.Error())
+
+	subprojectError := SetupSubprojectError{message: "test"}
+	assert.Equal(t, "test", subprojectError.Error())
+
+	setupError := SetupError{errors.New("test-1"), errors.New("test-2")}
+	assert.Equal(t, "test-1\ntest-2\n", setupError.Error())
+}
+
+func TestParseProjectIds(t *testing.T) {
+	output := []byte(`[info] welcome to sbt 1.9.7 (Eclipse Adoptium Java 17.0.8)
+[info] loading project definition from /build/project
+[info] In file:/build/
+[info] 	   core
+[info] 	 * root
+[info] In file:/other/
+[info] 	   other
+`)
+	assert.Equal(t, []string{"core", "root"}, parseProjectIds(output))
+	assert.Empty(t, parseProjectIds([]byte("[error] Not a valid command: projects")))
+}
+
+func TestParseBaseDirectories(t *testing.T) {
+	output := []byte(`[info] welcome to sbt 1.9.7 (Eclipse Adoptium Java 17.0.8)
+[info] loading project definition from /build/project
+[info] /build/core
+[info] /build
+`)
+	assert.Equal(t, []string{"/build/core", "/build"}, parseBaseDirectories(output))
+}
+
+func TestFindBuildDir(t *testing.T) {
+	buildDir, _ := filepath.Abs(filepath.Join("testdata", "build"))
+	standaloneDir, _ := filepath.Abs(filepath.Join("testdata", "standalone"))
+
+	assert.Equal(t, buildDir, findBuildDir(filepath.Join("testdata", "build", "build.sbt")))
+	assert.Equal(t, buildDir, findBuildDir(filepath.Join("testdata", "build", "core", "build.sbt")))
+	assert.Equal(t, standaloneDir, findBuildDir(filepath.Join("testdata", "standalone", "build.sbt")))
+}
+
+func TestConfigure(t *testing.T) {
+	buildDir, _ := filepath.Abs(filepath.Join("testdata", "build"))
+	coreDir := filepath.Join(buildDir, "core")
+	cmdFactoryMock := testdata.NewEchoCmdFactory()
+	cmdFactoryMock.ProjectsCmdOutput = "[info] In file:" + buildDir + "/\n[info] \t   core\n[info] \t * root"
+	cmdFactoryMock.BaseDirectoriesCmdOutput = "[info] " + coreDir + "\n[info] " + buildDir
+
+	s := NewSetup()
+	s.CmdFactory = cmdFactoryMock
+	s.Writer = &writerTestdata.FileWriterMock{}
+	setup, err := s.Configure([]string{
+		filepath.Join("testdata", "build", "build.sbt"),
+		filepath.Join("testdata", "build", "core", "build.sbt"),
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, setup.Builds, 1)
+	build := setup.Builds[0]
+	assert.Equal(t, buildDir, build.dir)
+	assert.Equal(t, filepath.Join(buildDir, buildFileName), build.buildFile)
+	assert.Equal(t, []Project{{id: "core", dir: coreDir}, {id: "root", dir: buildDir}}, build.projects)
+	assert.Equal(t, buildDir, setup.subProjectMap[coreDir])
+	assert.Equal(t, buildDir, setup.subProjectMap[buildDir])
+}
+
+func TestConfigurePluginErr(t *testing.T) {
+	createErr := errors.New("create-error")
+	s := NewSetup()
+	s.Writer = &writerTestdata.FileWriterMock{CreateErr: createErr}
+
+	_, err := s.Configure([]string{filepath.Join("testdata", "build", "build.sbt")})
+	assert.Equal(t, SetupPluginError{createErr.Error()}, err)
+}
+
+func TestConfigureProjectsErr(t *testing.T) {
+	cases := []struct {
+		name       string
+		cmdFactory testdata.CmdFactoryMock
+	}{
+		{
+			name: "Make projects command error",
+			cmdFactory: testdata.CmdFactoryMock{
+				ProjectsCmdName: "echo",
+				MakeProjectsErr: errors.New("make-projects-error"),
+			},
+		},
+		{
+			name:       "Projects command error",
+			cmdFactory: testdata.CmdFactoryMock{ProjectsCmdName: "bad-name"},
+		},
+		{
+			name:       "No projects",
+			cmdFactory: testdata.NewEchoCmdFactory(),
+		},
+		{
+			name: "Base directories command error",
+			cmdFactory: testdata.CmdFactoryMock{
+				ProjectsCmdName:        "echo",
+				ProjectsCmdOutput:      "[info] In file:/build/\n[info] \t * root",
+				BaseDirectoriesCmdName: "bad-name",
+			},
+		},
+		{
+			name: "Missing base directories",
+			cmdFactory: testdata.CmdFactoryMock{
+				ProjectsCmdName:        "echo",
+				ProjectsCmdOutput:      "[info] In file:/build/\n[info] \t * root",
+				BaseDirectoriesCmdName: "echo",
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			s := NewSetup()
+			s.CmdFactory = c.cmdFactory
+			s.Writer = &writerTestdata.FileWriterMock{}
+
+			setup, err := s.Configure([]string{filepath.Join("testdata", "standalone", "build.sbt")})
+			assert.IsType(t, SetupSubprojectError{}, err)
+			assert.Empty(t, setup.Builds)
+		})
+	}
+}
+
+func TestConfigureProjectsTimeout(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("sleep isn't available on Windows")
+	}
+	s := NewSetup()
+	s.CmdFactory = testdata.CmdFactoryMock{ProjectsCmdName: "sleep", ProjectsCmdOutput: "10"}
+	s.Writer = &writerTestdata.FileWriterMock{}
+	s.timeout = 100 * time.Millisecond
+
+	setup, err := s.Configure([]string{filepath.Join("testdata", "standalone", "build.sbt")})
+	assert.IsType(t, SetupSubprojectError{}, err)
+	assert.ErrorContains(t, err, "timed out after 100ms and was killed")
+	assert.Empty(t, setup.Builds)
+}
@@ -0,0 +1,66 @@
+package sbt
+
+import (
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/fatih/color"
+
+	"github.com/debricked/cli/internal/resolution/job"
+	"github.com/debricked/cli/internal/resolution/pm/writer"
+)
+
+type Strategy struct {
+	files       []string
+	ErrorWriter io.Writer
+	SbtSetup    ISetup
+}
+
+// Invoke makes one job per sbt build, resolving all of its projects at once.
+// A build.sbt that isn't part of a discovered build is resolved as the current project of its own directory
+func (s Strategy) Invoke() ([]job.IJob, error) {
+	var jobs []job.IJob
+	factory := CmdFactory{execPath: ExecPath{}}
+	fileWriter := writer.FileWriter{}
+	sbtSetup, err := s.SbtSetup.Configure(s.files)
+	if err != nil {
+		if _, ok := err.(SetupSubprojectError); ok {
+			warningColor := color.New(color.FgYellow, color.Bold).SprintFunc()
+			defaultOutputWriter := log.Writer()
+			log.SetOutput(s.ErrorWriter)
+			log.Println(warningColor("Warning:\n") + err.Error())
+			log.SetOutput(defaultOutputWriter)
+		} else {
+			return nil, err
+		}
+	}
+
+	buildDirs := make(map[string]bool)
+	for _, build := range sbtSetup.Builds {
+		buildDirs[build.dir] = true
+		jobs = append(jobs, NewJob(build.buildFile, build.dir, build.projects, factory, fileWriter))
+	}
+	for _, file := range s.files {
+		dir, _ := filepath.Abs(filepath.Dir(file))
+		if _, ok := sbtSetup.subProjectMap[dir]; ok {
+			continue
+		}
+		if _, ok := buildDirs[dir]; ok {
+			continue
+		}
+		buildDirs[dir] = true
+		jobs = append(jobs, NewJob(file, dir, []Project{{dir: dir}}, factory, fileWriter))
+	}
+
+	return jobs, nil
+}
+
+func NewStrategy(files []string, setupTimeout time.Duration) Strategy {
+	sbtSetup := NewSetup()
+	sbtSetup.timeout = setupTimeout
+
+	return Strategy{files, os.Stdout, sbtSetup}
+}
@@ -0,0 +1,93 @@
+package sbt
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type setupMock struct {
+	setup Setup
+	err   error
+}
+
+func (s setupMock) Configure(_ []string) (Setup, error) {
+	return s.setup, s.err
+}
+
+func TestNewStrategy(t *testing.T) {
+	s := NewStrategy(nil, 0)
+	assert.NotNil(t, s)
+	assert.Len(t, s.files, 0)
+
+	s = NewStrategy([]string{"file"}, time.Minute)
+	assert.NotNil(t, s)
+	assert.Len(t, s.files, 1)
+	setup, ok := s.SbtSetup.(*Setup)
+	assert.True(t, ok)
+	assert.Equal(t, time.Minute, setup.timeout)
+}
+
+func TestInvokeNoFiles(t *testing.T) {
+	s := NewStrategy([]string{}, 0)
+	s.SbtSetup = setupMock{setup: Setup{}}
+	jobs, err := s.Invoke()
+	assert.NoError(t, err)
+	assert.Empty(t, jobs)
+}
+
+func TestInvokeBuilds(t *testing.T) {
+	buildDir, _ := filepath.Abs(filepath.Join("testdata", "build"))
+	coreDir := filepath.Join(buildDir, "core")
+	standaloneFile := filepath.Join("testdata", "standalone", "build.sbt")
+	setup := Setup{
+		subProjectMap: map[string]string{buildDir: buildDir, coreDir: buildDir},
+		Builds: []Build{
+			{
+				dir:       buildDir,
+				buildFile: filepath.Join(buildDir, buildFileName),
+				projects:  []Project{{id: "core", dir: coreDir}, {id: "root", dir: buildDir}},
+			},
+		},
+	}
+
+	s := NewStrategy([]string{
+		filepath.Join("testdata", "build", "build.sbt"),
+		filepath.Join("testdata", "build", "core", "build.sbt"),
+		standaloneFile,
+	}, 0)
+	s.SbtSetup = setupMock{setup: setup}
+	jobs, err := s.Invoke()
+
+	assert.NoError(t, err)
+	assert.Len(t, jobs, 2)
+	assert.Equal(t, filepath.Join(buildDir, buildFileName), jobs[0].GetFile())
+	assert.Equal(t, standaloneFile, jobs[1].GetFile())
+	assert.Len(t, jobs[1].(*Job).projects, 1)
+}
+
+func TestInvokeSubprojectErr(t *testing.T) {
+	s := NewStrategy([]string{filepath.Join("testdata", "standalone", "build.sbt")}, 0)
+	errorWriter := &bytes.Buffer{}
+	s.ErrorWriter = errorWriter
+	s.SbtSetup = setupMock{setup: Setup{}, err: SetupSubprojectError{message: "subproject-error"}}
+	jobs, err := s.Invoke()
+
+	assert.NoError(t, err)
+	assert.Len(t, jobs, 1)
+	assert.Contains(t, errorWriter.String(), "subproject-error")
+}
+
+func TestInvokePluginErr(t *testing.T) {
+	pluginErr := SetupPluginError{message: "plugin-error"}
+	s := NewStrategy([]string{filepath.Join("testdata", "standalone", "build.sbt")}, 0)
+	s.SbtSetup = setupMock{setup: Setup{}, err: pluginErr}
+	jobs, err := s.Invoke()
+
+	assert.True(t, errors.Is(err, pluginErr))
+	assert.Empty(t, jobs)
+}
@@ -0,0 +1,36 @@
+package testdata
+
+import (
+	"os/exec"
+)
+
+type CmdFactoryMock struct {
+	ProjectsCmdName          string
+	ProjectsCmdOutput        string
+	MakeProjectsErr          error
+	BaseDirectoriesCmdName   string
+	BaseDirectoriesCmdOutput string
+	MakeBaseDirectoriesErr   error
+	DependencyTreeCmdName    string
+	MakeDependencyTreeErr    error
+}
+
+func NewEchoCmdFactory() CmdFactoryMock {
+	return CmdFactoryMock{
+		ProjectsCmdName:        "echo",
+		BaseDirectoriesCmdName: "echo",
+		DependencyTreeCmdName:  "echo",
+	}
+}
+
+func (f CmdFactoryMock) MakeProjectsCmd(_ string, _ string) (*exec.Cmd, error) {
+	return exec.Command(f.ProjectsCmdName, f.ProjectsCmdOutput), f.MakeProjectsErr
+}
+
+func (f CmdFactoryMock) MakeBaseDirectoriesCmd(_ string, _ string, _ []string) (*exec.Cmd, error) {
+	return exec.Command(f.BaseDirectoriesCmdName, f.BaseDirectoriesCmdOutput), f.MakeBaseDirectoriesErr
+}
+
+func (f CmdFactoryMock) MakeDependencyTreeCmd(_ string, _ string, _ []string, _ []string) (*exec.Cmd, error) {
+	return exec.Command(f.DependencyTreeCmdName, "MakeDependencyTreeCmd"), f.MakeDependencyTreeErr
+}
@@ -0,0 +1,38 @@
+package swift
+
+import (
+	"os/exec"
+	"path/filepath"
+)
+
+const swift = "swift"
+
+type ICmdFactory interface {
+	MakeResolveCmd(manifest string) (*exec.Cmd, error)
+}
+
+type IExecPath interface {
+	LookPath(file string) (string, error)
+}
+
+type ExecPath struct {
+}
+
+func (ExecPath) LookPath(file string) (string, error) {
+	return exec.LookPath(file)
+}
+
+type CmdFactory struct {
+	execPath IExecPath
+}
+
+// MakeResolveCmd makes a command resolving the dependencies of Package.swift into Package.resolved
+func (cmdf CmdFactory) MakeResolveCmd(manifest string) (*exec.Cmd, error) {
+	path, err := cmdf.execPath.LookPath(swift)
+
+	return &exec.Cmd{
+		Path: path,
+		Args: []string{swift, "package", "resolve"},
+		Dir:  filepath.Dir(manifest),
+	}, err
+}
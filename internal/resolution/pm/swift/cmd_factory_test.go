@@ -0,0 +1,28 @@
+package swift
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type execPathMock struct {
+	path string
+}
+
+func (e execPathMock) LookPath(file string) (string, error) {
+	if len(e.path) > 0 {
+		return e.path, nil
+	}
+
+	return file, nil
+}
+
+func TestMakeResolveCmd(t *testing.T) {
+	cmd, err := CmdFactory{execPath: execPathMock{}}.MakeResolveCmd(filepath.Join("dir", "Package.swift"))
+	assert.NoError(t, err)
+	assert.NotNil(t, cmd)
+	assert.Equal(t, []string{"swift", "package", "resolve"}, cmd.Args)
+	assert.Equal(t, "dir", cmd.Dir)
+}
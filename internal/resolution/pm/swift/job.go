@@ -0,0 +1,162 @@
+package swift
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/debricked/cli/internal/resolution/job"
+	"github.com/debricked/cli/internal/resolution/pm/util"
+)
+
+const (
+	swiftExecutableNotFoundErrRegex = `"swift": executable file not found`
+	toolsVersionErrRegex            = `is using Swift tools version ([^\s]+) but the installed version is ([^\s]+)`
+	unresolvableErrRegex            = `[Dd]ependencies could not be resolved because (.+)`
+	authenticationErrRegex          = `could not read Username for '([^']+)'|Authentication failed for '([^']+)'`
+	networkErrRegex                 = `[Ff]ailed to clone repository ([^\s:]+)|Could not resolve host: ([^\s]+)`
+)
+
+type Job struct {
+	job.BaseJob
+	cmdFactory ICmdFactory
+}
+
+func NewJob(
+	file string,
+	cmdFactory ICmdFactory,
+) *Job {
+	return &Job{
+		BaseJob:    job.NewBaseJob(file),
+		cmdFactory: cmdFactory,
+	}
+}
+
+func (j *Job) Run() {
+	status := "resolving package dependencies"
+	j.SendStatus(status)
+	resolveCmd, err := j.cmdFactory.MakeResolveCmd(j.GetFile())
+	if err != nil {
+		cmdErr := util.NewPMJobError(err.Error())
+		cmdErr.SetStatus(status)
+		if resolveCmd != nil {
+			cmdErr.SetCommand(resolveCmd.String())
+		}
+		j.handleError(cmdErr)
+
+		return
+	}
+
+	output, err := j.Output(resolveCmd)
+	if err != nil {
+		cmdErr := util.NewPMJobError(j.GetExitError(err, string(output)).Error())
+		cmdErr.SetStatus(status)
+		cmdErr.SetCommand(resolveCmd.String())
+		j.handleError(cmdErr)
+	}
+}
+
+// Plan returns the command and lock file of the job, without running it
+func (j *Job) Plan() job.Plan {
+	var plan job.Plan
+	plan.AddCommand(j.cmdFactory.MakeResolveCmd(j.GetFile()))
+	plan.AddLockFile(util.MakePathFromManifestFile(j.GetFile(), "Package.resolved"))
+
+	return plan
+}
+
+func (j *Job) handleError(cmdError job.IError) {
+	expressions := []string{
+		swiftExecutableNotFoundErrRegex,
+		toolsVersionErrRegex,
+		unresolvableErrRegex,
+		authenticationErrRegex,
+		networkErrRegex,
+	}
+
+	for _, expression := range expressions {
+		regex := regexp.MustCompile(expression)
+		matches := regex.FindAllStringSubmatch(cmdError.Error(), -1)
+
+		if len(matches) > 0 {
+			cmdError = j.addDocumentation(expression, matches, cmdError)
+			j.Errors().Append(cmdError)
+
+			return
+		}
+	}
+
+	j.Errors().Append(cmdError)
+}
+
+func (j *Job) addDocumentation(expr string, matches [][]string, cmdError job.IError) job.IError {
+	documentation := cmdError.Documentation()
+
+	switch expr {
+	case swiftExecutableNotFoundErrRegex:
+		documentation = j.GetExecutableNotFoundErrorDocumentation("Swift")
+	case toolsVersionErrRegex:
+		documentation = j.getToolsVersionErrorDocumentation(matches)
+	case unresolvableErrRegex:
+		documentation = j.getUnresolvableErrorDocumentation(matches)
+	case authenticationErrRegex:
+		documentation = j.getAuthenticationErrorDocumentation(matches)
+	case networkErrRegex:
+		documentation = j.getNetworkErrorDocumentation()
+	}
+
+	cmdError.SetDocumentation(documentation)
+
+	return cmdError
+}
+
+// firstMatch returns the first non-empty group of the first match, since alternatives have a group each
+func firstMatch(matches [][]string) string {
+	if len(matches) == 0 {
+		return ""
+	}
+	for _, group := range matches[0][1:] {
+		if len(group) > 0 {
+			return group
+		}
+	}
+
+	return ""
+}
+
+func (j *Job) getToolsVersionErrorDocumentation(matches [][]string) string {
+	installed := ""
+	if len(matches) > 0 && len(matches[0]) > 2 {
+		installed = " than " + matches[0][2]
+	}
+
+	return strings.Join(
+		[]string{
+			"Package.swift requires Swift tools version " + firstMatch(matches) + ", which is newer" + installed + ".",
+			"Please install a Swift toolchain supporting the tools version and make sure that it is the one accessible by the CLI.",
+		}, " ")
+}
+
+func (j *Job) getUnresolvableErrorDocumentation(matches [][]string) string {
+	return strings.Join(
+		[]string{
+			"Failed to resolve the package dependencies because " + strings.TrimSpace(firstMatch(matches)),
+			"Please check that the version requirements in Package.swift can be satisfied together.",
+		}, " ")
+}
+
+func (j *Job) getAuthenticationErrorDocumentation(matches [][]string) string {
+	return strings.Join(
+		[]string{
+			"Failed to authenticate to repository \"" + firstMatch(matches) + "\".",
+			"Please make sure that the CLI has access to the repository, for example by configuring git credentials or a .netrc file.",
+			"\n" + util.InstallPrivateDependencyMessage,
+		}, " ")
+}
+
+func (j *Job) getNetworkErrorDocumentation() string {
+	return strings.Join(
+		[]string{
+			"We weren't able to retrieve one or more dependencies.",
+			"Please check your Internet connection and try again.",
+		}, " ")
+}
@@ -0,0 +1,149 @@
+package swift
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	jobTestdata "github.com/debricked/cli/internal/resolution/job/testdata"
+	"github.com/debricked/cli/internal/resolution/pm/swift/testdata"
+	"github.com/debricked/cli/internal/resolution/pm/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewJob(t *testing.T) {
+	j := NewJob("file", CmdFactory{execPath: ExecPath{}})
+	assert.Equal(t, "file", j.GetFile())
+	assert.False(t, j.Errors().HasError())
+}
+
+func TestRun(t *testing.T) {
+	j := NewJob("Package.swift", testdata.NewEchoCmdFactory())
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	assert.False(t, j.Errors().HasError())
+}
+
+// writeFakeSwift writes a fake swift executable running script, since the Swift toolchain isn't available everywhere
+func writeFakeSwift(t *testing.T, script string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake executables are shell scripts")
+	}
+	fakeSwift := filepath.Join(t.TempDir(), "swift")
+	assert.NoError(t, os.WriteFile(fakeSwift, []byte("#!/bin/sh\n"+script+"\n"), 0700)) // #nosec G306
+
+	return fakeSwift
+}
+
+func TestRunFakeSwift(t *testing.T) {
+	fakeSwift := writeFakeSwift(t, `[ "$1 $2" = "package resolve" ] || exit 1
+echo '{"pins":[],"version":2}' > Package.resolved`)
+	dir := t.TempDir()
+	j := NewJob(filepath.Join(dir, "Package.swift"), CmdFactory{execPath: execPathMock{path: fakeSwift}})
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	assert.False(t, j.Errors().HasError())
+	assert.FileExists(t, filepath.Join(dir, "Package.resolved"))
+}
+
+func TestRunFakeSwiftErr(t *testing.T) {
+	fakeSwift := writeFakeSwift(t, `echo "error: Dependencies could not be resolved because no versions of 'alamofire' match the requirement 9.0.0..<10.0.0." >&2
+exit 1`)
+	j := NewJob(filepath.Join(t.TempDir(), "Package.swift"), CmdFactory{execPath: execPathMock{path: fakeSwift}})
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	errs := j.Errors().GetAll()
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "resolving package dependencies", errs[0].Status())
+	assert.Contains(t, errs[0].Documentation(), "no versions of 'alamofire' match the requirement 9.0.0..<10.0.0.")
+}
+
+func TestRunResolveCmdOutputErr(t *testing.T) {
+	cmdFactoryMock := testdata.NewEchoCmdFactory()
+	cmdFactoryMock.ResolveCmdName = "bad-name"
+	j := NewJob("Package.swift", cmdFactoryMock)
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	jobTestdata.AssertPathErr(t, j.Errors())
+}
+
+func TestRunResolveCmdErr(t *testing.T) {
+	cases := []struct {
+		name  string
+		error string
+		doc   string
+	}{
+		{
+			name:  "General error",
+			error: "cmd-error",
+			doc:   util.UnknownError,
+		},
+		{
+			name:  "Swift not found",
+			error: "exec: \"swift\": executable file not found in $PATH",
+			doc:   "Swift wasn't found. Please check if it is installed and accessible by the CLI.",
+		},
+		{
+			name:  "Tools version",
+			error: "error: package 'app' is using Swift tools version 5.9.0 but the installed version is 5.7.3",
+			doc:   "Package.swift requires Swift tools version 5.9.0, which is newer than 5.7.3. Please install a Swift toolchain supporting the tools version and make sure that it is the one accessible by the CLI.",
+		},
+		{
+			name:  "Unresolvable dependencies",
+			error: "error: Dependencies could not be resolved because root depends on 'alamofire' 9.0.0..<10.0.0.",
+			doc:   "Failed to resolve the package dependencies because root depends on 'alamofire' 9.0.0..<10.0.0. Please check that the version requirements in Package.swift can be satisfied together.",
+		},
+		{
+			name:  "Authentication",
+			error: "error: Failed to clone repository https://github.com/example/private.git:\n    fatal: could not read Username for 'https://github.com': terminal prompts disabled",
+			doc:   "Failed to authenticate to repository \"https://github.com\". Please make sure that the CLI has access to the repository, for example by configuring git credentials or a .netrc file. \n" + util.InstallPrivateDependencyMessage,
+		},
+		{
+			name:  "No network",
+			error: "error: Failed to clone repository https://github.com/Alamofire/Alamofire.git:\n    fatal: unable to access 'https://github.com/Alamofire/Alamofire.git/': Could not resolve host: github.com",
+			doc:   "We weren't able to retrieve one or more dependencies. Please check your Internet connection and try again.",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cmdFactoryMock := testdata.NewEchoCmdFactory()
+			cmdFactoryMock.MakeResolveErr = errors.New(c.error)
+			cmd, _ := cmdFactoryMock.MakeResolveCmd("Package.swift")
+			j := NewJob("Package.swift", cmdFactoryMock)
+
+			expectedError := util.NewPMJobError(c.error)
+			expectedError.SetDocumentation(c.doc)
+			expectedError.SetStatus("resolving package dependencies")
+			expectedError.SetCommand(cmd.String())
+
+			go jobTestdata.WaitStatus(j)
+			j.Run()
+
+			errs := j.Errors().GetAll()
+			assert.Len(t, errs, 1)
+			assert.Contains(t, errs, expectedError)
+		})
+	}
+}
+
+func TestPlan(t *testing.T) {
+	j := NewJob(filepath.Join("dir", "Package.swift"), testdata.NewEchoCmdFactory())
+
+	plan := j.Plan()
+
+	assert.Len(t, plan.Commands, 1)
+	assert.Contains(t, plan.Commands[0], "Package.swift")
+	assert.Equal(t, []string{filepath.Join("dir", "Package.resolved")}, plan.LockFiles)
+}
@@ -0,0 +1,40 @@
+package swift
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPm(t *testing.T) {
+	pm := NewPm()
+	assert.Equal(t, Name, pm.name)
+}
+
+func TestName(t *testing.T) {
+	pm := NewPm()
+	assert.Equal(t, Name, pm.Name())
+}
+
+func TestManifests(t *testing.T) {
+	pm := Pm{}
+	manifests := pm.Manifests()
+	assert.Len(t, manifests, 1)
+	assert.Equal(t, `^Package\.swift$`, manifests[0])
+
+	cases := map[string]bool{
+		"Package.swift":          true,
+		"Package.resolved":       false,
+		"Package@swift-5.swift":  false,
+		"MyPackage.swift":        false,
+		"Sources/Package.swifts": false,
+	}
+	for file, isMatch := range cases {
+		t.Run(file, func(t *testing.T) {
+			matched, err := regexp.MatchString(manifests[0], file)
+			assert.NoError(t, err)
+			assert.Equal(t, isMatch, matched)
+		})
+	}
+}
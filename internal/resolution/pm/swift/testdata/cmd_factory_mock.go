@@ -0,0 +1,20 @@
+package testdata
+
+import (
+	"os/exec"
+)
+
+type CmdFactoryMock struct {
+	ResolveCmdName string
+	MakeResolveErr error
+}
+
+func NewEchoCmdFactory() CmdFactoryMock {
+	return CmdFactoryMock{
+		ResolveCmdName: "echo",
+	}
+}
+
+func (f CmdFactoryMock) MakeResolveCmd(manifest string) (*exec.Cmd, error) {
+	return exec.Command(f.ResolveCmdName, manifest), f.MakeResolveErr
+}
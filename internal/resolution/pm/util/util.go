@@ -1,10 +1,14 @@
 package util
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/debricked/cli/internal/resolution/job"
 	"github.com/debricked/cli/internal/resolution/pm/writer"
@@ -25,3 +29,35 @@ func CloseFile(j job.IJob, fileWriter writer.IFileWriter, file *os.File) {
 		j.Errors().Critical(NewPMJobError(err.Error()))
 	}
 }
+
+// waitDelay is how long OutputWithTimeout waits for the output of processes started by a killed command
+const waitDelay = 5 * time.Second
+
+// OutputWithTimeout runs the command and returns its standard output, like exec.Cmd.Output.
+// The command is killed along with the processes it started if it doesn't finish within timeout,
+// or may run until it finishes if timeout is 0
+func OutputWithTimeout(cmd *exec.Cmd, timeout time.Duration) ([]byte, error) {
+	if timeout <= 0 {
+		return cmd.Output()
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	ctxCmd := exec.CommandContext(ctx, cmd.Path) // #nosec G204
+	ctxCmd.Args = cmd.Args
+	ctxCmd.Dir = cmd.Dir
+	ctxCmd.Env = cmd.Env
+	ctxCmd.Stdin = cmd.Stdin
+	ctxCmd.Stderr = cmd.Stderr
+	job.SetProcessGroup(ctxCmd)
+	ctxCmd.Cancel = func() error {
+		return job.KillProcessGroup(ctxCmd.Process)
+	}
+	ctxCmd.WaitDelay = waitDelay
+	output, err := ctxCmd.Output()
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return output, fmt.Errorf("%s timed out after %s and was killed", cmd.String(), timeout)
+	}
+
+	return output, err
+}
@@ -4,8 +4,11 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"runtime"
 	"testing"
+	"time"
 
 	"github.com/debricked/cli/internal/resolution/job"
 	"github.com/debricked/cli/internal/resolution/job/testdata"
@@ -49,3 +52,41 @@ func TestCloseFileErr(t *testing.T) {
 	criticalErrs := j.Errors().GetCriticalErrors()
 	assert.Len(t, criticalErrs, 1)
 }
+
+func TestOutputWithTimeout(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("echo is a shell builtin on Windows")
+	}
+	output, err := OutputWithTimeout(exec.Command("echo", "output"), time.Minute)
+	assert.NoError(t, err)
+	assert.Equal(t, "output\n", string(output))
+
+	output, err = OutputWithTimeout(exec.Command("echo", "output"), 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "output\n", string(output))
+}
+
+func TestOutputWithTimeoutKilled(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("sleep isn't available on Windows")
+	}
+	cmd := exec.Command("sleep", "10")
+	start := time.Now()
+	_, err := OutputWithTimeout(cmd, 100*time.Millisecond)
+
+	assert.Less(t, time.Since(start), 5*time.Second)
+	assert.ErrorContains(t, err, "timed out after 100ms and was killed")
+}
+
+func TestOutputWithTimeoutKillsStartedProcesses(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("sh isn't available on Windows")
+	}
+	// The sleep started by sh keeps stdout open until it is killed as well
+	cmd := exec.Command("sh", "-c", "sleep 30; echo done")
+	start := time.Now()
+	_, err := OutputWithTimeout(cmd, 100*time.Millisecond)
+
+	assert.Less(t, time.Since(start), waitDelay)
+	assert.ErrorContains(t, err, "timed out after 100ms and was killed")
+}
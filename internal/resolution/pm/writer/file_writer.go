@@ -2,6 +2,7 @@ package writer
 
 import (
 	"os"
+	"path/filepath"
 )
 
 type IFileWriter interface {
@@ -12,7 +13,14 @@ type IFileWriter interface {
 
 type FileWriter struct{}
 
+// Create creates the file, and its directory if it doesn't exist, such as the directory of a lock file
+// in the lock output dir
 func (fw FileWriter) Create(name string) (*os.File, error) {
+	err := os.MkdirAll(filepath.Dir(name), 0750)
+	if err != nil {
+		return nil, err
+	}
+
 	return os.Create(name)
 }
 
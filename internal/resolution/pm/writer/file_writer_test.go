@@ -2,6 +2,7 @@ package writer
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -18,6 +19,14 @@ func TestCreate(t *testing.T) {
 	defer deleteFile(t, testFile)
 }
 
+func TestCreateInNewDir(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "locks", "app", fileName)
+	testFile, err := fw.Create(name)
+	assert.NoError(t, err)
+	assert.NoError(t, fw.Close(testFile))
+	assert.FileExists(t, name)
+}
+
 func TestWrite(t *testing.T) {
 	content := []byte("{}")
 	testFile, _ := fw.Create(fileName)
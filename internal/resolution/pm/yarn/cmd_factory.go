@@ -3,10 +3,13 @@ package yarn
 import (
 	"os/exec"
 	"path/filepath"
+
+	"github.com/debricked/cli/internal/resolution/scope"
 )
 
 type ICmdFactory interface {
 	MakeInstallCmd(command string, file string) (*exec.Cmd, error)
+	MakeVersionCmd(command string, file string) (*exec.Cmd, error)
 }
 
 type IExecPath interface {
@@ -21,24 +24,47 @@ func (ExecPath) LookPath(file string) (string, error) {
 }
 
 type CmdFactory struct {
-	execPath IExecPath
+	execPath       IExecPath
+	lockfileOnly   bool
+	excludedScopes scope.Scopes
 }
 
+// MakeInstallCmd makes a command installing the dependencies of package.json.
+// In lockfile-only mode yarn.lock is resolved without installing packages, which requires Yarn 2 or later.
+// Excluding the dev scope installs without devDependencies, which yarn.lock still locks
 func (cmdf CmdFactory) MakeInstallCmd(command string, file string) (*exec.Cmd, error) {
 	path, err := cmdf.execPath.LookPath(command)
 
 	fileDir := filepath.Dir(file)
 
+	args := []string{command, "install",
+		"--non-interactive",  // We can't answer any prompts...
+		"--ignore-scripts",   // Avoid risky scripts
+		"--ignore-engines",   // We won't run the code, so we don't care about the engine versions
+		"--ignore-platform",  // We won't run the code, so we don't care about the platform, undocumented option
+		"--no-bin-links",     // We don't need symlinks to binaries as we won't run any code
+		"--production=false", // Include dev dependencies, unless the dev scope is excluded
+	}
+	if cmdf.excludedScopes.Excludes(scope.Dev) {
+		args[len(args)-1] = "--production=true"
+	}
+	if cmdf.lockfileOnly {
+		args = []string{command, "install", "--mode=update-lockfile"}
+	}
+
+	return &exec.Cmd{
+		Path: path,
+		Args: args,
+		Dir:  fileDir,
+	}, err
+}
+
+func (cmdf CmdFactory) MakeVersionCmd(command string, file string) (*exec.Cmd, error) {
+	path, err := cmdf.execPath.LookPath(command)
+
 	return &exec.Cmd{
 		Path: path,
-		Args: []string{command, "install",
-			"--non-interactive",  // We can't answer any prompts...
-			"--ignore-scripts",   // Avoid risky scripts
-			"--ignore-engines",   // We won't run the code, so we don't care about the engine versions
-			"--ignore-platform",  // We won't run the code, so we don't care about the platform, undocumented option
-			"--no-bin-links",     // We don't need symlinks to binaries as we won't run any code
-			"--production=false", // Always include dev dependencies
-		},
-		Dir: fileDir,
+		Args: []string{command, "--version"},
+		Dir:  filepath.Dir(file),
 	}, err
 }
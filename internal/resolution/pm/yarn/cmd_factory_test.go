@@ -3,6 +3,7 @@ package yarn
 import (
 	"testing"
 
+	"github.com/debricked/cli/internal/resolution/scope"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -17,3 +18,35 @@ func TestMakeInstallCmd(t *testing.T) {
 	assert.Contains(t, args, "yarn")
 	assert.Contains(t, args, "install")
 }
+
+func TestMakeInstallCmdLockfileOnly(t *testing.T) {
+	cmd, _ := CmdFactory{
+		execPath:     ExecPath{},
+		lockfileOnly: true,
+	}.MakeInstallCmd("yarn", "file")
+	assert.Equal(t, []string{"yarn", "install", "--mode=update-lockfile"}, cmd.Args)
+}
+
+func TestMakeInstallCmdExcludedScopes(t *testing.T) {
+	cmd, _ := CmdFactory{
+		execPath:       ExecPath{},
+		excludedScopes: scope.Scopes{scope.Dev},
+	}.MakeInstallCmd("yarn", "file")
+	assert.Contains(t, cmd.Args, "--production=true")
+	assert.NotContains(t, cmd.Args, "--production=false")
+
+	cmd, _ = CmdFactory{
+		execPath:       ExecPath{},
+		lockfileOnly:   true,
+		excludedScopes: scope.Scopes{scope.Dev},
+	}.MakeInstallCmd("yarn", "file")
+	assert.Equal(t, []string{"yarn", "install", "--mode=update-lockfile"}, cmd.Args)
+}
+
+func TestMakeVersionCmd(t *testing.T) {
+	cmd, _ := CmdFactory{
+		execPath: ExecPath{},
+	}.MakeVersionCmd("yarn", "file")
+	assert.NotNil(t, cmd)
+	assert.Equal(t, []string{"yarn", "--version"}, cmd.Args)
+}
@@ -15,24 +15,28 @@ const (
 	dependencyNotFoundErrRegex  = `error.*? "?(https?://[^"\s:]+)?: Not found`
 	registryUnavailableErrRegex = "error Error: getaddrinfo ENOTFOUND ([\\w\\.]+)"
 	permissionDeniedErrRegex    = "Error: (.*): Request failed \"404 Not Found\""
+	yarnClassicErrRegex         = `Yarn ([01]\.[\d.]+) can't resolve yarn.lock without installing`
 )
 
 type Job struct {
 	job.BaseJob
-	install     bool
-	yarnCommand string
-	cmdFactory  ICmdFactory
+	install      bool
+	lockfileOnly bool
+	yarnCommand  string
+	cmdFactory   ICmdFactory
 }
 
 func NewJob(
 	file string,
 	install bool,
+	lockfileOnly bool,
 	cmdFactory ICmdFactory,
 ) *Job {
 	return &Job{
-		BaseJob:    job.NewBaseJob(file),
-		install:    install,
-		cmdFactory: cmdFactory,
+		BaseJob:      job.NewBaseJob(file),
+		install:      install,
+		lockfileOnly: lockfileOnly,
+		cmdFactory:   cmdFactory,
 	}
 }
 
@@ -46,6 +50,10 @@ func (j *Job) Run() {
 		j.SendStatus(status)
 		j.yarnCommand = yarn
 
+		if j.lockfileOnly && !j.checkLockfileOnlySupport(status) {
+			return
+		}
+
 		installCmd, err := j.cmdFactory.MakeInstallCmd(j.yarnCommand, j.GetFile())
 
 		if err != nil {
@@ -54,7 +62,7 @@ func (j *Job) Run() {
 			return
 		}
 
-		if output, err := installCmd.Output(); err != nil {
+		if output, err := j.Output(installCmd); err != nil {
 			error := strings.Join([]string{string(output), j.GetExitError(err, "").Error()}, "")
 			j.handleError(j.createError(error, installCmd.String(), status))
 
@@ -63,6 +71,47 @@ func (j *Job) Run() {
 	}
 }
 
+// Plan returns the commands and lock file of the job, without running it
+func (j *Job) Plan() job.Plan {
+	var plan job.Plan
+	if j.install {
+		if j.lockfileOnly {
+			plan.AddCommand(j.cmdFactory.MakeVersionCmd(yarn, j.GetFile()))
+		}
+		plan.AddCommand(j.cmdFactory.MakeInstallCmd(yarn, j.GetFile()))
+		plan.AddLockFile(util.MakePathFromManifestFile(j.GetFile(), "yarn.lock"))
+	}
+
+	return plan
+}
+
+// checkLockfileOnlySupport returns true if Yarn can update yarn.lock without installing packages,
+// which Yarn Classic can't
+func (j *Job) checkLockfileOnlySupport(status string) bool {
+	versionCmd, err := j.cmdFactory.MakeVersionCmd(j.yarnCommand, j.GetFile())
+	if err != nil {
+		j.handleError(j.createError(err.Error(), versionCmd.String(), status))
+
+		return false
+	}
+
+	output, err := j.Output(versionCmd)
+	if err != nil {
+		j.handleError(j.createError(j.GetExitError(err, string(output)).Error(), versionCmd.String(), status))
+
+		return false
+	}
+
+	version := strings.TrimSpace(string(output))
+	if strings.HasPrefix(version, "0.") || strings.HasPrefix(version, "1.") {
+		j.handleError(j.createError("Yarn "+version+" can't resolve yarn.lock without installing", versionCmd.String(), status))
+
+		return false
+	}
+
+	return true
+}
+
 func (j *Job) createError(error string, cmd string, status string) job.IError {
 	cmdError := util.NewPMJobError(error)
 	cmdError.SetCommand(cmd)
@@ -78,6 +127,7 @@ func (j *Job) handleError(cmdError job.IError) {
 		dependencyNotFoundErrRegex,
 		registryUnavailableErrRegex,
 		permissionDeniedErrRegex,
+		yarnClassicErrRegex,
 	}
 
 	for _, expression := range expressions {
@@ -109,6 +159,8 @@ func (j *Job) addDocumentation(expr string, matches [][]string, cmdError job.IEr
 		documentation = j.getRegistryUnavailableErrorDocumentation(matches)
 	case permissionDeniedErrRegex:
 		documentation = j.getPermissionDeniedErrorDocumentation(matches)
+	case yarnClassicErrRegex:
+		documentation = j.getYarnClassicErrorDocumentation(matches)
 	}
 
 	cmdError.SetDocumentation(documentation)
@@ -174,3 +226,17 @@ func (j *Job) getPermissionDeniedErrorDocumentation(matches [][]string) string {
 			util.InstallPrivateDependencyMessage,
 		}, " ")
 }
+
+func (j *Job) getYarnClassicErrorDocumentation(matches [][]string) string {
+	version := ""
+	if len(matches) > 0 && len(matches[0]) > 1 {
+		version = matches[0][1]
+	}
+
+	return strings.Join(
+		[]string{
+			"Yarn " + version + " can't resolve dependencies without installing them, which is disabled by --lockfile-only.",
+			"Please use Yarn 2 or later, select another package manager using --js-package-manager,",
+			"or resolve without --lockfile-only.",
+		}, " ")
+}
@@ -15,7 +15,7 @@ const (
 )
 
 func TestNewJob(t *testing.T) {
-	j := NewJob("file", false, CmdFactory{
+	j := NewJob("file", false, false, CmdFactory{
 		execPath: ExecPath{},
 	})
 	assert.Equal(t, "file", j.GetFile())
@@ -85,7 +85,7 @@ func TestRunInstallCmdErr(t *testing.T) {
 			expectedError.SetStatus("installing dependencies")
 			expectedError.SetCommand(cmd.String())
 
-			j := NewJob("file", true, cmdFactoryMock)
+			j := NewJob("file", true, false, cmdFactoryMock)
 
 			go jobTestdata.WaitStatus(j)
 			j.Run()
@@ -101,7 +101,40 @@ func TestRunInstallCmdErr(t *testing.T) {
 func TestRunInstallCmdOutputErr(t *testing.T) {
 	cmdMock := testdata.NewEchoCmdFactory()
 	cmdMock.InstallCmdName = badName
-	j := NewJob("file", true, cmdMock)
+	j := NewJob("file", true, false, cmdMock)
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	jobTestdata.AssertPathErr(t, j.Errors())
+}
+
+func TestRunLockfileOnly(t *testing.T) {
+	j := NewJob("file", true, true, testdata.NewEchoCmdFactory())
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	assert.Empty(t, j.Errors().GetAll())
+}
+
+func TestRunLockfileOnlyYarnClassic(t *testing.T) {
+	cmdMock := testdata.NewEchoCmdFactory()
+	cmdMock.VersionCmdArgs = []string{"1.22.19"}
+	j := NewJob("file", true, true, cmdMock)
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	allErrors := j.Errors().GetAll()
+	assert.Len(t, allErrors, 1)
+	assert.Contains(t, allErrors[0].Documentation(), "Yarn 1.22.19 can't resolve dependencies without installing them, which is disabled by --lockfile-only. Please use Yarn 2 or later, select another package manager using --js-package-manager, or resolve without --lockfile-only.")
+}
+
+func TestRunLockfileOnlyVersionCmdErr(t *testing.T) {
+	cmdMock := testdata.NewEchoCmdFactory()
+	cmdMock.VersionCmdName = badName
+	j := NewJob("file", true, true, cmdMock)
 
 	go jobTestdata.WaitStatus(j)
 	j.Run()
@@ -1,43 +1,65 @@
 package yarn
 
 import (
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 )
 
 func TestNewStrategy(t *testing.T) {
-	s := NewStrategy(nil)
+	s := NewStrategy(nil, false, nil)
 	assert.NotNil(t, s)
 	assert.Len(t, s.files, 0)
 
-	s = NewStrategy([]string{})
+	s = NewStrategy([]string{}, false, nil)
 	assert.NotNil(t, s)
 	assert.Len(t, s.files, 0)
 
-	s = NewStrategy([]string{"file"})
+	s = NewStrategy([]string{"file"}, false, nil)
 	assert.NotNil(t, s)
 	assert.Len(t, s.files, 1)
 
-	s = NewStrategy([]string{"file-1", "file-2"})
+	s = NewStrategy([]string{"file-1", "file-2"}, false, nil)
 	assert.NotNil(t, s)
 	assert.Len(t, s.files, 2)
 }
 
 func TestInvokeNoFiles(t *testing.T) {
-	s := NewStrategy([]string{})
+	s := NewStrategy([]string{}, false, nil)
 	jobs, _ := s.Invoke()
 	assert.Empty(t, jobs)
 }
 
 func TestInvokeOneFile(t *testing.T) {
-	s := NewStrategy([]string{"file"})
+	s := NewStrategy([]string{"file"}, false, nil)
 	jobs, _ := s.Invoke()
 	assert.Len(t, jobs, 1)
 }
 
 func TestInvokeManyFiles(t *testing.T) {
-	s := NewStrategy([]string{"file-1", "file-2"})
+	s := NewStrategy([]string{"file-1", "file-2"}, false, nil)
 	jobs, _ := s.Invoke()
 	assert.Len(t, jobs, 2)
 }
+
+func TestInvokeLockfileOnly(t *testing.T) {
+	s := NewStrategy([]string{"file"}, true, nil)
+	jobs, _ := s.Invoke()
+	assert.Len(t, jobs, 1)
+	j, _ := jobs[0].(*Job)
+	assert.True(t, j.lockfileOnly)
+}
+
+func TestInvokeWorkspace(t *testing.T) {
+	s := NewStrategy([]string{
+		filepath.Join("testdata", "workspace", "packages", "button", "package.json"),
+		filepath.Join("testdata", "workspace", "packages", "icons", "package.json"),
+		filepath.Join("testdata", "locked", "packages", "button", "package.json"),
+	}, false, nil)
+	jobs, err := s.Invoke()
+
+	assert.NoError(t, err)
+	assert.Len(t, jobs, 1)
+	assert.Equal(t, filepath.Join("testdata", "workspace", "package.json"), jobs[0].GetFile())
+}
@@ -7,14 +7,23 @@ import (
 type CmdFactoryMock struct {
 	InstallCmdName string
 	MakeInstallErr error
+	VersionCmdName string
+	VersionCmdArgs []string
+	MakeVersionErr error
 }
 
 func NewEchoCmdFactory() CmdFactoryMock {
 	return CmdFactoryMock{
 		InstallCmdName: "echo",
+		VersionCmdName: "echo",
+		VersionCmdArgs: []string{"4.0.2"},
 	}
 }
 
 func (f CmdFactoryMock) MakeInstallCmd(command string, file string) (*exec.Cmd, error) {
 	return exec.Command(f.InstallCmdName), f.MakeInstallErr
 }
+
+func (f CmdFactoryMock) MakeVersionCmd(command string, file string) (*exec.Cmd, error) {
+	return exec.Command(f.VersionCmdName, f.VersionCmdArgs...), f.MakeVersionErr
+}
@@ -0,0 +1,147 @@
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/debricked/cli/internal/resolution/pm/composer"
+	"github.com/debricked/cli/internal/resolution/pm/gomod"
+	"github.com/debricked/cli/internal/resolution/pm/maven"
+	"github.com/debricked/cli/internal/resolution/pm/npm"
+	"github.com/debricked/cli/internal/resolution/pm/nuget"
+	"github.com/debricked/cli/internal/resolution/pm/pip"
+	"github.com/debricked/cli/internal/resolution/pm/pipenv"
+	"github.com/debricked/cli/internal/resolution/pm/pnpm"
+	"github.com/debricked/cli/internal/resolution/pm/pyproject"
+	"github.com/debricked/cli/internal/resolution/pm/yarn"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	Npm      = "npm"
+	Pip      = "pip"
+	Maven    = "maven"
+	Go       = "go"
+	Nuget    = "nuget"
+	Composer = "composer"
+)
+
+// registryPms maps the registries of the config to the package managers that use them
+var registryPms = map[string][]string{
+	Npm:      {npm.Name, yarn.Name, pnpm.Name},
+	Pip:      {pip.Name, pipenv.Name, pyproject.Name},
+	Maven:    {maven.Name},
+	Go:       {gomod.Name},
+	Nuget:    {nuget.Name},
+	Composer: {composer.Name},
+}
+
+var ErrNoSecret = errors.New("neither env nor file is set")
+
+// Secret is a credential read from an environment variable or a file, so that it is never written in the config
+type Secret struct {
+	Env  string `yaml:"env"`
+	File string `yaml:"file"`
+}
+
+func (s Secret) IsSet() bool {
+	return len(s.Env) > 0 || len(s.File) > 0
+}
+
+// Value returns the value of the environment variable, or the contents of the file without surrounding whitespace
+func (s Secret) Value() (string, error) {
+	if len(s.Env) > 0 {
+		value, ok := os.LookupEnv(s.Env)
+		if !ok {
+			return "", fmt.Errorf("environment variable %s isn't set", s.Env)
+		}
+
+		return value, nil
+	}
+	if len(s.File) > 0 {
+		content, err := os.ReadFile(filepath.Clean(s.File))
+		if err != nil {
+			return "", err
+		}
+
+		return strings.TrimSpace(string(content)), nil
+	}
+
+	return "", ErrNoSecret
+}
+
+// Registry is a mirror of the registry of a package manager, and the credentials of it
+type Registry struct {
+	Url      string   `yaml:"url"`
+	Username Secret   `yaml:"username"`
+	Password Secret   `yaml:"password"`
+	Token    Secret   `yaml:"token"`
+	Private  []string `yaml:"private"`
+}
+
+// credentials are the values of the secrets of a registry
+type credentials struct {
+	username string
+	password string
+	token    string
+}
+
+func (r Registry) credentials() (credentials, error) {
+	var creds credentials
+	secrets := map[*string]Secret{
+		&creds.username: r.Username,
+		&creds.password: r.Password,
+		&creds.token:    r.Token,
+	}
+	for value, secret := range secrets {
+		if !secret.IsSet() {
+			continue
+		}
+		var err error
+		*value, err = secret.Value()
+		if err != nil {
+			return creds, err
+		}
+	}
+
+	return creds, nil
+}
+
+// Config is the registries section of the registries config file, keyed by registry
+type Config struct {
+	Registries map[string]Registry `yaml:"registries"`
+}
+
+// ReadConfig reads the registries config file at path
+func ReadConfig(path string) (Config, error) {
+	var config Config
+	content, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return config, err
+	}
+	err = yaml.Unmarshal(content, &config)
+	if err != nil {
+		return config, fmt.Errorf("failed to parse registries config %s: %w", path, err)
+	}
+	for name := range config.Registries {
+		if _, ok := registryPms[name]; !ok {
+			return config, fmt.Errorf("invalid registry %s in %s, use one of %s", name, path, strings.Join(supportedRegistries(), ", "))
+		}
+	}
+
+	return config, nil
+}
+
+func supportedRegistries() []string {
+	var names []string
+	for name := range registryPms {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
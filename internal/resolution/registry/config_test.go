@@ -0,0 +1,63 @@
+package registry
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadConfig(t *testing.T) {
+	config, err := ReadConfig(filepath.Join("testdata", "registries.yaml"))
+
+	assert.NoError(t, err)
+	assert.Len(t, config.Registries, 3)
+	assert.Equal(t, "https://npm.example.com/", config.Registries[Npm].Url)
+	assert.Equal(t, Secret{Env: "DEBRICKED_TEST_NPM_TOKEN"}, config.Registries[Npm].Token)
+	assert.Equal(t, Secret{File: "testdata/password"}, config.Registries[Pip].Password)
+	assert.Equal(t, []string{"github.com/example/*", "example.com/internal"}, config.Registries[Go].Private)
+}
+
+func TestReadConfigInvalidRegistry(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "registries.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte("registries:\n  cargo:\n    url: https://example.com\n"), 0600))
+
+	_, err := ReadConfig(path)
+
+	assert.ErrorContains(t, err, "invalid registry cargo")
+	assert.ErrorContains(t, err, "use one of composer, go, maven, npm, nuget, pip")
+}
+
+func TestReadConfigInvalidYaml(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "registries.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte("registries: [\n"), 0600))
+
+	_, err := ReadConfig(path)
+
+	assert.ErrorContains(t, err, "failed to parse registries config")
+}
+
+func TestReadConfigNotExist(t *testing.T) {
+	_, err := ReadConfig(filepath.Join(t.TempDir(), "registries.yaml"))
+
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestSecretValue(t *testing.T) {
+	t.Setenv("DEBRICKED_TEST_SECRET", "env-secret")
+
+	value, err := Secret{Env: "DEBRICKED_TEST_SECRET"}.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "env-secret", value)
+
+	value, err = Secret{File: filepath.Join("testdata", "password")}.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "s3cr3t", value)
+
+	_, err = Secret{Env: "DEBRICKED_TEST_UNSET_SECRET"}.Value()
+	assert.ErrorContains(t, err, "environment variable DEBRICKED_TEST_UNSET_SECRET isn't set")
+
+	_, err = Secret{}.Value()
+	assert.ErrorIs(t, err, ErrNoSecret)
+}
@@ -0,0 +1,356 @@
+package registry
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	registryId  = "debricked-registry"
+	tempDirName = "debricked-registries-"
+	dirPerm     = 0700
+	filePerm    = 0600
+)
+
+// Environment holds the environment variables injected into the commands of the jobs of each package manager,
+// and the temporary directory of the configuration files they point to. The files are written outside
+// of the repository, so that no secrets end up in it, and are removed by Close
+type Environment struct {
+	dir  string
+	envs map[string][]string
+}
+
+// NewEnvironment reads the credentials of the registries of config and writes the configuration files of them
+func NewEnvironment(config Config) (*Environment, error) {
+	dir, err := os.MkdirTemp("", tempDirName)
+	if err != nil {
+		return nil, err
+	}
+	environment := &Environment{dir: dir, envs: make(map[string][]string)}
+
+	names := make([]string, 0, len(config.Registries))
+	for name := range config.Registries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		env, err := environment.makeEnv(name, config.Registries[name])
+		if err != nil {
+			_ = environment.Close()
+
+			return nil, fmt.Errorf("failed to configure %s registry: %w", name, err)
+		}
+		for _, pmName := range registryPms[name] {
+			environment.envs[pmName] = env
+		}
+	}
+
+	return environment, nil
+}
+
+// Env returns the environment variables of the package manager, or nil if it has no registry
+func (e *Environment) Env(pmName string) []string {
+	if e == nil {
+		return nil
+	}
+
+	return e.envs[pmName]
+}
+
+// Close removes the configuration files of the registries
+func (e *Environment) Close() error {
+	if e == nil {
+		return nil
+	}
+
+	return os.RemoveAll(e.dir)
+}
+
+func (e *Environment) makeEnv(name string, registry Registry) ([]string, error) {
+	creds, err := registry.credentials()
+	if err != nil {
+		return nil, err
+	}
+	switch name {
+	case Npm:
+		return e.makeNpmEnv(registry, creds)
+	case Pip:
+		return makePipEnv(registry, creds)
+	case Maven:
+		return e.makeMavenEnv(registry, creds)
+	case Go:
+		return e.makeGoEnv(registry, creds)
+	case Nuget:
+		return e.makeNugetEnv(registry, creds)
+	case Composer:
+		return e.makeComposerEnv(registry, creds)
+	default:
+		return nil, fmt.Errorf("unsupported registry %s", name)
+	}
+}
+
+// writeFile writes a configuration file in the directory of the environment, readable only by the user
+func (e *Environment) writeFile(name string, content []byte) (string, error) {
+	path := filepath.Join(e.dir, name)
+	err := os.MkdirAll(filepath.Dir(path), dirPerm)
+	if err != nil {
+		return "", err
+	}
+
+	return path, os.WriteFile(path, content, filePerm)
+}
+
+// makeNpmEnv points npm, pnpm and Yarn Classic at a user .npmrc, and sets the registry of Yarn Berry,
+// which doesn't read .npmrc files. The .npmrc of the project still applies
+func (e *Environment) makeNpmEnv(registry Registry, creds credentials) ([]string, error) {
+	var npmrc []string
+	var env []string
+	if len(registry.Url) > 0 {
+		npmrc = append(npmrc, "registry="+registry.Url)
+		env = append(env, "YARN_NPM_REGISTRY_SERVER="+registry.Url)
+	}
+	nerfDart, err := makeNerfDart(registry.Url)
+	if err != nil {
+		return nil, err
+	}
+	if len(creds.token) > 0 {
+		npmrc = append(npmrc, nerfDart+":_authToken="+creds.token)
+		env = append(env, "YARN_NPM_AUTH_TOKEN="+creds.token)
+	} else if len(creds.username) > 0 {
+		auth := base64.StdEncoding.EncodeToString([]byte(creds.username + ":" + creds.password))
+		npmrc = append(npmrc, nerfDart+":_auth="+auth)
+		env = append(env, "YARN_NPM_AUTH_IDENT="+creds.username+":"+creds.password)
+	}
+	npmrcPath, err := e.writeFile(filepath.Join(Npm, ".npmrc"), []byte(strings.Join(npmrc, "\n")+"\n"))
+	if err != nil {
+		return nil, err
+	}
+
+	return append(env, "NPM_CONFIG_USERCONFIG="+npmrcPath), nil
+}
+
+// makeNerfDart returns the URL of the registry without scheme, which npm scopes credentials by
+func makeNerfDart(registryUrl string) (string, error) {
+	if len(registryUrl) == 0 {
+		return "//registry.npmjs.org/", nil
+	}
+	u, err := url.Parse(registryUrl)
+	if err != nil {
+		return "", err
+	}
+
+	return "//" + u.Host + strings.TrimSuffix(u.Path, "/") + "/", nil
+}
+
+// makePipEnv sets the index of pip, with the credentials in the URL since pip has no other way to take them
+// from the environment. Tokens are sent as the password of the __token__ user, like PyPI expects
+func makePipEnv(registry Registry, creds credentials) ([]string, error) {
+	if len(registry.Url) == 0 {
+		return nil, nil
+	}
+	u, err := url.Parse(registry.Url)
+	if err != nil {
+		return nil, err
+	}
+	if len(creds.token) > 0 {
+		u.User = url.UserPassword("__token__", creds.token)
+	} else if len(creds.username) > 0 {
+		u.User = url.UserPassword(creds.username, creds.password)
+	}
+
+	return []string{"PIP_INDEX_URL=" + u.String()}, nil
+}
+
+type mavenSettings struct {
+	XMLName xml.Name      `xml:"settings"`
+	Servers []mavenServer `xml:"servers>server,omitempty"`
+	Mirrors []mavenMirror `xml:"mirrors>mirror,omitempty"`
+}
+
+type mavenServer struct {
+	Id       string `xml:"id"`
+	Username string `xml:"username,omitempty"`
+	Password string `xml:"password,omitempty"`
+}
+
+type mavenMirror struct {
+	Id       string `xml:"id"`
+	MirrorOf string `xml:"mirrorOf"`
+	Url      string `xml:"url"`
+}
+
+// makeMavenEnv writes a settings.xml mirroring all repositories, passed to Maven by MAVEN_ARGS, which requires
+// Maven 3.9 or later. Tokens are used as the password of the server
+func (e *Environment) makeMavenEnv(registry Registry, creds credentials) ([]string, error) {
+	settings := mavenSettings{}
+	password := creds.password
+	if len(creds.token) > 0 {
+		password = creds.token
+	}
+	if len(creds.username) > 0 || len(password) > 0 {
+		settings.Servers = append(settings.Servers, mavenServer{Id: registryId, Username: creds.username, Password: password})
+	}
+	if len(registry.Url) > 0 {
+		settings.Mirrors = append(settings.Mirrors, mavenMirror{Id: registryId, MirrorOf: "*", Url: registry.Url})
+	}
+	content, err := xml.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	settingsPath, err := e.writeFile(filepath.Join(Maven, "settings.xml"), append([]byte(xml.Header), content...))
+	if err != nil {
+		return nil, err
+	}
+
+	return []string{"MAVEN_ARGS=--settings " + settingsPath}, nil
+}
+
+// makeGoEnv sets the module proxy and the private modules of Go, and writes a .netrc with the credentials
+// of the proxy
+func (e *Environment) makeGoEnv(registry Registry, creds credentials) ([]string, error) {
+	var env []string
+	if len(registry.Url) > 0 {
+		env = append(env, "GOPROXY="+registry.Url)
+	}
+	if len(registry.Private) > 0 {
+		private := strings.Join(registry.Private, ",")
+		env = append(env, "GOPRIVATE="+private, "GONOSUMDB="+private)
+	}
+	password := creds.password
+	if len(creds.token) > 0 {
+		password = creds.token
+	}
+	if len(registry.Url) == 0 || len(password) == 0 {
+		return env, nil
+	}
+	u, err := url.Parse(registry.Url)
+	if err != nil {
+		return nil, err
+	}
+	username := creds.username
+	if len(username) == 0 {
+		username = registryId
+	}
+	netrc := fmt.Sprintf("machine %s login %s password %s\n", u.Hostname(), username, password)
+	netrcPath, err := e.writeFile(filepath.Join(Go, ".netrc"), []byte(netrc))
+	if err != nil {
+		return nil, err
+	}
+
+	return append(env, "NETRC="+netrcPath), nil
+}
+
+type nugetConfig struct {
+	XMLName                  xml.Name            `xml:"configuration"`
+	PackageSources           nugetPackageSources `xml:"packageSources"`
+	PackageSourceCredentials *nugetCredentials   `xml:"packageSourceCredentials,omitempty"`
+}
+
+type nugetPackageSources struct {
+	Clear *struct{}  `xml:"clear"`
+	Add   []nugetAdd `xml:"add"`
+}
+
+type nugetCredentials struct {
+	Source []nugetAdd `xml:"debricked-registry>add"`
+}
+
+type nugetAdd struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:"value,attr"`
+}
+
+// makeNugetEnv writes a user NuGet.Config replacing the package sources of the machine with the registry,
+// found by NuGet through DOTNET_CLI_HOME. The NuGet.Config of the project still applies, and the global packages
+// folder is kept in the home directory of the user, unless NUGET_PACKAGES is set
+func (e *Environment) makeNugetEnv(registry Registry, creds credentials) ([]string, error) {
+	config := nugetConfig{}
+	if len(registry.Url) > 0 {
+		config.PackageSources = nugetPackageSources{
+			Clear: &struct{}{},
+			Add:   []nugetAdd{{Key: registryId, Value: registry.Url}},
+		}
+	}
+	password := creds.password
+	if len(creds.token) > 0 {
+		password = creds.token
+	}
+	if len(password) > 0 {
+		username := creds.username
+		if len(username) == 0 {
+			username = registryId
+		}
+		config.PackageSourceCredentials = &nugetCredentials{Source: []nugetAdd{
+			{Key: "Username", Value: username},
+			{Key: "ClearTextPassword", Value: password},
+		}}
+	}
+	content, err := xml.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	home := filepath.Join(e.dir, Nuget)
+	_, err = e.writeFile(filepath.Join(Nuget, ".nuget", "NuGet", "NuGet.Config"), append([]byte(xml.Header), content...))
+	if err != nil {
+		return nil, err
+	}
+	env := []string{"DOTNET_CLI_HOME=" + home}
+	if _, ok := os.LookupEnv("NUGET_PACKAGES"); !ok {
+		if userHome, err := os.UserHomeDir(); err == nil {
+			env = append(env, "NUGET_PACKAGES="+filepath.Join(userHome, ".nuget", "packages"))
+		}
+	}
+
+	return env, nil
+}
+
+// makeComposerEnv writes the config.json and auth.json of a Composer home replacing packagist.org with the registry
+func (e *Environment) makeComposerEnv(registry Registry, creds credentials) ([]string, error) {
+	composerConfig := map[string]any{}
+	if len(registry.Url) > 0 {
+		composerConfig["repositories"] = map[string]any{
+			registryId:      map[string]string{"type": "composer", "url": registry.Url},
+			"packagist.org": false,
+		}
+	}
+	content, err := json.MarshalIndent(composerConfig, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	_, err = e.writeFile(filepath.Join(Composer, "config.json"), content)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(registry.Url) > 0 && (len(creds.token) > 0 || len(creds.username) > 0) {
+		u, err := url.Parse(registry.Url)
+		if err != nil {
+			return nil, err
+		}
+		auth := map[string]any{}
+		if len(creds.token) > 0 {
+			auth["bearer"] = map[string]string{u.Hostname(): creds.token}
+		} else {
+			auth["http-basic"] = map[string]any{
+				u.Hostname(): map[string]string{"username": creds.username, "password": creds.password},
+			}
+		}
+		content, err = json.MarshalIndent(auth, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		_, err = e.writeFile(filepath.Join(Composer, "auth.json"), content)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return []string{"COMPOSER_HOME=" + filepath.Join(e.dir, Composer)}, nil
+}
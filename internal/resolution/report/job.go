@@ -0,0 +1,107 @@
+package report
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/debricked/cli/internal/file"
+	"github.com/debricked/cli/internal/resolution/cache"
+	"github.com/debricked/cli/internal/resolution/job"
+)
+
+// Job is a job wrapped for the resolution report, recording its package manager, duration and the lock files it produced
+type Job struct {
+	job.IJob
+	pmName    string
+	formats   []*file.CompiledFormat
+	duration  time.Duration
+	lockFiles []string
+}
+
+// NewJob wraps j. Lock files are the files in the directory of the manifest file, or the directory mirroring it
+// in the lock output dir, that are generated by Debricked or match the lock file regexes of formats
+func NewJob(j job.IJob, pmName string, formats []*file.CompiledFormat) *Job {
+	return &Job{
+		IJob:    j,
+		pmName:  pmName,
+		formats: formats,
+	}
+}
+
+// Run runs the wrapped job and records the lock files that were created or modified while it ran
+func (j *Job) Run() {
+	dir, _ := file.MirrorPath(j.LockOutputDir(), filepath.Dir(j.GetFile()))
+	before := j.modTimes(dir)
+	start := time.Now()
+
+	j.IJob.Run()
+
+	j.duration = time.Since(start)
+	for lockFile, modTime := range j.modTimes(dir) {
+		if previous, ok := before[lockFile]; !ok || !previous.Equal(modTime) {
+			j.lockFiles = append(j.lockFiles, lockFile)
+		}
+	}
+	sort.Strings(j.lockFiles)
+}
+
+func (j *Job) PmName() string {
+	return j.pmName
+}
+
+func (j *Job) Duration() time.Duration {
+	return j.duration
+}
+
+func (j *Job) LockFiles() []string {
+	return j.lockFiles
+}
+
+// CacheStatus returns the cache status of the wrapped job, so that it's still shown while resolving
+func (j *Job) CacheStatus() string {
+	if cacheJob, ok := j.IJob.(cache.ICacheJob); ok {
+		return cacheJob.CacheStatus()
+	}
+
+	return ""
+}
+
+// modTimes returns the modification times of the lock files of the job in dir
+func (j *Job) modTimes(dir string) map[string]time.Time {
+	modTimes := make(map[string]time.Time)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return modTimes
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || !j.isLockFile(entry.Name()) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		modTimes[filepath.Join(dir, entry.Name())] = info.ModTime()
+	}
+
+	return modTimes
+}
+
+func (j *Job) isLockFile(name string) bool {
+	if !file.IsLockFileOf(j.GetFile(), name) {
+		return false
+	}
+	if file.IsGeneratedLockFile(name) {
+		return true
+	}
+	manifest := filepath.Base(j.GetFile())
+	for _, format := range j.formats {
+		if format.MatchFile(manifest) && format.MatchLockFile(name) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,86 @@
+package report
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/debricked/cli/internal/file"
+	"github.com/debricked/cli/internal/resolution/cache"
+	"github.com/debricked/cli/internal/resolution/job"
+	"github.com/stretchr/testify/assert"
+)
+
+// lockJob writes lock files next to the manifest, like a resolution job
+type lockJob struct {
+	job.BaseJob
+	lockFiles []string
+}
+
+func newLockJob(file string, lockFiles ...string) *lockJob {
+	return &lockJob{BaseJob: job.NewBaseJob(file), lockFiles: lockFiles}
+}
+
+func (j *lockJob) Run() {
+	time.Sleep(time.Millisecond)
+	for _, lockFile := range j.lockFiles {
+		_ = os.WriteFile(filepath.Join(filepath.Dir(j.GetFile()), lockFile), []byte("lock"), 0600)
+	}
+}
+
+type cacheJobMock struct {
+	*lockJob
+}
+
+func (j cacheJobMock) CacheStatus() string {
+	return cache.StatusHit
+}
+
+func newYarnFormat(t *testing.T) *file.CompiledFormat {
+	format, err := file.NewCompiledFormat(&file.Format{
+		ManifestFileRegex: `^package\.json$`,
+		LockFileRegexes:   []string{`^yarn\.lock$`},
+	})
+	assert.NoError(t, err)
+
+	return format
+}
+
+func TestNewJob(t *testing.T) {
+	inner := newLockJob("package.json")
+	j := NewJob(inner, "yarn", nil)
+	assert.Equal(t, "yarn", j.PmName())
+	assert.Equal(t, "package.json", j.GetFile())
+	assert.Empty(t, j.LockFiles())
+	assert.Empty(t, j.CacheStatus())
+}
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	manifest := filepath.Join(dir, "package.json")
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "unchanged.debricked.lock"), []byte("lock"), 0600))
+	inner := newLockJob(manifest, "yarn.lock", "yarn.debricked.lock", "package-lock.json")
+	j := NewJob(inner, "yarn", []*file.CompiledFormat{newYarnFormat(t)})
+
+	j.Run()
+
+	assert.Equal(t, []string{filepath.Join(dir, "yarn.debricked.lock"), filepath.Join(dir, "yarn.lock")}, j.LockFiles())
+	assert.Greater(t, j.Duration(), time.Duration(0))
+}
+
+func TestRunPipLockFiles(t *testing.T) {
+	dir := t.TempDir()
+	manifest := filepath.Join(dir, "requirements.txt")
+	inner := newLockJob(manifest, ".requirements.txt.pip.debricked.lock", ".requirements-dev.txt.pip.debricked.lock")
+	j := NewJob(inner, "pip", nil)
+
+	j.Run()
+
+	assert.Equal(t, []string{filepath.Join(dir, ".requirements.txt.pip.debricked.lock")}, j.LockFiles())
+}
+
+func TestCacheStatus(t *testing.T) {
+	j := NewJob(cacheJobMock{newLockJob("package.json")}, "yarn", nil)
+	assert.Equal(t, cache.StatusHit, j.CacheStatus())
+}
@@ -0,0 +1,226 @@
+package report
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/debricked/cli/internal/resolution/cache"
+	"github.com/debricked/cli/internal/resolution/job"
+)
+
+const (
+	StatusSuccess = "success"
+	StatusFailure = "failure"
+)
+
+type Error struct {
+	Message       string `json:"message"`
+	Command       string `json:"command"`
+	Documentation string `json:"documentation"`
+	Status        string `json:"status"`
+	Critical      bool   `json:"critical"`
+}
+
+type JobReport struct {
+	Manifest        string   `json:"manifest"`
+	PackageManager  string   `json:"packageManager"`
+	Status          string   `json:"status"`
+	CacheStatus     string   `json:"cacheStatus,omitempty"`
+	DurationSeconds float64  `json:"durationSeconds"`
+	Commands        []string `json:"commands"`
+	Toolchains      []string `json:"toolchains,omitempty"`
+	LockFiles       []string `json:"lockFiles"`
+	Errors          []Error  `json:"errors"`
+}
+
+type Report struct {
+	Jobs []JobReport `json:"jobs"`
+}
+
+// NewReport reports the jobs. Package managers, durations and lock files are only known for jobs wrapped by NewJob
+func NewReport(jobs []job.IJob) Report {
+	report := Report{Jobs: []JobReport{}}
+	for _, j := range jobs {
+		report.Jobs = append(report.Jobs, newJobReport(j))
+	}
+
+	return report
+}
+
+func newJobReport(j job.IJob) JobReport {
+	jobReport := JobReport{
+		Manifest:  j.GetFile(),
+		Status:    StatusSuccess,
+		Commands:  []string{},
+		LockFiles: []string{},
+		Errors:    []Error{},
+	}
+	if reportJob, ok := j.(*Job); ok {
+		jobReport.PackageManager = reportJob.PmName()
+		jobReport.DurationSeconds = reportJob.Duration().Seconds()
+		if reportJob.LockFiles() != nil {
+			jobReport.LockFiles = reportJob.LockFiles()
+		}
+	}
+	if cacheJob, ok := j.(cache.ICacheJob); ok {
+		jobReport.CacheStatus = cacheJob.CacheStatus()
+	}
+	if j.Commands() != nil {
+		jobReport.Commands = j.Commands()
+	}
+	for _, t := range j.Toolchains() {
+		jobReport.Toolchains = append(jobReport.Toolchains, t.String())
+	}
+	for _, err := range j.Errors().GetAll() {
+		jobReport.Status = StatusFailure
+		jobReport.Errors = append(jobReport.Errors, Error{
+			Message:       err.Error(),
+			Command:       err.Command(),
+			Documentation: strings.TrimSpace(err.Documentation()),
+			Status:        err.Status(),
+			Critical:      err.IsCritical(),
+		})
+	}
+
+	return jobReport
+}
+
+// WriteJson writes the report as JSON to path
+func (r Report) WriteJson(path string) error {
+	content, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, content, 0600)
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Time     string          `xml:"time,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// WriteJunit writes the report as JUnit XML to path, with a test suite per package manager and a test case per job
+func (r Report) WriteJunit(path string) error {
+	suites := junitTestSuites{Name: "debricked resolve"}
+	suiteIndexes := make(map[string]int)
+	var seconds float64
+	suiteSeconds := make(map[string]float64)
+	for _, jobReport := range r.Jobs {
+		suiteName := jobReport.PackageManager
+		if len(suiteName) == 0 {
+			suiteName = "unknown"
+		}
+		index, ok := suiteIndexes[suiteName]
+		if !ok {
+			index = len(suites.Suites)
+			suiteIndexes[suiteName] = index
+			suites.Suites = append(suites.Suites, junitTestSuite{Name: suiteName})
+		}
+		suite := &suites.Suites[index]
+
+		testCase := junitTestCase{
+			Name:      jobReport.Manifest,
+			ClassName: suiteName,
+			Time:      formatSeconds(jobReport.DurationSeconds),
+			SystemOut: jobReport.systemOut(),
+		}
+		if jobReport.Status == StatusFailure {
+			testCase.Failure = jobReport.failure()
+			suite.Failures++
+			suites.Failures++
+		}
+		suite.Tests++
+		suites.Tests++
+		suite.Cases = append(suite.Cases, testCase)
+		suiteSeconds[suiteName] += jobReport.DurationSeconds
+		seconds += jobReport.DurationSeconds
+	}
+	for i := range suites.Suites {
+		suites.Suites[i].Time = formatSeconds(suiteSeconds[suites.Suites[i].Name])
+	}
+	sort.SliceStable(suites.Suites, func(i, j int) bool {
+		return suites.Suites[i].Name < suites.Suites[j].Name
+	})
+	suites.Time = formatSeconds(seconds)
+
+	content, err := xml.MarshalIndent(suites, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, append([]byte(xml.Header), content...), 0600)
+}
+
+func (jobReport JobReport) failure() *junitFailure {
+	failure := &junitFailure{Type: "warning"}
+	var texts []string
+	for _, err := range jobReport.Errors {
+		if len(failure.Message) == 0 {
+			failure.Message = err.Message
+		}
+		if err.Critical {
+			failure.Type = "critical"
+		}
+		text := err.Message
+		if len(err.Command) > 0 {
+			text += "\nCommand: " + err.Command
+		}
+		if len(err.Documentation) > 0 {
+			text += "\n" + err.Documentation
+		}
+		texts = append(texts, text)
+	}
+	failure.Text = strings.Join(texts, "\n\n")
+
+	return failure
+}
+
+func (jobReport JobReport) systemOut() string {
+	var lines []string
+	for _, command := range jobReport.Commands {
+		lines = append(lines, "Command: "+command)
+	}
+	for _, lockFile := range jobReport.LockFiles {
+		lines = append(lines, "Lock file: "+lockFile)
+	}
+	if len(jobReport.CacheStatus) > 0 {
+		lines = append(lines, "Cache: "+jobReport.CacheStatus)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func formatSeconds(seconds float64) string {
+	return fmt.Sprintf("%.3f", seconds)
+}
@@ -0,0 +1,127 @@
+package report
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/debricked/cli/internal/resolution/job"
+	"github.com/debricked/cli/internal/resolution/job/testdata"
+	"github.com/debricked/cli/internal/resolution/toolchain"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestJobs(t *testing.T) []job.IJob {
+	dir := t.TempDir()
+	succeeded := NewJob(newLockJob(filepath.Join(dir, "package.json"), "yarn.debricked.lock"), "yarn", nil)
+	succeeded.Run()
+
+	failed := testdata.NewJobMock("build.gradle")
+	failed.SetCommands([]string{"gradle dependencies"})
+	failed.SetToolchains([]toolchain.Toolchain{{Name: toolchain.Maven, Executable: "mvnw", Source: "mvnw"}})
+	jobErr := job.NewBaseJobError("gradle failed")
+	jobErr.SetCommand("gradle dependencies")
+	jobErr.SetDocumentation("Gradle is not installed")
+	jobErr.SetStatus("resolving dependencies")
+	failed.SetErr(jobErr)
+
+	return []job.IJob{succeeded, NewJob(failed, "gradle", nil)}
+}
+
+func TestNewReport(t *testing.T) {
+	report := NewReport(newTestJobs(t))
+
+	assert.Len(t, report.Jobs, 2)
+	succeeded := report.Jobs[0]
+	assert.Equal(t, "yarn", succeeded.PackageManager)
+	assert.Equal(t, StatusSuccess, succeeded.Status)
+	assert.Len(t, succeeded.LockFiles, 1)
+	assert.Empty(t, succeeded.Commands)
+	assert.Empty(t, succeeded.Toolchains)
+	assert.Empty(t, succeeded.Errors)
+	assert.Empty(t, succeeded.CacheStatus)
+
+	failed := report.Jobs[1]
+	assert.Equal(t, "build.gradle", failed.Manifest)
+	assert.Equal(t, "gradle", failed.PackageManager)
+	assert.Equal(t, StatusFailure, failed.Status)
+	assert.Equal(t, []string{"gradle dependencies"}, failed.Commands)
+	assert.Equal(t, []string{"mvn (mvnw from mvnw)"}, failed.Toolchains)
+	assert.Empty(t, failed.LockFiles)
+	assert.Equal(t, []Error{{
+		Message:       "gradle failed",
+		Command:       "gradle dependencies",
+		Documentation: "Gradle is not installed",
+		Status:        "resolving dependencies",
+		Critical:      true,
+	}}, failed.Errors)
+}
+
+func TestNewReportUnwrappedJob(t *testing.T) {
+	report := NewReport([]job.IJob{testdata.NewJobMock("go.mod")})
+
+	assert.Len(t, report.Jobs, 1)
+	assert.Equal(t, "go.mod", report.Jobs[0].Manifest)
+	assert.Empty(t, report.Jobs[0].PackageManager)
+	assert.Equal(t, StatusSuccess, report.Jobs[0].Status)
+}
+
+func TestWriteJson(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.json")
+	report := NewReport(newTestJobs(t))
+
+	err := report.WriteJson(path)
+
+	assert.NoError(t, err)
+	content, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	var written Report
+	assert.NoError(t, json.Unmarshal(content, &written))
+	assert.Equal(t, report, written)
+}
+
+func TestWriteJsonErr(t *testing.T) {
+	err := NewReport(nil).WriteJson(filepath.Join(t.TempDir(), "missing", "report.json"))
+
+	assert.Error(t, err)
+}
+
+func TestWriteJunit(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.xml")
+	report := NewReport(newTestJobs(t))
+
+	err := report.WriteJunit(path)
+
+	assert.NoError(t, err)
+	content, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	var written junitTestSuites
+	assert.NoError(t, xml.Unmarshal(content, &written))
+	assert.Equal(t, 2, written.Tests)
+	assert.Equal(t, 1, written.Failures)
+	assert.Len(t, written.Suites, 2)
+
+	gradle := written.Suites[0]
+	assert.Equal(t, "gradle", gradle.Name)
+	assert.Equal(t, 1, gradle.Failures)
+	assert.Equal(t, "build.gradle", gradle.Cases[0].Name)
+	assert.Equal(t, "gradle failed", gradle.Cases[0].Failure.Message)
+	assert.Equal(t, "critical", gradle.Cases[0].Failure.Type)
+	assert.Contains(t, gradle.Cases[0].Failure.Text, "Command: gradle dependencies")
+	assert.Contains(t, gradle.Cases[0].Failure.Text, "Gradle is not installed")
+	assert.Equal(t, "Command: gradle dependencies", gradle.Cases[0].SystemOut)
+
+	yarn := written.Suites[1]
+	assert.Equal(t, "yarn", yarn.Name)
+	assert.Equal(t, 0, yarn.Failures)
+	assert.Nil(t, yarn.Cases[0].Failure)
+	assert.Contains(t, yarn.Cases[0].SystemOut, "Lock file: ")
+}
+
+func TestWriteJunitErr(t *testing.T) {
+	err := NewReport(nil).WriteJunit(filepath.Join(t.TempDir(), "missing", "report.xml"))
+
+	assert.Error(t, err)
+}
@@ -1,22 +1,51 @@
 package resolution
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"os"
 	"path"
+	"path/filepath"
 	"regexp"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/debricked/cli/internal/cmd/cmderror"
 	"github.com/debricked/cli/internal/file"
+	"github.com/debricked/cli/internal/git"
+	"github.com/debricked/cli/internal/resolution/cache"
+	"github.com/debricked/cli/internal/resolution/drift"
 	resolutionFile "github.com/debricked/cli/internal/resolution/file"
+	"github.com/debricked/cli/internal/resolution/isolation"
 	"github.com/debricked/cli/internal/resolution/job"
+	"github.com/debricked/cli/internal/resolution/lockoutput"
+	"github.com/debricked/cli/internal/resolution/pm"
+	"github.com/debricked/cli/internal/resolution/pm/bower"
+	"github.com/debricked/cli/internal/resolution/pm/gobinary"
+	"github.com/debricked/cli/internal/resolution/pm/gomod"
+	"github.com/debricked/cli/internal/resolution/pm/maven"
+	"github.com/debricked/cli/internal/resolution/pm/npm"
+	"github.com/debricked/cli/internal/resolution/pm/nuget"
+	"github.com/debricked/cli/internal/resolution/pm/ospkg"
+	"github.com/debricked/cli/internal/resolution/pm/pip"
+	"github.com/debricked/cli/internal/resolution/pm/pipenv"
+	"github.com/debricked/cli/internal/resolution/pm/pnpm"
+	"github.com/debricked/cli/internal/resolution/pm/pyproject"
+	"github.com/debricked/cli/internal/resolution/pm/yarn"
+	"github.com/debricked/cli/internal/resolution/registry"
+	"github.com/debricked/cli/internal/resolution/report"
+	"github.com/debricked/cli/internal/resolution/scope"
 	"github.com/debricked/cli/internal/resolution/strategy"
+	"github.com/debricked/cli/internal/resolution/toolchain"
 	"github.com/debricked/cli/internal/tui"
 )
 
 var (
-	ErrBadOpts = errors.New("failed to type case IOptions")
+	ErrBadOpts   = errors.New("failed to type case IOptions")
+	ErrUnknownPm = errors.New("unknown package manager")
 )
 
 type StrictnessLevel int
@@ -43,8 +72,30 @@ func GetStrictnessLevel(level int) (StrictnessLevel, error) {
 	}
 }
 
+// RegenerateDrifted is the regenerate level regenerating lock files which are stale relative to their manifest
+// files, besides generating missing lock files
+const RegenerateDrifted = 3
+
+// GetRegenerateLevel returns the regenerate level of the --regenerate flag, which is 0, 1, 2 or drifted. An unset
+// level is 0
+func GetRegenerateLevel(level string) (int, error) {
+	switch level {
+	case "", "0":
+		return 0, nil
+	case "1":
+		return 1, nil
+	case "2":
+		return 2, nil
+	case "drifted":
+		return RegenerateDrifted, nil
+	default:
+		return 0, fmt.Errorf("invalid regenerate level: %s", level)
+	}
+}
+
 type IResolver interface {
 	Resolve(paths []string, options IOptions) (IResolution, error)
+	Plan(paths []string, options IOptions) (Plan, error)
 }
 
 type Resolver struct {
@@ -52,7 +103,6 @@ type Resolver struct {
 	batchFactory    resolutionFile.IBatchFactory
 	strategyFactory strategy.IFactory
 	scheduler       IScheduler
-	npmPreferred    bool
 }
 
 type IOptions interface{}
@@ -62,7 +112,26 @@ type DebrickedOptions struct {
 	Exclusions           []string
 	Verbose              bool
 	Regenerate           int
+	CheckDrift           bool
+	ChangedSince         string
 	NpmPreferred         bool
+	JsPackageManager     string
+	LockfileOnly         bool
+	ExcludeScopes        []string
+	ProductionOnly       bool
+	Cache                bool
+	CacheDir             string
+	ResolutionTimeout    time.Duration
+	PmResolutionTimeouts map[string]time.Duration
+	ReportJson           string
+	ReportJunit          string
+	Isolated             bool
+	LockOutputDir        string
+	RegistriesConfig     string
+	Toolchains           map[string]string
+	OsPackages           bool
+	GoBinaries           bool
+	SkipManifests        bool
 	ResolutionStrictness StrictnessLevel
 }
 
@@ -77,12 +146,25 @@ func NewResolver(
 		batchFactory,
 		strategyFactory,
 		scheduler,
-		false,
 	}
 }
 
-func (r Resolver) setNpmPreferred(npmPreferred bool) {
-	r.batchFactory.SetNpmPreferred(npmPreferred)
+// setJsPackageManager sets the package manager resolving package.json files. NpmPreferred is kept as an alias of npm
+func (r Resolver) setJsPackageManager(options DebrickedOptions) error {
+	jsPackageManager := options.JsPackageManager
+	if len(jsPackageManager) == 0 {
+		jsPackageManager = resolutionFile.JsPackageManagerAuto
+	}
+	if options.NpmPreferred && jsPackageManager == resolutionFile.JsPackageManagerAuto {
+		jsPackageManager = npm.Name
+	}
+	err := resolutionFile.ValidateJsPackageManager(jsPackageManager)
+	if err != nil {
+		return err
+	}
+	r.batchFactory.SetJsPackageManager(jsPackageManager)
+
+	return nil
 }
 
 func (r Resolver) GetExitCode(resolution IResolution, options IOptions) (int, error) {
@@ -146,26 +228,111 @@ func (r Resolver) Resolve(paths []string, options IOptions) (IResolution, error)
 	if !ok {
 		return nil, ErrBadOpts
 	}
-	files, err := r.refinePaths(paths, dOptions.Exclusions, dOptions.Regenerate)
+	err := r.setJsPackageManager(dOptions)
 	if err != nil {
 		return nil, err
 	}
-	r.setNpmPreferred(dOptions.NpmPreferred)
-	pmBatches := r.batchFactory.Make(files)
-
+	toolchainSelector, err := toolchain.NewSelector(dOptions.Toolchains)
+	if err != nil {
+		return nil, err
+	}
+	excludedScopes, err := scope.NewScopes(dOptions.ExcludeScopes, dOptions.ProductionOnly)
+	if err != nil {
+		return nil, err
+	}
+	registries, err := makeRegistries(dOptions.RegistriesConfig)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = registries.Close()
+	}()
+	var files []string
+	var drifts []drift.Drift
+	if !dOptions.SkipManifests {
+		files, _, drifts, err = r.refinePaths(paths, dOptions)
+		if err != nil {
+			return nil, err
+		}
+	}
+	var formats []*file.CompiledFormat
+	if dOptions.reporting() || dOptions.Isolated {
+		// Without formats, only lock files generated by Debricked are reported and copied from isolated workspaces
+		formats, _ = r.finder.GetSupportedFormats()
+	}
+	workspace, err := makeWorkspace(paths, dOptions, formats)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = workspace.Close()
+	}()
+	r.batchFactory.SetRoots(workspace.Paths(refineRoots(paths)))
+	pmBatches := r.batchFactory.Make(workspace.Paths(files))
+	strategyOptions := strategy.Options{
+		LockfileOnly:   dOptions.LockfileOnly,
+		ScratchDir:     workspace.Dir(),
+		ExcludedScopes: excludedScopes,
+		// Only the regenerate level 2 regenerates lock files which aren't generated by Debricked
+		RegenerateNativeLockFiles: dOptions.Regenerate == 2,
+	}
 	var jobs []job.IJob
+	if dOptions.OsPackages {
+		osPackageJobs, err := ospkg.NewStrategy(refineRoots(paths)).Invoke()
+		if err != nil {
+			return nil, err
+		}
+		osPackageJobs = setLockOutputDirs(setTimeouts(osPackageJobs, dOptions.ResolutionTimeout), dOptions.LockOutputDir)
+		jobs = append(jobs, reportJobs(osPackageJobs, ospkg.Name, dOptions, formats)...)
+	}
+	if dOptions.GoBinaries {
+		goBinaryJobs, err := gobinary.NewStrategy(refineRoots(paths), dOptions.Exclusions).Invoke()
+		if err != nil {
+			return nil, err
+		}
+		goBinaryJobs = setLockOutputDirs(setTimeouts(goBinaryJobs, dOptions.ResolutionTimeout), dOptions.LockOutputDir)
+		jobs = append(jobs, reportJobs(goBinaryJobs, gobinary.Name, dOptions, formats)...)
+	}
+	resolutionCache := r.makeCache(dOptions)
+	jobCacheOptions, err := cacheOptions(dOptions, excludedScopes)
+	if err != nil {
+		return nil, err
+	}
 	for _, pmBatch := range pmBatches {
-		s, strategyErr := r.strategyFactory.Make(pmBatch, paths)
+		pmName := pmBatch.Pm().Name()
+		strategyOptions.SetupTimeout = dOptions.getResolutionTimeout(pmName)
+		s, strategyErr := r.strategyFactory.Make(pmBatch, workspace.Paths(paths), strategyOptions)
 		if strategyErr == nil {
 			newJobs, err := s.Invoke()
 			if err != nil {
 				return nil, err
 			}
-			jobs = append(jobs, newJobs...)
+			lockFiles := make([][]string, len(newJobs))
+			for i, newJob := range newJobs {
+				lockFiles[i] = plannedLockFiles(newJob)
+			}
+			if workspace != nil {
+				newJobs = workspace.Wrap(newJobs)
+			} else {
+				newJobs = lockOutputJobs(newJobs, dOptions)
+			}
+			newJobs = setEnvs(newJobs, registries.Env(pmName))
+			newJobs = setLockOutputDirs(newJobs, dOptions.LockOutputDir)
+			newJobs = setToolchains(newJobs, toolchainSelector, pmName)
+			for i, newJob := range setTimeouts(newJobs, dOptions.getResolutionTimeout(pmName)) {
+				if resolutionCache != nil {
+					newJobs[i] = resolutionCache.Wrap(newJob, pmName, jobCacheOptions, lockFiles[i])
+				}
+			}
+			jobs = append(jobs, reportJobs(newJobs, pmName, dOptions, formats)...)
 		}
 	}
 
 	resolution, err := r.scheduler.Schedule(jobs)
+	reportErr := writeReports(resolution, dOptions)
+	if reportErr != nil {
+		return resolution, reportErr
+	}
 
 	if resolution.HasErr() {
 		jobErrList := tui.NewJobsErrorList(os.Stdout, resolution.Jobs())
@@ -188,11 +355,260 @@ func (r Resolver) Resolve(paths []string, options IOptions) (IResolution, error)
 		}
 	}
 
+	if dOptions.CheckDrift && len(drifts) > 0 {
+		renderErr := tui.NewDriftList(os.Stdout, drifts).Render()
+		if renderErr != nil {
+			return resolution, renderErr
+		}
+
+		return resolution, cmderror.CommandError{
+			Code: 1,
+			Err:  fmt.Errorf("%d lock files are stale relative to their manifest files", len(drifts)),
+		}
+	}
+
 	return resolution, err
 }
 
-func (r Resolver) refinePaths(paths []string, exclusions []string, regenerate int) ([]string, error) {
+// getResolutionTimeout returns the timeout of jobs of the package manager, which overrides the global timeout when set
+func (options DebrickedOptions) getResolutionTimeout(pmName string) time.Duration {
+	if timeout, ok := options.PmResolutionTimeouts[pmName]; ok {
+		return timeout
+	}
+
+	return options.ResolutionTimeout
+}
+
+func setTimeouts(jobs []job.IJob, timeout time.Duration) []job.IJob {
+	for _, j := range jobs {
+		j.SetTimeout(timeout)
+	}
+
+	return jobs
+}
+
+func setEnvs(jobs []job.IJob, env []string) []job.IJob {
+	for _, j := range jobs {
+		j.SetEnv(env)
+	}
+
+	return jobs
+}
+
+func setLockOutputDirs(jobs []job.IJob, lockOutputDir string) []job.IJob {
+	for _, j := range jobs {
+		j.SetLockOutputDir(lockOutputDir)
+	}
+
+	return jobs
+}
+
+// lockOutputJobs wraps the jobs to move the lock files their package managers generate next to the manifest files
+// to the lock output dir, or returns them as they are if there is none
+func lockOutputJobs(jobs []job.IJob, options DebrickedOptions) []job.IJob {
+	if len(options.LockOutputDir) == 0 {
+		return jobs
+	}
+	wrappedJobs := make([]job.IJob, 0, len(jobs))
+	for _, j := range jobs {
+		wrappedJobs = append(wrappedJobs, lockoutput.NewJob(j, options.Exclusions))
+	}
+
+	return wrappedJobs
+}
+
+// pmToolchains maps package managers to the toolchain they run with
+var pmToolchains = map[string]string{
+	pip.Name:       toolchain.Python,
+	pipenv.Name:    toolchain.Python,
+	pyproject.Name: toolchain.Python,
+	npm.Name:       toolchain.Node,
+	yarn.Name:      toolchain.Node,
+	pnpm.Name:      toolchain.Node,
+	bower.Name:     toolchain.Node,
+	nuget.Name:     toolchain.Dotnet,
+	gomod.Name:     toolchain.Go,
+	maven.Name:     toolchain.Maven,
+}
+
+// setToolchains sets the toolchain of the package manager selected for the manifest file of each job
+func setToolchains(jobs []job.IJob, selector toolchain.Selector, pmName string) []job.IJob {
+	name, ok := pmToolchains[pmName]
+	if !ok {
+		return jobs
+	}
+	for _, j := range jobs {
+		if t, ok := selector.Select(name, j.GetFile()); ok {
+			j.SetToolchains([]toolchain.Toolchain{t})
+		}
+	}
+
+	return jobs
+}
+
+// makeRegistries reads the registries config file and writes the configuration files of its registries,
+// or returns nil if there is no config file
+func makeRegistries(configPath string) (*registry.Environment, error) {
+	if len(configPath) == 0 {
+		return nil, nil
+	}
+	config, err := registry.ReadConfig(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return registry.NewEnvironment(config)
+}
+
+// makeWorkspace copies the paths to a workspace the manifest files are resolved in, or returns nil if they aren't
+// resolved in isolation
+func makeWorkspace(paths []string, options DebrickedOptions, formats []*file.CompiledFormat) (*isolation.Workspace, error) {
+	if !options.Isolated || options.SkipManifests {
+		return nil, nil
+	}
+	workspace, err := isolation.NewWorkspace(paths, options.Exclusions, formats)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make isolated workspace: %w", err)
+	}
+
+	return workspace, nil
+}
+
+// GetPmResolutionTimeouts parses timeouts of package managers, keyed by package manager name
+func GetPmResolutionTimeouts(timeouts map[string]string) (map[string]time.Duration, error) {
+	pmNames := make(map[string]bool)
+	for _, p := range pm.Pms() {
+		pmNames[p.Name()] = true
+	}
+	pmTimeouts := make(map[string]time.Duration)
+	for pmName, value := range timeouts {
+		if !pmNames[pmName] {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownPm, pmName)
+		}
+		timeout, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid resolution timeout of %s: %w", pmName, err)
+		}
+		if timeout < 0 {
+			return nil, fmt.Errorf("invalid resolution timeout of %s: %s is negative", pmName, value)
+		}
+		pmTimeouts[pmName] = timeout
+	}
+
+	return pmTimeouts, nil
+}
+
+func (options DebrickedOptions) reporting() bool {
+	return len(options.ReportJson) > 0 || len(options.ReportJunit) > 0
+}
+
+// reportJobs wraps the jobs for the resolution report, or returns them as they are if no report is written
+func reportJobs(jobs []job.IJob, pmName string, options DebrickedOptions, formats []*file.CompiledFormat) []job.IJob {
+	if !options.reporting() {
+		return jobs
+	}
+	reportedJobs := make([]job.IJob, 0, len(jobs))
+	for _, j := range jobs {
+		reportedJobs = append(reportedJobs, report.NewJob(j, pmName, formats))
+	}
+
+	return reportedJobs
+}
+
+func writeReports(resolution IResolution, options DebrickedOptions) error {
+	if !options.reporting() {
+		return nil
+	}
+	resolutionReport := report.NewReport(resolution.Jobs())
+	if len(options.ReportJson) > 0 {
+		err := resolutionReport.WriteJson(options.ReportJson)
+		if err != nil {
+			return fmt.Errorf("failed to write resolution report: %w", err)
+		}
+	}
+	if len(options.ReportJunit) > 0 {
+		err := resolutionReport.WriteJunit(options.ReportJunit)
+		if err != nil {
+			return fmt.Errorf("failed to write resolution report: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// makeCache returns the cache of resolution results, or nil if it isn't enabled
+func (r Resolver) makeCache(options DebrickedOptions) cache.ICache {
+	if !options.Cache || len(options.CacheDir) == 0 {
+		return nil
+	}
+
+	return cache.NewCache(options.CacheDir, options.Exclusions, options.Isolated)
+}
+
+// plannedLockFiles returns the lock files the job plans to write, relative to the directory of its manifest file
+func plannedLockFiles(j job.IJob) []string {
+	planner, ok := j.(job.IPlanner)
+	if !ok {
+		return nil
+	}
+	dir, err := filepath.Abs(filepath.Dir(j.GetFile()))
+	if err != nil {
+		return nil
+	}
+	var lockFiles []string
+	for _, lockFile := range planner.Plan().LockFiles {
+		absLockFile, err := filepath.Abs(lockFile)
+		if err != nil {
+			continue
+		}
+		rel, err := filepath.Rel(dir, absLockFile)
+		if err == nil {
+			lockFiles = append(lockFiles, rel)
+		}
+	}
+
+	return lockFiles
+}
+
+// cacheOptions returns the options changing the lock files generated by resolution jobs, which are part of the cache key.
+// The registries config is included by the hash of its content, since its registries change the resolved dependencies
+func cacheOptions(options DebrickedOptions, excludedScopes scope.Scopes) (string, error) {
+	cacheOptions := fmt.Sprintf("lockfile-only=%t", options.LockfileOnly)
+	if len(excludedScopes) > 0 {
+		cacheOptions += fmt.Sprintf(" exclude-scopes=%s", excludedScopes)
+	}
+	if len(options.RegistriesConfig) > 0 {
+		content, err := os.ReadFile(filepath.Clean(options.RegistriesConfig))
+		if err != nil {
+			return "", err
+		}
+		configHash := sha256.Sum256(content)
+		cacheOptions += fmt.Sprintf(" registries=%s", hex.EncodeToString(configHash[:]))
+	}
+
+	return cacheOptions, nil
+}
+
+// refineRoots returns the directories of paths, which are searched for operating system package databases and Go binaries
+func refineRoots(paths []string) []string {
+	var roots []string
+	for _, arg := range paths {
+		cleanArg := path.Clean(arg)
+		fileInfo, err := os.Stat(cleanArg)
+		if err == nil && fileInfo.IsDir() {
+			roots = append(roots, cleanArg)
+		}
+	}
+
+	return roots
+}
+
+// refinePaths returns the manifest files of paths to resolve, the reasons manifest files in directories are skipped,
+// and the drift of the lock files of skipped manifest files if drift is checked
+func (r Resolver) refinePaths(paths []string, options DebrickedOptions) ([]string, map[string]string, []drift.Drift, error) {
 	var fileSet = map[string]bool{}
+	var skipped = map[string]string{}
+	var drifted = map[string][]drift.Drift{}
 	var dirs []string
 	for _, arg := range paths {
 		cleanArg := path.Clean(arg)
@@ -204,7 +620,7 @@ func (r Resolver) refinePaths(paths []string, exclusions []string, regenerate in
 
 		fileInfo, err := os.Stat(arg)
 		if err != nil {
-			return nil, err
+			return nil, nil, nil, err
 		}
 
 		if fileInfo.IsDir() {
@@ -214,22 +630,40 @@ func (r Resolver) refinePaths(paths []string, exclusions []string, regenerate in
 		}
 	}
 
-	err := r.searchDirs(fileSet, dirs, exclusions, regenerate)
+	err := r.searchDirs(fileSet, skipped, drifted, dirs, options)
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
 
 	var files []string
 	for f := range fileSet {
 		files = append(files, f)
+		// Skipped manifest files are resolved after all if they are passed as files or regenerated since they drifted
+		if _, ok := skipped[f]; ok {
+			delete(skipped, f)
+			delete(drifted, f)
+		}
+	}
+	var drifts []drift.Drift
+	for _, manifestDrifts := range drifted {
+		drifts = append(drifts, manifestDrifts...)
 	}
+	sort.Slice(drifts, func(i, j int) bool {
+		return drifts[i].LockFile < drifts[j].LockFile
+	})
 
-	return files, nil
+	return files, skipped, drifts, nil
 }
 
-func (r Resolver) searchDirs(fileSet map[string]bool, dirs []string, exclusions []string, regenerate int) error {
+func (r Resolver) searchDirs(
+	fileSet map[string]bool,
+	skipped map[string]string,
+	drifted map[string][]drift.Drift,
+	dirs []string,
+	options DebrickedOptions,
+) error {
 	for _, dir := range dirs {
-		err := r.processDir(fileSet, dir, exclusions, regenerate)
+		err := r.processDir(fileSet, skipped, drifted, dir, options)
 		if err != nil {
 			return err
 		}
@@ -238,27 +672,173 @@ func (r Resolver) searchDirs(fileSet map[string]bool, dirs []string, exclusions
 	return nil
 }
 
-func (r Resolver) processDir(fileSet map[string]bool, dir string, exclusions []string, regenerate int) error {
+func (r Resolver) processDir(
+	fileSet map[string]bool,
+	skipped map[string]string,
+	drifted map[string][]drift.Drift,
+	dir string,
+	options DebrickedOptions,
+) error {
 	fileGroups, err := r.finder.GetGroups(
 		dir,
-		exclusions,
+		options.Exclusions,
 		false,
 		file.StrictAll,
+		options.LockOutputDir,
 	)
 	if err != nil {
 		return err
 	}
-	r.processFileGroups(fileSet, fileGroups, regenerate)
+	err = r.addPmManifestFiles(&fileGroups, dir, options)
+	if err != nil {
+		return err
+	}
+	if len(options.ChangedSince) > 0 {
+		changedFiles, err := git.FindChangedFilesSince(dir, options.ChangedSince)
+		if err != nil {
+			return err
+		}
+		unchanged := make(map[string]bool)
+		for _, fileGroup := range fileGroups.ToSlice() {
+			unchanged[fileGroup.ManifestFile] = fileGroup.HasFile()
+		}
+		fileGroups.FilterGroupsByChangedFiles(changedFiles)
+		for _, fileGroup := range fileGroups.ToSlice() {
+			delete(unchanged, fileGroup.ManifestFile)
+		}
+		for manifestFile, hasFile := range unchanged {
+			if hasFile {
+				skipped[manifestFile] = fmt.Sprintf("not changed since %s", options.ChangedSince)
+			}
+		}
+	}
+	r.processFileGroups(fileSet, skipped, drifted, fileGroups, options)
 
 	return nil
 }
 
-func (r Resolver) processFileGroups(fileSet map[string]bool, fileGroups file.Groups, regenerate int) {
+// addPmManifestFiles adds groups without lock files of the manifest files of the package managers in dir which aren't
+// in any group, since the supported formats don't include the manifest files of every package manager,
+// such as pyproject.toml or build.sbt
+func (r Resolver) addPmManifestFiles(fileGroups *file.Groups, dir string, options DebrickedOptions) error {
+	manifestGroups, err := r.finder.GetManifestGroups(dir, pmManifestFormats(), options.Exclusions, options.LockOutputDir)
+	if err != nil {
+		return err
+	}
+	grouped := map[string]bool{}
 	for _, fileGroup := range fileGroups.ToSlice() {
-		if shouldGenerateLock(fileGroup, regenerate) {
+		grouped[filepath.Clean(fileGroup.ManifestFile)] = true
+		for _, lockFile := range fileGroup.LockFiles {
+			grouped[filepath.Clean(lockFile)] = true
+		}
+	}
+	for _, manifestGroup := range manifestGroups.ToSlice() {
+		if !grouped[filepath.Clean(manifestGroup.ManifestFile)] {
+			fileGroups.Add(manifestGroup)
+		}
+	}
+
+	return nil
+}
+
+// pmManifestFormats returns a format of the manifest files of each package manager
+func pmManifestFormats() []*file.CompiledFormat {
+	var formats []*file.CompiledFormat
+	for _, packageManager := range pm.Pms() {
+		format, err := file.NewPmCompiledFormat(
+			&file.Format{ManifestFileRegex: strings.Join(packageManager.Manifests(), "|")},
+			packageManager.Name(),
+		)
+		if err == nil {
+			formats = append(formats, format)
+		}
+	}
+
+	return formats
+}
+
+// processFileGroups adds the manifest files of groups whose lock files are generated to fileSet. Lock files of skipped
+// groups are checked for drift if drift is checked, or regenerated if they drifted at the drifted regenerate level.
+// Sibling lock files of the manifest files of generated groups, such as go.sum, are checked for drift as well
+// if drift is checked, since generating the lock files of the group doesn't update them
+func (r Resolver) processFileGroups(
+	fileSet map[string]bool,
+	skipped map[string]string,
+	drifted map[string][]drift.Drift,
+	fileGroups file.Groups,
+	options DebrickedOptions,
+) {
+	for _, fileGroup := range fileGroups.ToSlice() {
+		if shouldGenerateLock(fileGroup, options.Regenerate) {
 			fileSet[fileGroup.ManifestFile] = true
+			if options.CheckDrift {
+				if drifts := drift.CheckSiblings(fileGroup); len(drifts) > 0 {
+					drifted[fileGroup.ManifestFile] = drifts
+				}
+			}
+
+			continue
+		}
+		if !fileGroup.HasFile() {
+			continue
+		}
+		skipped[fileGroup.ManifestFile] = skipReason(fileGroup, options.Regenerate)
+		if !options.checksDrift() {
+			continue
+		}
+		drifts := drift.Check(fileGroup)
+		switch {
+		case len(drifts) == 0:
+		case options.Regenerate == RegenerateDrifted:
+			fileSet[fileGroup.ManifestFile] = true
+		default:
+			skipped[fileGroup.ManifestFile] = driftReason(drifts)
+			drifted[fileGroup.ManifestFile] = drifts
+		}
+	}
+}
+
+// checksDrift returns true if lock files of skipped manifest files are checked for drift
+func (options DebrickedOptions) checksDrift() bool {
+	return options.CheckDrift || options.Regenerate == RegenerateDrifted
+}
+
+// driftReason returns why stale lock files aren't regenerated
+func driftReason(drifts []drift.Drift) string {
+	var stale []string
+	for _, d := range drifts {
+		stale = append(stale, d.String())
+	}
+
+	return fmt.Sprintf("%s, --regenerate=drifted regenerates stale lock files", strings.Join(stale, "; "))
+}
+
+// skipReason returns why the lock files of the group aren't generated at the regenerate level
+func skipReason(fileGroup file.Group, regenerate int) string {
+	if regenerate == RegenerateDrifted {
+		return fmt.Sprintf(
+			"lock file %s exists and isn't stale relative to the manifest file",
+			strings.Join(fileGroup.LockFiles, ", "),
+		)
+	}
+	if regenerate == 1 {
+		var nativeLockFiles []string
+		for _, lockFile := range fileGroup.LockFiles {
+			if !file.IsGeneratedLockFile(lockFile) {
+				nativeLockFiles = append(nativeLockFiles, lockFile)
+			}
 		}
+
+		return fmt.Sprintf(
+			"lock file %s exists and isn't generated by Debricked, --regenerate=2 regenerates it",
+			strings.Join(nativeLockFiles, ", "),
+		)
 	}
+
+	return fmt.Sprintf(
+		"lock file %s exists, --regenerate=1 regenerates lock files generated by Debricked and --regenerate=2 all lock files",
+		strings.Join(fileGroup.LockFiles, ", "),
+	)
 }
 
 func shouldGenerateLock(fileGroup file.Group, regenerate int) bool {
@@ -272,6 +852,8 @@ func shouldGenerateLock(fileGroup file.Group, regenerate int) bool {
 		return onlyNonNativeLockFiles(fileGroup.LockFiles)
 	case 2:
 		return true
+	case RegenerateDrifted:
+		return !fileGroup.HasLockFiles()
 	}
 
 	return false
@@ -3,17 +3,32 @@ package resolution
 import (
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
+	clientTestdata "github.com/debricked/cli/internal/client/testdata"
+	"github.com/debricked/cli/internal/cmd/cmderror"
 	"github.com/debricked/cli/internal/file"
 	"github.com/debricked/cli/internal/file/testdata"
+	"github.com/debricked/cli/internal/io"
+	"github.com/debricked/cli/internal/resolution/cache"
 	resolutionFile "github.com/debricked/cli/internal/resolution/file"
 	fileTestdata "github.com/debricked/cli/internal/resolution/file/testdata"
+	"github.com/debricked/cli/internal/resolution/isolation"
 	"github.com/debricked/cli/internal/resolution/job"
 	jobTestdata "github.com/debricked/cli/internal/resolution/job/testdata"
+	"github.com/debricked/cli/internal/resolution/lockoutput"
+	"github.com/debricked/cli/internal/resolution/pm/gomod"
+	"github.com/debricked/cli/internal/resolution/report"
+	"github.com/debricked/cli/internal/resolution/scope"
 
 	"github.com/debricked/cli/internal/resolution/strategy"
 	strategyTestdata "github.com/debricked/cli/internal/resolution/strategy/testdata"
+	"github.com/debricked/cli/internal/resolution/toolchain"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -49,6 +64,283 @@ func TestResolve(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestResolveCache(t *testing.T) {
+	r := NewResolver(
+		&testdata.FinderMock{},
+		resolutionFile.NewBatchFactory(),
+		strategyTestdata.NewStrategyFactoryMock(),
+		NewScheduler(workers),
+	)
+	options := DebrickedOptions{
+		Verbose:  true,
+		Cache:    true,
+		CacheDir: t.TempDir(),
+	}
+	res, err := r.Resolve([]string{"../../go.mod"}, options)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, res.Jobs())
+	for _, j := range res.Jobs() {
+		assert.Implements(t, (*cache.ICacheJob)(nil), j)
+	}
+
+	options.Cache = false
+	res, err = r.Resolve([]string{"../../go.mod"}, options)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, res.Jobs())
+	for _, j := range res.Jobs() {
+		_, ok := j.(cache.ICacheJob)
+		assert.False(t, ok)
+	}
+}
+
+func TestResolveReport(t *testing.T) {
+	r := NewResolver(
+		&testdata.FinderMock{},
+		resolutionFile.NewBatchFactory(),
+		strategyTestdata.NewStrategyFactoryMock(),
+		NewScheduler(workers),
+	)
+	dir := t.TempDir()
+	options := DebrickedOptions{
+		Verbose:     true,
+		ReportJson:  filepath.Join(dir, "report.json"),
+		ReportJunit: filepath.Join(dir, "report.xml"),
+	}
+	res, err := r.Resolve([]string{"../../go.mod"}, options)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, res.Jobs())
+	for _, j := range res.Jobs() {
+		assert.IsType(t, &report.Job{}, j)
+	}
+	assert.FileExists(t, options.ReportJson)
+	assert.FileExists(t, options.ReportJunit)
+
+	options.ReportJson = filepath.Join(dir, "missing", "report.json")
+	_, err = r.Resolve([]string{"../../go.mod"}, options)
+	assert.ErrorContains(t, err, "failed to write resolution report")
+}
+
+func TestResolveTimeouts(t *testing.T) {
+	r := NewResolver(
+		&testdata.FinderMock{},
+		resolutionFile.NewBatchFactory(),
+		strategyTestdata.NewStrategyFactoryMock(),
+		NewScheduler(workers),
+	)
+	options := DebrickedOptions{
+		Verbose:           true,
+		ResolutionTimeout: time.Minute,
+	}
+	res, err := r.Resolve([]string{"../../go.mod"}, options)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, res.Jobs())
+	for _, j := range res.Jobs() {
+		assert.Equal(t, time.Minute, j.GetTimeout())
+	}
+
+	options.PmResolutionTimeouts = map[string]time.Duration{gomod.Name: time.Hour}
+	res, err = r.Resolve([]string{"../../go.mod"}, options)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, res.Jobs())
+	for _, j := range res.Jobs() {
+		assert.Equal(t, time.Hour, j.GetTimeout())
+	}
+}
+
+func TestResolveRegistries(t *testing.T) {
+	r := NewResolver(
+		&testdata.FinderMock{},
+		resolutionFile.NewBatchFactory(),
+		strategyTestdata.NewStrategyFactoryMock(),
+		NewScheduler(workers),
+	)
+	config := filepath.Join(t.TempDir(), "registries.yaml")
+	assert.NoError(t, os.WriteFile(config, []byte("registries:\n  go:\n    url: https://goproxy.example.com\n"), 0600))
+	options := DebrickedOptions{
+		RegistriesConfig: config,
+	}
+	res, err := r.Resolve([]string{"../../go.mod"}, options)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, res.Jobs())
+	for _, j := range res.Jobs() {
+		jobMock, ok := j.(*jobTestdata.JobMock)
+		assert.True(t, ok)
+		assert.Equal(t, []string{"GOPROXY=https://goproxy.example.com"}, jobMock.GetEnv())
+	}
+}
+
+func TestResolveRegistriesConfigErr(t *testing.T) {
+	r := NewResolver(
+		&testdata.FinderMock{},
+		resolutionFile.NewBatchFactory(),
+		strategyTestdata.NewStrategyFactoryMock(),
+		NewScheduler(workers),
+	)
+	options := DebrickedOptions{
+		RegistriesConfig: filepath.Join(t.TempDir(), "registries.yaml"),
+	}
+	res, err := r.Resolve([]string{"../../go.mod"}, options)
+	assert.Nil(t, res)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestResolveIsolated(t *testing.T) {
+	r := NewResolver(
+		&testdata.FinderMock{},
+		resolutionFile.NewBatchFactory(),
+		strategyTestdata.NewStrategyFactoryMock(),
+		NewScheduler(workers),
+	)
+	manifest := filepath.Join(t.TempDir(), "go.mod")
+	assert.NoError(t, os.WriteFile(manifest, []byte("module example.com/isolated\n"), 0600))
+	options := DebrickedOptions{
+		Isolated: true,
+	}
+	res, err := r.Resolve([]string{manifest}, options)
+	assert.NoError(t, err)
+	assert.Len(t, res.Jobs(), 1)
+	for _, j := range res.Jobs() {
+		isolatedJob, ok := j.(*isolation.Job)
+		assert.True(t, ok)
+		assert.Equal(t, manifest, isolatedJob.GetFile())
+		assert.NotEqual(t, manifest, isolatedJob.IJob.GetFile())
+		assert.NoFileExists(t, isolatedJob.IJob.GetFile())
+	}
+}
+
+func TestResolveLockOutputDir(t *testing.T) {
+	r := NewResolver(
+		&testdata.FinderMock{},
+		resolutionFile.NewBatchFactory(),
+		strategyTestdata.NewStrategyFactoryMock(),
+		NewScheduler(workers),
+	)
+	lockOutputDir := t.TempDir()
+	options := DebrickedOptions{
+		LockOutputDir: lockOutputDir,
+	}
+	res, err := r.Resolve([]string{"../../go.mod"}, options)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, res.Jobs())
+	for _, j := range res.Jobs() {
+		_, ok := j.(*lockoutput.Job)
+		assert.True(t, ok)
+		assert.Equal(t, lockOutputDir, j.LockOutputDir())
+	}
+}
+
+func TestMakeWorkspace(t *testing.T) {
+	workspace, err := makeWorkspace([]string{"."}, DebrickedOptions{}, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, workspace)
+
+	_, err = makeWorkspace([]string{filepath.Join(t.TempDir(), "missing")}, DebrickedOptions{Isolated: true}, nil)
+	assert.ErrorContains(t, err, "failed to make isolated workspace")
+
+	workspace, err = makeWorkspace([]string{"."}, DebrickedOptions{Isolated: true, SkipManifests: true}, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, workspace)
+}
+
+func TestResolveToolchains(t *testing.T) {
+	r := NewResolver(
+		&testdata.FinderMock{},
+		resolutionFile.NewBatchFactory(),
+		strategyTestdata.NewStrategyFactoryMock(),
+		NewScheduler(workers),
+	)
+	goExecutable := filepath.Join(t.TempDir(), "go")
+	assert.NoError(t, os.WriteFile(goExecutable, nil, 0600))
+	options := DebrickedOptions{
+		Toolchains: map[string]string{toolchain.Go: goExecutable},
+	}
+	res, err := r.Resolve([]string{"../../go.mod"}, options)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, res.Jobs())
+	for _, j := range res.Jobs() {
+		assert.Equal(t, []toolchain.Toolchain{{
+			Name:       toolchain.Go,
+			Executable: goExecutable,
+			Source:     toolchain.ConfiguredSource,
+		}}, j.Toolchains())
+	}
+}
+
+func TestResolveInvalidToolchain(t *testing.T) {
+	r := NewResolver(
+		&testdata.FinderMock{},
+		resolutionFile.NewBatchFactory(),
+		strategyTestdata.NewStrategyFactoryMock(),
+		NewScheduler(workers),
+	)
+	options := DebrickedOptions{
+		Toolchains: map[string]string{"ruby": "ruby"},
+	}
+	res, err := r.Resolve([]string{"../../go.mod"}, options)
+	assert.Nil(t, res)
+	assert.ErrorContains(t, err, "invalid toolchain ruby")
+}
+
+func TestResolveInvalidScope(t *testing.T) {
+	r := NewResolver(
+		&testdata.FinderMock{},
+		resolutionFile.NewBatchFactory(),
+		strategyTestdata.NewStrategyFactoryMock(),
+		NewScheduler(workers),
+	)
+	options := DebrickedOptions{
+		ExcludeScopes: []string{"docs"},
+	}
+	res, err := r.Resolve([]string{"../../go.mod"}, options)
+	assert.Nil(t, res)
+	assert.ErrorContains(t, err, "invalid scope docs")
+}
+
+func TestCacheOptions(t *testing.T) {
+	options, err := cacheOptions(DebrickedOptions{}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "lockfile-only=false", options)
+
+	options, err = cacheOptions(DebrickedOptions{LockfileOnly: true}, scope.Scopes{scope.Dev, scope.Test})
+	assert.NoError(t, err)
+	assert.Equal(t, "lockfile-only=true exclude-scopes=dev,test", options)
+}
+
+func TestCacheOptionsRegistriesConfig(t *testing.T) {
+	config := filepath.Join(t.TempDir(), "registries.yaml")
+	assert.NoError(t, os.WriteFile(config, []byte("registries:\n  npm:\n    url: https://npm.example.com\n"), 0600))
+	options, err := cacheOptions(DebrickedOptions{RegistriesConfig: config}, nil)
+	assert.NoError(t, err)
+	assert.Contains(t, options, " registries=")
+
+	assert.NoError(t, os.WriteFile(config, []byte("registries:\n  npm:\n    url: https://mirror.example.com\n"), 0600))
+	changedOptions, err := cacheOptions(DebrickedOptions{RegistriesConfig: config}, nil)
+	assert.NoError(t, err)
+	assert.NotEqual(t, options, changedOptions)
+
+	_, err = cacheOptions(DebrickedOptions{RegistriesConfig: filepath.Join(t.TempDir(), "missing.yaml")}, nil)
+	assert.Error(t, err)
+}
+
+func TestGetPmResolutionTimeouts(t *testing.T) {
+	timeouts, err := GetPmResolutionTimeouts(map[string]string{gomod.Name: "30m", "pip": "90s"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]time.Duration{gomod.Name: 30 * time.Minute, "pip": 90 * time.Second}, timeouts)
+
+	timeouts, err = GetPmResolutionTimeouts(nil)
+	assert.NoError(t, err)
+	assert.Empty(t, timeouts)
+
+	_, err = GetPmResolutionTimeouts(map[string]string{"unknown": "30m"})
+	assert.ErrorIs(t, err, ErrUnknownPm)
+
+	_, err = GetPmResolutionTimeouts(map[string]string{gomod.Name: "forever"})
+	assert.ErrorContains(t, err, "invalid resolution timeout of go")
+
+	_, err = GetPmResolutionTimeouts(map[string]string{gomod.Name: "-1m"})
+	assert.ErrorContains(t, err, "-1m is negative")
+}
+
 func TestResolveInvokeError(t *testing.T) {
 	r := NewResolver(
 		&testdata.FinderMock{},
@@ -137,6 +429,22 @@ func TestResolveInvalidDir(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestResolveInvalidJsPackageManager(t *testing.T) {
+	r := NewResolver(
+		&testdata.FinderMock{},
+		resolutionFile.NewBatchFactory(),
+		strategyTestdata.NewStrategyFactoryMock(),
+		SchedulerMock{},
+	)
+
+	options := DebrickedOptions{
+		JsPackageManager: "bun",
+	}
+	res, err := r.Resolve([]string{"."}, options)
+	assert.Nil(t, res)
+	assert.ErrorContains(t, err, "invalid JavaScript package manager: bun")
+}
+
 func TestResolveGetGroupsErr(t *testing.T) {
 	f := testdata.NewFinderMock()
 	testErr := errors.New("test")
@@ -197,6 +505,68 @@ func TestResolveDirWithManifestFiles(t *testing.T) {
 	}
 }
 
+func TestResolveDirWithPmManifestFiles(t *testing.T) {
+	f := testdata.NewFinderMock()
+	groups := file.Groups{}
+	groups.Add(file.Group{ManifestFile: goModFile})
+	f.SetGetGroupsReturnMock(groups, nil)
+	manifestGroups := file.Groups{}
+	manifestGroups.Add(file.Group{ManifestFile: goModFile})
+	manifestGroups.Add(file.Group{ManifestFile: "pyproject.toml"})
+	f.SetGetManifestGroupsReturnMock(manifestGroups)
+	r := NewResolver(
+		f,
+		resolutionFile.NewBatchFactory(),
+		strategyTestdata.NewStrategyFactoryMock(),
+		NewScheduler(workers),
+	)
+
+	res, err := r.Resolve([]string{"."}, DebrickedOptions{})
+
+	assert.NoError(t, err)
+	var files []string
+	for _, j := range res.Jobs() {
+		files = append(files, j.GetFile())
+	}
+	assert.ElementsMatch(t, []string{goModFile, "pyproject.toml"}, files)
+}
+
+func TestResolveDirFindsManifestFilesOfEveryPm(t *testing.T) {
+	dir := t.TempDir()
+	manifests := []string{
+		filepath.Join(dir, "poetry", "pyproject.toml"),
+		filepath.Join(dir, "pipenv", "Pipfile"),
+		filepath.Join(dir, "go", "go.mod"),
+		filepath.Join(dir, "sbt", "build.sbt"),
+		filepath.Join(dir, "swift", "Package.swift"),
+		filepath.Join(dir, "cocoapods", "Podfile"),
+		filepath.Join(dir, "bundler", "Gemfile"),
+	}
+	for _, manifest := range manifests {
+		assert.NoError(t, os.MkdirAll(filepath.Dir(manifest), 0750))
+		assert.NoError(t, os.WriteFile(manifest, []byte{}, 0600))
+	}
+	debClient := clientTestdata.NewDebClientMock()
+	debClient.SetServiceUp(false)
+	finder, err := file.NewFinder(debClient, io.FileSystem{})
+	assert.NoError(t, err)
+	r := NewResolver(
+		finder,
+		resolutionFile.NewBatchFactory(),
+		strategyTestdata.NewStrategyFactoryMock(),
+		NewScheduler(workers),
+	)
+
+	res, err := r.Resolve([]string{dir}, DebrickedOptions{})
+
+	assert.NoError(t, err)
+	var files []string
+	for _, j := range res.Jobs() {
+		files = append(files, j.GetFile())
+	}
+	assert.ElementsMatch(t, manifests, files)
+}
+
 func TestResolveDirWithExclusions(t *testing.T) {
 	f := testdata.NewFinderMock()
 	groups := file.Groups{}
@@ -224,6 +594,201 @@ func TestResolveDirWithExclusions(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+// initGitRepository makes a git repository in a temporary directory with a commit of go.mod, and returns its path
+func initGitRepository(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	r, err := git.PlainInit(dir, false)
+	assert.NoError(t, err)
+	w, err := r.Worktree()
+	assert.NoError(t, err)
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, goModFile), []byte("module example.com/app\n"), 0600))
+	_, err = w.Add(goModFile)
+	assert.NoError(t, err)
+	_, err = w.Commit("commit", &git.CommitOptions{Author: &object.Signature{Name: "author"}})
+	assert.NoError(t, err)
+
+	return dir
+}
+
+func TestResolveDirWithChangedSince(t *testing.T) {
+	dir := initGitRepository(t)
+	f := testdata.NewFinderMock()
+	groups := file.Groups{}
+	groups.Add(file.Group{ManifestFile: filepath.Join(dir, goModFile)})
+	f.SetGetGroupsReturnMock(groups, nil)
+
+	r := NewResolver(
+		f,
+		resolutionFile.NewBatchFactory(),
+		strategyTestdata.NewStrategyFactoryMock(),
+		SchedulerMock{},
+	)
+
+	options := DebrickedOptions{
+		Exclusions:   nil,
+		Verbose:      true,
+		Regenerate:   0,
+		ChangedSince: "HEAD",
+	}
+	res, err := r.Resolve([]string{dir}, options)
+
+	assert.Empty(t, res.Jobs())
+	assert.NoError(t, err)
+}
+
+// driftedGoModGroups returns the group of a go.mod whose requirement has no checksum in go.sum
+func driftedGoModGroups(t *testing.T) file.Groups {
+	t.Helper()
+	dir := t.TempDir()
+	manifestFile := filepath.Join(dir, goModFile)
+	assert.NoError(t, os.WriteFile(manifestFile, []byte("module example.com/app\n\nrequire github.com/pkg/errors v0.9.1\n"), 0600))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "go.sum"), []byte{}, 0600))
+	groups := file.Groups{}
+	groups.Add(file.Group{ManifestFile: manifestFile, LockFiles: []string{filepath.Join(dir, "gomod.debricked.lock")}})
+
+	return groups
+}
+
+func TestResolveCheckDrift(t *testing.T) {
+	f := testdata.NewFinderMock()
+	f.SetGetGroupsReturnMock(driftedGoModGroups(t), nil)
+	r := NewResolver(
+		f,
+		resolutionFile.NewBatchFactory(),
+		strategyTestdata.NewStrategyFactoryMock(),
+		SchedulerMock{},
+	)
+
+	res, err := r.Resolve([]string{"."}, DebrickedOptions{CheckDrift: true})
+
+	assert.Empty(t, res.Jobs())
+	var cmdErr cmderror.CommandError
+	assert.ErrorAs(t, err, &cmdErr)
+	assert.Equal(t, 1, cmdErr.Code)
+	assert.ErrorContains(t, err, "1 lock files are stale relative to their manifest files")
+}
+
+func TestResolveCheckDriftGeneratedGroup(t *testing.T) {
+	f := testdata.NewFinderMock()
+	groups := driftedGoModGroups(t)
+	group := groups.ToSlice()[0]
+	generatedGroups := file.Groups{}
+	generatedGroups.Add(file.Group{ManifestFile: group.ManifestFile})
+	f.SetGetGroupsReturnMock(generatedGroups, nil)
+	r := NewResolver(
+		f,
+		resolutionFile.NewBatchFactory(),
+		strategyTestdata.NewStrategyFactoryMock(),
+		SchedulerMock{},
+	)
+
+	res, err := r.Resolve([]string{"."}, DebrickedOptions{CheckDrift: true})
+
+	assert.Len(t, res.Jobs(), 1)
+	assert.ErrorContains(t, err, "1 lock files are stale relative to their manifest files")
+}
+
+func TestResolveRegenerateDrifted(t *testing.T) {
+	f := testdata.NewFinderMock()
+	groups := driftedGoModGroups(t)
+	f.SetGetGroupsReturnMock(groups, nil)
+	r := NewResolver(
+		f,
+		resolutionFile.NewBatchFactory(),
+		strategyTestdata.NewStrategyFactoryMock(),
+		SchedulerMock{},
+	)
+
+	res, err := r.Resolve([]string{"."}, DebrickedOptions{Regenerate: RegenerateDrifted, CheckDrift: true})
+
+	assert.NoError(t, err)
+	assert.Len(t, res.Jobs(), 1)
+	assert.Equal(t, groups.ToSlice()[0].ManifestFile, res.Jobs()[0].GetFile())
+}
+
+func TestResolveOsPackages(t *testing.T) {
+	root := t.TempDir()
+	dbDir := filepath.Join(root, "lib", "apk", "db")
+	assert.NoError(t, os.MkdirAll(dbDir, 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dbDir, "installed"), []byte("P:musl\nV:1.2.4-r4\n"), 0600))
+
+	f := testdata.NewFinderMock()
+	groups := file.Groups{}
+	groups.Add(file.Group{ManifestFile: goModFile})
+	f.SetGetGroupsReturnMock(groups, nil)
+
+	r := NewResolver(
+		f,
+		resolutionFile.NewBatchFactory(),
+		strategyTestdata.NewStrategyFactoryMock(),
+		NewScheduler(workers),
+	)
+
+	options := DebrickedOptions{
+		OsPackages:    true,
+		SkipManifests: true,
+	}
+	res, err := r.Resolve([]string{root}, options)
+
+	assert.NoError(t, err)
+	assert.Len(t, res.Jobs(), 1)
+	assert.Equal(t, filepath.Join(root, "lib", "apk", "db", "installed"), res.Jobs()[0].GetFile())
+}
+
+func TestResolveGoBinaries(t *testing.T) {
+	root := t.TempDir()
+	testBinary, err := os.Executable()
+	assert.NoError(t, err)
+	content, err := os.ReadFile(testBinary)
+	assert.NoError(t, err)
+	binary := filepath.Join(root, "app.exe")
+	assert.NoError(t, os.WriteFile(binary, content, 0600))
+
+	r := NewResolver(
+		testdata.NewFinderMock(),
+		resolutionFile.NewBatchFactory(),
+		strategyTestdata.NewStrategyFactoryMock(),
+		NewScheduler(workers),
+	)
+
+	options := DebrickedOptions{
+		GoBinaries:    true,
+		SkipManifests: true,
+	}
+	res, err := r.Resolve([]string{root}, options)
+
+	assert.NoError(t, err)
+	assert.Len(t, res.Jobs(), 1)
+	assert.False(t, res.HasErr())
+	assert.FileExists(t, filepath.Join(root, "app.exe.gomod.debricked.lock"))
+}
+
+func TestResolveDirWithChangedSinceBadRef(t *testing.T) {
+	dir := initGitRepository(t)
+	f := testdata.NewFinderMock()
+	groups := file.Groups{}
+	groups.Add(file.Group{ManifestFile: goModFile})
+	f.SetGetGroupsReturnMock(groups, nil)
+
+	r := NewResolver(
+		f,
+		resolutionFile.NewBatchFactory(),
+		strategyTestdata.NewStrategyFactoryMock(),
+		SchedulerMock{},
+	)
+
+	options := DebrickedOptions{
+		Exclusions:   nil,
+		Verbose:      true,
+		Regenerate:   0,
+		ChangedSince: "non-existing-ref",
+	}
+	_, err := r.Resolve([]string{dir}, options)
+
+	assert.ErrorContains(t, err, "failed to resolve git reference non-existing-ref")
+}
+
 func TestResolveHasResolutionErrs(t *testing.T) {
 	f := testdata.NewFinderMock()
 	groups := file.Groups{}
@@ -378,3 +943,17 @@ func TestGetStrictnessLevel(t *testing.T) {
 		})
 	}
 }
+
+func TestGetRegenerateLevel(t *testing.T) {
+	cases := map[string]int{"": 0, "0": 0, "1": 1, "2": 2, "drifted": RegenerateDrifted}
+	for level, expected := range cases {
+		t.Run(level, func(t *testing.T) {
+			regenerate, err := GetRegenerateLevel(level)
+			assert.NoError(t, err)
+			assert.Equal(t, expected, regenerate)
+		})
+	}
+
+	_, err := GetRegenerateLevel("3")
+	assert.ErrorContains(t, err, "invalid regenerate level: 3")
+}
@@ -1,10 +1,15 @@
 package resolution
 
 import (
+	"context"
+	"os"
+	"os/signal"
 	"sort"
 	"sync"
+	"syscall"
 
 	"github.com/chelnak/ysmrr"
+	"github.com/debricked/cli/internal/resolution/cache"
 	"github.com/debricked/cli/internal/resolution/job"
 	"github.com/debricked/cli/internal/tui"
 )
@@ -35,8 +40,13 @@ func (scheduler *Scheduler) Schedule(jobs []job.IJob) (IResolution, error) {
 
 	scheduler.spinnerManager = tui.NewSpinnerManager("Resolving", "waiting for worker")
 
+	// Package managers run in process groups of their own and don't receive interrupts from the terminal,
+	// so interrupts cancel the jobs instead, which kills the package managers
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
 	for w := 1; w <= scheduler.workers; w++ {
-		go scheduler.worker()
+		go scheduler.worker(ctx)
 	}
 
 	sort.Slice(jobs, func(i, j int) bool {
@@ -58,20 +68,35 @@ func (scheduler *Scheduler) Schedule(jobs []job.IJob) (IResolution, error) {
 
 	close(scheduler.queue)
 
-	return NewResolution(jobs), nil
+	return NewResolution(jobs), ctx.Err()
 }
 
-func (scheduler *Scheduler) worker() {
+func (scheduler *Scheduler) worker(ctx context.Context) {
 	for item := range scheduler.queue {
 		go scheduler.updateStatus(item)
 
-		item.job.Run()
+		scheduler.run(ctx, item.job)
 
 		scheduler.finish(item)
 
 		scheduler.waitGroup.Done()
 	}
 }
+
+// run runs the job, which is cancelled when ctx is done or its timeout has passed
+func (scheduler *Scheduler) run(ctx context.Context, j job.IJob) {
+	var cancel context.CancelFunc
+	if j.GetTimeout() > 0 {
+		ctx, cancel = context.WithTimeout(ctx, j.GetTimeout())
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
+	defer cancel()
+
+	j.SetContext(ctx)
+	j.Run()
+}
+
 func (scheduler *Scheduler) updateStatus(item queueItem) {
 	for {
 		msg := <-item.job.ReceiveStatus()
@@ -84,7 +109,11 @@ func (scheduler *Scheduler) finish(item queueItem) {
 		scheduler.spinnerManager.SetSpinnerMessage(item.spinner, item.job.GetFile(), "failed")
 		item.spinner.Error()
 	} else {
-		scheduler.spinnerManager.SetSpinnerMessage(item.spinner, item.job.GetFile(), "done")
+		message := "done"
+		if cacheJob, ok := item.job.(cache.ICacheJob); ok && len(cacheJob.CacheStatus()) > 0 {
+			message += " (" + cacheJob.CacheStatus() + ")"
+		}
+		scheduler.spinnerManager.SetSpinnerMessage(item.spinner, item.job.GetFile(), message)
 
 		item.spinner.Complete()
 	}
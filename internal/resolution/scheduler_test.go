@@ -1,11 +1,16 @@
 package resolution
 
 import (
+	"context"
 	"sort"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/debricked/cli/internal/resolution/cache"
 	"github.com/debricked/cli/internal/resolution/job"
 	"github.com/debricked/cli/internal/resolution/job/testdata"
+	"github.com/debricked/cli/internal/tui"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -78,3 +83,49 @@ func TestScheduleJobErr(t *testing.T) {
 	assert.Len(t, j.Errors().GetAll(), 1)
 	assert.Contains(t, j.Errors().GetAll(), jobErr)
 }
+
+type cacheJobMock struct {
+	*testdata.JobMock
+	status string
+}
+
+func (j cacheJobMock) CacheStatus() string {
+	return j.status
+}
+
+func TestFinishCacheStatus(t *testing.T) {
+	cases := map[string]string{
+		cache.StatusHit:  "done (cache hit)",
+		cache.StatusMiss: "done (cache miss)",
+		"":               "done",
+	}
+	for status, message := range cases {
+		t.Run(message, func(t *testing.T) {
+			scheduler := NewScheduler(1)
+			scheduler.spinnerManager = tui.NewSpinnerManager("Resolving", "waiting for worker")
+			j := cacheJobMock{JobMock: testdata.NewJobMock("file"), status: status}
+			spinner := scheduler.spinnerManager.AddSpinner(j.GetFile())
+
+			scheduler.finish(queueItem{job: j, spinner: spinner})
+
+			assert.True(t, strings.HasSuffix(spinner.GetMessage(), ": "+message))
+		})
+	}
+}
+
+func TestScheduleTimeout(t *testing.T) {
+	s := NewScheduler(10)
+	jobMock := testdata.NewJobMock("file")
+	jobMock.SetTimeout(time.Minute)
+	noTimeoutJobMock := testdata.NewJobMock("no-timeout-file")
+
+	_, err := s.Schedule([]job.IJob{jobMock, noTimeoutJobMock})
+
+	assert.NoError(t, err)
+	_, ok := jobMock.Context().Deadline()
+	assert.True(t, ok)
+	assert.ErrorIs(t, jobMock.Context().Err(), context.Canceled)
+	_, ok = noTimeoutJobMock.Context().Deadline()
+	assert.False(t, ok)
+	assert.ErrorIs(t, noTimeoutJobMock.Context().Err(), context.Canceled)
+}
@@ -0,0 +1,95 @@
+package scope
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	Dev  = "dev"
+	Test = "test"
+)
+
+var all = []string{Dev, Test}
+
+// fileNames are the words in the names of files dedicated to the dependencies of a scope
+var fileNames = map[string][]string{
+	Dev:  {"dev", "devel", "develop", "development"},
+	Test: {"test", "tests", "testing"},
+}
+
+var wordSeparatorRegex = regexp.MustCompile(`[-_.]`)
+
+// Scopes are the scopes of dependencies left out of resolution, such as development tooling and test dependencies,
+// which aren't shipped with the project
+type Scopes []string
+
+// NewScopes returns the scopes named by names, which may be comma-separated, or all scopes if productionOnly is true
+func NewScopes(names []string, productionOnly bool) (Scopes, error) {
+	if productionOnly {
+		names = all
+	}
+	unique := map[string]bool{}
+	for _, name := range strings.Split(strings.Join(names, ","), ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if len(name) == 0 {
+			continue
+		}
+		if !valid(name) {
+			return nil, fmt.Errorf("invalid scope %s. Valid scopes are %s", name, strings.Join(all, ", "))
+		}
+		unique[name] = true
+	}
+	var scopes Scopes
+	for name := range unique {
+		scopes = append(scopes, name)
+	}
+	sort.Strings(scopes)
+
+	return scopes, nil
+}
+
+// Excludes returns true if the dependencies of scope are left out
+func (s Scopes) Excludes(scope string) bool {
+	for _, excluded := range s {
+		if excluded == scope {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ExcludesFile returns true if file is dedicated to the dependencies of an excluded scope, which is named by a word
+// of its name, such as requirements-dev.txt or test-requirements.txt
+func (s Scopes) ExcludesFile(file string) bool {
+	words := wordSeparatorRegex.Split(strings.ToLower(filepath.Base(file)), -1)
+	for _, excluded := range s {
+		for _, word := range words {
+			for _, name := range fileNames[excluded] {
+				if word == name {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+func (s Scopes) String() string {
+	return strings.Join(s, ",")
+}
+
+func valid(name string) bool {
+	for _, scope := range all {
+		if scope == name {
+			return true
+		}
+	}
+
+	return false
+}
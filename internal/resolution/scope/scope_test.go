@@ -0,0 +1,56 @@
+package scope
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewScopes(t *testing.T) {
+	scopes, err := NewScopes([]string{"test", " Dev", "test", ""}, false)
+	assert.NoError(t, err)
+	assert.Equal(t, Scopes{Dev, Test}, scopes)
+
+	scopes, err = NewScopes([]string{"test,dev"}, false)
+	assert.NoError(t, err)
+	assert.Equal(t, Scopes{Dev, Test}, scopes)
+
+	scopes, err = NewScopes(nil, false)
+	assert.NoError(t, err)
+	assert.Empty(t, scopes)
+}
+
+func TestNewScopesProductionOnly(t *testing.T) {
+	scopes, err := NewScopes([]string{Test}, true)
+	assert.NoError(t, err)
+	assert.Equal(t, Scopes{Dev, Test}, scopes)
+}
+
+func TestNewScopesInvalid(t *testing.T) {
+	scopes, err := NewScopes([]string{"dev", "provided"}, false)
+	assert.Nil(t, scopes)
+	assert.ErrorContains(t, err, "invalid scope provided. Valid scopes are dev, test")
+}
+
+func TestExcludes(t *testing.T) {
+	scopes := Scopes{Test}
+	assert.True(t, scopes.Excludes(Test))
+	assert.False(t, scopes.Excludes(Dev))
+	assert.False(t, Scopes(nil).Excludes(Dev))
+}
+
+func TestExcludesFile(t *testing.T) {
+	scopes := Scopes{Dev}
+	assert.True(t, scopes.ExcludesFile("requirements-dev.txt"))
+	assert.True(t, scopes.ExcludesFile("dir/development_requirements.txt"))
+	assert.False(t, scopes.ExcludesFile("requirements-devices.txt"))
+	assert.False(t, scopes.ExcludesFile("requirements.test.txt"))
+	assert.False(t, scopes.ExcludesFile("dev/requirements.txt"))
+	assert.True(t, Scopes{Test}.ExcludesFile("requirements.test.txt"))
+	assert.False(t, Scopes(nil).ExcludesFile("requirements-dev.txt"))
+}
+
+func TestString(t *testing.T) {
+	assert.Equal(t, "dev,test", Scopes{Dev, Test}.String())
+	assert.Equal(t, "", Scopes(nil).String())
+}
@@ -2,9 +2,13 @@ package strategy
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/debricked/cli/internal/resolution/file"
 	"github.com/debricked/cli/internal/resolution/pm/bower"
+	"github.com/debricked/cli/internal/resolution/pm/bundler"
+	"github.com/debricked/cli/internal/resolution/pm/cargo"
+	"github.com/debricked/cli/internal/resolution/pm/cocoapods"
 	"github.com/debricked/cli/internal/resolution/pm/composer"
 	"github.com/debricked/cli/internal/resolution/pm/gomod"
 	"github.com/debricked/cli/internal/resolution/pm/gradle"
@@ -12,11 +16,34 @@ import (
 	"github.com/debricked/cli/internal/resolution/pm/npm"
 	"github.com/debricked/cli/internal/resolution/pm/nuget"
 	"github.com/debricked/cli/internal/resolution/pm/pip"
+	"github.com/debricked/cli/internal/resolution/pm/pipenv"
+	"github.com/debricked/cli/internal/resolution/pm/pnpm"
+	"github.com/debricked/cli/internal/resolution/pm/pyproject"
+	"github.com/debricked/cli/internal/resolution/pm/sbt"
+	"github.com/debricked/cli/internal/resolution/pm/swift"
 	"github.com/debricked/cli/internal/resolution/pm/yarn"
+	"github.com/debricked/cli/internal/resolution/scope"
 )
 
 type IFactory interface {
-	Make(pmBatch file.IBatch, paths []string) (IStrategy, error)
+	Make(pmBatch file.IBatch, paths []string, options Options) (IStrategy, error)
+}
+
+// Options configure how the strategies resolve their files
+type Options struct {
+	// LockfileOnly makes strategies resolve lock files without installing packages or running install scripts
+	LockfileOnly bool
+	// ScratchDir is where strategies write files needed outside of the directories of the manifest files,
+	// such as the Gradle init script, instead of the working directory
+	ScratchDir string
+	// ExcludedScopes are the scopes of dependencies, such as dev and test, that strategies leave out of resolution
+	ExcludedScopes scope.Scopes
+	// SetupTimeout is the time commands run by strategies to discover projects, such as the sbt projects, may run,
+	// or 0 if they may run until they finish
+	SetupTimeout time.Duration
+	// RegenerateNativeLockFiles makes strategies regenerate lock files which aren't generated by Debricked, such as
+	// Pipfile.lock, instead of leaving out the manifest files which already have them
+	RegenerateNativeLockFiles bool
 }
 
 type Factory struct{}
@@ -26,27 +53,47 @@ func NewStrategyFactory() Factory {
 }
 
 //nolint:all
-func (sf Factory) Make(pmFileBatch file.IBatch, paths []string) (IStrategy, error) {
+func (sf Factory) Make(pmFileBatch file.IBatch, paths []string, options Options) (IStrategy, error) {
 	name := pmFileBatch.Pm().Name()
 	switch name {
 	case maven.Name:
-		return maven.NewStrategy(pmFileBatch.Files()), nil
+		return maven.NewStrategy(pmFileBatch.Files(), options.ExcludedScopes), nil
 	case gradle.Name:
-		return gradle.NewStrategy(pmFileBatch.Files(), paths), nil
+		if len(options.ScratchDir) > 0 {
+			return gradle.NewIsolatedStrategy(pmFileBatch.Files(), paths, options.ScratchDir, options.ExcludedScopes, options.SetupTimeout), nil
+		}
+
+		return gradle.NewStrategy(pmFileBatch.Files(), paths, options.ExcludedScopes, options.SetupTimeout), nil
 	case gomod.Name:
 		return gomod.NewStrategy(pmFileBatch.Files()), nil
 	case pip.Name:
-		return pip.NewStrategy(pmFileBatch.Files()), nil
+		return pip.NewStrategy(pmFileBatch.Files(), options.LockfileOnly, options.ExcludedScopes), nil
 	case yarn.Name:
-		return yarn.NewStrategy(pmFileBatch.Files()), nil
+		return yarn.NewStrategy(pmFileBatch.Files(), options.LockfileOnly, options.ExcludedScopes), nil
 	case npm.Name:
-		return npm.NewStrategy(pmFileBatch.Files()), nil
+		return npm.NewStrategy(pmFileBatch.Files(), options.LockfileOnly, options.ExcludedScopes), nil
+	case pnpm.Name:
+		return pnpm.NewStrategy(pmFileBatch.Files()), nil
 	case bower.Name:
-		return bower.NewStrategy(pmFileBatch.Files()), nil
+		return bower.NewStrategy(pmFileBatch.Files(), options.LockfileOnly), nil
 	case nuget.Name:
-		return nuget.NewStrategy(pmFileBatch.Files()), nil
+		return nuget.NewStrategy(pmFileBatch.Files(), options.ExcludedScopes), nil
 	case composer.Name:
-		return composer.NewStrategy(pmFileBatch.Files()), nil
+		return composer.NewStrategy(pmFileBatch.Files(), options.ExcludedScopes), nil
+	case pyproject.Name:
+		return pyproject.NewStrategy(pmFileBatch.Files(), options.ExcludedScopes), nil
+	case pipenv.Name:
+		return pipenv.NewStrategy(pmFileBatch.Files(), options.RegenerateNativeLockFiles), nil
+	case cargo.Name:
+		return cargo.NewStrategy(pmFileBatch.Files(), options.RegenerateNativeLockFiles), nil
+	case bundler.Name:
+		return bundler.NewStrategy(pmFileBatch.Files()), nil
+	case sbt.Name:
+		return sbt.NewStrategy(pmFileBatch.Files(), options.SetupTimeout), nil
+	case swift.Name:
+		return swift.NewStrategy(pmFileBatch.Files(), options.RegenerateNativeLockFiles), nil
+	case cocoapods.Name:
+		return cocoapods.NewStrategy(pmFileBatch.Files(), options.RegenerateNativeLockFiles), nil
 	default:
 		return nil, fmt.Errorf("failed to make strategy from %s", name)
 	}
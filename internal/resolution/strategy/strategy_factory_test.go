@@ -2,16 +2,28 @@ package strategy
 
 import (
 	"testing"
+	"time"
 
 	"github.com/debricked/cli/internal/resolution/file"
+	"github.com/debricked/cli/internal/resolution/pm/bower"
+	"github.com/debricked/cli/internal/resolution/pm/bundler"
+	"github.com/debricked/cli/internal/resolution/pm/cargo"
+	"github.com/debricked/cli/internal/resolution/pm/cocoapods"
 	"github.com/debricked/cli/internal/resolution/pm/composer"
 	"github.com/debricked/cli/internal/resolution/pm/gomod"
 	"github.com/debricked/cli/internal/resolution/pm/gradle"
 	"github.com/debricked/cli/internal/resolution/pm/maven"
+	"github.com/debricked/cli/internal/resolution/pm/npm"
 	"github.com/debricked/cli/internal/resolution/pm/nuget"
 	"github.com/debricked/cli/internal/resolution/pm/pip"
+	"github.com/debricked/cli/internal/resolution/pm/pipenv"
+	"github.com/debricked/cli/internal/resolution/pm/pnpm"
+	"github.com/debricked/cli/internal/resolution/pm/pyproject"
+	"github.com/debricked/cli/internal/resolution/pm/sbt"
+	"github.com/debricked/cli/internal/resolution/pm/swift"
 	"github.com/debricked/cli/internal/resolution/pm/testdata"
 	"github.com/debricked/cli/internal/resolution/pm/yarn"
+	"github.com/debricked/cli/internal/resolution/scope"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -23,27 +35,122 @@ func TestNewStrategyFactory(t *testing.T) {
 func TestMakeErr(t *testing.T) {
 	f := NewStrategyFactory()
 	batch := file.NewBatch(testdata.PmMock{N: "test"})
-	s, err := f.Make(batch, nil)
+	s, err := f.Make(batch, nil, Options{})
 	assert.Nil(t, s)
 	assert.ErrorContains(t, err, "failed to make strategy from test")
 }
 
 func TestMake(t *testing.T) {
 	cases := map[string]IStrategy{
-		maven.Name:    maven.NewStrategy(nil),
-		gradle.Name:   gradle.NewStrategy(nil, nil),
-		gomod.Name:    gomod.NewStrategy(nil),
-		pip.Name:      pip.NewStrategy(nil),
-		yarn.Name:     yarn.NewStrategy(nil),
-		nuget.Name:    nuget.NewStrategy(nil),
-		composer.Name: composer.NewStrategy(nil),
+		maven.Name:     maven.NewStrategy(nil, nil),
+		gradle.Name:    gradle.NewStrategy(nil, nil, nil, 0),
+		gomod.Name:     gomod.NewStrategy(nil),
+		pip.Name:       pip.NewStrategy(nil, false, nil),
+		yarn.Name:      yarn.NewStrategy(nil, false, nil),
+		nuget.Name:     nuget.NewStrategy(nil, nil),
+		composer.Name:  composer.NewStrategy(nil, nil),
+		pyproject.Name: pyproject.NewStrategy(nil, nil),
+		pipenv.Name:    pipenv.NewStrategy(nil, false),
+		cargo.Name:     cargo.NewStrategy(nil, false),
+		bundler.Name:   bundler.NewStrategy(nil),
+		pnpm.Name:      pnpm.NewStrategy(nil),
+		sbt.Name:       sbt.NewStrategy(nil, 0),
+		swift.Name:     swift.NewStrategy(nil, false),
+		cocoapods.Name: cocoapods.NewStrategy(nil, false),
 	}
 	f := NewStrategyFactory()
 	var batch file.IBatch
 	for name, strategy := range cases {
 		batch = file.NewBatch(testdata.PmMock{N: name})
 		t.Run(name, func(t *testing.T) {
-			s, err := f.Make(batch, nil)
+			s, err := f.Make(batch, nil, Options{})
+			assert.NoError(t, err)
+			assert.Equal(t, strategy, s)
+		})
+	}
+}
+
+func TestMakeLockfileOnly(t *testing.T) {
+	cases := map[string]IStrategy{
+		pip.Name:   pip.NewStrategy(nil, true, nil),
+		yarn.Name:  yarn.NewStrategy(nil, true, nil),
+		npm.Name:   npm.NewStrategy(nil, true, nil),
+		bower.Name: bower.NewStrategy(nil, true),
+	}
+	f := NewStrategyFactory()
+	var batch file.IBatch
+	for name, strategy := range cases {
+		batch = file.NewBatch(testdata.PmMock{N: name})
+		t.Run(name, func(t *testing.T) {
+			s, err := f.Make(batch, nil, Options{LockfileOnly: true})
+			assert.NoError(t, err)
+			assert.Equal(t, strategy, s)
+		})
+	}
+}
+
+func TestMakeScratchDir(t *testing.T) {
+	f := NewStrategyFactory()
+	batch := file.NewBatch(testdata.PmMock{N: gradle.Name})
+	s, err := f.Make(batch, nil, Options{ScratchDir: "scratch"})
+	assert.NoError(t, err)
+	assert.Equal(t, gradle.NewIsolatedStrategy(nil, nil, "scratch", nil, 0), s)
+}
+
+func TestMakeExcludedScopes(t *testing.T) {
+	excludedScopes := scope.Scopes{scope.Dev, scope.Test}
+	cases := map[string]IStrategy{
+		maven.Name:     maven.NewStrategy(nil, excludedScopes),
+		gradle.Name:    gradle.NewStrategy(nil, nil, excludedScopes, 0),
+		pip.Name:       pip.NewStrategy(nil, false, excludedScopes),
+		yarn.Name:      yarn.NewStrategy(nil, false, excludedScopes),
+		npm.Name:       npm.NewStrategy(nil, false, excludedScopes),
+		nuget.Name:     nuget.NewStrategy(nil, excludedScopes),
+		composer.Name:  composer.NewStrategy(nil, excludedScopes),
+		pyproject.Name: pyproject.NewStrategy(nil, excludedScopes),
+	}
+	f := NewStrategyFactory()
+	var batch file.IBatch
+	for name, strategy := range cases {
+		batch = file.NewBatch(testdata.PmMock{N: name})
+		t.Run(name, func(t *testing.T) {
+			s, err := f.Make(batch, nil, Options{ExcludedScopes: excludedScopes})
+			assert.NoError(t, err)
+			assert.Equal(t, strategy, s)
+		})
+	}
+}
+
+func TestMakeSetupTimeout(t *testing.T) {
+	cases := map[string]IStrategy{
+		gradle.Name: gradle.NewStrategy(nil, nil, nil, time.Minute),
+		sbt.Name:    sbt.NewStrategy(nil, time.Minute),
+	}
+	f := NewStrategyFactory()
+	var batch file.IBatch
+	for name, strategy := range cases {
+		batch = file.NewBatch(testdata.PmMock{N: name})
+		t.Run(name, func(t *testing.T) {
+			s, err := f.Make(batch, nil, Options{SetupTimeout: time.Minute})
+			assert.NoError(t, err)
+			assert.Equal(t, strategy, s)
+		})
+	}
+}
+
+func TestMakeRegenerateNativeLockFiles(t *testing.T) {
+	cases := map[string]IStrategy{
+		pipenv.Name:    pipenv.NewStrategy(nil, true),
+		swift.Name:     swift.NewStrategy(nil, true),
+		cocoapods.Name: cocoapods.NewStrategy(nil, true),
+		cargo.Name:     cargo.NewStrategy(nil, true),
+	}
+	f := NewStrategyFactory()
+	var batch file.IBatch
+	for name, strategy := range cases {
+		batch = file.NewBatch(testdata.PmMock{N: name})
+		t.Run(name, func(t *testing.T) {
+			s, err := f.Make(batch, nil, Options{RegenerateNativeLockFiles: true})
 			assert.NoError(t, err)
 			assert.Equal(t, strategy, s)
 		})
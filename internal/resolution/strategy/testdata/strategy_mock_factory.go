@@ -11,7 +11,7 @@ func NewStrategyFactoryMock() FactoryMock {
 	return FactoryMock{}
 }
 
-func (sf FactoryMock) Make(pmFileBatch file.IBatch, paths []string) (strategy.IStrategy, error) {
+func (sf FactoryMock) Make(pmFileBatch file.IBatch, _ []string, _ strategy.Options) (strategy.IStrategy, error) {
 
 	return NewStrategyMock(pmFileBatch.Files()), nil
 }
@@ -22,7 +22,7 @@ func NewStrategyFactoryErrorMock() FactoryErrorMock {
 	return FactoryErrorMock{}
 }
 
-func (sf FactoryErrorMock) Make(pmFileBatch file.IBatch, paths []string) (strategy.IStrategy, error) {
+func (sf FactoryErrorMock) Make(pmFileBatch file.IBatch, _ []string, _ strategy.Options) (strategy.IStrategy, error) {
 
 	return NewStrategyErrorMock(pmFileBatch.Files()), nil
 }
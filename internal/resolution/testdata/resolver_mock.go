@@ -11,6 +11,7 @@ import (
 type ResolverMock struct {
 	Err   error
 	files []string
+	plan  resolution.Plan
 }
 
 func (r *ResolverMock) SetNpmPreferred(_ bool) {
@@ -32,6 +33,14 @@ func (r *ResolverMock) Resolve(_ []string, _ resolution.IOptions) (resolution.IR
 	return resolution.NewResolution([]job.IJob{}), r.Err
 }
 
+func (r *ResolverMock) Plan(_ []string, _ resolution.IOptions) (resolution.Plan, error) {
+	return r.plan, r.Err
+}
+
+func (r *ResolverMock) SetPlan(plan resolution.Plan) {
+	r.plan = plan
+}
+
 func (r *ResolverMock) SetFiles(files []string) {
 	r.files = files
 }
@@ -0,0 +1,272 @@
+package toolchain
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+
+	internalOs "github.com/debricked/cli/internal/runtime/os"
+)
+
+const ConfiguredSource = "--toolchain"
+
+var (
+	pythonMinorVersionRegex = regexp.MustCompile(`^(\d+\.\d+)`)
+	goToolchainRegex        = regexp.MustCompile(`(?m)^toolchain\s+go(\S+)`)
+)
+
+// Selector selects the toolchains of manifest files, preferring configured executables over version files
+type Selector struct {
+	executables map[string]string
+	lookPath    func(file string) (string, error)
+}
+
+// NewSelector makes a selector of toolchains, with the executables configured by toolchain name
+func NewSelector(executables map[string]string) (Selector, error) {
+	for name, executable := range executables {
+		if _, ok := commands[name]; !ok {
+			return Selector{}, fmt.Errorf("invalid toolchain %s, use one of %s", name, strings.Join(Names(), ", "))
+		}
+		if _, err := os.Stat(executable); err != nil {
+			return Selector{}, fmt.Errorf("invalid executable of toolchain %s: %w", name, err)
+		}
+	}
+
+	return Selector{executables: executables, lookPath: exec.LookPath}, nil
+}
+
+// Names returns the names of the toolchains that can be configured
+func Names() []string {
+	var names []string
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Select returns the toolchain of the manifest file, or false if neither an executable is configured
+// nor a version file or wrapper is found
+func (s Selector) Select(name string, manifest string) (Toolchain, bool) {
+	if executable, ok := s.executables[name]; ok {
+		if absExecutable, err := filepath.Abs(executable); err == nil {
+			executable = absExecutable
+		}
+
+		return Toolchain{Name: name, Executable: executable, Source: ConfiguredSource}, true
+	}
+
+	dir := filepath.Dir(manifest)
+	switch name {
+	case Python:
+		return s.selectPython(dir)
+	case Node:
+		return s.selectNode(dir)
+	case Dotnet:
+		return selectDotnet(dir)
+	case Go:
+		return selectGo(manifest)
+	case Maven:
+		wrapper := FindMavenWrapper(dir)
+
+		return Toolchain{Name: name, Executable: wrapper, Source: filepath.Base(wrapper)}, len(wrapper) > 0
+	default:
+		return Toolchain{}, false
+	}
+}
+
+// selectPython selects the Python of .python-version, installed by pyenv or in PATH as pythonX.Y. Without
+// such a Python, the version is still recorded, since pyenv shims select it by the version file themselves
+func (s Selector) selectPython(dir string) (Toolchain, bool) {
+	versionFile, version := findVersionFile(dir, ".python-version")
+	if len(versionFile) == 0 {
+		return Toolchain{}, false
+	}
+	toolchain := Toolchain{Name: Python, Version: version, Source: versionFile}
+	pyenvRoot := os.Getenv("PYENV_ROOT")
+	if len(pyenvRoot) == 0 {
+		if home, err := os.UserHomeDir(); err == nil {
+			pyenvRoot = filepath.Join(home, ".pyenv")
+		}
+	}
+	binDir, python := "bin", "python"
+	if runtime.GOOS == internalOs.Windows {
+		binDir, python = "", "python.exe"
+	}
+	if executable := filepath.Join(pyenvRoot, "versions", version, binDir, python); isFile(executable) {
+		toolchain.Executable = executable
+
+		return toolchain, true
+	}
+	if match := pythonMinorVersionRegex.FindStringSubmatch(version); match != nil {
+		if executable, err := s.lookPath("python" + match[1]); err == nil {
+			toolchain.Executable = executable
+		}
+	}
+
+	return toolchain, true
+}
+
+// selectNode selects the Node.js of .nvmrc or .node-version, using the latest installation by nvm
+// matching the version
+func (s Selector) selectNode(dir string) (Toolchain, bool) {
+	versionFile, version := findVersionFile(dir, ".nvmrc", ".node-version")
+	if len(versionFile) == 0 {
+		return Toolchain{}, false
+	}
+	toolchain := Toolchain{Name: Node, Version: version, Source: versionFile}
+	nvmDir := os.Getenv("NVM_DIR")
+	if len(nvmDir) == 0 {
+		if home, err := os.UserHomeDir(); err == nil {
+			nvmDir = filepath.Join(home, ".nvm")
+		}
+	}
+	installations, err := os.ReadDir(filepath.Join(nvmDir, "versions", "node"))
+	if err != nil {
+		return toolchain, true
+	}
+	prefix := "v" + strings.TrimPrefix(version, "v")
+	var latest string
+	for _, installation := range installations {
+		name := installation.Name()
+		if name != prefix && !strings.HasPrefix(name, prefix+".") {
+			continue
+		}
+		if len(latest) == 0 || compareVersions(name, latest) > 0 {
+			latest = name
+		}
+	}
+	if executable := filepath.Join(nvmDir, "versions", "node", latest, "bin", "node"); len(latest) > 0 && isFile(executable) {
+		toolchain.Executable = executable
+	}
+
+	return toolchain, true
+}
+
+// selectDotnet records the SDK version of global.json, which the dotnet executable selects by itself
+func selectDotnet(dir string) (Toolchain, bool) {
+	globalJson, _ := findVersionFile(dir, "global.json")
+	if len(globalJson) == 0 {
+		return Toolchain{}, false
+	}
+	var content struct {
+		Sdk struct {
+			Version string `json:"version"`
+		} `json:"sdk"`
+	}
+	rawContent, err := os.ReadFile(globalJson)
+	if err != nil || json.Unmarshal(rawContent, &content) != nil || len(content.Sdk.Version) == 0 {
+		return Toolchain{}, false
+	}
+
+	return Toolchain{Name: Dotnet, Version: content.Sdk.Version, Source: globalJson}, true
+}
+
+// selectGo records the toolchain directive of go.mod or go.work, which the go command selects by itself
+func selectGo(manifest string) (Toolchain, bool) {
+	content, err := os.ReadFile(manifest)
+	if err != nil {
+		return Toolchain{}, false
+	}
+	match := goToolchainRegex.FindSubmatch(content)
+	if match == nil {
+		return Toolchain{}, false
+	}
+
+	return Toolchain{Name: Go, Version: string(match[1]), Source: manifest}, true
+}
+
+// FindMavenWrapper returns the path of the Maven wrapper of the project in dir, searching the parent directories
+// of modules, or an empty string if there is none
+func FindMavenWrapper(dir string) string {
+	wrapper := "mvnw"
+	if runtime.GOOS == internalOs.Windows {
+		wrapper = "mvnw.cmd"
+	}
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return ""
+	}
+	for {
+		if path := filepath.Join(dir, wrapper); isFile(path) {
+			return path
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir || !isFile(filepath.Join(parent, "pom.xml")) {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// findVersionFile returns the path and the first line of the closest of the files in dir or its parent directories,
+// up to the root of the repository
+func findVersionFile(dir string, names ...string) (string, string) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", ""
+	}
+	for {
+		for _, name := range names {
+			path := filepath.Join(dir, name)
+			if isFile(path) {
+				return path, readFirstLine(path)
+			}
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", ""
+		}
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return "", ""
+		}
+		dir = parent
+	}
+}
+
+func readFirstLine(path string) string {
+	file, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer file.Close()
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) > 0 && !strings.HasPrefix(line, "#") {
+			return line
+		}
+	}
+
+	return ""
+}
+
+func isFile(path string) bool {
+	info, err := os.Stat(path)
+
+	return err == nil && !info.IsDir()
+}
+
+// compareVersions compares the dot separated numbers of versions such as v18.17.1
+func compareVersions(a string, b string) int {
+	aParts := strings.Split(strings.TrimPrefix(a, "v"), ".")
+	bParts := strings.Split(strings.TrimPrefix(b, "v"), ".")
+	for i := 0; i < len(aParts) && i < len(bParts); i++ {
+		aNumber, _ := strconv.Atoi(aParts[i])
+		bNumber, _ := strconv.Atoi(bParts[i])
+		if aNumber != bNumber {
+			return aNumber - bNumber
+		}
+	}
+
+	return len(aParts) - len(bParts)
+}
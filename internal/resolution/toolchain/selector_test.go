@@ -0,0 +1,183 @@
+package toolchain
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeFile(t *testing.T, path string, content string) {
+	t.Helper()
+	assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0600))
+}
+
+func TestNewSelector(t *testing.T) {
+	executable := filepath.Join(t.TempDir(), "python")
+	writeFile(t, executable, "")
+
+	_, err := NewSelector(map[string]string{Python: executable})
+	assert.NoError(t, err)
+
+	_, err = NewSelector(map[string]string{"ruby": executable})
+	assert.EqualError(t, err, "invalid toolchain ruby, use one of dotnet, go, mvn, node, python")
+
+	_, err = NewSelector(map[string]string{Python: filepath.Join(t.TempDir(), "python")})
+	assert.ErrorContains(t, err, "invalid executable of toolchain python")
+}
+
+func TestSelectConfigured(t *testing.T) {
+	executable := filepath.Join(t.TempDir(), "python")
+	writeFile(t, executable, "")
+	selector, err := NewSelector(map[string]string{Python: executable})
+	assert.NoError(t, err)
+
+	toolchain, ok := selector.Select(Python, "requirements.txt")
+
+	assert.True(t, ok)
+	assert.Equal(t, Toolchain{Name: Python, Executable: executable, Source: ConfiguredSource}, toolchain)
+}
+
+func TestSelectNone(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, ".git", "HEAD"), "")
+	selector, _ := NewSelector(nil)
+
+	for _, name := range Names() {
+		_, ok := selector.Select(name, filepath.Join(dir, "manifest"))
+		assert.False(t, ok, name)
+	}
+}
+
+func TestSelectPythonPyenv(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("pyenv-win installs python.exe")
+	}
+	pyenvRoot := t.TempDir()
+	t.Setenv("PYENV_ROOT", pyenvRoot)
+	python := filepath.Join(pyenvRoot, "versions", "3.11.4", "bin", "python")
+	writeFile(t, python, "")
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, ".python-version"), "3.11.4\n")
+	selector, _ := NewSelector(nil)
+
+	toolchain, ok := selector.Select(Python, filepath.Join(dir, "sub", "requirements.txt"))
+
+	assert.True(t, ok)
+	assert.Equal(t, Toolchain{
+		Name:       Python,
+		Executable: python,
+		Version:    "3.11.4",
+		Source:     filepath.Join(dir, ".python-version"),
+	}, toolchain)
+}
+
+func TestSelectPythonPath(t *testing.T) {
+	t.Setenv("PYENV_ROOT", t.TempDir())
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, ".python-version"), "# comment\n3.11\n")
+	selector := Selector{lookPath: func(file string) (string, error) {
+		if file == "python3.11" {
+			return "/usr/bin/python3.11", nil
+		}
+
+		return "", errors.New("executable file not found in $PATH")
+	}}
+
+	toolchain, ok := selector.Select(Python, filepath.Join(dir, "requirements.txt"))
+	assert.True(t, ok)
+	assert.Equal(t, "/usr/bin/python3.11", toolchain.Executable)
+	assert.Equal(t, "3.11", toolchain.Version)
+
+	writeFile(t, filepath.Join(dir, ".python-version"), "3.12\n")
+	toolchain, ok = selector.Select(Python, filepath.Join(dir, "requirements.txt"))
+	assert.True(t, ok)
+	assert.Empty(t, toolchain.Executable)
+	assert.Equal(t, "3.12", toolchain.Version)
+}
+
+func TestSelectNodeNvm(t *testing.T) {
+	nvmDir := t.TempDir()
+	t.Setenv("NVM_DIR", nvmDir)
+	for _, version := range []string{"v16.20.2", "v18.9.0", "v18.17.1", "v180.0.0"} {
+		writeFile(t, filepath.Join(nvmDir, "versions", "node", version, "bin", "node"), "")
+	}
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, ".nvmrc"), "v18\n")
+	selector, _ := NewSelector(nil)
+
+	toolchain, ok := selector.Select(Node, filepath.Join(dir, "package.json"))
+
+	assert.True(t, ok)
+	assert.Equal(t, filepath.Join(nvmDir, "versions", "node", "v18.17.1", "bin", "node"), toolchain.Executable)
+	assert.Equal(t, "v18", toolchain.Version)
+}
+
+func TestSelectNodeVersionFile(t *testing.T) {
+	t.Setenv("NVM_DIR", t.TempDir())
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, ".node-version"), "20.9.0")
+	selector, _ := NewSelector(nil)
+
+	toolchain, ok := selector.Select(Node, filepath.Join(dir, "package.json"))
+
+	assert.True(t, ok)
+	assert.Equal(t, Toolchain{Name: Node, Version: "20.9.0", Source: filepath.Join(dir, ".node-version")}, toolchain)
+}
+
+func TestSelectDotnet(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "global.json"), `{"sdk": {"version": "8.0.100", "rollForward": "latestFeature"}}`)
+	selector, _ := NewSelector(nil)
+
+	toolchain, ok := selector.Select(Dotnet, filepath.Join(dir, "src", "app.csproj"))
+
+	assert.True(t, ok)
+	assert.Equal(t, Toolchain{Name: Dotnet, Version: "8.0.100", Source: filepath.Join(dir, "global.json")}, toolchain)
+}
+
+func TestSelectGo(t *testing.T) {
+	goMod := filepath.Join(t.TempDir(), "go.mod")
+	writeFile(t, goMod, "module example.com/app\n\ngo 1.21\n\ntoolchain go1.21.3\n")
+	selector, _ := NewSelector(nil)
+
+	toolchain, ok := selector.Select(Go, goMod)
+
+	assert.True(t, ok)
+	assert.Equal(t, Toolchain{Name: Go, Version: "1.21.3", Source: goMod}, toolchain)
+}
+
+func TestSelectMavenWrapper(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the wrapper of Windows is mvnw.cmd")
+	}
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "pom.xml"), "<project/>")
+	writeFile(t, filepath.Join(dir, "mvnw"), "")
+	writeFile(t, filepath.Join(dir, "module", "pom.xml"), "<project/>")
+	selector, _ := NewSelector(nil)
+
+	toolchain, ok := selector.Select(Maven, filepath.Join(dir, "module", "pom.xml"))
+
+	assert.True(t, ok)
+	assert.Equal(t, Toolchain{Name: Maven, Executable: filepath.Join(dir, "mvnw"), Source: "mvnw"}, toolchain)
+}
+
+func TestFindMavenWrapperOutsideProject(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "mvnw"), "")
+	writeFile(t, filepath.Join(dir, "project", "pom.xml"), "<project/>")
+
+	assert.Empty(t, FindMavenWrapper(filepath.Join(dir, "project")))
+}
+
+func TestCompareVersions(t *testing.T) {
+	assert.Positive(t, compareVersions("v18.17.1", "v18.9.0"))
+	assert.Negative(t, compareVersions("v16.20.2", "v18.9.0"))
+	assert.Zero(t, compareVersions("v18.9.0", "v18.9.0"))
+	assert.Positive(t, compareVersions("v18.9.0", "v18.9"))
+}
@@ -0,0 +1,113 @@
+package toolchain
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	Python = "python"
+	Node   = "node"
+	Dotnet = "dotnet"
+	Go     = "go"
+	Maven  = "mvn"
+)
+
+// commands are the commands run by jobs that are replaced by the executable of each toolchain
+var commands = map[string][]string{
+	Python: {"python3", "python"},
+	Node:   {"node"},
+	Dotnet: {"dotnet"},
+	Go:     {"go"},
+	Maven:  {"mvn", "mvnw"},
+}
+
+// Toolchain is the executable a job runs its commands with, selected by configuration, a version file or a wrapper
+type Toolchain struct {
+	Name string
+	// Executable is the path of the executable, or empty if the executable found in PATH is used
+	Executable string
+	// Version is the version requested by the version file, if any
+	Version string
+	// Source is the version file or wrapper the toolchain was selected by, or the flag configuring it
+	Source string
+}
+
+func (t Toolchain) String() string {
+	name := t.Name
+	if len(t.Version) > 0 {
+		name += " " + t.Version
+	}
+	var details []string
+	if len(t.Executable) > 0 {
+		details = append(details, t.Executable)
+	}
+	if len(t.Source) > 0 {
+		details = append(details, "from "+t.Source)
+	}
+	if len(details) == 0 {
+		return name
+	}
+
+	return fmt.Sprintf("%s (%s)", name, strings.Join(details, " "))
+}
+
+// Replaces returns the executable of the toolchain if it replaces the command, which is the name of the executable
+// of a command made by a command factory
+func (t Toolchain) Replaces(command string) (string, bool) {
+	if len(t.Executable) == 0 {
+		return "", false
+	}
+	command = strings.TrimSuffix(filepath.Base(command), ".exe")
+	for _, replaced := range commands[t.Name] {
+		if command == replaced {
+			return t.Executable, true
+		}
+	}
+	for _, sibling := range siblingCommands[t.Name] {
+		if command == sibling {
+			if path, ok := findSibling(t.Executable, sibling); ok {
+				return path, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// Env returns the environment of commands using the toolchain, with the directory of the executable first in PATH,
+// so that scripts such as npm run the node of the toolchain
+func (t Toolchain) Env() []string {
+	if len(t.Executable) == 0 {
+		return nil
+	}
+	env := []string{"PATH=" + filepath.Dir(t.Executable) + string(os.PathListSeparator) + os.Getenv("PATH")}
+	if t.Name == Python {
+		env = append(env, "PIPENV_PYTHON="+t.Executable)
+	}
+
+	return env
+}
+
+// siblingCommands are commands installed next to the executable of a toolchain, such as npm next to node,
+// which are replaced by the sibling of the executable if there is one
+var siblingCommands = map[string][]string{
+	Node: {"npm", "npx", "yarn", "pnpm", "corepack"},
+}
+
+func findSibling(executable string, command string) (string, bool) {
+	candidates := []string{command}
+	if filepath.Ext(executable) == ".exe" {
+		candidates = []string{command + ".cmd", command + ".exe"}
+	}
+	for _, candidate := range candidates {
+		path := filepath.Join(filepath.Dir(executable), candidate)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path, true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,74 @@
+package toolchain
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestString(t *testing.T) {
+	cases := []struct {
+		toolchain Toolchain
+		expected  string
+	}{
+		{Toolchain{Name: Go}, "go"},
+		{Toolchain{Name: Go, Version: "1.21.3", Source: "go.mod"}, "go 1.21.3 (from go.mod)"},
+		{Toolchain{Name: Python, Executable: "/opt/py311/bin/python", Source: ConfiguredSource}, "python (/opt/py311/bin/python from --toolchain)"},
+		{
+			Toolchain{Name: Python, Version: "3.11", Executable: "/usr/bin/python3.11", Source: ".python-version"},
+			"python 3.11 (/usr/bin/python3.11 from .python-version)",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.expected, func(t *testing.T) {
+			assert.Equal(t, c.expected, c.toolchain.String())
+		})
+	}
+}
+
+func TestReplaces(t *testing.T) {
+	python := Toolchain{Name: Python, Executable: "/opt/py311/bin/python"}
+	for _, command := range []string{"python3", "python", "/usr/bin/python3", "python.exe"} {
+		executable, ok := python.Replaces(command)
+		assert.True(t, ok, command)
+		assert.Equal(t, "/opt/py311/bin/python", executable)
+	}
+	_, ok := python.Replaces("pip")
+	assert.False(t, ok)
+
+	_, ok = Toolchain{Name: Python, Version: "3.11"}.Replaces("python3")
+	assert.False(t, ok)
+}
+
+func TestReplacesSibling(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("siblings of Windows are .cmd files")
+	}
+	binDir := t.TempDir()
+	node := filepath.Join(binDir, "node")
+	npm := filepath.Join(binDir, "npm")
+	assert.NoError(t, os.WriteFile(node, nil, 0600))
+	assert.NoError(t, os.WriteFile(npm, nil, 0600))
+	toolchain := Toolchain{Name: Node, Executable: node}
+
+	executable, ok := toolchain.Replaces("npm")
+	assert.True(t, ok)
+	assert.Equal(t, npm, executable)
+	_, ok = toolchain.Replaces("yarn")
+	assert.False(t, ok)
+}
+
+func TestEnv(t *testing.T) {
+	t.Setenv("PATH", "path")
+	assert.Nil(t, Toolchain{Name: Go, Version: "1.21.3"}.Env())
+
+	executable := filepath.Join("opt", "py311", "bin", "python")
+	env := Toolchain{Name: Python, Executable: executable}.Env()
+	assert.Equal(t, []string{
+		"PATH=" + filepath.Join("opt", "py311", "bin") + string(os.PathListSeparator) + "path",
+		"PIPENV_PYTHON=" + executable,
+	}, env)
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/debricked/cli/internal/callgraph"
 	"github.com/debricked/cli/internal/callgraph/config"
@@ -15,6 +16,7 @@ import (
 	"github.com/debricked/cli/internal/file"
 	"github.com/debricked/cli/internal/fingerprint"
 	"github.com/debricked/cli/internal/git"
+	"github.com/debricked/cli/internal/image"
 	"github.com/debricked/cli/internal/resolution"
 	"github.com/debricked/cli/internal/tui"
 	"github.com/debricked/cli/internal/upload"
@@ -22,8 +24,9 @@ import (
 )
 
 var (
-	BadOptsErr      = errors.New("failed to type case IOptions")
-	FailPipelineErr = errors.New("")
+	BadOptsErr           = errors.New("failed to type case IOptions")
+	FailPipelineErr      = errors.New("")
+	ImageChangedSinceErr = errors.New("'image' and 'changed-since' flags are mutually exclusive")
 )
 
 type IScanner interface {
@@ -50,6 +53,10 @@ type DebrickedOptions struct {
 	Exclusions               []string
 	Verbose                  bool
 	Regenerate               int
+	ChangedSince             string
+	Image                    string
+	OsPackages               bool
+	GoBinaries               bool
 	RepositoryName           string
 	CommitName               string
 	BranchName               string
@@ -57,6 +64,20 @@ type DebrickedOptions struct {
 	RepositoryUrl            string
 	IntegrationName          string
 	NpmPreferred             bool
+	JsPackageManager         string
+	LockfileOnly             bool
+	ExcludeScopes            []string
+	ProductionOnly           bool
+	Cache                    bool
+	CacheDir                 string
+	ResolutionTimeout        time.Duration
+	PmResolutionTimeouts     map[string]time.Duration
+	ReportJson               string
+	ReportJunit              string
+	RegistriesConfig         string
+	Toolchains               map[string]string
+	Isolated                 bool
+	LockOutputDir            string
 	PassOnTimeOut            bool
 	WriteToJson              bool
 	CallGraphUploadTimeout   int
@@ -93,6 +114,13 @@ func (dScanner *DebrickedScanner) Scan(o IOptions) error {
 
 	MapEnvToOptions(&dOptions, e)
 
+	if len(dOptions.Image) > 0 {
+		dOptions.Image, _ = filepath.Abs(dOptions.Image)
+	}
+	if len(dOptions.LockOutputDir) > 0 {
+		dOptions.LockOutputDir, _ = filepath.Abs(dOptions.LockOutputDir)
+	}
+
 	if err := SetWorkingDirectory(&dOptions); err != nil {
 		return err
 	}
@@ -109,6 +137,14 @@ func (dScanner *DebrickedScanner) Scan(o IOptions) error {
 		return err
 	}
 
+	if len(dOptions.Image) > 0 {
+		restore, err := SetImageWorkingDirectory(&dOptions)
+		if err != nil {
+			return err
+		}
+		defer restore()
+	}
+
 	result, err := dScanner.scan(dOptions, *gitMetaObject)
 	if err != nil {
 		return dScanner.handleScanError(err, dOptions.PassOnTimeOut)
@@ -140,13 +176,31 @@ func (dScanner *DebrickedScanner) Scan(o IOptions) error {
 
 func (dScanner *DebrickedScanner) scanResolve(options DebrickedOptions) error {
 	resolveOptions := resolution.DebrickedOptions{
-		Path:         options.Path,
-		Verbose:      options.Verbose,
-		Regenerate:   options.Regenerate,
-		Exclusions:   options.Exclusions,
-		NpmPreferred: options.NpmPreferred,
+		Path:                 options.Path,
+		Verbose:              options.Verbose,
+		Regenerate:           options.Regenerate,
+		ChangedSince:         options.ChangedSince,
+		Exclusions:           options.Exclusions,
+		NpmPreferred:         options.NpmPreferred,
+		JsPackageManager:     options.JsPackageManager,
+		LockfileOnly:         options.LockfileOnly,
+		ExcludeScopes:        options.ExcludeScopes,
+		ProductionOnly:       options.ProductionOnly,
+		Cache:                options.Cache,
+		CacheDir:             options.CacheDir,
+		ResolutionTimeout:    options.ResolutionTimeout,
+		PmResolutionTimeouts: options.PmResolutionTimeouts,
+		ReportJson:           options.ReportJson,
+		ReportJunit:          options.ReportJunit,
+		RegistriesConfig:     options.RegistriesConfig,
+		Toolchains:           options.Toolchains,
+		Isolated:             options.Isolated,
+		LockOutputDir:        options.LockOutputDir,
+		OsPackages:           options.OsPackages,
+		GoBinaries:           options.GoBinaries,
 	}
-	if options.Resolve {
+	if options.Resolve || options.OsPackages || options.GoBinaries {
+		resolveOptions.SkipManifests = !options.Resolve
 		_, resErr := dScanner.resolver.Resolve([]string{options.Path}, resolveOptions)
 		if resErr != nil {
 			return resErr
@@ -197,16 +251,24 @@ func (dScanner *DebrickedScanner) scan(options DebrickedOptions, gitMetaObject g
 		}
 	}
 
-	fileGroups, err := dScanner.finder.GetGroups(options.Path, options.Exclusions, false, file.StrictAll)
+	fileGroups, err := dScanner.finder.GetGroups(options.Path, options.Exclusions, false, file.StrictAll, options.LockOutputDir)
 	if err != nil {
 		return nil, err
 	}
+	if len(options.ChangedSince) > 0 {
+		changedFiles, err := git.FindChangedFilesSince(options.Path, options.ChangedSince)
+		if err != nil {
+			return nil, err
+		}
+		fileGroups.FilterGroupsByChangedFiles(changedFiles)
+	}
 
 	uploaderOptions := upload.DebrickedOptions{
 		FileGroups:             fileGroups,
 		GitMetaObject:          gitMetaObject,
 		IntegrationsName:       options.IntegrationName,
 		CallGraphUploadTimeout: options.CallGraphUploadTimeout,
+		LockOutputDir:          options.LockOutputDir,
 	}
 	result, err := (*dScanner.uploader).Upload(uploaderOptions)
 	if err != nil {
@@ -239,6 +301,42 @@ func SetWorkingDirectory(d *DebrickedOptions) error {
 	return nil
 }
 
+// SetImageWorkingDirectory flattens the image into a temporary directory and sets it as working directory,
+// so that dependency files are found, fingerprinted and uploaded with their paths inside the image.
+// Resolution and call graph generation are disabled since the image is not built from the working directory,
+// but operating system packages and Go binaries are still collected if enabled.
+// Changed files can't be found in an image, so ImageChangedSinceErr is returned if they are asked for.
+// The returned function restores the working directory and removes the flattened image
+func SetImageWorkingDirectory(d *DebrickedOptions) (func(), error) {
+	if len(d.ChangedSince) > 0 {
+		return nil, ImageChangedSinceErr
+	}
+	workingDirectory, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	root, err := image.FlattenToTempDir(d.Image)
+	if err != nil {
+		return nil, err
+	}
+	restore := func() {
+		_ = os.Chdir(workingDirectory)
+		_ = os.RemoveAll(root)
+	}
+	err = os.Chdir(root)
+	if err != nil {
+		restore()
+
+		return nil, err
+	}
+	d.Path = ""
+	d.Resolve = false
+	d.CallGraph = false
+	fmt.Printf("Scanning image: %s\n", d.Image)
+
+	return restore, nil
+}
+
 func MapEnvToOptions(o *DebrickedOptions, env env.Env) {
 	if len(o.RepositoryName) == 0 {
 		o.RepositoryName = env.Repository
@@ -30,8 +30,11 @@ import (
 	"github.com/debricked/cli/internal/file"
 	"github.com/debricked/cli/internal/fingerprint"
 	"github.com/debricked/cli/internal/git"
+	imageTestdata "github.com/debricked/cli/internal/image/testdata"
 	ioFs "github.com/debricked/cli/internal/io"
 	"github.com/debricked/cli/internal/resolution"
+	resolutionFile "github.com/debricked/cli/internal/resolution/file"
+	"github.com/debricked/cli/internal/resolution/strategy"
 	resolveTestdata "github.com/debricked/cli/internal/resolution/testdata"
 	"github.com/debricked/cli/internal/upload"
 	"github.com/stretchr/testify/assert"
@@ -658,3 +661,145 @@ func TestScanWithFingerprint(t *testing.T) {
 	cwd, _ = os.Getwd()
 	assert.Contains(t, cwd, path)
 }
+
+func TestSetImageWorkingDirectory(t *testing.T) {
+	imagePath := filepath.Join(t.TempDir(), "image.tar")
+	err := imageTestdata.WriteDockerImage(imagePath, map[string]string{"app/package.json": "{}"})
+	assert.NoError(t, err)
+	cwd, _ := os.Getwd()
+	defer resetWd(t, cwd)
+
+	opts := DebrickedOptions{Path: "path", Image: imagePath, Resolve: true, CallGraph: true}
+	restore, err := SetImageWorkingDirectory(&opts)
+
+	assert.NoError(t, err)
+	assert.FileExists(t, filepath.Join("app", "package.json"))
+	assert.Empty(t, opts.Path)
+	assert.False(t, opts.Resolve)
+	assert.False(t, opts.CallGraph)
+
+	root, _ := os.Getwd()
+	restore()
+	newCwd, _ := os.Getwd()
+	assert.Equal(t, cwd, newCwd)
+	assert.NoDirExists(t, root)
+}
+
+func TestSetImageWorkingDirectoryBadImage(t *testing.T) {
+	cwd, _ := os.Getwd()
+	defer resetWd(t, cwd)
+
+	opts := DebrickedOptions{Image: filepath.Join(t.TempDir(), "image.tar")}
+	restore, err := SetImageWorkingDirectory(&opts)
+
+	assert.Error(t, err)
+	assert.Nil(t, restore)
+	newCwd, _ := os.Getwd()
+	assert.Equal(t, cwd, newCwd)
+}
+
+func TestSetImageWorkingDirectoryChangedSince(t *testing.T) {
+	cwd, _ := os.Getwd()
+	defer resetWd(t, cwd)
+
+	opts := DebrickedOptions{Image: filepath.Join(t.TempDir(), "image.tar"), ChangedSince: "origin/main"}
+	restore, err := SetImageWorkingDirectory(&opts)
+
+	assert.ErrorIs(t, err, ImageChangedSinceErr)
+	assert.EqualError(t, err, "'image' and 'changed-since' flags are mutually exclusive")
+	assert.Nil(t, restore)
+	newCwd, _ := os.Getwd()
+	assert.Equal(t, cwd, newCwd)
+}
+
+type optionsRecordingResolver struct {
+	options []resolution.DebrickedOptions
+}
+
+func (r *optionsRecordingResolver) Resolve(_ []string, options resolution.IOptions) (resolution.IResolution, error) {
+	r.options = append(r.options, options.(resolution.DebrickedOptions))
+
+	return resolution.NewResolution(nil), nil
+}
+
+func (r *optionsRecordingResolver) Plan(_ []string, _ resolution.IOptions) (resolution.Plan, error) {
+	return resolution.Plan{}, nil
+}
+
+func TestScanResolveOsPackages(t *testing.T) {
+	cases := []struct {
+		name          string
+		options       DebrickedOptions
+		resolved      bool
+		skipManifests bool
+	}{
+		{name: "Resolve", options: DebrickedOptions{Resolve: true}, resolved: true},
+		{name: "No resolve", options: DebrickedOptions{}},
+		{name: "Resolve and OS packages", options: DebrickedOptions{Resolve: true, OsPackages: true}, resolved: true},
+		{name: "Only OS packages", options: DebrickedOptions{OsPackages: true}, resolved: true, skipManifests: true},
+		{name: "Only Go binaries", options: DebrickedOptions{GoBinaries: true}, resolved: true, skipManifests: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			resolver := &optionsRecordingResolver{}
+			scanner := DebrickedScanner{resolver: resolver}
+
+			err := scanner.scanResolve(c.options)
+
+			assert.NoError(t, err)
+			if !c.resolved {
+				assert.Empty(t, resolver.options)
+
+				return
+			}
+			assert.Len(t, resolver.options, 1)
+			assert.Equal(t, c.options.OsPackages, resolver.options[0].OsPackages)
+			assert.Equal(t, c.options.GoBinaries, resolver.options[0].GoBinaries)
+			assert.Equal(t, c.skipManifests, resolver.options[0].SkipManifests)
+		})
+	}
+}
+
+type recordingUploader struct {
+	options []upload.DebrickedOptions
+}
+
+func (u *recordingUploader) Upload(o upload.IOptions) (*upload.UploadResult, error) {
+	u.options = append(u.options, o.(upload.DebrickedOptions))
+
+	return &upload.UploadResult{}, nil
+}
+
+func TestScanUploadsOsPackageLockFiles(t *testing.T) {
+	dir := t.TempDir()
+	databases := map[string]string{
+		filepath.Join("var", "lib", "dpkg", "status"):  "Package: bash\nStatus: install ok installed\nVersion: 5.2.15-2\n",
+		filepath.Join("lib", "apk", "db", "installed"): "P:musl\nV:1.2.4-r2\n",
+	}
+	for database, content := range databases {
+		assert.NoError(t, os.MkdirAll(filepath.Join(dir, filepath.Dir(database)), 0750))
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, database), []byte(content), 0600))
+	}
+	clientMock := testdata.NewDebClientMock()
+	addMockedFormatsResponse(clientMock, "yarn\\.lock")
+	var debClient client.IDebClient = clientMock
+	finder, _ := file.NewFinder(debClient, ioFs.FileSystem{})
+	resolver := resolution.NewResolver(finder, resolutionFile.NewBatchFactory(), strategy.NewStrategyFactory(), resolution.NewScheduler(1))
+	uploader := &recordingUploader{}
+	var iUploader upload.IUploader = uploader
+	scanner := DebrickedScanner{finder: finder, uploader: &iUploader, resolver: resolver}
+
+	_, err := scanner.scan(DebrickedOptions{Path: dir, OsPackages: true}, git.MetaObject{})
+
+	assert.NoError(t, err)
+	assert.Len(t, uploader.options, 1)
+	var lockFiles []string
+	for _, group := range uploader.options[0].FileGroups.ToSlice() {
+		lockFiles = append(lockFiles, group.LockFiles...)
+	}
+	assert.ElementsMatch(t, []string{
+		filepath.Join(dir, "var", "lib", "dpkg", "dpkg.debricked.lock"),
+		filepath.Join(dir, "lib", "apk", "db", "apk.debricked.lock"),
+	}, lockFiles)
+}
@@ -0,0 +1,46 @@
+package tui
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/debricked/cli/internal/resolution/drift"
+	"github.com/fatih/color"
+)
+
+const (
+	driftTitle = "Stale lock files"
+)
+
+type DriftList struct {
+	mirror io.Writer
+	drifts []drift.Drift
+}
+
+func NewDriftList(mirror io.Writer, drifts []drift.Drift) DriftList {
+	return DriftList{mirror: mirror, drifts: drifts}
+}
+
+func (driftList DriftList) Render() error {
+	var listBuffer bytes.Buffer
+
+	formattedTitle := fmt.Sprintf("%s\n", color.BlueString(driftTitle))
+	underlining := fmt.Sprintf(strings.Repeat("-", len(driftTitle)+1) + "\n")
+	listBuffer.Write([]byte(formattedTitle))
+	listBuffer.Write([]byte(underlining))
+
+	for _, d := range driftList.drifts {
+		listBuffer.Write([]byte(fmt.Sprintf(
+			"%s\n* stale relative to %s: %s\n",
+			color.YellowString(d.LockFile),
+			d.ManifestFile,
+			d.Reason,
+		)))
+	}
+
+	_, err := driftList.mirror.Write(listBuffer.Bytes())
+
+	return err
+}
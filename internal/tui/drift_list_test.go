@@ -0,0 +1,25 @@
+package tui
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/debricked/cli/internal/resolution/drift"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderDriftList(t *testing.T) {
+	var listBuffer bytes.Buffer
+	drifts := []drift.Drift{
+		{ManifestFile: "package.json", LockFile: "yarn.lock", Reason: "dependencies lodash@^4.17.21 isn't locked"},
+	}
+	driftList := NewDriftList(&listBuffer, drifts)
+
+	err := driftList.Render()
+
+	assert.NoError(t, err)
+	output := listBuffer.String()
+	assert.Contains(t, output, "Stale lock files")
+	assert.Contains(t, output, "yarn.lock")
+	assert.Contains(t, output, "* stale relative to package.json: dependencies lodash@^4.17.21 isn't locked")
+}
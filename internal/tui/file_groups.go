@@ -0,0 +1,104 @@
+package tui
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/debricked/cli/internal/file"
+	"github.com/jedib0t/go-pretty/v6/table"
+)
+
+type FileGroupList struct {
+	mirror io.Writer
+	groups []file.Group
+}
+
+func NewFileGroupList(mirror io.Writer, groups []file.Group) FileGroupList {
+	return FileGroupList{mirror: mirror, groups: groups}
+}
+
+// RenderCsv renders one row per lock file, or one row for manifest files lacking lock files
+func (l FileGroupList) RenderCsv() error {
+	writer := csv.NewWriter(l.mirror)
+	err := writer.Write([]string{"ecosystem", "manifestFile", "lockFile", "generated", "documentationUrl"})
+	if err != nil {
+		return err
+	}
+	for _, group := range l.groups {
+		if !group.HasLockFiles() {
+			err = writer.Write([]string{group.GetEcosystem(), group.ManifestFile, "", "false", group.GetDocumentationUrl()})
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+		for _, lockFile := range group.LockFiles {
+			generated := fmt.Sprintf("%t", file.IsGeneratedLockFile(lockFile))
+			err = writer.Write([]string{group.GetEcosystem(), group.ManifestFile, lockFile, generated, ""})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	writer.Flush()
+
+	return writer.Error()
+}
+
+func (l FileGroupList) RenderTable() {
+	t := table.NewWriter()
+	t.SetOutputMirror(l.mirror)
+	t.SetStyle(table.StyleRounded)
+	t.AppendHeader(table.Row{"Ecosystem", "Manifest file", "Lock files", "Documentation"})
+	for _, group := range l.groups {
+		var lockFiles []string
+		for _, lockFile := range group.LockFiles {
+			if file.IsGeneratedLockFile(lockFile) {
+				lockFile = lockFile + " " + file.GeneratedLockFileMarker
+			}
+			lockFiles = append(lockFiles, lockFile)
+		}
+		documentation := ""
+		if group.HasFile() && !group.HasLockFiles() {
+			documentation = group.GetDocumentationUrl()
+		}
+		t.AppendRow(table.Row{group.GetEcosystem(), group.ManifestFile, strings.Join(lockFiles, "\n"), documentation})
+	}
+	t.Render()
+}
+
+type FileGroupStats struct {
+	mirror io.Writer
+	stats  file.Stats
+}
+
+func NewFileGroupStats(mirror io.Writer, stats file.Stats) FileGroupStats {
+	return FileGroupStats{mirror: mirror, stats: stats}
+}
+
+func (s FileGroupStats) Render() {
+	t := table.NewWriter()
+	t.SetOutputMirror(s.mirror)
+	t.SetStyle(table.StyleRounded)
+	t.SetTitle("Statistics")
+
+	ecosystems := make([]string, 0, len(s.stats.Ecosystems))
+	for ecosystem := range s.stats.Ecosystems {
+		ecosystems = append(ecosystems, ecosystem)
+	}
+	sort.Strings(ecosystems)
+	for _, ecosystem := range ecosystems {
+		t.AppendRow(table.Row{ecosystem, s.stats.Ecosystems[ecosystem]})
+	}
+	t.AppendSeparator()
+	t.AppendRow(table.Row{"Pairs of manifest and lock files", s.stats.Pairs})
+	t.AppendRow(table.Row{"Unpaired manifest files", s.stats.UnpairedManifests})
+	t.AppendRow(table.Row{"Unpaired lock files", s.stats.UnpairedLockFiles})
+	t.AppendRow(table.Row{"Lock files generated by Debricked", s.stats.GeneratedLockFiles})
+	t.AppendFooter(table.Row{"Groups", s.stats.Groups})
+	t.Render()
+}
@@ -0,0 +1,54 @@
+package tui
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/debricked/cli/internal/file"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileGroupListRenderCsv(t *testing.T) {
+	var mirror bytes.Buffer
+	groups := []file.Group{
+		*file.NewGroup("package.json", nil, []string{"yarn.lock", "package-lock.json"}),
+		*file.NewGroup("go.mod", nil, []string{}),
+	}
+
+	err := NewFileGroupList(&mirror, groups).RenderCsv()
+
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		"ecosystem,manifestFile,lockFile,generated,documentationUrl\n"+
+			"unknown,package.json,yarn.lock,false,\n"+
+			"unknown,package.json,package-lock.json,false,\n"+
+			"unknown,go.mod,,false,\n",
+		mirror.String(),
+	)
+}
+
+func TestFileGroupListRenderTable(t *testing.T) {
+	var mirror bytes.Buffer
+	groups := []file.Group{
+		*file.NewGroup("go.mod", nil, []string{"gomod.debricked.lock"}),
+	}
+
+	NewFileGroupList(&mirror, groups).RenderTable()
+
+	output := mirror.String()
+	assert.Contains(t, output, "MANIFEST FILE")
+	assert.Contains(t, output, "gomod.debricked.lock "+file.GeneratedLockFileMarker)
+}
+
+func TestFileGroupStatsRender(t *testing.T) {
+	var mirror bytes.Buffer
+	stats := file.Stats{Groups: 2, Ecosystems: map[string]int{"php": 1, "golang": 1}, Pairs: 1, UnpairedManifests: 1}
+
+	NewFileGroupStats(&mirror, stats).Render()
+
+	output := mirror.String()
+	assert.Contains(t, output, "golang")
+	assert.Contains(t, output, "php")
+	assert.Contains(t, output, "Unpaired manifest files")
+}
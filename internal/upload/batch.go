@@ -38,10 +38,11 @@ type uploadBatch struct {
 	integrationName  string
 	ciUploadId       int
 	callGraphTimeout int
+	lockOutputDir    string
 }
 
-func newUploadBatch(client *client.IDebClient, fileGroups file.Groups, gitMetaObject *git.MetaObject, integrationName string, callGraphTimeout int) *uploadBatch {
-	return &uploadBatch{client: client, fileGroups: fileGroups, gitMetaObject: gitMetaObject, integrationName: integrationName, ciUploadId: 0, callGraphTimeout: callGraphTimeout}
+func newUploadBatch(client *client.IDebClient, fileGroups file.Groups, gitMetaObject *git.MetaObject, integrationName string, callGraphTimeout int, lockOutputDir string) *uploadBatch {
+	return &uploadBatch{client: client, fileGroups: fileGroups, gitMetaObject: gitMetaObject, integrationName: integrationName, ciUploadId: 0, callGraphTimeout: callGraphTimeout, lockOutputDir: lockOutputDir}
 }
 
 // upload concurrently posts all file groups to Debricked
@@ -118,7 +119,7 @@ func (uploadBatch *uploadBatch) uploadFile(filePath string, timeout int) error {
 
 	_, _ = io.Copy(fileData, f)
 
-	_ = writer.WriteField("fileRelativePath", getRelativeFilePath(filePath))
+	_ = writer.WriteField("fileRelativePath", getRelativeFilePath(filePath, uploadBatch.lockOutputDir))
 	_ = writer.WriteField("repositoryName", uploadBatch.gitMetaObject.RepositoryName)
 	_ = writer.WriteField("commitName", uploadBatch.gitMetaObject.CommitName)
 	_ = writer.WriteField("repositoryUrl", uploadBatch.gitMetaObject.RepositoryUrl)
@@ -279,7 +280,11 @@ type uploadFinish struct {
 	DebrickedIntegration string `json:"debrickedIntegration"`
 }
 
-func getRelativeFilePath(filePath string) string {
+// getRelativeFilePath returns the directory of the file, or the directory it mirrors if it is in the lock output dir
+func getRelativeFilePath(filePath string, lockOutputDir string) string {
+	if sourcePath, ok := file.SourcePath(lockOutputDir, filePath); ok {
+		filePath = sourcePath
+	}
 	relFilePath := filepath.Dir(filePath)
 	if strings.EqualFold(".", relFilePath) {
 		relFilePath = ""
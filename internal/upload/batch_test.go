@@ -7,6 +7,7 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -17,6 +18,17 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+func TestGetRelativeFilePath(t *testing.T) {
+	assert.Equal(t, "", getRelativeFilePath("go.mod", ""))
+	assert.Equal(t, filepath.Join("app", "sub"), getRelativeFilePath(filepath.Join("app", "sub", "go.mod"), ""))
+
+	lockOutputDir := t.TempDir()
+	lockFile := filepath.Join(lockOutputDir, "app", "sub", "gomod.debricked.lock")
+	assert.Equal(t, filepath.Join("app", "sub"), getRelativeFilePath(lockFile, lockOutputDir))
+	assert.Equal(t, "", getRelativeFilePath(filepath.Join(lockOutputDir, "gomod.debricked.lock"), lockOutputDir))
+	assert.Equal(t, "app", getRelativeFilePath(filepath.Join("app", "go.mod"), lockOutputDir))
+}
+
 func TestUploadWithBadFiles(t *testing.T) {
 	group := file.NewGroup("package.json", nil, []string{"yarn.lock"})
 	var groups file.Groups
@@ -36,7 +48,7 @@ func TestUploadWithBadFiles(t *testing.T) {
 	clientMock.AddMockResponse(mockRes)
 	clientMock.AddMockResponse(mockRes)
 	c = clientMock
-	batch := newUploadBatch(&c, groups, metaObj, "CLI", 10*60)
+	batch := newUploadBatch(&c, groups, metaObj, "CLI", 10*60, "")
 	var buf bytes.Buffer
 	log.SetOutput(&buf)
 	err = batch.upload()
@@ -48,7 +60,7 @@ func TestUploadWithBadFiles(t *testing.T) {
 }
 
 func TestInitAnalysisWithoutAnyFiles(t *testing.T) {
-	batch := newUploadBatch(nil, file.Groups{}, nil, "CLI", 10*60)
+	batch := newUploadBatch(nil, file.Groups{}, nil, "CLI", 10*60, "")
 	err := batch.initAnalysis()
 
 	assert.ErrorContains(t, err, "failed to find dependency files")
@@ -71,7 +83,7 @@ func TestWaitWithPollingTerminatedError(t *testing.T) {
 	}
 	clientMock.AddMockResponse(mockRes)
 	c = clientMock
-	batch := newUploadBatch(&c, groups, metaObj, "CLI", 10*60)
+	batch := newUploadBatch(&c, groups, metaObj, "CLI", 10*60, "")
 
 	uploadResult, err := batch.wait()
 
@@ -96,7 +108,7 @@ func TestInitUploadBadFile(t *testing.T) {
 	clientMock.AddMockResponse(mockRes)
 
 	var c client.IDebClient = clientMock
-	batch := newUploadBatch(&c, groups, metaObj, "CLI", 10*60)
+	batch := newUploadBatch(&c, groups, metaObj, "CLI", 10*60, "")
 
 	files, err := batch.initUpload()
 
@@ -123,7 +135,7 @@ func TestInitUpload(t *testing.T) {
 	clientMock.AddMockResponse(mockRes)
 
 	var c client.IDebClient = clientMock
-	batch := newUploadBatch(&c, groups, metaObj, "CLI", 10*60)
+	batch := newUploadBatch(&c, groups, metaObj, "CLI", 10*60, "")
 
 	files, err := batch.initUpload()
 
@@ -15,6 +15,7 @@ type DebrickedOptions struct {
 	GitMetaObject          git.MetaObject
 	IntegrationsName       string
 	CallGraphUploadTimeout int
+	LockOutputDir          string
 }
 
 type IUploader interface {
@@ -35,7 +36,7 @@ func NewUploader(c client.IDebClient) (*Uploader, error) {
 
 func (uploader *Uploader) Upload(o IOptions) (*UploadResult, error) {
 	dOptions := o.(DebrickedOptions)
-	batch := newUploadBatch(uploader.client, dOptions.FileGroups, &dOptions.GitMetaObject, dOptions.IntegrationsName, dOptions.CallGraphUploadTimeout)
+	batch := newUploadBatch(uploader.client, dOptions.FileGroups, &dOptions.GitMetaObject, dOptions.IntegrationsName, dOptions.CallGraphUploadTimeout, dOptions.LockOutputDir)
 
 	err := batch.upload()
 	if err != nil {